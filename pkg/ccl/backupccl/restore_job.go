@@ -41,6 +41,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/execinfrapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/stats"
 	"github.com/cockroachdb/cockroach/pkg/storage/cloud"
@@ -2403,7 +2404,11 @@ func getRestoringPrivileges(
 			// SCHEMA}. But also like CREATE {TABLE,SCHEMA}, we set the owner to the
 			// user creating the table (the one running the restore).
 			// TODO(dt): Make this more configurable.
-			updatedPrivileges = sql.CreateInheritedPrivilegesFromDBDesc(parentDB, user)
+			objectType := privilege.Table
+			if tbl, ok := desc.(catalog.TableDescriptor); ok && tbl.IsSequence() {
+				objectType = privilege.Sequence
+			}
+			updatedPrivileges = sql.CreateInheritedPrivilegesFromDBDesc(parentDB, user, objectType)
 		}
 	case catalog.TypeDescriptor, catalog.DatabaseDescriptor:
 		if descCoverage == tree.RequestedDescriptors {
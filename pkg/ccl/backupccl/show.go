@@ -420,12 +420,16 @@ func showPrivileges(descriptor *descpb.Descriptor) string {
 	if b == nil {
 		return ""
 	}
+	desc := b.BuildImmutable()
 	var objectType privilege.ObjectType
 	switch b.DescriptorType() {
 	case catalog.Database:
 		objectType = privilege.Database
 	case catalog.Table:
 		objectType = privilege.Table
+		if tbl, ok := desc.(catalog.TableDescriptor); ok && tbl.IsSequence() {
+			objectType = privilege.Sequence
+		}
 	case catalog.Type:
 		objectType = privilege.Type
 	case catalog.Schema:
@@ -433,7 +437,7 @@ func showPrivileges(descriptor *descpb.Descriptor) string {
 	default:
 		return ""
 	}
-	privDesc := b.BuildImmutable().GetPrivileges()
+	privDesc := desc.GetPrivileges()
 	if privDesc == nil {
 		return ""
 	}
@@ -247,6 +247,9 @@ var systemTableBackupConfiguration = map[string]systemBackupConfiguration{
 	systemschema.JoinTokensTable.GetName(): {
 		shouldIncludeInClusterBackup: optOutOfClusterBackup,
 	},
+	systemschema.LoginAttemptsTable.GetName(): {
+		shouldIncludeInClusterBackup: optOutOfClusterBackup,
+	},
 }
 
 // GetSystemTablesToIncludeInClusterBackup returns a set of system table names that
@@ -5914,7 +5914,7 @@ create function: could not be parsed
 alter default privileges: could not be parsed
 alter table alter column add: could not be parsed
 copy from unsupported format: could not be parsed
-grant privileges on schema with: could not be parsed
+GRANT USAGE ON SCHEMA schemaname TO davidt WITH GRANT OPTION: unsupported by IMPORT
 COMMENT ON TABLE t IS 'This should be skipped': unsupported by IMPORT
 COMMENT ON DATABASE t IS 'This should be skipped': unsupported by IMPORT
 COMMENT ON COLUMN t IS 'This should be skipped': unsupported by IMPORT
@@ -5924,7 +5924,8 @@ unsupported function call: set_config in stmt: SELECT set_config('search_path',
 		checkFiles(schemaFileContents, pgDumpUnsupportedSchemaStmtLog)
 
 		ingestionFileContents := []string{
-			`unsupported 3 fn args in select: ['search_path' '' false]: unsupported by IMPORT
+			`unsupported *tree.Grant statement: GRANT USAGE ON SCHEMA schemaname TO davidt WITH GRANT OPTION: unsupported by IMPORT
+unsupported 3 fn args in select: ['search_path' '' false]: unsupported by IMPORT
 unsupported *tree.Delete statement: DELETE FROM geometry_columns WHERE (f_table_name = 'nyc_census_blocks') AND (f_table_schema = 'public'): unsupported by IMPORT
 `,
 		}
@@ -813,7 +813,7 @@ func readPostgresStmt(
 		// ignore_unsupported flag as well?
 	case *tree.Insert, *tree.CopyFrom, *tree.Delete, copyData:
 		// handled during the data ingestion pass.
-	case *tree.CreateExtension, *tree.CommentOnDatabase, *tree.CommentOnTable,
+	case *tree.CreateExtension, *tree.CommentOnDatabase, *tree.CommentOnSchema, *tree.CommentOnTable,
 		*tree.CommentOnIndex, *tree.CommentOnColumn, *tree.SetVar, *tree.Analyze:
 		// These are the statements that can be parsed by CRDB but are not
 		// supported, or are not required to be processed, during an IMPORT.
@@ -1304,7 +1304,7 @@ func (m *pgDumpReader) readFile(
 				}
 				return wrapErrorWithUnsupportedHint(err)
 			}
-		case *tree.CreateExtension, *tree.CommentOnDatabase, *tree.CommentOnTable,
+		case *tree.CreateExtension, *tree.CommentOnDatabase, *tree.CommentOnSchema, *tree.CommentOnTable,
 			*tree.CommentOnIndex, *tree.CommentOnColumn, *tree.AlterSequence:
 			// handled during schema extraction.
 		case *tree.SetVar, *tree.BeginTransaction, *tree.CommitTransaction, *tree.Analyze:
@@ -17,6 +17,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/ccl/utilccl"
 	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/server"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
@@ -24,6 +25,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/ui"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
 	"github.com/cockroachdb/cockroach/pkg/util/uuid"
 	"github.com/cockroachdb/errors"
 	"github.com/coreos/go-oidc"
@@ -39,6 +41,7 @@ const (
 	oidcCallbackPath         = "/oidc/v1/callback"
 	genericCallbackHTTPError = "OIDC: unable to complete authentication"
 	genericLoginHTTPError    = "OIDC: unable to initiate authentication"
+	externalPrincipalMethod  = "oidc"
 	counterPrefix            = "auth.oidc."
 	beginAuthCounterName     = counterPrefix + "begin_auth"
 	beginCallbackCounterName = counterPrefix + "begin_callback"
@@ -122,6 +125,9 @@ type oidcAuthenticationServer struct {
 	// to help us gracefully recover from auth provider downtime without operator intervention.
 	enabled     bool
 	initialized bool
+	// externalPrincipals records the mapping of OIDC principals onto SQL
+	// users for crdb_internal.external_principal_mappings.
+	externalPrincipals *sql.ExternalPrincipals
 }
 
 type oidcAuthenticationConf struct {
@@ -186,6 +192,16 @@ func reloadConfigLocked(
 
 	server.initialized = false
 	server.conf = conf
+	if server.externalPrincipals != nil {
+		if conf.enabled {
+			server.externalPrincipals.SetRule(externalPrincipalMethod, sql.ExternalPrincipalRule{
+				Source:  "claim " + conf.claimJSONKey,
+				Mapping: conf.principalRegex.String(),
+			})
+		} else {
+			server.externalPrincipals.ClearRule(externalPrincipalMethod)
+		}
+	}
 	if server.conf.enabled {
 		// `enabled` stores the configuration state and records the operator's _intent_ that the feature
 		// be enabled. Since the call to `NewProvider` below makes an HTTP request and could fail for
@@ -260,10 +276,11 @@ var ConfigureOIDC = func(
 	locality roachpb.Locality,
 	mux *http.ServeMux,
 	userLoginFromSSO func(ctx context.Context, username string) (*http.Cookie, error),
+	externalPrincipals *sql.ExternalPrincipals,
 	ambientCtx log.AmbientContext,
 	cluster uuid.UUID,
 ) (server.OIDC, error) {
-	oidcAuthentication := &oidcAuthenticationServer{}
+	oidcAuthentication := &oidcAuthenticationServer{externalPrincipals: externalPrincipals}
 
 	// Don't want to use GRPC here since these endpoints require HTTP-Redirect behaviors and the
 	// callback endpoint will be receiving specialized parameters that grpc-gateway will only get
@@ -372,6 +389,13 @@ var ConfigureOIDC = func(
 			return
 		}
 
+		if oidcAuthentication.externalPrincipals != nil {
+			role, _ := security.MakeSQLUsernameFromUserInput(username, security.UsernameValidation)
+			oidcAuthentication.externalPrincipals.RecordLogin(
+				externalPrincipalMethod, principal, role, timeutil.Now(),
+			)
+		}
+
 		http.SetCookie(w, cookie)
 		http.Redirect(w, r, "/", http.StatusTemporaryRedirect)
 
@@ -76,6 +76,7 @@ var debugZipTablesPerNode = []string{
 	"crdb_internal.node_transaction_statistics",
 	"crdb_internal.node_transactions",
 	"crdb_internal.node_txn_stats",
+	"crdb_internal.node_virtual_table_populations",
 }
 
 // collectCPUProfiles collects CPU profiles in parallel over all nodes
@@ -66,6 +66,7 @@ table_name NOT IN (
 	-- allowlisted tables that don't need to be in debug zip
 	'backward_dependencies',
 	'builtin_functions',
+	'catalog_discrepancies',
 	'create_statements',
 	'create_type_statements',
 	'cross_db_references',
@@ -79,9 +80,11 @@ table_name NOT IN (
 	'ranges',
 	'ranges_no_leases',
 	'predefined_comments',
+	'serial_sequences',
 	'session_trace',
 	'session_variables',
-	'tables'
+	'tables',
+	'view_dependency_closure'
 )
 ORDER BY name ASC`)
 	assert.NoError(t, err)
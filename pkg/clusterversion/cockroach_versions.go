@@ -278,6 +278,58 @@ const (
 	// JoinTokensTable adds the system table for storing ephemeral generated
 	// join tokens.
 	JoinTokensTable
+	// GrantOptions enables granting privileges WITH GRANT OPTION and recording
+	// the grantor of privileges in descriptors.
+	GrantOptions
+	// DefaultPrivileges enables ALTER DEFAULT PRIVILEGES, which stores the
+	// default privileges in database descriptors.
+	DefaultPrivileges
+	// SequencePrivileges enables granting the privileges which are specific to
+	// sequences, such as USAGE, which older nodes fail to validate.
+	SequencePrivileges
+	// IdentityColumns enables the creation of GENERATED AS IDENTITY columns.
+	IdentityColumns
+	// CollationVersions enables recording the version of the collation tables in
+	// the descriptors of collated string columns and of the indexes on them.
+	CollationVersions
+	// ViewCheckOption enables the creation of views WITH CHECK OPTION, which
+	// older nodes would not enforce.
+	ViewCheckOption
+	// MaterializedViewRefreshTime enables recording the time as of which the
+	// data of a materialized view was last refreshed.
+	MaterializedViewRefreshTime
+	// ViewSecurityOptions enables the creation of views with the
+	// security_invoker and security_barrier options, which older nodes would not
+	// enforce.
+	ViewSecurityOptions
+	// SequenceCycle enables the creation of sequences with the CYCLE option,
+	// which older nodes would not honor.
+	SequenceCycle
+	// SequenceIntegerTypes enables the creation of sequences with the AS option,
+	// whose bounds older nodes would not enforce.
+	SequenceIntegerTypes
+	// NotVisibleIndexes enables the creation of indexes which are NOT VISIBLE
+	// to the optimizer.
+	NotVisibleIndexes
+	// DeferrableForeignKeys enables the creation of DEFERRABLE foreign key
+	// constraints.
+	DeferrableForeignKeys
+	// ConstraintComments enables COMMENT ON CONSTRAINT, which stores a new type
+	// of comment in system.comments.
+	ConstraintComments
+	// MatchPartialForeignKeys enables the creation of foreign key constraints
+	// with MATCH PARTIAL.
+	MatchPartialForeignKeys
+	// DatabaseDefaultCollations enables recording the default collation of
+	// databases in their descriptors.
+	DatabaseDefaultCollations
+	// SchemaComments enables COMMENT ON SCHEMA, which stores a new type of
+	// comment in system.comments.
+	SchemaComments
+	// PasswordPolicies adds the system.login_attempts table, which counts the failed
+	// logins of the roles with the LOGINATTEMPTS option, and enables the
+	// PASSWORDCOMPLEXITY, PASSWORDLIFETIME and LOGINATTEMPTS role options.
+	PasswordPolicies
 
 	// Step (1): Add new versions here.
 )
@@ -484,6 +536,74 @@ var versionsSingleton = keyedVersions([]keyedVersion{
 		Key:     JoinTokensTable,
 		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 50},
 	},
+	{
+		Key:     GrantOptions,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 52},
+	},
+	{
+		Key:     DefaultPrivileges,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 54},
+	},
+	{
+		Key:     SequencePrivileges,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 56},
+	},
+	{
+		Key:     IdentityColumns,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 58},
+	},
+	{
+		Key:     CollationVersions,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 60},
+	},
+	{
+		Key:     ViewCheckOption,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 62},
+	},
+	{
+		Key:     MaterializedViewRefreshTime,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 64},
+	},
+	{
+		Key:     ViewSecurityOptions,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 66},
+	},
+	{
+		Key:     SequenceCycle,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 68},
+	},
+	{
+		Key:     SequenceIntegerTypes,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 70},
+	},
+	{
+		Key:     NotVisibleIndexes,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 72},
+	},
+	{
+		Key:     DeferrableForeignKeys,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 74},
+	},
+	{
+		Key:     ConstraintComments,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 76},
+	},
+	{
+		Key:     MatchPartialForeignKeys,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 78},
+	},
+	{
+		Key:     DatabaseDefaultCollations,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 80},
+	},
+	{
+		Key:     SchemaComments,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 82},
+	},
+	{
+		Key:     PasswordPolicies,
+		Version: roachpb.Version{Major: 20, Minor: 2, Internal: 84},
+	},
 	// Step (2): Add new versions here.
 })
 
@@ -404,12 +404,15 @@ const (
 	SqllivenessID                       = 39
 	MigrationsID                        = 40
 	JoinTokensTableID                   = 41
+	LoginAttemptsTableID                = 42
 
 	// CommentType is type for system.comments
-	DatabaseCommentType = 0
-	TableCommentType    = 1
-	ColumnCommentType   = 2
-	IndexCommentType    = 3
+	DatabaseCommentType   = 0
+	TableCommentType      = 1
+	ColumnCommentType     = 2
+	IndexCommentType      = 3
+	ConstraintCommentType = 4
+	SchemaCommentType     = 5
 )
 
 const (
@@ -67,6 +67,11 @@ var migrations = []migration.Migration{
 		toCV(clusterversion.JoinTokensTable),
 		joinTokensTableMigration,
 	),
+	migration.NewSQLMigration(
+		"add the system.login_attempts table",
+		toCV(clusterversion.PasswordPolicies),
+		loginAttemptsTableMigration,
+	),
 }
 
 func init() {
@@ -161,7 +161,7 @@ func UserAuthPasswordHook(insecureMode bool, password string, hashedPassword []b
 
 		// If the requested user has an empty password, disallow authentication.
 		if len(password) == 0 || CompareHashAndPassword(hashedPassword, password) != nil {
-			return nil, errors.Errorf(ErrPasswordUserAuthFailed, requestedUser)
+			return nil, errors.Mark(errors.Errorf(ErrPasswordUserAuthFailed, requestedUser), ErrInvalidPassword)
 		}
 
 		return nil, nil
@@ -172,3 +172,8 @@ func UserAuthPasswordHook(insecureMode bool, password string, hashedPassword []b
 // of a user. It should be used when the password is incorrect or the user
 // does not exist.
 const ErrPasswordUserAuthFailed = "password authentication failed for user %s"
+
+// ErrInvalidPassword marks the errors of the authentication hooks which
+// reject the password presented by the client, as opposed to the other
+// authentication failures.
+var ErrInvalidPassword = errors.New("invalid password")
@@ -80,6 +80,7 @@ var ConfigureOIDC = func(
 	locality roachpb.Locality,
 	mux *http.ServeMux,
 	userLoginFromSSO func(ctx context.Context, username string) (*http.Cookie, error),
+	externalPrincipals *sql.ExternalPrincipals,
 	ambientCtx log.AmbientContext,
 	cluster uuid.UUID,
 ) (OIDC, error) {
@@ -422,6 +423,14 @@ func (s *authenticationServer) verifyPassword(
 	if !exists || !canLogin {
 		return false, false, nil
 	}
+	execCfg := s.server.sqlServer.execCfg
+	attempts, err := sql.GetLoginAttempts(ctx, execCfg, username)
+	if err != nil {
+		return false, false, err
+	}
+	if attempts.Locked() {
+		return false, false, nil
+	}
 	hashedPassword, err := pwRetrieveFn(ctx)
 	if err != nil {
 		return false, false, err
@@ -437,7 +446,13 @@ func (s *authenticationServer) verifyPassword(
 		}
 	}
 
-	return security.CompareHashAndPassword(hashedPassword, password) == nil, false, nil
+	valid = security.CompareHashAndPassword(hashedPassword, password) == nil
+	if err := sql.RecordLoginAttempt(
+		ctx, execCfg.InternalExecutor, username, attempts, valid,
+	); err != nil {
+		return false, false, err
+	}
+	return valid, false, nil
 }
 
 // CreateAuthSecret creates a secret, hash pair to populate a session auth token.
@@ -1732,7 +1732,8 @@ func (s *Server) PreStart(ctx context.Context) error {
 	// the system settings initialized for it to pick up from the oidcAuthenticationServer.
 	oidc, err := ConfigureOIDC(
 		ctx, s.ClusterSettings(), s.cfg.Locality,
-		&s.mux, s.authentication.UserLoginFromSSO, s.cfg.AmbientCtx, s.ClusterID(),
+		&s.mux, s.authentication.UserLoginFromSSO, s.sqlServer.execCfg.ExternalPrincipals,
+		s.cfg.AmbientCtx, s.ClusterID(),
 	)
 	if err != nil {
 		return err
@@ -263,6 +263,17 @@ const (
 	Public
 )
 
+func (v Visibility) String() string {
+	switch v {
+	case Reserved:
+		return "reserved"
+	case Public:
+		return "public"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(v))
+	}
+}
+
 type common struct {
 	description string
 	visibility  Visibility
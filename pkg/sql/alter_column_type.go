@@ -130,6 +130,11 @@ func AlterColumnType(
 			col.Type.SQLString(), typ.SQLString())
 	case schemachange.ColumnConversionTrivial:
 		col.Type = typ
+		// The values are not rewritten, so they remain ordered according to the
+		// collation version recorded previously, if any.
+		if col.CollationVersion == "" {
+			col.CollationVersion = collationVersionOfType(params, typ)
+		}
 	case schemachange.ColumnConversionGeneral, schemachange.ColumnConversionValidate:
 		if err := alterColumnTypeGeneral(ctx, tableDesc, col, typ, t.Using, params, cmds, tn); err != nil {
 			return err
@@ -148,6 +153,16 @@ func AlterColumnType(
 	return nil
 }
 
+// collationVersionOfType returns the collation version to record for a
+// column of the given type. No version is recorded until the cluster supports
+// it.
+func collationVersionOfType(params runParams, typ *types.T) string {
+	if !params.p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.CollationVersions) {
+		return ""
+	}
+	return tabledesc.CollationVersionOfType(typ)
+}
+
 func alterColumnTypeGeneral(
 	ctx context.Context,
 	tableDesc *tabledesc.Mutable,
@@ -361,13 +376,14 @@ func alterColumnTypeGeneral(
 	}
 
 	newCol := descpb.ColumnDescriptor{
-		Name:            shadowColName,
-		Type:            toType,
-		Nullable:        col.Nullable,
-		DefaultExpr:     newColDefaultExpr,
-		UsesSequenceIds: col.UsesSequenceIds,
-		OwnsSequenceIds: col.OwnsSequenceIds,
-		ComputeExpr:     newColComputeExpr,
+		Name:             shadowColName,
+		Type:             toType,
+		Nullable:         col.Nullable,
+		DefaultExpr:      newColDefaultExpr,
+		UsesSequenceIds:  col.UsesSequenceIds,
+		OwnsSequenceIds:  col.OwnsSequenceIds,
+		ComputeExpr:      newColComputeExpr,
+		CollationVersion: collationVersionOfType(params, toType),
 	}
 
 	// Ensure new column is created in the same column family as the original
@@ -0,0 +1,226 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/dbdesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// alterDefaultPrivilegesNode represents an ALTER DEFAULT PRIVILEGES
+// statement.
+type alterDefaultPrivilegesNode struct {
+	n *tree.AlterDefaultPrivileges
+
+	dbDesc    *dbdesc.Mutable
+	schemaIDs []descpb.ID
+}
+
+// AlterDefaultPrivileges changes the privileges granted on objects created
+// in the current database.
+// Privileges: the current user must be a member of the target roles, as in
+// postgres.
+func (p *planner) AlterDefaultPrivileges(
+	ctx context.Context, n *tree.AlterDefaultPrivileges,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"ALTER DEFAULT PRIVILEGES",
+	); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.DefaultPrivileges) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use ALTER DEFAULT PRIVILEGES",
+			clusterversion.DefaultPrivileges)
+	}
+
+	var privs privilege.List
+	var target tree.AlterDefaultPrivilegesTargetObject
+	if n.IsGrant {
+		privs, target = n.Grant.Privileges, n.Grant.Target
+	} else {
+		privs, target = n.Revoke.Privileges, n.Revoke.Target
+	}
+	if target == tree.Schemas && len(n.Schemas) > 0 {
+		return nil, pgerror.Newf(pgcode.InvalidGrantOperation,
+			"cannot use IN SCHEMA clause when using GRANT/REVOKE ON SCHEMAS")
+	}
+	if err := privilege.ValidatePrivileges(
+		privs, defaultPrivilegesObjectType(target).ToPrivilegeObjectType(),
+	); err != nil {
+		return nil, err
+	}
+
+	_, dbDesc, err := p.Descriptors().GetMutableDatabaseByName(
+		ctx, p.txn, p.CurrentDatabase(), tree.DatabaseLookupFlags{Required: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var schemaIDs []descpb.ID
+	for _, sc := range n.Schemas {
+		if sc.ExplicitCatalog && sc.Catalog() != dbDesc.GetName() {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"cannot alter default privileges of schema %q in another database", sc.Schema())
+		}
+		_, resSchema, err := p.ResolveMutableSchemaDescriptor(
+			ctx, dbDesc.GetID(), sc.Schema(), true /* required */)
+		if err != nil {
+			return nil, err
+		}
+		switch resSchema.Kind {
+		case catalog.SchemaPublic, catalog.SchemaUserDefined:
+			schemaIDs = append(schemaIDs, resSchema.ID)
+		default:
+			return nil, pgerror.Newf(pgcode.InvalidSchemaName,
+				"cannot alter default privileges of schema %q", resSchema.Name)
+		}
+	}
+
+	return &alterDefaultPrivilegesNode{
+		n:         n,
+		dbDesc:    dbDesc,
+		schemaIDs: schemaIDs,
+	}, nil
+}
+
+// defaultPrivilegesObjectType maps the target of an ALTER DEFAULT PRIVILEGES
+// statement to the object type stored in the database descriptor.
+func defaultPrivilegesObjectType(
+	target tree.AlterDefaultPrivilegesTargetObject,
+) descpb.DefaultPrivilegesForRole_ObjectType {
+	switch target {
+	case tree.Tables:
+		return descpb.DefaultPrivilegesForRole_TABLES
+	case tree.Sequences:
+		return descpb.DefaultPrivilegesForRole_SEQUENCES
+	case tree.Types:
+		return descpb.DefaultPrivilegesForRole_TYPES
+	case tree.Schemas:
+		return descpb.DefaultPrivilegesForRole_SCHEMAS
+	default:
+		panic(errors.AssertionFailedf("unknown default privileges target %d", target))
+	}
+}
+
+func (n *alterDefaultPrivilegesNode) startExec(params runParams) error {
+	ctx, p := params.ctx, params.p
+
+	roles := n.n.Roles
+	if len(roles) == 0 {
+		roles = []security.SQLUsername{p.User()}
+	}
+	if err := n.checkCanAlterDefaultPrivileges(params, roles); err != nil {
+		return err
+	}
+
+	var privs privilege.List
+	var target tree.AlterDefaultPrivilegesTargetObject
+	var granteeNames tree.NameList
+	if n.n.IsGrant {
+		privs, target, granteeNames = n.n.Grant.Privileges, n.n.Grant.Target, n.n.Grant.Grantees
+	} else {
+		privs, target, granteeNames = n.n.Revoke.Privileges, n.n.Revoke.Target, n.n.Revoke.Grantees
+	}
+
+	users, err := p.GetAllRoles(ctx)
+	if err != nil {
+		return err
+	}
+	users[security.PublicRoleName()] = true // isRole
+	grantees := make([]security.SQLUsername, len(granteeNames))
+	for i, name := range granteeNames {
+		grantees[i] = security.MakeSQLUsernameFromPreNormalizedString(string(name))
+		if _, ok := users[grantees[i]]; !ok {
+			return errors.Errorf("user or role %s does not exist", &granteeNames[i])
+		}
+		if n.n.IsGrant && n.n.Grant.WithGrantOption && grantees[i].IsPublicRole() {
+			return pgerror.Newf(pgcode.InvalidGrantOperation,
+				"grant options can only be granted to roles")
+		}
+	}
+
+	schemaIDs := n.schemaIDs
+	if len(schemaIDs) == 0 {
+		// The defaults apply to objects created in any schema.
+		schemaIDs = []descpb.ID{0}
+	}
+	if n.dbDesc.DefaultPrivileges == nil {
+		n.dbDesc.DefaultPrivileges = &descpb.DefaultPrivilegeDescriptor{}
+	}
+	objectType := defaultPrivilegesObjectType(target)
+	for _, role := range roles {
+		for _, schemaID := range schemaIDs {
+			for _, grantee := range grantees {
+				if n.n.IsGrant {
+					n.dbDesc.DefaultPrivileges.GrantDefaultPrivileges(
+						role, schemaID, objectType, grantee, privs, n.n.Grant.WithGrantOption,
+					)
+				} else {
+					n.dbDesc.DefaultPrivileges.RevokeDefaultPrivileges(
+						role, schemaID, objectType, grantee, privs, n.n.Revoke.GrantOptionFor,
+					)
+				}
+			}
+		}
+	}
+
+	return p.writeNonDropDatabaseChange(
+		ctx, n.dbDesc, tree.AsStringWithFQNames(n.n, params.Ann()),
+	)
+}
+
+// checkCanAlterDefaultPrivileges checks that the current user is either an
+// admin or a member of each of the given roles.
+func (n *alterDefaultPrivilegesNode) checkCanAlterDefaultPrivileges(
+	params runParams, roles []security.SQLUsername,
+) error {
+	ctx, p := params.ctx, params.p
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return err
+	}
+	if hasAdmin {
+		return nil
+	}
+	memberOf, err := p.MemberOfWithAdminOption(ctx, p.User())
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if role == p.User() {
+			continue
+		}
+		if _, ok := memberOf[role]; !ok {
+			return pgerror.Newf(pgcode.InsufficientPrivilege, "must be member of role %q", role)
+		}
+	}
+	return nil
+}
+
+// ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
+func (n *alterDefaultPrivilegesNode) ReadingOwnWrites() {}
+
+func (n *alterDefaultPrivilegesNode) Next(runParams) (bool, error) { return false, nil }
+func (n *alterDefaultPrivilegesNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *alterDefaultPrivilegesNode) Close(context.Context)        {}
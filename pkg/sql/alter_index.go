@@ -126,6 +126,24 @@ func (n *alterIndexNode) startExec(params runParams) error {
 				return err
 			}
 			*n.indexDesc = newIndexDesc
+		case *tree.AlterIndexVisible:
+			telemetry.Inc(sqltelemetry.SchemaChangeAlterCounterWithExtra("index", "visible"))
+			if t.NotVisible && n.indexDesc.ID == n.tableDesc.GetPrimaryIndexID() {
+				return pgerror.Newf(
+					pgcode.FeatureNotSupported,
+					"primary index %q cannot be made not visible",
+					n.indexDesc.Name,
+				)
+			}
+			if t.NotVisible {
+				if err := checkClusterSupportsNotVisibleIndexes(params.EvalContext()); err != nil {
+					return err
+				}
+			}
+			if n.indexDesc.NotVisible != t.NotVisible {
+				n.indexDesc.NotVisible = t.NotVisible
+				descriptorChanged = true
+			}
 		default:
 			return errors.AssertionFailedf(
 				"unsupported alter command: %T", cmd)
@@ -101,6 +101,9 @@ func (p *planner) checkPasswordOptionConstraints(
 		roleOptions.Contains(roleoption.NOCREATELOGIN) ||
 		roleOptions.Contains(roleoption.PASSWORD) ||
 		roleOptions.Contains(roleoption.VALIDUNTIL) ||
+		roleOptions.Contains(roleoption.PASSWORDCOMPLEXITY) ||
+		roleOptions.Contains(roleoption.PASSWORDLIFETIME) ||
+		roleOptions.Contains(roleoption.LOGINATTEMPTS) ||
 		roleOptions.Contains(roleoption.LOGIN) ||
 		// CREATE ROLE NOLOGIN is valid without CREATELOGIN.
 		(roleOptions.Contains(roleoption.NOLOGIN) && !newUser) ||
@@ -108,8 +111,9 @@ func (p *planner) checkPasswordOptionConstraints(
 		(newUser && !roleOptions.Contains(roleoption.NOLOGIN) && !roleOptions.Contains(roleoption.LOGIN)) {
 		// Only a role who has CREATELOGIN itself can grant CREATELOGIN or
 		// NOCREATELOGIN to another role, or set up a password for
-		// authentication, or set up password validity, or enable/disable
-		// LOGIN privilege; even if they have CREATEROLE privilege.
+		// authentication, or set up password validity or policies, or
+		// enable/disable LOGIN privilege; even if they have CREATEROLE
+		// privilege.
 		if err := p.CheckRoleOption(ctx, roleoption.CREATELOGIN); err != nil {
 			return err
 		}
@@ -161,6 +165,12 @@ func (n *alterRoleNode) startExec(params runParams) error {
 		return errors.Newf("role/user %s does not exist", normalizedUsername)
 	}
 
+	if err := params.p.checkPasswordPolicyOptions(params.ctx, n.roleOptions); err != nil {
+		return err
+	}
+
+	passwordSet := false
+	var policy passwordPolicy
 	if n.roleOptions.Contains(roleoption.PASSWORD) {
 		isNull, password, err := n.roleOptions.GetPassword()
 		if err != nil {
@@ -180,9 +190,16 @@ func (n *alterRoleNode) startExec(params runParams) error {
 
 		var hashedPassword []byte
 		if !isNull {
+			if policy, err = params.p.getPasswordPolicy(params.ctx, normalizedUsername, n.roleOptions); err != nil {
+				return err
+			}
+			if err := policy.checkPassword(password); err != nil {
+				return err
+			}
 			if hashedPassword, err = params.p.checkPasswordAndGetHash(params.ctx, password); err != nil {
 				return err
 			}
+			passwordSet = true
 		}
 
 		if hashedPassword == nil {
@@ -245,6 +262,25 @@ func (n *alterRoleNode) startExec(params runParams) error {
 			return err
 		}
 	}
+	if n.roleOptions.Contains(roleoption.LOGIN) {
+		// LOGIN unlocks a role locked by its LOGINATTEMPTS policy.
+		if err := params.p.resetLoginAttempts(params.ctx, opName, normalizedUsername); err != nil {
+			return err
+		}
+	}
+	if passwordSet {
+		if err := params.p.onPasswordSet(
+			params.ctx, opName, normalizedUsername, policy, n.roleOptions.Contains(roleoption.VALIDUNTIL),
+		); err != nil {
+			return err
+		}
+	}
+	if len(stmts) > 0 || n.roleOptions.Contains(roleoption.PASSWORD) {
+		// The options or the password of the role changed.
+		if err := params.p.bumpUsersTableVersion(params.ctx); err != nil {
+			return err
+		}
+	}
 
 	optStrs := make([]string, len(n.roleOptions))
 	for i := range optStrs {
@@ -364,6 +364,11 @@ func (n *alterTableNode) startExec(params runParams) error {
 				// 	return err
 				// }
 
+			case *tree.ExclusionConstraintTableDef:
+				return unimplemented.NewWithIssueDetail(
+					46657, "exclusion constraint", "exclusion constraints are not supported",
+				)
+
 			default:
 				return errors.AssertionFailedf(
 					"unsupported constraint: %T", t.ConstraintDef)
@@ -698,6 +703,11 @@ func (n *alterTableNode) startExec(params runParams) error {
 				}, params.ExecCfg().Settings); err != nil {
 				return err
 			}
+			if err := params.p.removeConstraintComment(
+				params.ctx, n.tableDesc.ID, details.ConstraintID(),
+			); err != nil {
+				return err
+			}
 			descriptorChanged = true
 			if err := validateDescriptor(params.ctx, params.p, n.tableDesc); err != nil {
 				return err
@@ -1063,6 +1073,10 @@ func applyColumnMutation(
 		return AlterColumnType(ctx, tableDesc, col, t, params, cmds, tn)
 
 	case *tree.AlterTableSetDefault:
+		if col.GeneratedAsIdentityType != descpb.GeneratedAsIdentityType_NOT_IDENTITY_COLUMN {
+			return pgerror.Newf(pgcode.Syntax,
+				`column "%s" of relation "%s" is an identity column`, col.Name, tableDesc.Name)
+		}
 		if len(col.UsesSequenceIds) > 0 {
 			if err := params.p.removeSequenceDependencies(params.ctx, tableDesc, col); err != nil {
 				return err
@@ -1146,6 +1160,12 @@ func applyColumnMutation(
 				`column "%s" is in a primary index`, col.Name)
 		}
 
+		// Identity columns are implicitly NOT NULL.
+		if col.GeneratedAsIdentityType != descpb.GeneratedAsIdentityType_NOT_IDENTITY_COLUMN {
+			return pgerror.Newf(pgcode.Syntax,
+				`column "%s" of relation "%s" is an identity column`, col.Name, tableDesc.Name)
+		}
+
 		// See if there's already a mutation to add/drop a not null constraint.
 		for i := range tableDesc.Mutations {
 			if constraint := tableDesc.Mutations[i].GetConstraint(); constraint != nil &&
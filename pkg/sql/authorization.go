@@ -29,6 +29,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/iterutil"
 	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
 	"github.com/cockroachdb/errors"
 )
@@ -39,6 +40,10 @@ type MembershipCache struct {
 	tableVersion descpb.DescriptorVersion
 	// userCache is a mapping from username to userRoleMembership.
 	userCache map[security.SQLUsername]userRoleMembership
+	// memberships holds all the memberships stored in system.role_members. It
+	// is nil if they haven't been loaded since the last version change. See
+	// getRoleMemberships.
+	memberships roleMembershipGraph
 }
 
 // userRoleMembership is a mapping of "rolename" -> "with admin option".
@@ -183,19 +188,45 @@ func (p *planner) HasOwnership(ctx context.Context, descriptor catalog.Descripto
 func (p *planner) checkRolePredicate(
 	ctx context.Context, user security.SQLUsername, predicate func(role security.SQLUsername) bool,
 ) (bool, error) {
-	if ok := predicate(user); ok {
-		return ok, nil
+	found := false
+	err := p.forEachEnabledRole(ctx, user, func(role security.SQLUsername) error {
+		if found = predicate(role); found {
+			return iterutil.StopIteration()
+		}
+		return nil
+	})
+	return found, err
+}
+
+// forEachEnabledRole calls fn with every role whose privileges the user can
+// use: the user itself, then every role it is a member of, directly or
+// through other roles. As in Postgres, these are the roles listed by
+// information_schema.enabled_roles, and the roles considered by privilege
+// checks. The role memberships are only looked up if fn does not stop the
+// iteration on the user itself.
+// Requires a valid transaction to be open.
+func (p *planner) forEachEnabledRole(
+	ctx context.Context, user security.SQLUsername, fn func(role security.SQLUsername) error,
+) error {
+	if err := fn(user); err != nil {
+		if iterutil.Done(err) {
+			return nil
+		}
+		return err
 	}
 	memberOf, err := p.MemberOfWithAdminOption(ctx, user)
 	if err != nil {
-		return false, err
+		return err
 	}
 	for role := range memberOf {
-		if ok := predicate(role); ok {
-			return ok, nil
+		if err := fn(role); err != nil {
+			if iterutil.Done(err) {
+				return nil
+			}
+			return err
 		}
 	}
-	return false, nil
+	return nil
 }
 
 // CheckAnyPrivilege implements the AuthorizationAccessor interface.
@@ -211,27 +242,19 @@ func (p *planner) CheckAnyPrivilege(ctx context.Context, descriptor catalog.Desc
 	user := p.SessionData().User()
 	privs := descriptor.GetPrivileges()
 
-	// Check if 'user' itself has privileges.
-	if privs.AnyPrivilege(user) {
-		return nil
-	}
-
 	// Check if 'public' has privileges.
 	if privs.AnyPrivilege(security.PublicRoleName()) {
 		return nil
 	}
 
-	// Expand role memberships.
-	memberOf, err := p.MemberOfWithAdminOption(ctx, user)
+	// Check if 'user' itself or any of the roles it is a member of has
+	// privileges. We don't care about the admin option.
+	hasPriv, err := p.checkRolePredicate(ctx, user, privs.AnyPrivilege)
 	if err != nil {
 		return err
 	}
-
-	// Iterate over the roles that 'user' is a member of. We don't care about the admin option.
-	for role := range memberOf {
-		if privs.AnyPrivilege(role) {
-			return nil
-		}
+	if hasPriv {
+		return nil
 	}
 
 	return pgerror.Newf(pgcode.InsufficientPrivilege,
@@ -297,7 +320,9 @@ func (p *planner) RequireAdminRole(ctx context.Context, action string) error {
 
 // MemberOfWithAdminOption looks up all the roles 'member' belongs to (direct and indirect) and
 // returns a map of "role" -> "isAdmin".
-// The "isAdmin" flag applies to both direct and indirect members.
+// The "isAdmin" flag applies to both direct and indirect members: it is set
+// if any of the memberships through which the role is reached holds the
+// admin option.
 // Requires a valid transaction to be open.
 func (p *planner) MemberOfWithAdminOption(
 	ctx context.Context, member security.SQLUsername,
@@ -333,6 +358,7 @@ func (p *planner) MemberOfWithAdminOption(
 			// Update version and drop the map.
 			roleMembersCache.tableVersion = tableVersion
 			roleMembersCache.userCache = make(map[security.SQLUsername]userRoleMembership)
+			roleMembersCache.memberships = nil
 		}
 
 		userMapping, ok := roleMembersCache.userCache[member]
@@ -372,48 +398,213 @@ func (p *planner) MemberOfWithAdminOption(
 func (p *planner) resolveMemberOfWithAdminOption(
 	ctx context.Context, member security.SQLUsername, txn *kv.Txn,
 ) (map[security.SQLUsername]bool, error) {
-	ret := map[security.SQLUsername]bool{}
-
-	// Keep track of members we looked up.
-	visited := map[security.SQLUsername]struct{}{}
-	toVisit := []security.SQLUsername{member}
 	lookupRolesStmt := `SELECT "role", "isAdmin" FROM system.role_members WHERE "member" = $1`
+	return walkRoleMemberships(ctx, member,
+		func(ctx context.Context, m security.SQLUsername) (_ []roleMembershipEdge, retErr error) {
+			it, err := p.ExecCfg().InternalExecutor.QueryIterator(
+				ctx, "expand-roles", txn, lookupRolesStmt, m.Normalized(),
+			)
+			if err != nil {
+				return nil, err
+			}
+			defer func() { retErr = errors.CombineErrors(retErr, it.Close()) }()
+
+			var edges []roleMembershipEdge
+			var ok bool
+			for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
+				row := it.Cur()
+				roleName := tree.MustBeDString(row[0])
+				isAdmin := row[1].(*tree.DBool)
+
+				// system.role_members stores pre-normalized usernames.
+				edges = append(edges, roleMembershipEdge{
+					role:    security.MakeSQLUsernameFromPreNormalizedString(string(roleName)),
+					isAdmin: bool(*isAdmin),
+				})
+			}
+			return edges, err
+		},
+		nil /* fn */)
+}
+
+// roleMembershipEdge is a row of system.role_members, seen from the member.
+type roleMembershipEdge struct {
+	role    security.SQLUsername
+	isAdmin bool
+}
 
+// walkRoleMemberships walks breadth-first the roles that member is a direct or
+// indirect member of, looking up the direct memberships of every role reached
+// with directMemberships. If fn is not nil, it is called once per role with
+// the shortest path of roles leading to it from the member, member included.
+//
+// It returns a map of "role" -> "isAdmin" as described in
+// MemberOfWithAdminOption: a role may be reachable through several
+// memberships, and the admin option is held if any of them grants it.
+func walkRoleMemberships(
+	ctx context.Context,
+	member security.SQLUsername,
+	directMemberships func(context.Context, security.SQLUsername) ([]roleMembershipEdge, error),
+	fn func(role security.SQLUsername, path []security.SQLUsername) error,
+) (map[security.SQLUsername]bool, error) {
+	ret := map[security.SQLUsername]bool{}
+
+	type step struct {
+		role security.SQLUsername
+		path []security.SQLUsername
+	}
+	// Keep track of the roles we reached, the member included.
+	visited := map[security.SQLUsername]struct{}{member: {}}
+	toVisit := []step{{role: member, path: []security.SQLUsername{member}}}
 	for len(toVisit) > 0 {
 		// Pop first element.
-		m := toVisit[0]
+		cur := toVisit[0]
 		toVisit = toVisit[1:]
-		if _, ok := visited[m]; ok {
-			continue
-		}
-		visited[m] = struct{}{}
 
-		it, err := p.ExecCfg().InternalExecutor.QueryIterator(
-			ctx, "expand-roles", txn, lookupRolesStmt, m.Normalized(),
-		)
+		edges, err := directMemberships(ctx, cur.role)
 		if err != nil {
 			return nil, err
 		}
+		for _, edge := range edges {
+			// The admin option must not be overwritten by a membership without
+			// it.
+			ret[edge.role] = ret[edge.role] || edge.isAdmin
+			if _, ok := visited[edge.role]; ok {
+				continue
+			}
+			visited[edge.role] = struct{}{}
+			path := make([]security.SQLUsername, len(cur.path)+1)
+			copy(path, cur.path)
+			path[len(cur.path)] = edge.role
+			if fn != nil {
+				if err := fn(edge.role, path); err != nil {
+					return nil, err
+				}
+			}
+			toVisit = append(toVisit, step{role: edge.role, path: path})
+		}
+	}
+
+	return ret, nil
+}
 
-		var ok bool
-		for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
-			row := it.Cur()
-			roleName := tree.MustBeDString(row[0])
-			isAdmin := row[1].(*tree.DBool)
+// roleMembershipGraph holds all the memberships stored in system.role_members,
+// keyed by member. It is used to expand the memberships of many users, which
+// would otherwise each require their own lookups in system.role_members.
+type roleMembershipGraph map[security.SQLUsername][]roleMembershipEdge
+
+// directMemberships returns the roles that member is a direct member of. It
+// can be passed to walkRoleMemberships.
+func (g roleMembershipGraph) directMemberships(
+	_ context.Context, member security.SQLUsername,
+) ([]roleMembershipEdge, error) {
+	return g[member], nil
+}
 
-			// system.role_members stores pre-normalized usernames.
-			role := security.MakeSQLUsernameFromPreNormalizedString(string(roleName))
-			ret[role] = bool(*isAdmin)
+// memberOfWithAdminOption is like MemberOfWithAdminOption, but it expands the
+// memberships stored in the graph.
+func (g roleMembershipGraph) memberOfWithAdminOption(
+	ctx context.Context, member security.SQLUsername,
+) (map[security.SQLUsername]bool, error) {
+	return walkRoleMemberships(ctx, member, g.directMemberships, nil /* fn */)
+}
 
-			// We need to expand this role. Let the "pop" worry about already-visited elements.
-			toVisit = append(toVisit, role)
-		}
+// getRoleMemberships returns all the memberships stored in system.role_members.
+// They are read once per statement, and shared across statements through the
+// MembershipCache until system.role_members is modified. The returned graph
+// must not be modified.
+// Requires a valid transaction to be open.
+func (p *planner) getRoleMemberships(ctx context.Context) (roleMembershipGraph, error) {
+	if p.roleMemberships != nil {
+		return p.roleMemberships, nil
+	}
+	if p.txn == nil || !p.txn.IsOpen() {
+		return nil, errors.AssertionFailedf("cannot use getRoleMemberships without a txn")
+	}
+
+	roleMembersCache := p.execCfg.RoleMemberCache
+
+	// Lookup table version.
+	_, tableDesc, err := p.Descriptors().GetImmutableTableByName(
+		ctx,
+		p.txn,
+		&roleMembersTableName,
+		p.ObjectLookupFlags(true /*required*/, false /*requireMutable*/),
+	)
+	if err != nil {
+		return nil, err
+	}
+	tableVersion := tableDesc.GetVersion()
+	if tableDesc.IsUncommittedVersion() {
+		// The memberships were modified by the current transaction.
+		graph, err := loadRoleMemberships(ctx, p.ExecCfg(), p.txn)
 		if err != nil {
 			return nil, err
 		}
+		p.roleMemberships = graph
+		return graph, nil
 	}
 
-	return ret, nil
+	// We loop in case the table version changes while we're loading the
+	// memberships.
+	for {
+		roleMembersCache.Lock()
+		if roleMembersCache.tableVersion != tableVersion {
+			// Update version and drop the cached memberships.
+			roleMembersCache.tableVersion = tableVersion
+			roleMembersCache.userCache = make(map[security.SQLUsername]userRoleMembership)
+			roleMembersCache.memberships = nil
+		}
+		graph := roleMembersCache.memberships
+		roleMembersCache.Unlock()
+
+		if graph == nil {
+			// Load the memberships outside the lock.
+			if graph, err = loadRoleMemberships(ctx, p.ExecCfg(), nil /* txn */); err != nil {
+				return nil, err
+			}
+
+			roleMembersCache.Lock()
+			if roleMembersCache.tableVersion != tableVersion {
+				// Table version has changed while we were loading, unlock and start over.
+				tableVersion = roleMembersCache.tableVersion
+				roleMembersCache.Unlock()
+				continue
+			}
+			roleMembersCache.memberships = graph
+			roleMembersCache.Unlock()
+		}
+
+		p.roleMemberships = graph
+		return graph, nil
+	}
+}
+
+// loadRoleMemberships reads all the memberships stored in system.role_members.
+func loadRoleMemberships(
+	ctx context.Context, execCfg *ExecutorConfig, txn *kv.Txn,
+) (roleMembershipGraph, error) {
+	query := `SELECT "role", "member", "isAdmin" FROM system.role_members`
+	rows, err := execCfg.InternalExecutor.QueryBuffered(ctx, "read-members", txn, query)
+	if err != nil {
+		return nil, err
+	}
+	// The cache relies on a nil graph to denote memberships which aren't
+	// loaded.
+	graph := make(roleMembershipGraph)
+	for _, row := range rows {
+		roleName := tree.MustBeDString(row[0])
+		memberName := tree.MustBeDString(row[1])
+		isAdmin := row[2].(*tree.DBool)
+
+		// system.role_members stores pre-normalized usernames.
+		member := security.MakeSQLUsernameFromPreNormalizedString(string(memberName))
+		graph[member] = append(graph[member], roleMembershipEdge{
+			role:    security.MakeSQLUsernameFromPreNormalizedString(string(roleName)),
+			isAdmin: bool(*isAdmin),
+		})
+	}
+	return graph, nil
 }
 
 // HasRoleOption implements the AuthorizationAccessor interface.
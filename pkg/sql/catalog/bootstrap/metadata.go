@@ -347,6 +347,7 @@ func addSystemDescriptorsToSchema(target *MetadataSchema) {
 	// Tables introduced in 21.1.
 
 	target.AddDescriptor(keys.SystemDatabaseID, systemschema.JoinTokensTable)
+	target.AddDescriptor(keys.SystemDatabaseID, systemschema.LoginAttemptsTable)
 }
 
 // addSplitIDs adds a split point for each of the PseudoTableIDs to the supplied
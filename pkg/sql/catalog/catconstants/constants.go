@@ -81,6 +81,18 @@ const (
 	CrdbInternalClusterDatabasePrivilegesTableID
 	CrdbInternalInterleaved
 	CrdbInternalCrossDbRefrences
+	CrdbInternalCatalogDiscrepanciesTableID
+	CrdbInternalDefaultPrivilegesTableID
+	CrdbInternalRolesTableID
+	CrdbInternalDatabasePrivilegesTableID
+	CrdbInternalMaterializedViewsTableID
+	CrdbInternalViewDependencyClosureTableID
+	CrdbInternalSerialSequencesTableID
+	CrdbInternalRoleOptionsTableID
+	CrdbInternalRoleMembershipClosureTableID
+	CrdbInternalRolePasswordPoliciesTableID
+	CrdbInternalExternalPrincipalMappingsTableID
+	CrdbInternalVirtualTablePopulationsTableID
 	InformationSchemaID
 	InformationSchemaAdministrableRoleAuthorizationsID
 	InformationSchemaApplicableRolesID
@@ -92,11 +104,17 @@ const (
 	InformationSchemaColumnsTableID
 	InformationSchemaColumnUDTUsageID
 	InformationSchemaConstraintColumnUsageTableID
+	InformationSchemaElementTypesID
 	InformationSchemaEnabledRolesID
+	InformationSchemaEnginesID
+	InformationSchemaEventsID
 	InformationSchemaKeyColumnUsageTableID
 	InformationSchemaParametersTableID
+	InformationSchemaPartitionsTableID
+	InformationSchemaPluginsID
 	InformationSchemaReferentialConstraintsTableID
 	InformationSchemaRoleTableGrantsID
+	InformationSchemaRoutinePrivilegesID
 	InformationSchemaRoutineTableID
 	InformationSchemaSchemataTableID
 	InformationSchemaSchemataTablePrivilegesID
@@ -107,6 +125,7 @@ const (
 	InformationSchemaTablePrivilegesID
 	InformationSchemaTablesTableID
 	InformationSchemaTypePrivilegesID
+	InformationSchemaUsagePrivilegesID
 	InformationSchemaViewsTableID
 	InformationSchemaUserPrivilegesID
 	PgCatalogID
@@ -166,6 +185,7 @@ const (
 	PgCatalogRulesTableID
 	PgCatalogSecLabelsTableID
 	PgCatalogSecurityLabelTableID
+	PgCatalogSequenceTableID
 	PgCatalogSequencesTableID
 	PgCatalogSettingsTableID
 	PgCatalogShadowTableID
@@ -166,5 +166,9 @@ func IndexForDisplay(
 		f.WriteString(pred)
 	}
 
+	if index.NotVisible {
+		f.WriteString(" NOT VISIBLE")
+	}
+
 	return f.CloseAndGetString(), nil
 }
@@ -56,6 +56,12 @@ func TestIndexForDisplay(t *testing.T) {
 	partialIndex := baseIndex
 	partialIndex.Predicate = "a > 1:::INT8"
 
+	notVisibleIndex := baseIndex
+	notVisibleIndex.NotVisible = true
+
+	notVisiblePartialIndex := partialIndex
+	notVisiblePartialIndex.NotVisible = true
+
 	testData := []struct {
 		index      descpb.IndexDescriptor
 		tableName  tree.TableName
@@ -69,6 +75,8 @@ func TestIndexForDisplay(t *testing.T) {
 		{invertedIndex, descpb.AnonymousTable, "", "", "INVERTED INDEX baz (a)"},
 		{storingIndex, descpb.AnonymousTable, "", "", "INDEX baz (a ASC, b DESC) STORING (c)"},
 		{partialIndex, descpb.AnonymousTable, "", "", "INDEX baz (a ASC, b DESC) WHERE a > 1:::INT8"},
+		{notVisibleIndex, descpb.AnonymousTable, "", "", "INDEX baz (a ASC, b DESC) NOT VISIBLE"},
+		{notVisiblePartialIndex, descpb.AnonymousTable, "", "", "INDEX baz (a ASC, b DESC) WHERE a > 1:::INT8 NOT VISIBLE"},
 		{
 			partialIndex,
 			descpb.AnonymousTable,
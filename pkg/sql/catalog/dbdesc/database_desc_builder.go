@@ -127,6 +127,14 @@ func MaybeWithDatabaseRegionConfig(regionConfig *multiregion.RegionConfig) NewIn
 	}
 }
 
+// WithDefaultCollation is an option allowing the default collation of the
+// database to be set on the database descriptor.
+func WithDefaultCollation(collation string) NewInitialOption {
+	return func(desc *descpb.DatabaseDescriptor) {
+		desc.DefaultCollation = collation
+	}
+}
+
 // NewInitial constructs a new Mutable for an initial version from an id and
 // name with default privileges.
 func NewInitial(
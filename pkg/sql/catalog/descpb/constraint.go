@@ -120,3 +120,19 @@ type ConstraintDetail struct {
 	// Only populated for Check Constraints.
 	CheckConstraint *TableDescriptor_CheckConstraint
 }
+
+// ConstraintID returns the ID of the constraint, which is stored on the
+// descriptor of the constraint or of the index backing it.
+func (c ConstraintDetail) ConstraintID() ConstraintID {
+	switch {
+	case c.Index != nil:
+		return c.Index.ConstraintID
+	case c.UniqueWithoutIndexConstraint != nil:
+		return c.UniqueWithoutIndexConstraint.ConstraintID
+	case c.FK != nil:
+		return c.FK.ConstraintID
+	case c.CheckConstraint != nil:
+		return c.CheckConstraint.ConstraintID
+	}
+	return InvalidConstraintID
+}
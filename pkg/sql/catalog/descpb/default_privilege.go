@@ -0,0 +1,167 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package descpb
+
+import (
+	"sort"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/errors"
+)
+
+// Role accesses the role field.
+func (d DefaultPrivilegesForRole) Role() security.SQLUsername {
+	return d.UserProto.Decode()
+}
+
+// ToPrivilegeObjectType returns the privilege.ObjectType used to validate
+// and display the privileges of objects of this type.
+func (o DefaultPrivilegesForRole_ObjectType) ToPrivilegeObjectType() privilege.ObjectType {
+	switch o {
+	case DefaultPrivilegesForRole_TABLES:
+		return privilege.Table
+	case DefaultPrivilegesForRole_SEQUENCES:
+		return privilege.Sequence
+	case DefaultPrivilegesForRole_TYPES:
+		return privilege.Type
+	case DefaultPrivilegesForRole_SCHEMAS:
+		return privilege.Schema
+	default:
+		panic(errors.AssertionFailedf("unknown default privileges object type %d", o))
+	}
+}
+
+// lessThan orders entries by role, then schema and then object type.
+func (d DefaultPrivilegesForRole) lessThan(
+	role security.SQLUsername, schemaID ID, objectType DefaultPrivilegesForRole_ObjectType,
+) bool {
+	if r := d.Role(); r != role {
+		return r.LessThan(role)
+	}
+	if d.SchemaID != schemaID {
+		return d.SchemaID < schemaID
+	}
+	return d.ObjectType < objectType
+}
+
+func (d DefaultPrivilegesForRole) matches(
+	role security.SQLUsername, schemaID ID, objectType DefaultPrivilegesForRole_ObjectType,
+) bool {
+	return d.Role() == role && d.SchemaID == schemaID && d.ObjectType == objectType
+}
+
+// findIndex looks for the entry with the given key and returns its index in
+// the list if found. Otherwise, returns the index at which it would be
+// inserted and false.
+func (p DefaultPrivilegeDescriptor) findIndex(
+	role security.SQLUsername, schemaID ID, objectType DefaultPrivilegesForRole_ObjectType,
+) (int, bool) {
+	idx := sort.Search(len(p.DefaultPrivilegesPerRole), func(i int) bool {
+		return !p.DefaultPrivilegesPerRole[i].lessThan(role, schemaID, objectType)
+	})
+	return idx, idx < len(p.DefaultPrivilegesPerRole) &&
+		p.DefaultPrivilegesPerRole[idx].matches(role, schemaID, objectType)
+}
+
+// findOrCreate looks for the entry with the given key, creating it if needed.
+func (p *DefaultPrivilegeDescriptor) findOrCreate(
+	role security.SQLUsername, schemaID ID, objectType DefaultPrivilegesForRole_ObjectType,
+) *DefaultPrivilegesForRole {
+	idx, found := p.findIndex(role, schemaID, objectType)
+	if !found {
+		p.DefaultPrivilegesPerRole = append(p.DefaultPrivilegesPerRole, DefaultPrivilegesForRole{})
+		copy(p.DefaultPrivilegesPerRole[idx+1:], p.DefaultPrivilegesPerRole[idx:])
+		p.DefaultPrivilegesPerRole[idx] = DefaultPrivilegesForRole{
+			UserProto:  role.EncodeProto(),
+			SchemaID:   schemaID,
+			ObjectType: objectType,
+		}
+	}
+	return &p.DefaultPrivilegesPerRole[idx]
+}
+
+// GrantDefaultPrivileges records that the privileges in privList are to be
+// granted to grantee on the objects of the given type created by role. A
+// schemaID of 0 applies to objects created in any schema of the database.
+func (p *DefaultPrivilegeDescriptor) GrantDefaultPrivileges(
+	role security.SQLUsername,
+	schemaID ID,
+	objectType DefaultPrivilegesForRole_ObjectType,
+	grantee security.SQLUsername,
+	privList privilege.List,
+	withGrantOption bool,
+) {
+	defaultPrivs := p.findOrCreate(role, schemaID, objectType)
+	defaultPrivs.Privileges.Grant(grantee, privList)
+	if withGrantOption {
+		defaultPrivs.Privileges.GrantGrantOption(grantee, privList)
+	}
+}
+
+// RevokeDefaultPrivileges removes the privileges in privList from the
+// defaults granted to grantee by role. If grantOptionFor is set, only the
+// grant option is removed.
+func (p *DefaultPrivilegeDescriptor) RevokeDefaultPrivileges(
+	role security.SQLUsername,
+	schemaID ID,
+	objectType DefaultPrivilegesForRole_ObjectType,
+	grantee security.SQLUsername,
+	privList privilege.List,
+	grantOptionFor bool,
+) {
+	idx, found := p.findIndex(role, schemaID, objectType)
+	if !found {
+		// Revoking default privileges which were never granted is a no-op.
+		return
+	}
+	defaultPrivs := &p.DefaultPrivilegesPerRole[idx]
+	if grantOptionFor {
+		defaultPrivs.Privileges.RevokeGrantOption(grantee, privList, objectType.ToPrivilegeObjectType())
+	} else {
+		defaultPrivs.Privileges.Revoke(grantee, privList, objectType.ToPrivilegeObjectType())
+	}
+	if len(defaultPrivs.Privileges.Users) == 0 {
+		p.DefaultPrivilegesPerRole = append(
+			p.DefaultPrivilegesPerRole[:idx], p.DefaultPrivilegesPerRole[idx+1:]...)
+	}
+}
+
+// ApplyDefaultPrivileges grants to privs the default privileges set by role
+// for objects of the given type created in the given schema. Defaults set
+// for all schemas and defaults set for that specific schema both apply. The
+// role is recorded as the grantor. It is a no-op on a nil receiver.
+func (p *DefaultPrivilegeDescriptor) ApplyDefaultPrivileges(
+	role security.SQLUsername,
+	schemaID ID,
+	objectType DefaultPrivilegesForRole_ObjectType,
+	privs *PrivilegeDescriptor,
+) {
+	if p == nil {
+		return
+	}
+	for _, id := range []ID{0, schemaID} {
+		idx, found := p.findIndex(role, id, objectType)
+		if !found {
+			continue
+		}
+		for _, u := range p.DefaultPrivilegesPerRole[idx].Privileges.Users {
+			privList := privilege.ListFromBitField(u.Privileges, objectType.ToPrivilegeObjectType())
+			grantOptionList := privilege.ListFromBitField(u.WithGrantOption, objectType.ToPrivilegeObjectType())
+			privs.Grant(u.User(), privList)
+			privs.GrantGrantOption(u.User(), grantOptionList)
+			privs.RecordGrant(u.User(), role, privList, grantOptionList)
+		}
+		if schemaID == 0 {
+			break
+		}
+	}
+}
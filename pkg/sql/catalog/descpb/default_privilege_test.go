@@ -0,0 +1,100 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package descpb
+
+import (
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+)
+
+func TestDefaultPrivileges(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	creator := security.MakeSQLUsernameFromPreNormalizedString("creator")
+	fooUser := security.MakeSQLUsernameFromPreNormalizedString("foo")
+	barUser := security.MakeSQLUsernameFromPreNormalizedString("bar")
+	const schemaID = ID(52)
+
+	var defaultPrivs DefaultPrivilegeDescriptor
+	defaultPrivs.GrantDefaultPrivileges(
+		creator, 0 /* schemaID */, DefaultPrivilegesForRole_TABLES,
+		fooUser, privilege.List{privilege.SELECT}, false, /* withGrantOption */
+	)
+	defaultPrivs.GrantDefaultPrivileges(
+		creator, schemaID, DefaultPrivilegesForRole_TABLES,
+		barUser, privilege.List{privilege.INSERT}, true, /* withGrantOption */
+	)
+	defaultPrivs.GrantDefaultPrivileges(
+		creator, 0 /* schemaID */, DefaultPrivilegesForRole_TYPES,
+		fooUser, privilege.List{privilege.USAGE}, false, /* withGrantOption */
+	)
+	if n := len(defaultPrivs.DefaultPrivilegesPerRole); n != 3 {
+		t.Fatalf("expected 3 entries, found %d: %+v", n, defaultPrivs)
+	}
+
+	// A table created in the schema receives both the database-wide and the
+	// schema-specific defaults, granted by the creating role.
+	privs := NewDefaultPrivilegeDescriptor(creator)
+	defaultPrivs.ApplyDefaultPrivileges(creator, schemaID, DefaultPrivilegesForRole_TABLES, privs)
+	if !privs.CheckPrivilege(fooUser, privilege.SELECT) {
+		t.Errorf("expected SELECT for %s in %+v", fooUser, privs)
+	}
+	if privs.CheckPrivilege(fooUser, privilege.USAGE) {
+		t.Errorf("unexpected USAGE for %s in %+v", fooUser, privs)
+	}
+	if !privs.CheckGrantOptions(barUser, privilege.List{privilege.INSERT}) {
+		t.Errorf("expected grant option for INSERT for %s in %+v", barUser, privs)
+	}
+	if u, ok := privs.findUser(barUser); !ok || u.Grantor() != creator {
+		t.Errorf("expected %s to be the grantor in %+v", creator, privs)
+	}
+
+	// Objects created elsewhere or by other roles are unaffected by the
+	// schema-specific defaults.
+	privs = NewDefaultPrivilegeDescriptor(creator)
+	defaultPrivs.ApplyDefaultPrivileges(creator, schemaID+1, DefaultPrivilegesForRole_TABLES, privs)
+	if privs.CheckPrivilege(barUser, privilege.INSERT) {
+		t.Errorf("unexpected INSERT for %s in %+v", barUser, privs)
+	}
+	privs = NewDefaultPrivilegeDescriptor(fooUser)
+	defaultPrivs.ApplyDefaultPrivileges(fooUser, schemaID, DefaultPrivilegesForRole_TABLES, privs)
+	if privs.CheckPrivilege(fooUser, privilege.SELECT) {
+		t.Errorf("unexpected SELECT for %s in %+v", fooUser, privs)
+	}
+
+	// Revoking the grant option keeps the privilege; revoking the last
+	// privilege removes the entry.
+	defaultPrivs.RevokeDefaultPrivileges(
+		creator, schemaID, DefaultPrivilegesForRole_TABLES,
+		barUser, privilege.List{privilege.INSERT}, true, /* grantOptionFor */
+	)
+	if n := len(defaultPrivs.DefaultPrivilegesPerRole); n != 3 {
+		t.Fatalf("expected 3 entries, found %d: %+v", n, defaultPrivs)
+	}
+	defaultPrivs.RevokeDefaultPrivileges(
+		creator, schemaID, DefaultPrivilegesForRole_TABLES,
+		barUser, privilege.List{privilege.INSERT}, false, /* grantOptionFor */
+	)
+	if n := len(defaultPrivs.DefaultPrivilegesPerRole); n != 2 {
+		t.Fatalf("expected 2 entries, found %d: %+v", n, defaultPrivs)
+	}
+
+	// A nil descriptor has no defaults.
+	var nilDefaultPrivs *DefaultPrivilegeDescriptor
+	privs = NewDefaultPrivilegeDescriptor(creator)
+	nilDefaultPrivs.ApplyDefaultPrivileges(creator, schemaID, DefaultPrivilegesForRole_TABLES, privs)
+	if privs.AnyPrivilege(fooUser) {
+		t.Errorf("unexpected privileges for %s in %+v", fooUser, privs)
+	}
+}
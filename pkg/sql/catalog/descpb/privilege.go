@@ -49,6 +49,16 @@ func (u UserPrivileges) User() security.SQLUsername {
 	return u.UserProto.Decode()
 }
 
+// Grantor accesses the grantor field.
+func (u UserPrivileges) Grantor() security.SQLUsername {
+	return u.GrantorProto.Decode()
+}
+
+// Grantor accesses the grantor field.
+func (g GrantedPrivileges) Grantor() security.SQLUsername {
+	return g.GrantorProto.Decode()
+}
+
 // findUserIndex looks for a given user and returns its
 // index in the User array if found. Returns -1 otherwise.
 func (p PrivilegeDescriptor) findUserIndex(user security.SQLUsername) int {
@@ -169,6 +179,33 @@ func (p *PrivilegeDescriptor) Grant(user security.SQLUsername, privList privileg
 	userPriv.Privileges |= bits
 }
 
+// RecordGrant records that grantor granted the privileges in privList to the
+// given user, along with the grant option for the privileges in
+// grantOptionList. The grantor also becomes the one reported for the user. It
+// is a no-op if the user has no privileges.
+func (p *PrivilegeDescriptor) RecordGrant(
+	user, grantor security.SQLUsername, privList, grantOptionList privilege.List,
+) {
+	userPriv, ok := p.findUser(user)
+	if !ok {
+		return
+	}
+	userPriv.GrantorProto = grantor.EncodeProto()
+	var grant *GrantedPrivileges
+	for i := range userPriv.Grants {
+		if userPriv.Grants[i].Grantor() == grantor {
+			grant = &userPriv.Grants[i]
+			break
+		}
+	}
+	if grant == nil {
+		userPriv.Grants = append(userPriv.Grants, GrantedPrivileges{GrantorProto: grantor.EncodeProto()})
+		grant = &userPriv.Grants[len(userPriv.Grants)-1]
+	}
+	grant.Privileges |= privList.ToBitField()
+	grant.WithGrantOption |= grantOptionList.ToBitField()
+}
+
 // Revoke removes privileges from this descriptor for a given list of users.
 func (p *PrivilegeDescriptor) Revoke(
 	user security.SQLUsername, privList privilege.List, objectType privilege.ObjectType,
@@ -188,26 +225,147 @@ func (p *PrivilegeDescriptor) Revoke(
 		return
 	}
 
-	if isPrivilegeSet(userPriv.Privileges, privilege.ALL) {
+	userPriv.Privileges = removePrivileges(userPriv.Privileges, bits, objectType)
+	// A user cannot retain the grant option for a privilege it no longer holds.
+	userPriv.WithGrantOption = removePrivileges(userPriv.WithGrantOption, bits, objectType)
+	userPriv.removeGrants(bits, objectType, false /* grantOptionOnly */, nil /* byGrantor */)
+
+	if userPriv.Privileges == 0 {
+		p.removeUser(user)
+	}
+}
+
+// removePrivileges clears bits from privs. If privs holds the 'ALL' privilege,
+// it is first expanded into all other privileges valid for objectType.
+func removePrivileges(privs, bits uint32, objectType privilege.ObjectType) uint32 {
+	if isPrivilegeSet(privs, privilege.ALL) {
 		// User has 'ALL' privilege. Remove it and set
 		// all other privileges one.
 		validPrivs := privilege.GetValidPrivilegesForObject(objectType)
-		userPriv.Privileges = 0
+		privs = 0
 		for _, v := range validPrivs {
 			if v != privilege.ALL {
-				userPriv.Privileges |= v.Mask()
+				privs |= v.Mask()
 			}
 		}
 	}
 
 	// One doesn't see "AND NOT" very often.
-	userPriv.Privileges &^= bits
+	return privs &^ bits
+}
+
+// GrantGrantOption allows the given user to grant the privileges in privList
+// to other users. It is a no-op if the user has no privileges.
+func (p *PrivilegeDescriptor) GrantGrantOption(user security.SQLUsername, privList privilege.List) {
+	userPriv, ok := p.findUser(user)
+	if !ok || isPrivilegeSet(userPriv.WithGrantOption, privilege.ALL) {
+		return
+	}
+
+	bits := privList.ToBitField()
+	if isPrivilegeSet(bits, privilege.ALL) {
+		userPriv.WithGrantOption = privilege.ALL.Mask()
+		return
+	}
+	userPriv.WithGrantOption |= bits
+}
+
+// RevokeGrantOption removes the grant option for the privileges in privList
+// from the given user. The privileges themselves are retained.
+func (p *PrivilegeDescriptor) RevokeGrantOption(
+	user security.SQLUsername, privList privilege.List, objectType privilege.ObjectType,
+) {
+	userPriv, ok := p.findUser(user)
+	if !ok {
+		return
+	}
 
+	bits := privList.ToBitField()
+	userPriv.removeGrants(bits, objectType, true /* grantOptionOnly */, nil /* byGrantor */)
+	if isPrivilegeSet(bits, privilege.ALL) {
+		userPriv.WithGrantOption = 0
+		return
+	}
+	userPriv.WithGrantOption = removePrivileges(userPriv.WithGrantOption, bits, objectType)
+}
+
+// RevokeGrantedBy removes the privileges in privList, or only their grant
+// option if grantOptionOnly is set, which were granted to the given user by
+// the grantors for which byGrantor returns true. The privileges which were
+// also granted by other grantors, or which were not granted with GRANT, are
+// retained.
+func (p *PrivilegeDescriptor) RevokeGrantedBy(
+	user security.SQLUsername,
+	privList privilege.List,
+	objectType privilege.ObjectType,
+	grantOptionOnly bool,
+	byGrantor func(grantor security.SQLUsername) bool,
+) {
+	userPriv, ok := p.findUser(user)
+	if !ok {
+		return
+	}
+	privs, grantOptions := userPriv.removeGrants(
+		privList.ToBitField(), objectType, grantOptionOnly, byGrantor)
+	if privs != 0 {
+		userPriv.Privileges = removePrivileges(userPriv.Privileges, privs, objectType)
+		// A user cannot retain the grant option for a privilege it no longer
+		// holds.
+		grantOptions |= privs
+	}
+	if grantOptions != 0 {
+		userPriv.WithGrantOption = removePrivileges(userPriv.WithGrantOption, grantOptions, objectType)
+	}
 	if userPriv.Privileges == 0 {
 		p.removeUser(user)
 	}
 }
 
+// removeGrants removes the privileges in bits, or only their grant option if
+// grantOptionOnly is set, from the grants recorded for the user by the
+// grantors for which byGrantor returns true, or by any grantor if byGrantor is
+// nil. It returns the privileges and the grant options which were removed and
+// which no other grantor granted.
+func (u *UserPrivileges) removeGrants(
+	bits uint32,
+	objectType privilege.ObjectType,
+	grantOptionOnly bool,
+	byGrantor func(grantor security.SQLUsername) bool,
+) (privs, grantOptions uint32) {
+	bits = expandPrivileges(bits, objectType)
+	var retainedPrivs, retainedGrantOptions uint32
+	grants := u.Grants[:0]
+	for _, g := range u.Grants {
+		gPrivs := expandPrivileges(g.Privileges, objectType)
+		gGrantOptions := expandPrivileges(g.WithGrantOption, objectType)
+		if byGrantor == nil || byGrantor(g.Grantor()) {
+			if !grantOptionOnly {
+				privs |= gPrivs & bits
+				g.Privileges = gPrivs &^ bits
+			}
+			grantOptions |= gGrantOptions & bits
+			g.WithGrantOption = gGrantOptions &^ bits
+		} else {
+			retainedPrivs |= gPrivs
+			retainedGrantOptions |= gGrantOptions
+		}
+		if g.Privileges != 0 || g.WithGrantOption != 0 {
+			grants = append(grants, g)
+		}
+	}
+	u.Grants = grants
+	return privs &^ retainedPrivs, grantOptions &^ retainedGrantOptions
+}
+
+// expandPrivileges replaces the 'ALL' privilege in bits, if set, with all the
+// other privileges valid for objectType.
+func expandPrivileges(bits uint32, objectType privilege.ObjectType) uint32 {
+	if !isPrivilegeSet(bits, privilege.ALL) {
+		return bits
+	}
+	return removePrivileges(bits, privilege.ALL.Mask(), objectType)
+}
+
 // MaybeFixPrivileges fixes the privilege descriptor if needed, including:
 // * adding default privileges for the "admin" role
 // * fixing default privileges for the "root" user
@@ -304,6 +462,13 @@ func (p PrivilegeDescriptor) Validate(id ID, objectType privilege.ObjectType) er
 	}
 
 	allowedPrivilegesBits := privilege.GetValidPrivilegesForObject(objectType).ToBitField()
+	// Sequences used to be granted the privileges of tables. Those which are
+	// not valid for sequences are tolerated on existing descriptors: no
+	// privilege check asks for them on a sequence, and they are not reported.
+	var legacyPrivilegesBits uint32
+	if objectType == privilege.Sequence {
+		legacyPrivilegesBits = privilege.TablePrivileges.ToBitField() &^ allowedPrivilegesBits
+	}
 
 	// For all non-super users, privileges must not exceed the allowed privileges.
 	// Also the privileges must be valid on the object type.
@@ -313,12 +478,12 @@ func (p PrivilegeDescriptor) Validate(id ID, objectType privilege.ObjectType) er
 			continue
 		}
 
-		if remaining := u.Privileges &^ allowedPrivilegesBits; remaining != 0 {
+		if remaining := u.Privileges &^ (allowedPrivilegesBits | legacyPrivilegesBits); remaining != 0 {
 			return fmt.Errorf("user %s must not have %s privileges on %s%s with ID=%d",
 				u.User(), privilege.ListFromBitField(remaining, privilege.Any), maybeSystem, objectType, id)
 		}
 		// Get all the privilege bits set on the descriptor even if they're not valid.
-		privs := privilege.ListFromBitField(u.Privileges, privilege.Any)
+		privs := privilege.ListFromBitField(u.Privileges&^legacyPrivilegesBits, privilege.Any)
 		if err := privilege.ValidatePrivileges(
 			privs, objectType,
 		); err != nil {
@@ -393,6 +558,34 @@ func (p PrivilegeDescriptor) CheckPrivilege(user security.SQLUsername, priv priv
 	return isPrivilegeSet(userPriv.Privileges, priv)
 }
 
+// CheckGrantOptions returns true if 'user' holds the grant option for every
+// privilege in privList on this descriptor.
+func (p PrivilegeDescriptor) CheckGrantOptions(
+	user security.SQLUsername, privList privilege.List,
+) bool {
+	userPriv, ok := p.findUser(user)
+	if !ok {
+		return false
+	}
+	for _, priv := range privList {
+		if !isPrivilegeSet(userPriv.WithGrantOption, privilege.ALL) &&
+			!isPrivilegeSet(userPriv.WithGrantOption, priv) {
+			return false
+		}
+	}
+	return true
+}
+
+// CanGrant returns true if the user may grant 'priv' to other users, either
+// because it holds the grant option for it or because it holds the GRANT
+// privilege.
+func (u UserPrivileges) CanGrant(priv privilege.Kind) bool {
+	if isPrivilegeSet(u.WithGrantOption, privilege.ALL) || isPrivilegeSet(u.WithGrantOption, priv) {
+		return true
+	}
+	return isPrivilegeSet(u.Privileges, privilege.ALL) || isPrivilegeSet(u.Privileges, privilege.GRANT)
+}
+
 // AnyPrivilege returns true if 'user' has any privilege on this descriptor.
 func (p PrivilegeDescriptor) AnyPrivilege(user security.SQLUsername) bool {
 	if p.Owner() == user {
@@ -449,6 +642,7 @@ var SystemAllowedPrivileges = map[ID]privilege.List{
 	keys.SqllivenessID:                        privilege.ReadWriteData,
 	keys.MigrationsID:                         privilege.ReadWriteData,
 	keys.JoinTokensTableID:                    privilege.ReadWriteData,
+	keys.LoginAttemptsTableID:                 privilege.ReadWriteData,
 }
 
 // SetOwner sets the owner of the privilege descriptor to the provided string.
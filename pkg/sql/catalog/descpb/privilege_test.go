@@ -249,6 +249,115 @@ func TestAnyPrivilege(t *testing.T) {
 	}
 }
 
+func TestGrantOption(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	testUser := security.TestUserName()
+	descriptor := NewPrivilegeDescriptor(
+		testUser, privilege.List{privilege.SELECT, privilege.INSERT}, security.AdminRoleName(),
+	)
+	userPrivs := func() UserPrivileges {
+		u, ok := descriptor.findUser(testUser)
+		if !ok {
+			t.Fatalf("user %s not found in %+v", testUser, descriptor)
+		}
+		return *u
+	}
+
+	if descriptor.CheckGrantOptions(testUser, privilege.List{privilege.SELECT}) {
+		t.Errorf("unexpected grant option for SELECT in %+v", descriptor)
+	}
+	if userPrivs().CanGrant(privilege.SELECT) {
+		t.Errorf("unexpected grantable SELECT in %+v", descriptor)
+	}
+
+	descriptor.GrantGrantOption(testUser, privilege.List{privilege.SELECT, privilege.INSERT})
+	if !descriptor.CheckGrantOptions(testUser, privilege.List{privilege.SELECT, privilege.INSERT}) {
+		t.Errorf("expected grant options for SELECT and INSERT in %+v", descriptor)
+	}
+
+	// Revoking the grant option retains the privilege itself.
+	descriptor.RevokeGrantOption(testUser, privilege.List{privilege.INSERT}, privilege.Table)
+	if descriptor.CheckGrantOptions(testUser, privilege.List{privilege.INSERT}) {
+		t.Errorf("unexpected grant option for INSERT in %+v", descriptor)
+	}
+	if !descriptor.CheckPrivilege(testUser, privilege.INSERT) {
+		t.Errorf("expected INSERT privilege in %+v", descriptor)
+	}
+	if !userPrivs().CanGrant(privilege.SELECT) {
+		t.Errorf("expected SELECT to be grantable in %+v", descriptor)
+	}
+
+	// Revoking the privilege also revokes its grant option.
+	descriptor.Revoke(testUser, privilege.List{privilege.SELECT}, privilege.Table)
+	if userPrivs().CanGrant(privilege.SELECT) {
+		t.Errorf("unexpected grant option for SELECT in %+v", descriptor)
+	}
+
+	// The grant option can't be held by users without privileges.
+	barUser := security.MakeSQLUsernameFromPreNormalizedString("bar")
+	descriptor.GrantGrantOption(barUser, privilege.List{privilege.ALL})
+	if descriptor.CheckGrantOptions(barUser, privilege.List{privilege.SELECT}) {
+		t.Errorf("unexpected grant option for user without privileges in %+v", descriptor)
+	}
+}
+
+// TestRevokeGrantedBy checks that revoking the privileges granted by some
+// grantors retains the privileges granted by others.
+func TestRevokeGrantedBy(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	fooUser := security.MakeSQLUsernameFromPreNormalizedString("foo")
+	barUser := security.MakeSQLUsernameFromPreNormalizedString("bar")
+	testUser := security.TestUserName()
+	byFoo := func(grantor security.SQLUsername) bool { return grantor == fooUser }
+
+	descriptor := NewDefaultPrivilegeDescriptor(security.AdminRoleName())
+	grant := func(grantor security.SQLUsername, privList, grantOptionList privilege.List) {
+		descriptor.Grant(testUser, privList)
+		descriptor.GrantGrantOption(testUser, grantOptionList)
+		descriptor.RecordGrant(testUser, grantor, privList, grantOptionList)
+	}
+	grant(fooUser, privilege.List{privilege.SELECT, privilege.INSERT}, privilege.List{privilege.SELECT})
+	grant(barUser, privilege.List{privilege.INSERT}, nil)
+	if u, _ := descriptor.findUser(testUser); u.Grantor() != barUser {
+		t.Errorf("expected grantor %s, got %s", barUser, u.Grantor())
+	}
+
+	// Only the grant option granted by foo is revoked.
+	descriptor.RevokeGrantedBy(testUser, privilege.List{privilege.SELECT}, privilege.Table, true, byFoo)
+	if descriptor.CheckGrantOptions(testUser, privilege.List{privilege.SELECT}) {
+		t.Errorf("unexpected grant option for SELECT in %+v", descriptor)
+	}
+	if !descriptor.CheckPrivilege(testUser, privilege.SELECT) {
+		t.Errorf("expected SELECT privilege in %+v", descriptor)
+	}
+
+	// INSERT was also granted by bar, so it is retained.
+	descriptor.RevokeGrantedBy(testUser, privilege.List{privilege.ALL}, privilege.Table, false, byFoo)
+	if descriptor.CheckPrivilege(testUser, privilege.SELECT) {
+		t.Errorf("unexpected SELECT privilege in %+v", descriptor)
+	}
+	if !descriptor.CheckPrivilege(testUser, privilege.INSERT) {
+		t.Errorf("expected INSERT privilege in %+v", descriptor)
+	}
+
+	// Privileges which weren't granted by foo are retained.
+	descriptor.Grant(testUser, privilege.List{privilege.DELETE})
+	descriptor.RevokeGrantedBy(testUser, privilege.List{privilege.DELETE}, privilege.Table, false, byFoo)
+	if !descriptor.CheckPrivilege(testUser, privilege.DELETE) {
+		t.Errorf("expected DELETE privilege in %+v", descriptor)
+	}
+
+	// Revoking the privileges granted by every grantor drops the user.
+	descriptor.RevokeGrantedBy(testUser, privilege.List{privilege.INSERT}, privilege.Table, false,
+		func(security.SQLUsername) bool { return true })
+	descriptor.Revoke(testUser, privilege.List{privilege.DELETE}, privilege.Table)
+	if _, ok := descriptor.findUser(testUser); ok {
+		t.Errorf("unexpected user %s in %+v", testUser, descriptor)
+	}
+}
+
 // TestPrivilegeValidate exercises validation for non-system descriptors.
 func TestPrivilegeValidate(t *testing.T) {
 	defer leaktest.AfterTest(t)()
@@ -586,6 +695,37 @@ func TestFixPrivileges(t *testing.T) {
 	}
 }
 
+// TestValidateLegacySequencePrivileges checks that the table privileges which
+// sequences used to be granted are kept, and tolerated by validation.
+func TestValidateLegacySequencePrivileges(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	id := ID(keys.MinUserDescID)
+	fooUser := security.MakeSQLUsernameFromPreNormalizedString("foo")
+
+	desc := NewDefaultPrivilegeDescriptor(security.RootUserName())
+	desc.Grant(fooUser, privilege.List{privilege.SELECT, privilege.INSERT, privilege.DELETE})
+	if MaybeFixPrivileges(id, &desc) {
+		t.Fatalf("unexpected modification of privileges %v", desc)
+	}
+	if err := desc.Validate(id, privilege.Sequence); err != nil {
+		t.Fatal(err)
+	}
+	u, ok := desc.findUser(fooUser)
+	if !ok {
+		t.Fatalf("expected user %s in %v", fooUser, desc)
+	}
+	if a, e := privilege.ListFromBitField(u.Privileges, privilege.Sequence), (privilege.List{privilege.SELECT}); a.ToBitField() != e.ToBitField() {
+		t.Errorf("expected privileges %v, got %v", e, a)
+	}
+
+	// Privileges which were never valid for tables are still rejected.
+	desc.Grant(fooUser, privilege.List{privilege.CONNECT})
+	if err := desc.Validate(id, privilege.Sequence); !testutils.IsError(err, "must not have CONNECT privileges") {
+		t.Fatalf("expected CONNECT to be rejected, got %v", err)
+	}
+}
+
 func TestValidateOwnership(t *testing.T) {
 	defer leaktest.AfterTest(t)()
 
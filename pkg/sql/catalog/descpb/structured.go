@@ -14,6 +14,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catconstants"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/encoding"
 	"github.com/cockroachdb/errors"
 )
@@ -181,6 +182,17 @@ func (MutationID) SafeValue() {}
 // InvalidMutationID is the uninitialised mutation id.
 const InvalidMutationID MutationID = 0
 
+// ConstraintID is a custom type for the IDs of the constraints of a
+// TableDescriptor.
+type ConstraintID uint32
+
+// SafeValue implements the redact.SafeValue interface.
+func (ConstraintID) SafeValue() {}
+
+// InvalidConstraintID is the ID of a constraint which hasn't been allocated
+// one yet.
+const InvalidConstraintID ConstraintID = 0
+
 // IsSet returns whether or not the foreign key actually references a table.
 func (f ForeignKeyReference) IsSet() bool {
 	return f.Table != 0
@@ -336,6 +348,19 @@ func (opts *TableDescriptor_SequenceOpts) EffectiveCacheSize() int64 {
 	return opts.CacheSize
 }
 
+// IntegerType returns the integer type of the sequence as specified with AS.
+// Sequences created without AS (or before AS was supported) are INT8.
+func (opts *TableDescriptor_SequenceOpts) IntegerType() *types.T {
+	switch opts.AsIntegerType {
+	case types.Int2.SQLString():
+		return types.Int2
+	case types.Int4.SQLString():
+		return types.Int4
+	default:
+		return types.Int
+	}
+}
+
 // SafeValue implements the redact.SafeValue interface.
 func (ConstraintValidity) SafeValue() {}
 
@@ -172,6 +172,8 @@ type DatabaseDescriptor interface {
 	ForEachSchemaInfo(func(id descpb.ID, name string, isDropped bool) error) error
 	GetSchemaID(name string) descpb.ID
 	GetNonDroppedSchemaName(schemaID descpb.ID) string
+	GetDefaultPrivileges() *descpb.DefaultPrivilegeDescriptor
+	GetDefaultCollation() string
 }
 
 // SchemaDescriptor will eventually be called schemadesc.Descriptor.
@@ -191,8 +193,12 @@ type TableDescriptor interface {
 	GetSequenceOpts() *descpb.TableDescriptor_SequenceOpts
 	GetCreateQuery() string
 	GetViewQuery() string
+	GetViewCheckOption() descpb.ViewCheckOption
+	GetViewSecurityInvoker() bool
+	GetViewSecurityBarrier() bool
 	GetLease() *descpb.TableDescriptor_SchemaChangeLease
 	GetCreateAsOfTime() hlc.Timestamp
+	GetRefreshAsOfTime() hlc.Timestamp
 	GetModificationTime() hlc.Timestamp
 	GetDropTime() int64
 	GetFormatVersion() descpb.FormatVersion
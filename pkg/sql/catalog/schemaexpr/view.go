@@ -0,0 +1,125 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package schemaexpr
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+)
+
+// UpdatableView is the parsed query of a view which rows can be inserted,
+// updated and deleted through, following the rules for automatically
+// updatable views of PostgreSQL: the query of the view must be a simple
+// SELECT from a single table or view, without WITH, DISTINCT, GROUP BY,
+// HAVING, LIMIT, OFFSET, set operations, nor aggregate, window or
+// set-returning functions in its targets.
+type UpdatableView struct {
+	// Source is the table or view the view selects from.
+	Source *tree.UnresolvedObjectName
+	// SourceAlias is the alias given to Source in the view query, if any.
+	SourceAlias tree.Name
+	// Where is the filter of the view query, or nil if it has none.
+	Where *tree.Where
+	// Targets are the targets of the view query, one per column of the view.
+	Targets tree.SelectExprs
+}
+
+// ParseUpdatableView parses the given view query. It returns nil if the view
+// is not updatable.
+func ParseUpdatableView(
+	query string, searchPath sessiondata.SearchPath,
+) (*UpdatableView, error) {
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		return nil, nil
+	}
+	for {
+		if sel.With != nil || sel.Limit != nil {
+			return nil, nil
+		}
+		paren, ok := sel.Select.(*tree.ParenSelect)
+		if !ok {
+			break
+		}
+		sel = paren.Select
+	}
+	clause, ok := sel.Select.(*tree.SelectClause)
+	if !ok || clause.Distinct || clause.DistinctOn != nil || clause.GroupBy != nil ||
+		clause.Having != nil || clause.Window != nil || len(clause.From.Tables) != 1 {
+		return nil, nil
+	}
+	from, ok := clause.From.Tables[0].(*tree.AliasedTableExpr)
+	if !ok || from.Ordinality || len(from.As.Cols) != 0 {
+		return nil, nil
+	}
+	source, ok := from.Expr.(*tree.UnresolvedObjectName)
+	if !ok {
+		return nil, nil
+	}
+	for _, expr := range clause.Exprs {
+		v := viewTargetVisitor{searchPath: searchPath}
+		tree.WalkExprConst(&v, expr.Expr)
+		if v.notUpdatable {
+			return nil, nil
+		}
+	}
+	return &UpdatableView{
+		Source:      source,
+		SourceAlias: from.As.Alias,
+		Where:       clause.Where,
+		Targets:     clause.Exprs,
+	}, nil
+}
+
+// SourceColumn returns the name of the column of the source of the view that
+// the i-th column of the view is a plain reference to, if it is one. Only such
+// columns can be updated through the view.
+func (v *UpdatableView) SourceColumn(i int) (_ tree.Name, ok bool) {
+	name, ok := v.Targets[i].Expr.(*tree.UnresolvedName)
+	if !ok || name.Star {
+		return "", false
+	}
+	return tree.Name(name.Parts[0]), true
+}
+
+// viewTargetVisitor looks for the aggregate, window and set-returning
+// functions which prevent a view from being updatable.
+type viewTargetVisitor struct {
+	searchPath   sessiondata.SearchPath
+	notUpdatable bool
+}
+
+var _ tree.Visitor = &viewTargetVisitor{}
+
+func (v *viewTargetVisitor) VisitPre(expr tree.Expr) (recurse bool, newExpr tree.Expr) {
+	if v.notUpdatable {
+		return false, expr
+	}
+	if f, ok := expr.(*tree.FuncExpr); ok {
+		if f.WindowDef != nil {
+			v.notUpdatable = true
+			return false, expr
+		}
+		def, err := f.Func.Resolve(v.searchPath)
+		if err == nil && def.Class != tree.NormalClass {
+			v.notUpdatable = true
+			return false, expr
+		}
+	}
+	return true, expr
+}
+
+func (*viewTargetVisitor) VisitPost(expr tree.Expr) tree.Expr { return expr }
@@ -365,6 +365,13 @@ CREATE TABLE system.join_tokens (
     expiration   TIMESTAMPTZ NOT NULL,
     FAMILY "primary" (id, secret, expiration)
 )`
+
+	LoginAttemptsTableSchema = `
+CREATE TABLE system.login_attempts (
+    username     STRING NOT NULL PRIMARY KEY,
+    failed       INT8 NOT NULL,
+    FAMILY "primary" (username, failed)
+)`
 )
 
 func pk(name string) descpb.IndexDescriptor {
@@ -1807,6 +1814,38 @@ var (
 		FormatVersion:  descpb.InterleavedFormatVersion,
 		NextMutationID: 1,
 	})
+
+	// LoginAttemptsTable is the descriptor for the login_attempts table. It
+	// stores the number of consecutive failed logins of the roles which have
+	// the LOGINATTEMPTS option.
+	LoginAttemptsTable = makeTable(descpb.TableDescriptor{
+		Name:                    "login_attempts",
+		ID:                      keys.LoginAttemptsTableID,
+		ParentID:                keys.SystemDatabaseID,
+		UnexposedParentSchemaID: keys.PublicSchemaID,
+		Version:                 1,
+		Columns: []descpb.ColumnDescriptor{
+			{Name: "username", ID: 1, Type: types.String, Nullable: false},
+			{Name: "failed", ID: 2, Type: types.Int, Nullable: false},
+		},
+		NextColumnID: 3,
+		Families: []descpb.ColumnFamilyDescriptor{
+			{
+				Name:            "primary",
+				ID:              0,
+				ColumnNames:     []string{"username", "failed"},
+				ColumnIDs:       []descpb.ColumnID{1, 2},
+				DefaultColumnID: 2,
+			},
+		},
+		NextFamilyID: 1,
+		PrimaryIndex: pk("username"),
+		NextIndexID:  2,
+		Privileges: descpb.NewCustomSuperuserPrivilegeDescriptor(
+			descpb.SystemAllowedPrivileges[keys.LoginAttemptsTableID], security.NodeUserName()),
+		FormatVersion:  descpb.InterleavedFormatVersion,
+		NextMutationID: 1,
+	})
 )
 
 // newCommentPrivilegeDescriptor returns a privilege descriptor for comment table
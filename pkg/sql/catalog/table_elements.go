@@ -122,6 +122,7 @@ type Index interface {
 	IsPartial() bool
 	IsUnique() bool
 	IsDisabled() bool
+	IsNotVisible() bool
 	IsSharded() bool
 	IsCreatedExplicitly() bool
 	GetPredicate() string
@@ -129,6 +130,7 @@ type Index interface {
 	GetGeoConfig() geoindex.Config
 	GetVersion() descpb.IndexDescriptorVersion
 	GetEncodingType() descpb.IndexDescriptorEncodingType
+	GetCollationVersion() string
 
 	GetSharded() descpb.ShardedDescriptor
 	GetShardColumnName() string
@@ -260,10 +262,27 @@ type Column interface {
 	// GetPGAttributeNum returns the PGAttributeNum of the column descriptor
 	// if the PGAttributeNum is set (non-zero). Returns the ID of the
 	// column descriptor if the PGAttributeNum is not set.
+	//
+	// The attribute number identifies the column in pg_catalog and
+	// information_schema. As in PostgreSQL, attribute numbers are never
+	// compacted nor reused: dropping a column leaves a gap in the numbering,
+	// and a column which replaces another one, as after ALTER COLUMN TYPE,
+	// takes over its attribute number.
 	GetPGAttributeNum() uint32
 
 	// IsSystemColumn returns true iff the column is a system column.
 	IsSystemColumn() bool
+
+	// IsGeneratedAsIdentity returns true iff the column is an identity column.
+	IsGeneratedAsIdentity() bool
+
+	// GetGeneratedAsIdentityType returns how the identity column was declared,
+	// or NOT_IDENTITY_COLUMN if the column is not an identity column.
+	GetGeneratedAsIdentityType() descpb.GeneratedAsIdentityType
+
+	// GetCollationVersion returns the version of the collation tables recorded
+	// for the column, or the empty string if none was recorded.
+	GetCollationVersion() string
 }
 
 // ConstraintToUpdate is an interface around a constraint mutation.
@@ -176,6 +176,23 @@ func (w column) IsSystemColumn() bool {
 	return w.desc.SystemColumnKind != descpb.SystemColumnKind_NONE
 }
 
+// IsGeneratedAsIdentity returns true iff the column is an identity column.
+func (w column) IsGeneratedAsIdentity() bool {
+	return w.desc.GeneratedAsIdentityType != descpb.GeneratedAsIdentityType_NOT_IDENTITY_COLUMN
+}
+
+// GetGeneratedAsIdentityType returns how the identity column was declared,
+// or NOT_IDENTITY_COLUMN if the column is not an identity column.
+func (w column) GetGeneratedAsIdentityType() descpb.GeneratedAsIdentityType {
+	return w.desc.GeneratedAsIdentityType
+}
+
+// GetCollationVersion returns the version of the collation tables recorded
+// for the column, or the empty string if none was recorded.
+func (w column) GetCollationVersion() string {
+	return w.desc.CollationVersion
+}
+
 // columnCache contains precomputed slices of catalog.Column interfaces.
 type columnCache struct {
 	all       []catalog.Column
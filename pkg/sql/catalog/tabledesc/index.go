@@ -88,6 +88,11 @@ func (w index) IsDisabled() bool {
 	return w.desc.Disabled
 }
 
+// IsNotVisible returns true iff the index is not visible to the optimizer.
+func (w index) IsNotVisible() bool {
+	return w.desc.NotVisible
+}
+
 // IsSharded returns true iff the index is hash sharded.
 func (w index) IsSharded() bool {
 	return w.desc.IsSharded()
@@ -106,6 +111,13 @@ func (w index) GetPredicate() string {
 	return w.desc.Predicate
 }
 
+// GetCollationVersion returns the version of the collation tables recorded
+// when the index was created, or the empty string if none of its key columns
+// is a collated string.
+func (w index) GetCollationVersion() string {
+	return w.desc.CollationVersion
+}
+
 // GetType returns the type of index, inverted or forward.
 func (w index) GetType() descpb.IndexDescriptor_Type {
 	return w.desc.Type
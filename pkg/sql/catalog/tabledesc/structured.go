@@ -651,6 +651,14 @@ func (desc *Mutable) allocateIndexIDs(columnNames map[string]descpb.ColumnID) er
 			compositeColIDs.Add(col.ID)
 		}
 	}
+	// Only columns that record a collation version make an index record one, so
+	// that no collation versions are written before the cluster supports them.
+	var collatedColIDs catalog.TableColSet
+	for _, col := range desc.DeletableColumns() {
+		if col.GetCollationVersion() != "" {
+			collatedColIDs.Add(col.GetID())
+		}
+	}
 
 	// Populate IDs.
 	for _, idx := range desc.AllIndexes() {
@@ -717,6 +725,16 @@ func (desc *Mutable) allocateIndexIDs(columnNames map[string]descpb.ColumnID) er
 				index.CompositeColumnIDs = append(index.CompositeColumnIDs, colID)
 			}
 		}
+
+		// The order of collated string keys depends on the collation tables, so
+		// record the version the index is built with.
+		index.CollationVersion = ""
+		for _, colID := range index.ColumnIDs {
+			if collatedColIDs.Contains(colID) {
+				index.CollationVersion = tree.CollationVersion
+				break
+			}
+		}
 		for _, colID := range index.ExtraColumnIDs {
 			if compositeColIDs.Contains(colID) {
 				index.CompositeColumnIDs = append(index.CompositeColumnIDs, colID)
@@ -1456,6 +1474,49 @@ func (desc *Mutable) RenameConstraint(
 	}
 }
 
+// EnsureConstraintID returns the ID of a constraint, allocating one from the
+// table descriptor if the constraint doesn't have one yet.
+func (desc *Mutable) EnsureConstraintID(
+	detail descpb.ConstraintDetail, name string,
+) (descpb.ConstraintID, error) {
+	var id *descpb.ConstraintID
+	var validity descpb.ConstraintValidity
+	switch {
+	case detail.Index != nil:
+		id = &detail.Index.ConstraintID
+	case detail.UniqueWithoutIndexConstraint != nil:
+		id, validity = &detail.UniqueWithoutIndexConstraint.ConstraintID, detail.UniqueWithoutIndexConstraint.Validity
+	case detail.FK != nil:
+		id, validity = &detail.FK.ConstraintID, detail.FK.Validity
+	case detail.CheckConstraint != nil:
+		id, validity = &detail.CheckConstraint.ConstraintID, detail.CheckConstraint.Validity
+	default:
+		return descpb.InvalidConstraintID, errors.AssertionFailedf(
+			"constraint %q has no descriptor", name)
+	}
+	// Constraints being validated or dropped may be referenced by a mutation
+	// whose copy of the constraint replaces the one on the table descriptor
+	// once the mutation completes.
+	switch validity {
+	case descpb.ConstraintValidity_Validating:
+		return descpb.InvalidConstraintID, unimplemented.NewWithIssueDetailf(42844,
+			"constraint-id-mutation",
+			"constraint %q in the middle of being added, try again later", tree.ErrNameString(name))
+	case descpb.ConstraintValidity_Dropping:
+		return descpb.InvalidConstraintID, unimplemented.NewWithIssueDetailf(42844,
+			"constraint-id-mutation",
+			"constraint %q in the middle of being dropped", tree.ErrNameString(name))
+	}
+	if *id == descpb.InvalidConstraintID {
+		if desc.NextConstraintID == descpb.InvalidConstraintID {
+			desc.NextConstraintID = 1
+		}
+		*id = desc.NextConstraintID
+		desc.NextConstraintID++
+	}
+	return *id, nil
+}
+
 // GetIndexMutationCapabilities returns:
 // 1. Whether the index is a mutation
 // 2. if so, is it in state DELETE_AND_WRITE_ONLY
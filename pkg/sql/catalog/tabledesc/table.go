@@ -16,6 +16,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
@@ -29,14 +30,24 @@ import (
 	"github.com/cockroachdb/errors"
 )
 
+// CollationVersionOfType returns the version of the collation tables to
+// record for a column of the given type, or the empty string if the type is
+// not a collated string.
+func CollationVersionOfType(typ *types.T) string {
+	if typ.Family() != types.CollatedStringFamily {
+		return ""
+	}
+	return tree.CollationVersion
+}
+
 // MakeColumnDefDescs creates the column descriptor for a column, as well as the
 // index descriptor if the column is a primary key or unique.
 //
-// If the column type *may* be SERIAL (or SERIAL-like), it is the
-// caller's responsibility to call sql.processSerialInColumnDef() and
-// sql.doCreateSequence() before MakeColumnDefDescs() to remove the
-// SERIAL type and replace it with a suitable integer type and default
-// expression.
+// If the column type *may* be SERIAL (or SERIAL-like), or if the column
+// may be an identity column, it is the caller's responsibility to call
+// sql.processSerialInColumnDef() and sql.doCreateSequence() before
+// MakeColumnDefDescs() to remove the SERIAL type and replace it with a
+// suitable integer type and default expression.
 //
 // semaCtx can be nil if no default expression is used for the
 // column or during cluster bootstrapping.
@@ -55,6 +66,12 @@ func MakeColumnDefDescs(
 		return nil, nil, nil, pgerror.New(pgcode.FeatureNotSupported,
 			"SERIAL cannot be used in this context")
 	}
+	if d.IsGeneratedAsIdentity() && !d.HasDefaultExpr() {
+		// Likewise, processSerialInColumnDef() must have created the sequence of
+		// the identity column and set the default expression that uses it.
+		return nil, nil, nil, pgerror.New(pgcode.FeatureNotSupported,
+			"identity columns cannot be used in this context")
+	}
 
 	if len(d.CheckExprs) > 0 {
 		// Should never happen since `HoistConstraints` moves these to table level
@@ -81,6 +98,10 @@ func MakeColumnDefDescs(
 		return nil, nil, nil, err
 	}
 	col.Type = resType
+	if evalCtx == nil || evalCtx.Settings == nil ||
+		evalCtx.Settings.Version.IsActive(ctx, clusterversion.CollationVersions) {
+		col.CollationVersion = CollationVersionOfType(resType)
+	}
 
 	var typedExpr tree.TypedExpr
 	if d.HasDefaultExpr() {
@@ -108,6 +129,18 @@ func MakeColumnDefDescs(
 		col.ComputeExpr = &s
 	}
 
+	if d.IsGeneratedAsIdentity() {
+		switch d.GeneratedIdentity.GeneratedAsIdentityType {
+		case tree.GeneratedAlways:
+			col.GeneratedAsIdentityType = descpb.GeneratedAsIdentityType_GENERATED_ALWAYS
+		case tree.GeneratedByDefault:
+			col.GeneratedAsIdentityType = descpb.GeneratedAsIdentityType_GENERATED_BY_DEFAULT
+		default:
+			return nil, nil, nil, errors.AssertionFailedf(
+				"unknown identity column type %d", d.GeneratedIdentity.GeneratedAsIdentityType)
+		}
+	}
+
 	var idx *descpb.IndexDescriptor
 	if d.PrimaryKey.IsPrimaryKey || (d.Unique.IsUnique && !d.Unique.WithoutIndex) {
 		if !d.PrimaryKey.Sharded {
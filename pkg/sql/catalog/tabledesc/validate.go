@@ -573,7 +573,11 @@ func (desc *wrapper) ValidateSelf(vea catalog.ValidationErrorAccumulator) {
 	}
 
 	// Validate the privilege descriptor.
-	vea.Report(desc.Privileges.Validate(desc.GetID(), privilege.Table))
+	objectType := privilege.Table
+	if desc.IsSequence() {
+		objectType = privilege.Sequence
+	}
+	vea.Report(desc.Privileges.Validate(desc.GetID(), objectType))
 
 	// Ensure that mutations cannot be queued if a primary key change or
 	// an alter column type schema change has either been started in
@@ -98,7 +98,11 @@ var validationMap = []struct {
 			"ViewQuery": {
 				status: todoIAmKnowinglyAddingTechDebt,
 				reason: "initial import: TODO(features): add validation"},
-			"IsMaterializedView": {status: thisFieldReferencesNoObjects},
+			"IsMaterializedView":  {status: thisFieldReferencesNoObjects},
+			"ViewCheckOption":     {status: thisFieldReferencesNoObjects},
+			"RefreshAsOfTime":     {status: thisFieldReferencesNoObjects},
+			"ViewSecurityInvoker": {status: thisFieldReferencesNoObjects},
+			"ViewSecurityBarrier": {status: thisFieldReferencesNoObjects},
 			"DependsOn": {
 				status: todoIAmKnowinglyAddingTechDebt,
 				reason: "initial import: TODO(features): add validation"},
@@ -124,6 +128,7 @@ var validationMap = []struct {
 			"Temporary":                     {status: thisFieldReferencesNoObjects},
 			"LocalityConfig":                {status: iSolemnlySwearThisFieldIsValidated},
 			"PartitionAllBy":                {status: iSolemnlySwearThisFieldIsValidated},
+			"NextConstraintID":              {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
@@ -162,6 +167,9 @@ var validationMap = []struct {
 			"Disabled":          {status: thisFieldReferencesNoObjects},
 			"GeoConfig":         {status: thisFieldReferencesNoObjects},
 			"Predicate":         {status: iSolemnlySwearThisFieldIsValidated},
+			"NotVisible":        {status: thisFieldReferencesNoObjects},
+			"ConstraintID":      {status: thisFieldReferencesNoObjects},
+			"CollationVersion":  {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
@@ -188,6 +196,8 @@ var validationMap = []struct {
 				reason: "initial import: TODO(features): add validation"},
 			"AlterColumnTypeInProgress": {status: thisFieldReferencesNoObjects},
 			"SystemColumnKind":          {status: thisFieldReferencesNoObjects},
+			"GeneratedAsIdentityType":   {status: thisFieldReferencesNoObjects},
+			"CollationVersion":          {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
@@ -206,16 +216,20 @@ var validationMap = []struct {
 			"OnDelete":          {status: thisFieldReferencesNoObjects},
 			"OnUpdate":          {status: thisFieldReferencesNoObjects},
 			"Match":             {status: thisFieldReferencesNoObjects},
+			"Deferrable":        {status: thisFieldReferencesNoObjects},
+			"InitiallyDeferred": {status: thisFieldReferencesNoObjects},
+			"ConstraintID":      {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
 		obj: descpb.UniqueWithoutIndexConstraint{},
 		fieldMap: map[string]validationStatusInfo{
-			"TableID":   {status: iSolemnlySwearThisFieldIsValidated},
-			"ColumnIDs": {status: iSolemnlySwearThisFieldIsValidated},
-			"Name":      {status: thisFieldReferencesNoObjects},
-			"Validity":  {status: thisFieldReferencesNoObjects},
-			"Predicate": {status: iSolemnlySwearThisFieldIsValidated},
+			"TableID":      {status: iSolemnlySwearThisFieldIsValidated},
+			"ColumnIDs":    {status: iSolemnlySwearThisFieldIsValidated},
+			"Name":         {status: thisFieldReferencesNoObjects},
+			"Validity":     {status: thisFieldReferencesNoObjects},
+			"Predicate":    {status: iSolemnlySwearThisFieldIsValidated},
+			"ConstraintID": {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
@@ -242,16 +256,18 @@ var validationMap = []struct {
 	{
 		obj: descpb.DatabaseDescriptor{},
 		fieldMap: map[string]validationStatusInfo{
-			"Name":             {status: iSolemnlySwearThisFieldIsValidated},
-			"ID":               {status: iSolemnlySwearThisFieldIsValidated},
-			"Version":          {status: thisFieldReferencesNoObjects},
-			"ModificationTime": {status: thisFieldReferencesNoObjects},
-			"DrainingNames":    {status: thisFieldReferencesNoObjects},
-			"Privileges":       {status: iSolemnlySwearThisFieldIsValidated},
-			"Schemas":          {status: iSolemnlySwearThisFieldIsValidated},
-			"State":            {status: thisFieldReferencesNoObjects},
-			"OfflineReason":    {status: thisFieldReferencesNoObjects},
-			"RegionConfig":     {status: iSolemnlySwearThisFieldIsValidated},
+			"Name":              {status: iSolemnlySwearThisFieldIsValidated},
+			"ID":                {status: iSolemnlySwearThisFieldIsValidated},
+			"Version":           {status: thisFieldReferencesNoObjects},
+			"ModificationTime":  {status: thisFieldReferencesNoObjects},
+			"DrainingNames":     {status: thisFieldReferencesNoObjects},
+			"Privileges":        {status: iSolemnlySwearThisFieldIsValidated},
+			"Schemas":           {status: iSolemnlySwearThisFieldIsValidated},
+			"State":             {status: thisFieldReferencesNoObjects},
+			"OfflineReason":     {status: thisFieldReferencesNoObjects},
+			"RegionConfig":      {status: iSolemnlySwearThisFieldIsValidated},
+			"DefaultPrivileges": {status: thisFieldReferencesNoObjects},
+			"DefaultCollation":  {status: thisFieldReferencesNoObjects},
 		},
 	},
 	{
@@ -0,0 +1,154 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkv"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// catalogSnapshot pins, for the duration of a transaction, the descriptors
+// read by the virtual tables along with the lookup contexts built from them.
+// The descriptors are read once, at the first catalog read of the
+// transaction, so that all the information_schema and pg_catalog reads of the
+// transaction observe the same view of the catalog, even if schema changes
+// commit concurrently. A query over several virtual tables (e.g. a join of
+// information_schema.tables and information_schema.columns) also reads and
+// indexes the descriptors only once.
+//
+// The descriptors and the lookup contexts are shared by all the virtual
+// tables of the transaction and must not be modified.
+//
+// The snapshot is stored in extraTxnState. It is cleared when the transaction
+// finishes or restarts, and before every statement of a transaction which
+// executed DDL statements (see resetPlanner). Internal planners don't have
+// one.
+type catalogSnapshot struct {
+	// The virtual tables of a statement may be populated concurrently.
+	mu struct {
+		syncutil.Mutex
+		// txn is the transaction which read the descriptors. Statements may be
+		// prepared outside of the transaction of the connExecutor, in which
+		// case they don't share its snapshot.
+		txn *kv.Txn
+		// descs is nil until the descriptors are read.
+		descs []catalog.Descriptor
+		// lookupCtxs holds the lookup contexts built from descs, keyed by the
+		// ID of the database they are restricted to, or by 0 if they aren't.
+		lookupCtxs map[descpb.ID]*internalLookupCtx
+	}
+}
+
+// clear drops the snapshot.
+func (s *catalogSnapshot) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.txn = nil
+	s.mu.descs = nil
+	s.mu.lookupCtxs = nil
+}
+
+// getCatalogDescriptors returns all the descriptors visible to the
+// transaction. The result is shared by all the virtual tables of the
+// transaction and must not be modified.
+func (p *planner) getCatalogDescriptors(ctx context.Context) ([]catalog.Descriptor, error) {
+	s := p.extendedEvalCtx.catalogSnapshot
+	if s == nil {
+		return p.Descriptors().GetAllDescriptors(ctx, p.txn)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return p.getCatalogDescriptorsLocked(ctx)
+}
+
+// getCatalogSnapshot is like getCatalogDescriptors, but it also returns a
+// lookup context over the descriptors whose iteration is restricted to the
+// given database, if not nil.
+func (p *planner) getCatalogSnapshot(
+	ctx context.Context, dbContext catalog.DatabaseDescriptor,
+) ([]catalog.Descriptor, *internalLookupCtx, error) {
+	s := p.extendedEvalCtx.catalogSnapshot
+	if s == nil {
+		descs, err := p.Descriptors().GetAllDescriptors(ctx, p.txn)
+		if err != nil {
+			return nil, nil, err
+		}
+		lCtx, err := p.newCatalogLookupCtx(ctx, descs, dbContext)
+		if err != nil {
+			return nil, nil, err
+		}
+		return descs, lCtx, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	descs, err := p.getCatalogDescriptorsLocked(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	var dbID descpb.ID
+	if dbContext != nil {
+		dbID = dbContext.GetID()
+	}
+	lCtx, ok := s.mu.lookupCtxs[dbID]
+	if !ok {
+		lCtx, err = p.newCatalogLookupCtx(ctx, descs, dbContext)
+		if err != nil {
+			return nil, nil, err
+		}
+		if s.mu.lookupCtxs == nil {
+			s.mu.lookupCtxs = make(map[descpb.ID]*internalLookupCtx)
+		}
+		s.mu.lookupCtxs[dbID] = lCtx
+	}
+	return descs, lCtx, nil
+}
+
+// getCatalogDescriptorsLocked reads the descriptors of the snapshot, if they
+// weren't read yet. p.extendedEvalCtx.catalogSnapshot.mu must be held.
+func (p *planner) getCatalogDescriptorsLocked(ctx context.Context) ([]catalog.Descriptor, error) {
+	s := p.extendedEvalCtx.catalogSnapshot
+	if s.mu.txn != p.txn {
+		s.mu.txn = p.txn
+		s.mu.descs = nil
+		s.mu.lookupCtxs = nil
+	}
+	if s.mu.descs == nil {
+		descs, err := p.Descriptors().GetAllDescriptors(ctx, p.txn)
+		if err != nil {
+			return nil, err
+		}
+		if descs == nil {
+			descs = []catalog.Descriptor{}
+		}
+		s.mu.descs = descs
+	}
+	return s.mu.descs, nil
+}
+
+// newCatalogLookupCtx builds a lookup context over the given descriptors,
+// falling back to reading the descriptors which are missing from them. The
+// names of the temporary schemas are resolved upfront, so that the lookup
+// context isn't modified afterwards.
+func (p *planner) newCatalogLookupCtx(
+	ctx context.Context, descs []catalog.Descriptor, dbContext catalog.DatabaseDescriptor,
+) (*internalLookupCtx, error) {
+	lCtx := newInternalLookupCtx(ctx, descs, dbContext,
+		catalogkv.NewOneLevelUncachedDescGetter(p.txn, p.execCfg.Codec))
+	if err := resolveTemporarySchemaNames(ctx, p, lCtx); err != nil {
+		return nil, err
+	}
+	return lCtx, nil
+}
@@ -232,7 +232,7 @@ func nonMatchingRowQuery(
 // reuse an existing kv.Txn safely.
 func validateForeignKey(
 	ctx context.Context,
-	srcTable *tabledesc.Mutable,
+	srcTable catalog.TableDescriptor,
 	fk *descpb.ForeignKeyConstraint,
 	ie *InternalExecutor,
 	txn *kv.Txn,
@@ -262,7 +262,7 @@ func validateForeignKey(
 
 		log.Infof(ctx, "validating MATCH FULL FK %q (%q [%v] -> %q [%v]) with query %q",
 			fk.Name,
-			srcTable.Name, colNames,
+			srcTable.GetName(), colNames,
 			targetTable.GetName(), referencedColumnNames,
 			query,
 		)
@@ -288,7 +288,7 @@ func validateForeignKey(
 
 	log.Infof(ctx, "validating FK %q (%q [%v] -> %q [%v]) with query %q",
 		fk.Name,
-		srcTable.Name, colNames, targetTable.GetName(), referencedColumnNames,
+		srcTable.GetName(), colNames, targetTable.GetName(), referencedColumnNames,
 		query,
 	)
 
@@ -299,7 +299,74 @@ func validateForeignKey(
 	if values.Len() > 0 {
 		return pgerror.WithConstraintName(pgerror.Newf(pgcode.ForeignKeyViolation,
 			"foreign key violation: %q row %s has no match in %q",
-			srcTable.Name, formatValues(colNames, values), targetTable.GetName()), fk.Name)
+			srcTable.GetName(), formatValues(colNames, values), targetTable.GetName()), fk.Name)
+	}
+	return nil
+}
+
+// deferredFKCheck identifies a foreign key constraint that has to be
+// validated before the transaction commits.
+type deferredFKCheck struct {
+	tableID descpb.ID
+	name    string
+}
+
+// DeferForeignKeyCheck is part of the tree.EvalPlanner interface.
+func (p *planner) DeferForeignKeyCheck(tableID int64, fkName string) bool {
+	// In an implicit transaction the end of the statement is the end of the
+	// transaction, so there is nothing to gain from deferring the check.
+	if p.extendedEvalCtx.TxnImplicit || p.extendedEvalCtx.DeferredFKChecks == nil {
+		return false
+	}
+	p.extendedEvalCtx.DeferredFKChecks[deferredFKCheck{
+		tableID: descpb.ID(tableID),
+		name:    fkName,
+	}] = struct{}{}
+	return true
+}
+
+// validateDeferredForeignKeys validates the initially deferred foreign key
+// constraints that were violated by statements in the current transaction.
+// The constraints are validated in full, since later statements may have
+// resolved the violations.
+func (ex *connExecutor) validateDeferredForeignKeys(ctx context.Context) error {
+	txn := ex.state.mu.txn
+	ie := ex.server.cfg.InternalExecutor
+	for check := range ex.extraTxnState.deferredFKChecks {
+		tableDesc, err := ex.extraTxnState.descCollection.GetImmutableTableByID(
+			ctx, txn, check.tableID, tree.ObjectLookupFlags{
+				CommonLookupFlags: tree.CommonLookupFlags{
+					Required:       true,
+					IncludeDropped: true,
+				},
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if tableDesc.Dropped() {
+			continue
+		}
+		// The constraint may have been dropped after the check was deferred.
+		var fk *descpb.ForeignKeyConstraint
+		for i := range tableDesc.GetOutboundFKs() {
+			if c := &tableDesc.GetOutboundFKs()[i]; c.Name == check.name {
+				fk = c
+				break
+			}
+		}
+		if fk == nil {
+			continue
+		}
+		var syntheticDescs []catalog.Descriptor
+		if tableDesc.IsUncommittedVersion() {
+			syntheticDescs = append(syntheticDescs, tableDesc)
+		}
+		if err := ie.WithSyntheticDescriptors(syntheticDescs, func() error {
+			return validateForeignKey(ctx, tableDesc, fk, ie, txn, ex.server.cfg.Codec)
+		}); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,129 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// objectComments holds the comments of one type, keyed by object ID and then
+// by sub ID.
+type objectComments map[descpb.ID]map[tree.DInt]tree.Datum
+
+// commentCache caches, for the duration of a transaction, the comments read
+// by the virtual tables, so that a statement or a transaction reading several
+// of them (e.g. information_schema.columns, information_schema.schemata and
+// pg_catalog.pg_description) only reads system.comments once.
+//
+// The cache is stored in extraTxnState. It is cleared when the transaction
+// finishes or restarts, and by every statement which may write to
+// system.comments (see makeExecPlan).
+type commentCache struct {
+	// The virtual tables of a statement may be populated concurrently.
+	mu struct {
+		syncutil.Mutex
+		// rows is nil until the comments are read.
+		rows []tree.Datums
+		// byType holds the comments indexed by object ID, for each comment
+		// type which was requested.
+		byType map[tree.DInt]objectComments
+	}
+}
+
+// clear drops the cached comments.
+func (c *commentCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.rows = nil
+	c.mu.byType = nil
+}
+
+// getComments returns the comments stored in system.comments merged with the
+// predefined comments of the virtual tables, as rows of (object_id, sub_id,
+// comment, type). The result is cached for the transaction and must not be
+// modified.
+func getComments(ctx context.Context, p *planner) ([]tree.Datums, error) {
+	cache := p.extendedEvalCtx.comments
+	if cache == nil {
+		// Internal planners don't cache the comments.
+		return queryComments(ctx, p)
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.mu.rows == nil {
+		rows, err := queryComments(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		if rows == nil {
+			rows = []tree.Datums{}
+		}
+		cache.mu.rows = rows
+	}
+	return cache.mu.rows, nil
+}
+
+// getCommentsByObject returns the comments of the given type, keyed by object
+// ID and then by sub ID. The result is cached for the transaction and must not
+// be modified.
+func getCommentsByObject(
+	ctx context.Context, p *planner, commentType tree.DInt,
+) (objectComments, error) {
+	comments, err := getComments(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	cache := p.extendedEvalCtx.comments
+	if cache != nil {
+		cache.mu.Lock()
+		defer cache.mu.Unlock()
+		if res, ok := cache.mu.byType[commentType]; ok {
+			return res, nil
+		}
+	}
+	res := make(objectComments)
+	for _, comment := range comments {
+		if tree.MustBeDInt(comment[3]) != commentType {
+			continue
+		}
+		objID := descpb.ID(tree.MustBeDInt(comment[0]))
+		if res[objID] == nil {
+			res[objID] = make(map[tree.DInt]tree.Datum)
+		}
+		res[objID][tree.MustBeDInt(comment[1])] = comment[2]
+	}
+	if cache != nil {
+		if cache.mu.byType == nil {
+			cache.mu.byType = make(map[tree.DInt]objectComments)
+		}
+		cache.mu.byType[commentType] = res
+	}
+	return res, nil
+}
+
+// queryComments reads the comments, bypassing the cache.
+func queryComments(ctx context.Context, p *planner) ([]tree.Datums, error) {
+	return p.extendedEvalCtx.ExecCfg.InternalExecutor.QueryBuffered(
+		ctx,
+		"select-comments",
+		p.EvalContext().Txn,
+		`SELECT COALESCE(pc.object_id, sc.object_id) AS object_id,
+              COALESCE(pc.sub_id, sc.sub_id) AS sub_id,
+              COALESCE(pc.comment, sc.comment) AS comment,
+              COALESCE(pc.type, sc.type) AS type
+         FROM (SELECT * FROM system.comments) AS sc
+    FULL JOIN (SELECT * FROM crdb_internal.predefined_comments) AS pc
+           ON (pc.object_id = sc.object_id AND pc.sub_id = sc.sub_id AND pc.type = sc.type)`)
+}
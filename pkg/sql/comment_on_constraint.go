@@ -0,0 +1,162 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+)
+
+type commentOnConstraintNode struct {
+	n         *tree.CommentOnConstraint
+	tableDesc *tabledesc.Mutable
+	detail    descpb.ConstraintDetail
+}
+
+// CommentOnConstraint adds a comment on a constraint.
+// Privileges: CREATE on table.
+func (p *planner) CommentOnConstraint(
+	ctx context.Context, n *tree.CommentOnConstraint,
+) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"COMMENT ON CONSTRAINT",
+	); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.ConstraintComments) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use COMMENT ON CONSTRAINT",
+			clusterversion.ConstraintComments)
+	}
+
+	tableDesc, err := p.ResolveMutableTableDescriptorEx(ctx, n.Table, true, tree.ResolveRequireTableDesc)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.CheckPrivilege(ctx, tableDesc, privilege.CREATE); err != nil {
+		return nil, err
+	}
+
+	info, err := tableDesc.GetConstraintInfo()
+	if err != nil {
+		return nil, err
+	}
+	detail, ok := info[string(n.Constraint)]
+	if !ok {
+		return nil, pgerror.Newf(pgcode.UndefinedObject,
+			"constraint %q of relation %q does not exist", n.Constraint, tableDesc.GetName())
+	}
+
+	return &commentOnConstraintNode{n: n, tableDesc: tableDesc, detail: detail}, nil
+}
+
+func (n *commentOnConstraintNode) startExec(params runParams) error {
+	// Constraints are only allocated an ID, under which their comment is
+	// stored, once they are first commented.
+	constraintID := n.detail.ConstraintID()
+	if constraintID == descpb.InvalidConstraintID {
+		if n.n.Comment == nil {
+			// There is no comment to remove.
+			return n.logEvent(params)
+		}
+		var err error
+		if constraintID, err = n.tableDesc.EnsureConstraintID(n.detail, string(n.n.Constraint)); err != nil {
+			return err
+		}
+		if err := params.p.writeSchemaChange(
+			params.ctx, n.tableDesc, descpb.InvalidMutationID, tree.AsStringWithFQNames(n.n, params.Ann()),
+		); err != nil {
+			return err
+		}
+	}
+
+	if n.n.Comment != nil {
+		_, err := params.p.extendedEvalCtx.ExecCfg.InternalExecutor.ExecEx(
+			params.ctx,
+			"set-constraint-comment",
+			params.p.Txn(),
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			"UPSERT INTO system.comments VALUES ($1, $2, $3, $4)",
+			keys.ConstraintCommentType,
+			n.tableDesc.ID,
+			constraintID,
+			*n.n.Comment)
+		if err != nil {
+			return err
+		}
+	} else if err := params.p.removeConstraintComment(
+		params.ctx, n.tableDesc.ID, constraintID,
+	); err != nil {
+		return err
+	}
+
+	return n.logEvent(params)
+}
+
+func (n *commentOnConstraintNode) logEvent(params runParams) error {
+	comment := ""
+	if n.n.Comment != nil {
+		comment = *n.n.Comment
+	}
+
+	tn, err := params.p.getQualifiedTableName(params.ctx, n.tableDesc)
+	if err != nil {
+		return err
+	}
+
+	return params.p.logEvent(params.ctx,
+		n.tableDesc.ID,
+		&eventpb.CommentOnConstraint{
+			TableName:      tn.FQString(),
+			ConstraintName: string(n.n.Constraint),
+			Comment:        comment,
+			NullComment:    n.n.Comment == nil,
+		})
+}
+
+// removeConstraintComment removes the comment of a constraint, if any. It
+// must be called when a constraint which has been allocated an ID is dropped.
+func (p *planner) removeConstraintComment(
+	ctx context.Context, tableID descpb.ID, constraintID descpb.ConstraintID,
+) error {
+	if constraintID == descpb.InvalidConstraintID {
+		return nil
+	}
+	_, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.ExecEx(
+		ctx,
+		"delete-constraint-comment",
+		p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		"DELETE FROM system.comments WHERE type=$1 AND object_id=$2 AND sub_id=$3",
+		keys.ConstraintCommentType,
+		tableID,
+		constraintID)
+
+	return err
+}
+
+func (n *commentOnConstraintNode) Next(runParams) (bool, error) { return false, nil }
+func (n *commentOnConstraintNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *commentOnConstraintNode) Close(context.Context)        {}
@@ -0,0 +1,144 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+)
+
+type commentOnSchemaNode struct {
+	n      *tree.CommentOnSchema
+	scDesc catalog.SchemaDescriptor
+}
+
+// CommentOnSchema adds a comment on a schema. Only user-defined schemas can
+// be commented: the public, virtual and temporary schemas do not have a
+// descriptor whose ID the comment could be stored under.
+// Privileges: ownership of the schema, or admin.
+//   notes: postgres requires ownership of the schema.
+func (p *planner) CommentOnSchema(ctx context.Context, n *tree.CommentOnSchema) (planNode, error) {
+	if err := checkSchemaChangeEnabled(
+		ctx,
+		p.ExecCfg(),
+		"COMMENT ON SCHEMA",
+	); err != nil {
+		return nil, err
+	}
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.SchemaComments) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use COMMENT ON SCHEMA",
+			clusterversion.SchemaComments)
+	}
+
+	dbName := p.CurrentDatabase()
+	if n.Name.ExplicitCatalog {
+		dbName = n.Name.Catalog()
+	}
+	_, db, err := p.Descriptors().GetImmutableDatabaseByName(ctx, p.txn, dbName,
+		tree.DatabaseLookupFlags{Required: true})
+	if err != nil {
+		return nil, err
+	}
+	found, schema, err := p.ResolveMutableSchemaDescriptor(ctx, db.GetID(), n.Name.Schema(), true /* required */)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, pgerror.Newf(pgcode.InvalidSchemaName, "schema %q does not exist", n.Name.String())
+	}
+	if schema.Kind != catalog.SchemaUserDefined {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"cannot comment on schema %q", n.Name.String())
+	}
+
+	// The user must be a superuser or the owner of the schema to comment on it.
+	hasAdmin, err := p.HasAdminRole(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAdmin {
+		hasOwnership, err := p.HasOwnership(ctx, schema.Desc)
+		if err != nil {
+			return nil, err
+		}
+		if !hasOwnership {
+			return nil, pgerror.Newf(pgcode.InsufficientPrivilege,
+				"must be owner of schema %q", schema.Name)
+		}
+	}
+
+	return &commentOnSchemaNode{n: n, scDesc: schema.Desc}, nil
+}
+
+func (n *commentOnSchemaNode) startExec(params runParams) error {
+	if n.n.Comment != nil {
+		_, err := params.p.extendedEvalCtx.ExecCfg.InternalExecutor.ExecEx(
+			params.ctx,
+			"set-schema-comment",
+			params.p.Txn(),
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			"UPSERT INTO system.comments VALUES ($1, $2, 0, $3)",
+			keys.SchemaCommentType,
+			n.scDesc.GetID(),
+			*n.n.Comment)
+		if err != nil {
+			return err
+		}
+	} else if err := params.p.removeSchemaComment(params.ctx, n.scDesc.GetID()); err != nil {
+		return err
+	}
+
+	comment := ""
+	if n.n.Comment != nil {
+		comment = *n.n.Comment
+	}
+	scName, err := params.p.getQualifiedSchemaName(params.ctx, n.scDesc)
+	if err != nil {
+		return err
+	}
+	return params.p.logEvent(params.ctx,
+		n.scDesc.GetID(),
+		&eventpb.CommentOnSchema{
+			SchemaName:  scName.String(),
+			Comment:     comment,
+			NullComment: n.n.Comment == nil,
+		})
+}
+
+// removeSchemaComment removes the comment of a schema, if any. It must be
+// called when a user-defined schema is dropped.
+func (p *planner) removeSchemaComment(ctx context.Context, schemaID descpb.ID) error {
+	_, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.ExecEx(
+		ctx,
+		"delete-schema-comment",
+		p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		"DELETE FROM system.comments WHERE type=$1 AND object_id=$2 AND sub_id=0",
+		keys.SchemaCommentType,
+		schemaID)
+
+	return err
+}
+
+func (n *commentOnSchemaNode) Next(runParams) (bool, error) { return false, nil }
+func (n *commentOnSchemaNode) Values() tree.Datums          { return tree.Datums{} }
+func (n *commentOnSchemaNode) Close(context.Context)        {}
@@ -637,6 +637,7 @@ func (s *Server) newConnExecutor(
 		s.cfg.LeaseManager, s.cfg.Settings, sd, s.cfg.HydratedTables)
 	ex.extraTxnState.txnRewindPos = -1
 	ex.extraTxnState.schemaChangeJobsCache = make(map[descpb.ID]*jobs.Job)
+	ex.extraTxnState.deferredFKChecks = make(map[deferredFKCheck]struct{})
 	ex.mu.ActiveQueries = make(map[ClusterWideID]*queryMeta)
 	ex.machine = fsm.MakeMachine(TxnStateTransitions, stateNoTxn{}, &ex.state)
 
@@ -706,6 +707,11 @@ func (s *Server) newConnExecutorWithTxn(
 	// This allows the InternalExecutor to see schema changes made by the
 	// parent executor.
 	ex.extraTxnState.descCollection.SetSyntheticDescriptors(syntheticDescs)
+
+	// The transaction is committed by its owner rather than by this executor,
+	// so foreign key checks cannot be deferred until the commit.
+	ex.extraTxnState.deferredFKChecks = nil
+	ex.planner.extendedEvalCtx.DeferredFKChecks = nil
 	return ex
 }
 
@@ -1000,6 +1006,11 @@ type connExecutor struct {
 		// queued up for the given ID.
 		schemaChangeJobsCache map[descpb.ID]*jobs.Job
 
+		// deferredFKChecks contains the initially deferred foreign key
+		// constraints that were found to be violated by a statement in the
+		// transaction. They are validated again before the transaction commits.
+		deferredFKChecks map[deferredFKCheck]struct{}
+
 		// autoRetryCounter keeps track of the which iteration of a transaction
 		// auto-retry we're currently in. It's 0 whenever the transaction state is not
 		// stateOpen.
@@ -1009,6 +1020,10 @@ type connExecutor struct {
 		// executed so far.
 		numDDL int
 
+		// changedRoles is set if the transaction executed statements which
+		// change roles or role memberships. See changesRoles.
+		changedRoles bool
+
 		// numRows keeps track of the number of rows that have been observed by this
 		// transaction. This is simply the summation of number of rows observed by
 		// comprising statements.
@@ -1108,6 +1123,12 @@ type connExecutor struct {
 		// has admin privilege. hasAdminRoleCache is set for the first statement
 		// in a transaction.
 		hasAdminRoleCache HasAdminRoleCache
+
+		// comments caches the comments read by the virtual tables.
+		comments commentCache
+
+		// catalogSnapshot pins the descriptors read by the virtual tables.
+		catalogSnapshot catalogSnapshot
 	}
 
 	// sessionData contains the user-configurable connection variables.
@@ -1299,6 +1320,8 @@ func (ns *prepStmtNamespace) resetTo(
 func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) error {
 	ex.extraTxnState.jobs = nil
 	ex.extraTxnState.hasAdminRoleCache = HasAdminRoleCache{}
+	ex.extraTxnState.comments.clear()
+	ex.extraTxnState.catalogSnapshot.clear()
 	if ex.server.cfg.Settings.Version.IsActive(ctx, clusterversion.NewSchemaChanger) {
 		ex.extraTxnState.schemaChangerState = SchemaChangerState{
 			mode: ex.sessionData.NewSchemaChangerMode,
@@ -1309,6 +1332,10 @@ func (ex *connExecutor) resetExtraTxnState(ctx context.Context, ev txnEvent) err
 		delete(ex.extraTxnState.schemaChangeJobsCache, k)
 	}
 
+	for k := range ex.extraTxnState.deferredFKChecks {
+		delete(ex.extraTxnState.deferredFKChecks, k)
+	}
+
 	ex.extraTxnState.descCollection.ReleaseAll(ctx)
 
 	// Close all portals.
@@ -2219,8 +2246,11 @@ func (ex *connExecutor) initEvalCtx(ctx context.Context, evalCtx *extendedEvalCo
 		TxnModesSetter:       ex,
 		Jobs:                 &ex.extraTxnState.jobs,
 		SchemaChangeJobCache: ex.extraTxnState.schemaChangeJobsCache,
+		DeferredFKChecks:     ex.extraTxnState.deferredFKChecks,
 		schemaAccessors:      scInterface,
 		sqlStatsCollector:    ex.statsCollector,
+		comments:             &ex.extraTxnState.comments,
+		catalogSnapshot:      &ex.extraTxnState.catalogSnapshot,
 	}
 }
 
@@ -2301,6 +2331,16 @@ func (ex *connExecutor) resetPlanner(
 	p.autoCommit = false
 	p.isPreparing = false
 	p.avoidCachedDescriptors = false
+	p.connectPrivileges = nil
+	p.anyPrivileges = nil
+	p.userRolesCache = nil
+	p.roleMemberships = nil
+	// A transaction which executed DDL statements reads the descriptors again
+	// for every statement, so that it observes its own changes.
+	if ex.extraTxnState.numDDL > 0 {
+		ex.extraTxnState.catalogSnapshot.clear()
+	}
+	p.txnHasDDL = ex.extraTxnState.numDDL > 0
 }
 
 // txnStateTransitionsApplyWrapper is a wrapper on top of Machine built with the
@@ -2396,6 +2436,12 @@ func (ex *connExecutor) txnStateTransitionsApplyWrapper(
 			ex.extraTxnState.jobs); err != nil {
 			handleErr(err)
 		}
+		if ex.extraTxnState.numDDL > 0 || ex.extraTxnState.changedRoles {
+			// The rangefeed which invalidates the cached introspection
+			// results lags behind the commit, so they are invalidated
+			// right away for the following statements of the session.
+			ex.server.cfg.VirtualSchemas.introspectionQueries.invalidate()
+		}
 
 		fallthrough
 	case txnRestart, txnRollback:
@@ -755,6 +755,10 @@ func (ex *connExecutor) commitSQLTransactionInternal(
 		}
 	}
 
+	if err := ex.validateDeferredForeignKeys(ctx); err != nil {
+		return err
+	}
+
 	if err := ex.extraTxnState.descCollection.ValidateUncommittedDescriptors(ctx, ex.state.mu.txn); err != nil {
 		return err
 	}
@@ -964,6 +968,15 @@ func (ex *connExecutor) makeExecPlan(ctx context.Context, planner *planner) erro
 	if flags.IsSet(planFlagIsDDL) {
 		ex.extraTxnState.numDDL++
 	}
+	if changesRoles(planner.stmt.AST) {
+		ex.extraTxnState.changedRoles = true
+	}
+
+	// Statements which may write to system.comments invalidate the comments
+	// cached for the transaction.
+	if flags.IsSet(planFlagIsDDL) || tree.CanWriteData(planner.stmt.AST) {
+		ex.extraTxnState.comments.clear()
+	}
 
 	return nil
 }
@@ -1493,6 +1506,7 @@ func (ex *connExecutor) recordTransactionStart() (onTxnFinish func(txnEvent), on
 	ex.extraTxnState.transactionStatementsHash = util.MakeFNV64()
 	ex.extraTxnState.transactionStatementIDs = nil
 	ex.extraTxnState.numRows = 0
+	ex.extraTxnState.changedRoles = false
 	ex.extraTxnState.shouldCollectTxnExecutionStats = false
 	ex.extraTxnState.accumulatedStats = execstats.QueryLevelStats{}
 	ex.extraTxnState.rowsRead = 0
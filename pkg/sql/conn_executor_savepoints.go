@@ -189,6 +189,8 @@ func (ex *connExecutor) execRollbackToSavepointInOpenState(
 	}
 
 	ex.extraTxnState.savepoints.popToIdx(idx)
+	// The comments cached since the savepoint may include rolled back writes.
+	ex.extraTxnState.comments.clear()
 
 	if entry.kvToken.Initial() {
 		return eventTxnRestart{}, nil
@@ -267,6 +269,8 @@ func (ex *connExecutor) execRollbackToSavepointInAbortedState(
 	if err := ex.state.mu.txn.RollbackToSavepoint(ctx, entry.kvToken); err != nil {
 		return ex.makeErrEvent(err, s)
 	}
+	// The comments cached since the savepoint may include rolled back writes.
+	ex.extraTxnState.comments.clear()
 
 	if entry.kvToken.Initial() {
 		return eventTxnRestart{}, nil
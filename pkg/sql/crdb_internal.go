@@ -54,7 +54,9 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/duration"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil"
 	"github.com/cockroachdb/cockroach/pkg/util/json"
@@ -131,6 +133,18 @@ var crdbInternal = virtualSchema{
 		catconstants.CrdbInternalClusterDatabasePrivilegesTableID: crdbInternalClusterDatabasePrivilegesTable,
 		catconstants.CrdbInternalInterleaved:                      crdbInternalInterleaved,
 		catconstants.CrdbInternalCrossDbRefrences:                 crdbInternalCrossDbReferences,
+		catconstants.CrdbInternalCatalogDiscrepanciesTableID:      crdbInternalCatalogDiscrepanciesTable,
+		catconstants.CrdbInternalDefaultPrivilegesTableID:         crdbInternalDefaultPrivilegesTable,
+		catconstants.CrdbInternalRolesTableID:                     crdbInternalRolesTable,
+		catconstants.CrdbInternalDatabasePrivilegesTableID:        crdbInternalDatabasePrivilegesTable,
+		catconstants.CrdbInternalMaterializedViewsTableID:         crdbInternalMaterializedViewsTable,
+		catconstants.CrdbInternalViewDependencyClosureTableID:     crdbInternalViewDependencyClosureTable,
+		catconstants.CrdbInternalSerialSequencesTableID:           crdbInternalSerialSequencesTable,
+		catconstants.CrdbInternalRoleOptionsTableID:               crdbInternalRoleOptionsTable,
+		catconstants.CrdbInternalRoleMembershipClosureTableID:     crdbInternalRoleMembershipClosureTable,
+		catconstants.CrdbInternalRolePasswordPoliciesTableID:      crdbInternalRolePasswordPoliciesTable,
+		catconstants.CrdbInternalExternalPrincipalMappingsTableID: crdbInternalExternalPrincipalMappingsTable,
+		catconstants.CrdbInternalVirtualTablePopulationsTableID:   crdbInternalVirtualTablePopulationsTable,
 	},
 	validWithNoDatabaseContext: true,
 }
@@ -303,7 +317,7 @@ CREATE TABLE crdb_internal.tables (
 	generator: func(ctx context.Context, p *planner, dbDesc catalog.DatabaseDescriptor, stopper *stop.Stopper) (virtualTableGenerator, cleanupFunc, error) {
 		row := make(tree.Datums, 14)
 		worker := func(pusher rowPusher) error {
-			descs, err := p.Descriptors().GetAllDescriptors(ctx, p.txn)
+			descs, err := p.getCatalogDescriptors(ctx)
 			if err != nil {
 				return err
 			}
@@ -410,7 +424,7 @@ CREATE TABLE crdb_internal.tables (
 			}
 			return nil
 		}
-		return setupGenerator(ctx, worker, stopper)
+		return setupGenerator(ctx, bufferRows(len(row), worker), stopper)
 	},
 }
 
@@ -504,7 +518,7 @@ CREATE TABLE crdb_internal.schema_changes (
   direction     STRING NOT NULL
 )`,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		descs, err := p.Descriptors().GetAllDescriptors(ctx, p.txn)
+		descs, err := p.getCatalogDescriptors(ctx)
 		if err != nil {
 			return err
 		}
@@ -1287,7 +1301,9 @@ CREATE TABLE crdb_internal.cluster_settings (
   value         STRING NOT NULL,
   type          STRING NOT NULL,
   public        BOOL NOT NULL, -- whether the setting is documented, which implies the user can expect support.
-  description   STRING NOT NULL
+  description   STRING NOT NULL,
+  default_value STRING NOT NULL,
+  visibility    STRING NOT NULL  -- 'public' or 'reserved'
 )`,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		hasAdmin, err := p.HasAdminRole(ctx)
@@ -1305,6 +1321,9 @@ CREATE TABLE crdb_internal.cluster_settings (
 						"crdb_internal.cluster_settings", roleoption.MODIFYCLUSTERSETTING)
 			}
 		}
+		// Fill a Values struct with the defaults.
+		var defaults settings.Values
+		defaults.Init(nil /* opaque */)
 		for _, k := range settings.Keys() {
 			if !hasAdmin && settings.AdminOnly(k) {
 				continue
@@ -1313,12 +1332,21 @@ CREATE TABLE crdb_internal.cluster_settings (
 			strVal := setting.String(&p.ExecCfg().Settings.SV)
 			isPublic := setting.Visibility() == settings.Public
 			desc := setting.Description()
+			var defaultVal string
+			if sm, ok := setting.(*settings.VersionSetting); ok {
+				// Version settings have no default they can be reset to.
+				defaultVal = sm.SettingsListDefault()
+			} else {
+				defaultVal = setting.String(&defaults)
+			}
 			if err := addRow(
 				tree.NewDString(k),
 				tree.NewDString(strVal),
 				tree.NewDString(setting.Typ()),
 				tree.MakeDBool(tree.DBool(isPublic)),
 				tree.NewDString(desc),
+				tree.NewDString(defaultVal),
+				tree.NewDString(setting.Visibility().String()),
 			); err != nil {
 				return err
 			}
@@ -1332,23 +1360,28 @@ var crdbInternalSessionVariablesTable = virtualSchemaTable{
 	comment: `session variables (RAM)`,
 	schema: `
 CREATE TABLE crdb_internal.session_variables (
-  variable STRING NOT NULL,
-  value    STRING NOT NULL,
-  hidden   BOOL   NOT NULL
+  variable      STRING NOT NULL,
+  value         STRING NOT NULL,
+  hidden        BOOL   NOT NULL,
+  default_value STRING,          -- the value RESET restores, if any
+  read_only     BOOL   NOT NULL, -- whether the value cannot be changed
+  scope         STRING NOT NULL, -- 'session' or 'cluster'
+  description   STRING NOT NULL
 )`,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		for _, vName := range varNames {
-			gen := varGen[vName]
-			value := gen.Get(&p.extendedEvalCtx)
-			if err := addRow(
+		return p.forEachSessionVar(func(vName string, gen sessionVar) error {
+			value := tree.NewDString(gen.Get(&p.extendedEvalCtx))
+			_, defaultValue := sessionVarDefaultDatums(p, vName, gen, value)
+			return addRow(
 				tree.NewDString(vName),
-				tree.NewDString(value),
+				value,
 				tree.MakeDBool(tree.DBool(gen.Hidden)),
-			); err != nil {
-				return err
-			}
-		}
-		return nil
+				defaultValue,
+				tree.MakeDBool(tree.DBool(gen.readOnly())),
+				tree.NewDString(gen.scope()),
+				tree.NewDString(gen.Description),
+			)
+		})
 	},
 }
 
@@ -2287,13 +2320,14 @@ CREATE TABLE crdb_internal.table_indexes (
   index_name       STRING NOT NULL,
   index_type       STRING NOT NULL,
   is_unique        BOOL NOT NULL,
-  is_inverted      BOOL NOT NULL
+  is_inverted      BOOL NOT NULL,
+  collation_version STRING
 )
 `,
 	generator: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, stopper *stop.Stopper) (virtualTableGenerator, cleanupFunc, error) {
 		primary := tree.NewDString("primary")
 		secondary := tree.NewDString("secondary")
-		row := make(tree.Datums, 7)
+		row := make(tree.Datums, 8)
 		worker := func(pusher rowPusher) error {
 			return forEachTableDescAll(ctx, p, dbContext, hideVirtual,
 				func(db catalog.DatabaseDescriptor, _ string, table catalog.TableDescriptor) error {
@@ -2309,6 +2343,10 @@ CREATE TABLE crdb_internal.table_indexes (
 						if idx.Primary() {
 							idxType = primary
 						}
+						collationVersion := tree.DNull
+						if v := idx.GetCollationVersion(); v != "" {
+							collationVersion = tree.NewDString(v)
+						}
 						row = append(row,
 							tableID,
 							tableName,
@@ -2317,6 +2355,7 @@ CREATE TABLE crdb_internal.table_indexes (
 							idxType,
 							tree.MakeDBool(tree.DBool(idx.IsUnique())),
 							tree.MakeDBool(idx.GetType() == descpb.IndexDescriptor_INVERTED),
+							collationVersion,
 						)
 						return pusher.pushRow(row...)
 					})
@@ -2699,6 +2738,7 @@ CREATE TABLE crdb_internal.forward_dependencies (
 
 // crdbInternalRangesView exposes system ranges.
 var crdbInternalRangesView = virtualSchemaView{
+	systemTenantOnly: true,
 	schema: `
 CREATE VIEW crdb_internal.ranges AS SELECT
 	range_id,
@@ -2749,7 +2789,8 @@ FROM crdb_internal.ranges_no_leases
 //
 // TODO(tbg): prefix with kv_.
 var crdbInternalRangesNoLeasesTable = virtualSchemaTable{
-	comment: `range metadata without leaseholder details (KV join; expensive!)`,
+	comment:          `range metadata without leaseholder details (KV join; expensive!)`,
+	systemTenantOnly: true,
 	// NB 1: The `replicas` column is the union of `voting_replicas` and
 	// `non_voting_replicas` and does not include `learner_replicas`.
 	// NB 2: All the values in the `*replicas` columns correspond to store IDs.
@@ -2777,7 +2818,7 @@ CREATE TABLE crdb_internal.ranges_no_leases (
 		if err := p.RequireAdminRole(ctx, "read crdb_internal.ranges_no_leases"); err != nil {
 			return nil, nil, err
 		}
-		descs, err := p.Descriptors().GetAllDescriptors(ctx, p.txn)
+		descs, err := p.getCatalogDescriptors(ctx)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -3247,7 +3288,8 @@ func getAllNodeDescriptors(p *planner) ([]roachpb.NodeDescriptor, error) {
 
 // crdbInternalGossipNodesTable exposes local information about the cluster nodes.
 var crdbInternalGossipNodesTable = virtualSchemaTable{
-	comment: "locally known gossiped node details (RAM; local node only)",
+	comment:          "locally known gossiped node details (RAM; local node only)",
+	systemTenantOnly: true,
 	schema: `
 CREATE TABLE crdb_internal.gossip_nodes (
   node_id               INT NOT NULL,
@@ -3377,7 +3419,8 @@ CREATE TABLE crdb_internal.gossip_nodes (
 // for compatibility with v20.1 binaries where the `cockroach node` cli
 // processes make use of it.
 var crdbInternalGossipLivenessTable = virtualSchemaTable{
-	comment: "locally known gossiped node liveness (RAM; local node only)",
+	comment:          "locally known gossiped node liveness (RAM; local node only)",
+	systemTenantOnly: true,
 	schema: `
 CREATE TABLE crdb_internal.gossip_liveness (
   node_id          INT NOT NULL,
@@ -3459,7 +3502,8 @@ CREATE TABLE crdb_internal.gossip_liveness (
 
 // crdbInternalGossipAlertsTable exposes current health alerts in the cluster.
 var crdbInternalGossipAlertsTable = virtualSchemaTable{
-	comment: "locally known gossiped health alerts (RAM; local node only)",
+	comment:          "locally known gossiped health alerts (RAM; local node only)",
+	systemTenantOnly: true,
 	schema: `
 CREATE TABLE crdb_internal.gossip_alerts (
   node_id         INT NOT NULL,
@@ -3531,7 +3575,8 @@ CREATE TABLE crdb_internal.gossip_alerts (
 // crdbInternalGossipNetwork exposes the local view of the gossip network (i.e
 // the gossip client connections from source_id node to target_id node).
 var crdbInternalGossipNetworkTable = virtualSchemaTable{
-	comment: "locally known edges in the gossip network (RAM; local node only)",
+	comment:          "locally known edges in the gossip network (RAM; local node only)",
+	systemTenantOnly: true,
 	schema: `
 CREATE TABLE crdb_internal.gossip_network (
   source_id       INT NOT NULL,    -- source node of a gossip connection
@@ -3759,7 +3804,8 @@ CREATE TABLE crdb_internal.partitions (
 //
 // TODO(tbg): s/kv_/cluster_/
 var crdbInternalKVNodeStatusTable = virtualSchemaTable{
-	comment: "node details across the entire cluster (cluster RPC; expensive!)",
+	comment:          "node details across the entire cluster (cluster RPC; expensive!)",
+	systemTenantOnly: true,
 	schema: `
 CREATE TABLE crdb_internal.kv_node_status (
   node_id        INT NOT NULL,
@@ -3882,7 +3928,8 @@ CREATE TABLE crdb_internal.kv_node_status (
 //
 // TODO(tbg): s/kv_/cluster_/
 var crdbInternalKVStoreStatusTable = virtualSchemaTable{
-	comment: "store details and status (cluster RPC; expensive!)",
+	comment:          "store details and status (cluster RPC; expensive!)",
+	systemTenantOnly: true,
 	schema: `
 CREATE TABLE crdb_internal.kv_store_status (
   node_id            INT NOT NULL,
@@ -4062,9 +4109,12 @@ CREATE TABLE crdb_internal.invalid_objects (
 		if err != nil {
 			return err
 		}
-		const allowAdding = true
+		lCtx, err := p.newCatalogLookupCtx(ctx, descs, dbContext)
+		if err != nil {
+			return err
+		}
 		if err := forEachTableDescWithTableLookupInternalFromDescriptors(
-			ctx, p, dbContext, hideVirtual, allowAdding, descs, func(
+			ctx, p, dbContext, hideVirtual, publicAndAddingTableDescs, descs, lCtx, func(
 				dbDesc catalog.DatabaseDescriptor, schema string, descriptor catalog.TableDescriptor, fn tableLookupFn,
 			) error {
 				if descriptor == nil {
@@ -4091,7 +4141,7 @@ CREATE TABLE crdb_internal.invalid_objects (
 
 		// Validate type descriptors.
 		return forEachTypeDescWithTableLookupInternalFromDescriptors(
-			ctx, p, dbContext, allowAdding, descs, func(
+			ctx, p, dbContext, allowAdding, descs, lCtx, func(
 				dbDesc catalog.DatabaseDescriptor, schema string, descriptor catalog.TypeDescriptor, fn tableLookupFn,
 			) error {
 				if descriptor == nil {
@@ -4149,6 +4199,753 @@ CREATE TABLE crdb_internal.cluster_database_privileges (
 	},
 }
 
+var crdbInternalDatabasePrivilegesTable = virtualSchemaTable{
+	comment: `virtual table with the database privileges visible to the current user`,
+	schema: `
+CREATE TABLE crdb_internal.database_privileges (
+	database_name   STRING NOT NULL,
+	grantor         STRING,
+	grantee         STRING NOT NULL,
+	privilege_type  STRING NOT NULL,
+	is_grantable    STRING NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		// Unlike cluster_database_privileges, which is used to check the
+		// privileges of any user, the grants are filtered like in
+		// information_schema.table_privileges.
+		visibility, err := makeGrantVisibility(ctx, p)
+		if err != nil {
+			return err
+		}
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				dbNameStr := tree.NewDString(db.GetName())
+				privs := db.GetPrivileges()
+				for _, u := range privs.Users {
+					if !visibility.isVisible(privs, u) {
+						continue
+					}
+					grantor := grantorDatum(u)
+					granteeStr := tree.NewDString(u.User().Normalized())
+					for _, priv := range privilege.ListFromBitField(u.Privileges, privilege.Database) {
+						if err := addRow(
+							dbNameStr,                      // database_name
+							grantor,                        // grantor
+							granteeStr,                     // grantee
+							tree.NewDString(priv.String()), // privilege_type
+							yesOrNoDatum(u.CanGrant(priv)), // is_grantable
+						); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
+	},
+}
+
+var crdbInternalMaterializedViewsTable = virtualSchemaTable{
+	comment: `materialized views with the state of their data (RAM)`,
+	schema: `
+CREATE TABLE crdb_internal.materialized_views (
+	descriptor_id        INT NOT NULL,
+	database_name        STRING NOT NULL,
+	schema_name          STRING NOT NULL,
+	name                 STRING NOT NULL,
+	owner                STRING NOT NULL,
+	definition           STRING NOT NULL,
+	created              TIMESTAMPTZ,
+	last_refreshed       TIMESTAMPTZ,
+	refresh_in_progress  BOOL NOT NULL,
+	staleness            INTERVAL
+)`,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		now := p.EvalContext().GetStmtTimestamp()
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual,
+			func(db catalog.DatabaseDescriptor, scName string, desc catalog.TableDescriptor) error {
+				if !desc.MaterializedView() {
+					return nil
+				}
+				// The data of the view dates from the last refresh, or from the
+				// creation of the view if it was never refreshed.
+				dataTime := desc.GetRefreshAsOfTime()
+				if dataTime.IsEmpty() {
+					dataTime = desc.GetCreateAsOfTime()
+				}
+				staleness := tree.DNull
+				if !dataTime.IsEmpty() {
+					staleness = tree.NewDInterval(
+						duration.MakeDuration(now.Sub(dataTime.GoTime()).Nanoseconds(), 0 /* days */, 0 /* months */),
+						types.DefaultIntervalTypeMetadata,
+					)
+				}
+				refreshInProgress := false
+				for _, m := range desc.AllMutations() {
+					if m.AsMaterializedViewRefresh() != nil {
+						refreshInProgress = true
+						break
+					}
+				}
+				owner := getOwnerOfDesc(desc)
+				return addRow(
+					tree.NewDInt(tree.DInt(desc.GetID())),          // descriptor_id
+					tree.NewDString(db.GetName()),                  // database_name
+					tree.NewDString(scName),                        // schema_name
+					tree.NewDString(desc.GetName()),                // name
+					tree.NewDString(owner.Normalized()),            // owner
+					tree.NewDString(viewDefinition(p, desc)),       // definition
+					descriptorTimestamp(desc.GetCreateAsOfTime()),  // created
+					descriptorTimestamp(desc.GetRefreshAsOfTime()), // last_refreshed
+					tree.MakeDBool(tree.DBool(refreshInProgress)),  // refresh_in_progress
+					staleness, // staleness
+				)
+			})
+	},
+}
+
+var crdbInternalViewDependencyClosureTable = virtualSchemaTable{
+	comment: `transitive dependencies of views accessible by current user in current database (KV scan)`,
+	schema: `
+CREATE TABLE crdb_internal.view_dependency_closure (
+	view_id               INT NOT NULL,
+	view_database         STRING NOT NULL,
+	view_schema           STRING NOT NULL,
+	view_name             STRING NOT NULL,
+	dependency_id         INT NOT NULL,
+	dependency_database   STRING NOT NULL,
+	dependency_schema     STRING NOT NULL,
+	dependency_name       STRING NOT NULL,
+	dependency_type       STRING NOT NULL,
+	depth                 INT NOT NULL,
+	path                  INT[] NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTableDescWithTableLookup(ctx, p, dbContext, hideVirtual,
+			func(db catalog.DatabaseDescriptor, scName string, view catalog.TableDescriptor, lookupFn tableLookupFn) error {
+				if !view.IsView() {
+					return nil
+				}
+				viewID := tree.NewDInt(tree.DInt(view.GetID()))
+				viewDatabase := tree.NewDString(db.GetName())
+				viewSchema := tree.NewDString(scName)
+				viewName := tree.NewDString(view.GetName())
+
+				// Walk the dependency links breadth-first, so that every
+				// dependency is reported once with the shortest path leading to
+				// it from the view.
+				type step struct {
+					id   descpb.ID
+					path []descpb.ID
+				}
+				visited := map[descpb.ID]struct{}{view.GetID(): {}}
+				queue := []step{{id: view.GetID(), path: []descpb.ID{view.GetID()}}}
+				for len(queue) > 0 {
+					cur := queue[0]
+					queue = queue[1:]
+					curDesc, err := lookupFn.getTableByID(cur.id)
+					if err != nil {
+						return err
+					}
+					for _, depID := range curDesc.GetDependsOn() {
+						if _, ok := visited[depID]; ok {
+							continue
+						}
+						visited[depID] = struct{}{}
+						dep, err := lookupFn.getTableByID(depID)
+						if err != nil {
+							return err
+						}
+						depSchema, err := lookupFn.getSchemaNameByID(dep.GetParentSchemaID())
+						if err != nil {
+							return err
+						}
+						path := make([]descpb.ID, len(cur.path)+1)
+						copy(path, cur.path)
+						path[len(cur.path)] = depID
+						pathArr := tree.NewDArray(types.Int)
+						for _, id := range path {
+							if err := pathArr.Append(tree.NewDInt(tree.DInt(id))); err != nil {
+								return err
+							}
+						}
+						depType := typeTable
+						if dep.IsView() {
+							depType = typeView
+						} else if dep.IsSequence() {
+							depType = typeSequence
+						}
+						if err := addRow(
+							viewID,                         // view_id
+							viewDatabase,                   // view_database
+							viewSchema,                     // view_schema
+							viewName,                       // view_name
+							tree.NewDInt(tree.DInt(depID)), // dependency_id
+							tree.NewDString(lookupFn.getDatabaseName(dep)), // dependency_database
+							tree.NewDString(depSchema),                     // dependency_schema
+							tree.NewDString(dep.GetName()),                 // dependency_name
+							depType,                                        // dependency_type
+							tree.NewDInt(tree.DInt(len(path)-1)),           // depth
+							pathArr,                                        // path
+						); err != nil {
+							return err
+						}
+						if dep.IsView() {
+							queue = append(queue, step{id: depID, path: path})
+						}
+					}
+				}
+				return nil
+			})
+	},
+}
+
+var crdbInternalSerialSequencesTable = virtualSchemaTable{
+	comment: `columns backed by a sequence, as reported by pg_get_serial_sequence (KV scan)`,
+	schema: `
+CREATE TABLE crdb_internal.serial_sequences (
+	table_id              INT NOT NULL,
+	table_database        STRING NOT NULL,
+	table_schema          STRING NOT NULL,
+	table_name            STRING NOT NULL,
+	column_id             INT NOT NULL,
+	column_name           STRING NOT NULL,
+	sequence_id           INT NOT NULL,
+	sequence_database     STRING NOT NULL,
+	sequence_schema       STRING NOT NULL,
+	sequence_name         STRING NOT NULL,
+	is_identity           BOOL NOT NULL,
+	is_owned              BOOL NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTableDescWithTableLookup(ctx, p, dbContext, hideVirtual,
+			func(db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor, lookupFn tableLookupFn) error {
+				if !table.IsTable() {
+					return nil
+				}
+				tableID := tree.NewDInt(tree.DInt(table.GetID()))
+				tableDatabase := tree.NewDString(db.GetName())
+				tableSchema := tree.NewDString(scName)
+				tableName := tree.NewDString(table.GetName())
+				for _, col := range table.PublicColumns() {
+					seqID, owned, ok := serialSequenceID(col)
+					if !ok {
+						continue
+					}
+					seq, err := lookupFn.getTableByID(seqID)
+					if err != nil {
+						return err
+					}
+					seqSchema, err := lookupFn.getSchemaNameByID(seq.GetParentSchemaID())
+					if err != nil {
+						return err
+					}
+					seqDatabase := lookupFn.getDatabaseName(seq)
+					if err := addRow(
+						tableID,                              // table_id
+						tableDatabase,                        // table_database
+						tableSchema,                          // table_schema
+						tableName,                            // table_name
+						tree.NewDInt(tree.DInt(col.GetID())), // column_id
+						tree.NewDString(col.GetName()),       // column_name
+						tree.NewDInt(tree.DInt(seqID)),       // sequence_id
+						tree.NewDString(seqDatabase),         // sequence_database
+						tree.NewDString(seqSchema),           // sequence_schema
+						tree.NewDString(seq.GetName()),       // sequence_name
+						tree.MakeDBool(tree.DBool(col.IsGeneratedAsIdentity())), // is_identity
+						tree.MakeDBool(tree.DBool(owned)),                       // is_owned
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+	},
+}
+
+var crdbInternalDefaultPrivilegesTable = virtualSchemaTable{
+	comment: `virtual table with the default privileges set by ALTER DEFAULT PRIVILEGES`,
+	schema: `
+CREATE TABLE crdb_internal.default_privileges (
+	database_name   STRING NOT NULL,
+	schema_name     STRING,
+	role            STRING NOT NULL,
+	object_type     STRING NOT NULL,
+	grantee         STRING NOT NULL,
+	privilege_type  STRING NOT NULL,
+	is_grantable    BOOL NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				dbNameStr := tree.NewDString(db.GetName())
+				return forEachDefaultPrivilege(db, func(
+					defaultPrivs *descpb.DefaultPrivilegesForRole, schemaName string,
+				) error {
+					// A NULL schema name means the defaults apply to all schemas.
+					schemaNameDatum := tree.DNull
+					if schemaName != "" {
+						schemaNameDatum = tree.NewDString(schemaName)
+					}
+					roleStr := tree.NewDString(defaultPrivs.Role().Normalized())
+					objectTypeStr := tree.NewDString(strings.ToLower(defaultPrivs.ObjectType.String()))
+					objectType := defaultPrivs.ObjectType.ToPrivilegeObjectType()
+					for _, u := range defaultPrivs.Privileges.Users {
+						granteeStr := tree.NewDString(u.User().Normalized())
+						for _, priv := range privilege.ListFromBitField(u.Privileges, objectType) {
+							isGrantable := tree.MakeDBool(tree.DBool(u.CanGrant(priv)))
+							if err := addRow(
+								dbNameStr,                      // database_name
+								schemaNameDatum,                // schema_name
+								roleStr,                        // role
+								objectTypeStr,                  // object_type
+								granteeStr,                     // grantee
+								tree.NewDString(priv.String()), // privilege_type
+								isGrantable,                    // is_grantable
+							); err != nil {
+								return err
+							}
+						}
+					}
+					return nil
+				})
+			})
+	},
+}
+
+// forEachDefaultPrivilege calls fn on every default privileges entry of the
+// given database, along with the name of the schema it is restricted to. The
+// schema name is empty for entries which apply to all schemas. Entries for
+// schemas which were dropped are skipped.
+func forEachDefaultPrivilege(
+	db catalog.DatabaseDescriptor,
+	fn func(defaultPrivs *descpb.DefaultPrivilegesForRole, schemaName string) error,
+) error {
+	defaultPrivs := db.GetDefaultPrivileges()
+	if defaultPrivs == nil {
+		return nil
+	}
+	for i := range defaultPrivs.DefaultPrivilegesPerRole {
+		entry := &defaultPrivs.DefaultPrivilegesPerRole[i]
+		var schemaName string
+		switch entry.SchemaID {
+		case 0:
+		case keys.PublicSchemaID:
+			schemaName = tree.PublicSchema
+		default:
+			if schemaName = db.GetNonDroppedSchemaName(entry.SchemaID); schemaName == "" {
+				continue
+			}
+		}
+		if err := fn(entry, schemaName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var crdbInternalRolesTable = virtualSchemaTable{
+	comment: `virtual table with the role options of every user and role`,
+	schema: `
+CREATE TABLE crdb_internal.roles (
+	role_name         STRING NOT NULL,
+	is_role           BOOL NOT NULL,
+	can_login         BOOL NOT NULL,
+	create_role       BOOL NOT NULL,
+	create_db         BOOL NOT NULL,
+	valid_until       TIMESTAMPTZ,
+	connection_limit  INT NOT NULL,
+	options           STRING[] NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		// Like pg_roles, this table does not expose sensitive information, so
+		// no privilege on system.users is required to read it.
+		roles, err := p.getRoles(ctx)
+		if err != nil {
+			return err
+		}
+		for i := range roles {
+			role := &roles[i]
+			// root and admin implicitly hold every role option.
+			isSuperuser := role.username.IsRootUser() || role.username.IsAdminRole()
+			canLogin, createRole, createDB := true, isSuperuser, isSuperuser
+			validUntil := tree.DNull
+			if role.validUntil != nil {
+				validUntil = tree.MustMakeDTimestampTZ(*role.validUntil, time.Microsecond)
+			}
+			options := tree.NewDArray(types.String)
+			for _, opt := range role.options {
+				switch opt.option {
+				case "NOLOGIN":
+					canLogin = false
+				case "CREATEROLE":
+					createRole = true
+				case "CREATEDB":
+					createDB = true
+				}
+				if err := options.Append(tree.NewDString(opt.option)); err != nil {
+					return err
+				}
+			}
+			if err := addRow(
+				tree.NewDString(role.username.Normalized()), // role_name
+				tree.MakeDBool(tree.DBool(role.isRole)),     // is_role
+				tree.MakeDBool(tree.DBool(canLogin)),        // can_login
+				tree.MakeDBool(tree.DBool(createRole)),      // create_role
+				tree.MakeDBool(tree.DBool(createDB)),        // create_db
+				validUntil,                                  // valid_until
+				negOneVal,                                   // connection_limit
+				options,                                     // options
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var crdbInternalRoleOptionsTable = virtualSchemaTable{
+	comment: `virtual table with one row per option of every user and role`,
+	schema: `
+CREATE TABLE crdb_internal.role_options (
+	role_name STRING NOT NULL,
+	option    STRING NOT NULL,
+	value     STRING,
+	INDEX(role_name)
+)`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		roles, err := p.getRoles(ctx)
+		if err != nil {
+			return err
+		}
+		for i := range roles {
+			if err := addRoleOptionsRows(&roles[i], addRow); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	indexes: []virtualIndex{
+		{
+			populate: func(ctx context.Context, constraint tree.Datum, p *planner, _ catalog.DatabaseDescriptor,
+				addRow func(...tree.Datum) error) (bool, error) {
+				roleName, ok := constraint.(*tree.DString)
+				if !ok {
+					return false, nil
+				}
+				roles, err := p.getRoles(ctx)
+				if err != nil {
+					return false, err
+				}
+				// The roles are sorted by username.
+				i := sort.Search(len(roles), func(i int) bool {
+					return roles[i].username.Normalized() >= string(*roleName)
+				})
+				if i == len(roles) || roles[i].username.Normalized() != string(*roleName) {
+					return false, nil
+				}
+				return true, addRoleOptionsRows(&roles[i], addRow)
+			},
+		},
+	},
+}
+
+// addRoleOptionsRows adds the rows of crdb_internal.role_options for a role.
+// The PASSWORD option is listed for the roles which have a password, without
+// its value.
+func addRoleOptionsRows(role *roleMetadata, addRow func(...tree.Datum) error) error {
+	roleName := tree.NewDString(role.username.Normalized())
+	if role.hasPassword {
+		if err := addRow(
+			roleName,                    // role_name
+			tree.NewDString("PASSWORD"), // option
+			tree.DNull,                  // value
+		); err != nil {
+			return err
+		}
+	}
+	for _, opt := range role.options {
+		value := tree.DNull
+		if opt.value != nil {
+			value = tree.NewDString(*opt.value)
+		}
+		if err := addRow(
+			roleName,                    // role_name
+			tree.NewDString(opt.option), // option
+			value,                       // value
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var crdbInternalRolePasswordPoliciesTable = virtualSchemaTable{
+	comment: `password policy and login state of every user and role`,
+	schema: `
+CREATE TABLE crdb_internal.role_password_policies (
+	role_name              STRING NOT NULL,
+	password_complexity    INT,
+	password_lifetime      INTERVAL,
+	login_attempts         INT,
+	valid_until            TIMESTAMPTZ,
+	password_expired       BOOL NOT NULL,
+	failed_login_attempts  INT NOT NULL,
+	locked                 BOOL NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.role_password_policies"); err != nil {
+			return err
+		}
+		roles, err := p.getRoles(ctx)
+		if err != nil {
+			return err
+		}
+		failed, err := p.getFailedLoginAttempts(ctx)
+		if err != nil {
+			return err
+		}
+		now := p.EvalContext().GetStmtTimestamp()
+		for i := range roles {
+			role := &roles[i]
+			var policy passwordPolicy
+			attempts := LoginAttempts{Failed: failed[role.username]}
+			if attempts.Limit, err = role.loginAttemptsLimit(); err != nil {
+				return err
+			}
+			complexity, lifetime, loginAttempts := tree.DNull, tree.DNull, tree.DNull
+			for _, opt := range role.options {
+				switch opt.option {
+				case "PASSWORDCOMPLEXITY", "PASSWORDLIFETIME":
+					if err := policy.set(opt.option, opt.value); err != nil {
+						return err
+					}
+				}
+			}
+			if policy.complexity != 0 {
+				complexity = tree.NewDInt(tree.DInt(policy.complexity))
+			}
+			if policy.lifetime != nil {
+				lifetime = policy.lifetime
+			}
+			if attempts.Limit != 0 {
+				loginAttempts = tree.NewDInt(tree.DInt(attempts.Limit))
+			}
+			validUntil := tree.DNull
+			expired := false
+			if role.validUntil != nil {
+				validUntil = tree.MustMakeDTimestampTZ(*role.validUntil, time.Microsecond)
+				expired = !role.validUntil.After(now)
+			}
+			if err := addRow(
+				tree.NewDString(role.username.Normalized()), // role_name
+				complexity,                               // password_complexity
+				lifetime,                                 // password_lifetime
+				loginAttempts,                            // login_attempts
+				validUntil,                               // valid_until
+				tree.MakeDBool(tree.DBool(expired)),      // password_expired
+				tree.NewDInt(tree.DInt(attempts.Failed)), // failed_login_attempts
+				tree.MakeDBool(tree.DBool(attempts.Locked())), // locked
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var crdbInternalExternalPrincipalMappingsTable = virtualSchemaTable{
+	comment: `mapping of external principals onto roles by the external authentication methods (RAM; local node only)`,
+	schema: `
+CREATE TABLE crdb_internal.external_principal_mappings (
+	node_id         INT NOT NULL,
+	method          STRING NOT NULL,
+	source          STRING,
+	mapping         STRING,
+	role_name       STRING,
+	last_principal  STRING,
+	last_login      TIMESTAMPTZ
+)`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.external_principal_mappings"); err != nil {
+			return err
+		}
+		nodeID, _ := p.execCfg.NodeID.OptionalNodeID() // zero if not available
+		for _, m := range p.execCfg.ExternalPrincipals.mappings() {
+			source, mapping := tree.DNull, tree.DNull
+			if m.rule != nil {
+				source, mapping = tree.NewDString(m.rule.Source), tree.NewDString(m.rule.Mapping)
+			}
+			roleName, lastPrincipal, lastLogin := tree.DNull, tree.DNull, tree.DNull
+			if !m.role.Undefined() {
+				roleName = tree.NewDString(m.role.Normalized())
+				lastPrincipal = tree.NewDString(m.login.principal)
+				lastLogin = tree.MustMakeDTimestampTZ(m.login.time, time.Microsecond)
+			}
+			if err := addRow(
+				tree.NewDInt(tree.DInt(nodeID)), // node_id
+				tree.NewDString(m.method),       // method
+				source,                          // source
+				mapping,                         // mapping
+				roleName,                        // role_name
+				lastPrincipal,                   // last_principal
+				lastLogin,                       // last_login
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// crdbInternalVirtualTablePopulationsTable exposes the cost of the
+// populations of the information_schema and pg_catalog tables on the current
+// node.
+var crdbInternalVirtualTablePopulationsTable = virtualSchemaTable{
+	comment: `cumulative cost of populating the information_schema and pg_catalog tables (RAM; local node only)`,
+	schema: `
+CREATE TABLE crdb_internal.node_virtual_table_populations (
+	node_id        INT NOT NULL,
+	table_id       INT NOT NULL,
+	schema_name    STRING NOT NULL,
+	table_name     STRING NOT NULL,
+	populations    INT NOT NULL,
+	rows           INT NOT NULL,
+	total_latency  INTERVAL NOT NULL,
+	max_latency    INTERVAL NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		if err := p.RequireAdminRole(ctx, "read crdb_internal.node_virtual_table_populations"); err != nil {
+			return err
+		}
+		nodeID, _ := p.execCfg.NodeID.OptionalNodeID() // zero if not available
+		for _, s := range p.execCfg.VirtualSchemas.metrics.populationStats() {
+			totalLatency := tree.NewDInterval(
+				duration.MakeDuration(s.totalLatency.Nanoseconds(), 0 /* days */, 0 /* months */),
+				types.DefaultIntervalTypeMetadata,
+			)
+			maxLatency := tree.NewDInterval(
+				duration.MakeDuration(s.maxLatency.Nanoseconds(), 0 /* days */, 0 /* months */),
+				types.DefaultIntervalTypeMetadata,
+			)
+			if err := addRow(
+				tree.NewDInt(tree.DInt(nodeID)),        // node_id
+				tree.NewDInt(tree.DInt(s.id)),          // table_id
+				tree.NewDString(s.schemaName),          // schema_name
+				tree.NewDString(s.tableName),           // table_name
+				tree.NewDInt(tree.DInt(s.populations)), // populations
+				tree.NewDInt(tree.DInt(s.rows)),        // rows
+				totalLatency,                           // total_latency
+				maxLatency,                             // max_latency
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var crdbInternalRoleMembershipClosureTable = virtualSchemaTable{
+	comment: `direct and indirect role memberships of every user and role`,
+	schema: `
+CREATE TABLE crdb_internal.role_membership_closure (
+	member        STRING NOT NULL,
+	role          STRING NOT NULL,
+	depth         INT NOT NULL,
+	path          STRING[] NOT NULL,
+	admin_option  BOOL NOT NULL,
+	INDEX(member)
+)`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		// Like pg_auth_members, this table is readable by every user.
+		memberships, err := p.getRoleMemberships(ctx)
+		if err != nil {
+			return err
+		}
+		members := make([]security.SQLUsername, 0, len(memberships))
+		for member := range memberships {
+			members = append(members, member)
+		}
+		sort.Slice(members, func(i, j int) bool {
+			return members[i].Normalized() < members[j].Normalized()
+		})
+		for _, member := range members {
+			if err := addRoleMembershipClosureRows(ctx, member, memberships, addRow); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	indexes: []virtualIndex{
+		{
+			populate: func(ctx context.Context, constraint tree.Datum, p *planner, _ catalog.DatabaseDescriptor,
+				addRow func(...tree.Datum) error) (bool, error) {
+				memberName, ok := constraint.(*tree.DString)
+				if !ok {
+					return false, nil
+				}
+				memberships, err := p.getRoleMemberships(ctx)
+				if err != nil {
+					return false, err
+				}
+				// system.role_members stores pre-normalized usernames.
+				member := security.MakeSQLUsernameFromPreNormalizedString(string(*memberName))
+				if _, ok := memberships[member]; !ok {
+					return false, nil
+				}
+				return true, addRoleMembershipClosureRows(ctx, member, memberships, addRow)
+			},
+		},
+	},
+}
+
+// addRoleMembershipClosureRows adds the rows of
+// crdb_internal.role_membership_closure for a member. Every role is reported
+// once, with the shortest path leading to it from the member. The admin option
+// is the one considered by the privilege checks, which may be granted by a
+// membership that is not on that path.
+func addRoleMembershipClosureRows(
+	ctx context.Context,
+	member security.SQLUsername,
+	memberships roleMembershipGraph,
+	addRow func(...tree.Datum) error,
+) error {
+	type reachedRole struct {
+		role security.SQLUsername
+		path []security.SQLUsername
+	}
+	var reached []reachedRole
+	adminOption, err := walkRoleMemberships(ctx, member, memberships.directMemberships,
+		func(role security.SQLUsername, path []security.SQLUsername) error {
+			reached = append(reached, reachedRole{role: role, path: path})
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	// The rows are only added once the walk is over, as the admin option on a
+	// role may be granted by a membership found after the role.
+	memberName := tree.NewDString(member.Normalized())
+	for _, r := range reached {
+		pathArr := tree.NewDArray(types.String)
+		for _, role := range r.path {
+			if err := pathArr.Append(tree.NewDString(role.Normalized())); err != nil {
+				return err
+			}
+		}
+		if err := addRow(
+			memberName,                             // member
+			tree.NewDString(r.role.Normalized()),   // role
+			tree.NewDInt(tree.DInt(len(r.path)-1)), // depth
+			pathArr,                                // path
+			tree.MakeDBool(tree.DBool(adminOption[r.role])), // admin_option
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 var crdbInternalInterleaved = virtualSchemaTable{
 	comment: `virtual table with interleaved table information`,
 	schema: `
@@ -4378,3 +5175,222 @@ CREATE TABLE crdb_internal.cross_db_references (
 			})
 	},
 }
+
+// crdbInternalCatalogDiscrepanciesTable cross-checks information_schema
+// against pg_catalog. Both catalogs are populated independently from the same
+// descriptors, so any disagreement between them points at a bug in one of the
+// populate functions.
+var crdbInternalCatalogDiscrepanciesTable = virtualSchemaTable{
+	comment: `discrepancies between information_schema and pg_catalog (expensive!)`,
+	schema: `
+CREATE TABLE crdb_internal.catalog_discrepancies (
+  database_name STRING NOT NULL,
+  schema_name   STRING NOT NULL,
+  object_name   STRING,
+  error         STRING NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				return validateCatalogConsistency(ctx, p, db, addRow)
+			})
+	},
+}
+
+// virtualColumnOrdinals returns the positions of the given columns in the rows
+// produced by the populate function of the given virtual table, so that the
+// rows can be read independently of the order of the columns.
+func virtualColumnOrdinals(p *planner, id descpb.ID, names ...string) ([]int, error) {
+	e, err := p.getVirtualTabler().getVirtualTableEntryByID(id)
+	if err != nil {
+		return nil, err
+	}
+	ords := make([]int, len(names))
+	for i, name := range names {
+		col, err := e.desc.FindColumnWithName(tree.Name(name))
+		if err != nil {
+			return nil, err
+		}
+		ords[i] = col.Ordinal()
+	}
+	return ords, nil
+}
+
+// validateCatalogConsistency compares the schemas and relations reported by
+// information_schema.schemata and information_schema.tables with the ones
+// reported by pg_catalog.pg_namespace and pg_catalog.pg_class for the given
+// database, and emits a row for every discrepancy. The catalogs are read with
+// the default catalog session settings, as some of these settings change the
+// contents of information_schema but not the ones of pg_catalog.
+func validateCatalogConsistency(
+	ctx context.Context,
+	p *planner,
+	db catalog.DatabaseDescriptor,
+	addRow func(...tree.Datum) error,
+) error {
+	type relation struct {
+		schema, name string
+	}
+
+	// The zero session data holds the default catalog settings.
+	pi, cleanup := NewInternalPlanner(
+		"validate-catalog-consistency",
+		p.txn,
+		p.User(),
+		&MemoryMetrics{},
+		p.ExecCfg(),
+		sessiondatapb.SessionData{},
+	)
+	defer cleanup()
+	p = pi.(*planner)
+
+	// Gather the pg_catalog side.
+	nspCols, err := virtualColumnOrdinals(p, catconstants.PgCatalogNamespaceTableID, "oid", "nspname")
+	if err != nil {
+		return err
+	}
+	pgSchemas := make(map[string]struct{})
+	pgSchemaNames := make(map[tree.DInt]string)
+	if err := pgCatalogNamespaceTable.populate(ctx, p, db, func(row ...tree.Datum) error {
+		name := string(tree.MustBeDString(row[nspCols[1]]))
+		pgSchemas[name] = struct{}{}
+		pgSchemaNames[tree.MustBeDOid(row[nspCols[0]]).DInt] = name
+		return nil
+	}); err != nil {
+		return err
+	}
+	classCols, err := virtualColumnOrdinals(p, catconstants.PgCatalogClassTableID, "relname", "relnamespace", "relkind")
+	if err != nil {
+		return err
+	}
+	pgRelKinds := make(map[relation]string)
+	if err := pgCatalogClassTable.populate(ctx, p, db, func(row ...tree.Datum) error {
+		relKind := string(tree.MustBeDString(row[classCols[2]]))
+		// information_schema.tables lists neither indexes nor sequences.
+		if relKind == string(*relKindIndex) || relKind == string(*relKindSequence) {
+			return nil
+		}
+		rel := relation{
+			schema: pgSchemaNames[tree.MustBeDOid(row[classCols[1]]).DInt],
+			name:   string(tree.MustBeDString(row[classCols[0]])),
+		}
+		pgRelKinds[rel] = relKind
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Gather the information_schema side.
+	schemataCols, err := virtualColumnOrdinals(p, catconstants.InformationSchemaSchemataTableID, "schema_name")
+	if err != nil {
+		return err
+	}
+	infoSchemas := make(map[string]struct{})
+	if err := informationSchemaSchemataTable.populate(ctx, p, db, func(row ...tree.Datum) error {
+		infoSchemas[string(tree.MustBeDString(row[schemataCols[0]]))] = struct{}{}
+		return nil
+	}); err != nil {
+		return err
+	}
+	tablesCols, err := virtualColumnOrdinals(p, catconstants.InformationSchemaTablesTableID, "table_schema", "table_name", "table_type")
+	if err != nil {
+		return err
+	}
+	// Only the columns read below are needed, which spares reading the
+	// statistics of every table.
+	var tablesNeeded util.FastIntSet
+	for _, ord := range tablesCols {
+		tablesNeeded.Add(ord)
+	}
+	infoTableTypes := make(map[relation]string)
+	if err := informationSchemaTablesTable.populate(withNeededVirtualColumns(ctx, tablesNeeded), p, db, func(row ...tree.Datum) error {
+		rel := relation{
+			schema: string(tree.MustBeDString(row[tablesCols[0]])),
+			name:   string(tree.MustBeDString(row[tablesCols[1]])),
+		}
+		infoTableTypes[rel] = string(tree.MustBeDString(row[tablesCols[2]]))
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	dbName := tree.NewDString(db.GetName())
+	report := func(schema string, name tree.Datum, format string, args ...interface{}) error {
+		return addRow(
+			dbName,
+			tree.NewDString(schema),
+			name,
+			tree.NewDString(fmt.Sprintf(format, args...)),
+		)
+	}
+
+	// Compare the schemas.
+	schemaNames := make([]string, 0, len(pgSchemas)+len(infoSchemas))
+	for name := range pgSchemas {
+		schemaNames = append(schemaNames, name)
+	}
+	for name := range infoSchemas {
+		if _, ok := pgSchemas[name]; !ok {
+			schemaNames = append(schemaNames, name)
+		}
+	}
+	sort.Strings(schemaNames)
+	for _, name := range schemaNames {
+		_, inPG := pgSchemas[name]
+		_, inInfo := infoSchemas[name]
+		switch {
+		case !inInfo:
+			if err := report(name, tree.DNull, "schema missing from information_schema.schemata"); err != nil {
+				return err
+			}
+		case !inPG:
+			if err := report(name, tree.DNull, "schema missing from pg_catalog.pg_namespace"); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Compare the relations.
+	rels := make([]relation, 0, len(pgRelKinds)+len(infoTableTypes))
+	for rel := range pgRelKinds {
+		rels = append(rels, rel)
+	}
+	for rel := range infoTableTypes {
+		if _, ok := pgRelKinds[rel]; !ok {
+			rels = append(rels, rel)
+		}
+	}
+	sort.Slice(rels, func(i, j int) bool {
+		if rels[i].schema != rels[j].schema {
+			return rels[i].schema < rels[j].schema
+		}
+		return rels[i].name < rels[j].name
+	})
+	for _, rel := range rels {
+		relKind, inPG := pgRelKinds[rel]
+		tableType, inInfo := infoTableTypes[rel]
+		var err error
+		switch {
+		case !inInfo:
+			err = report(rel.schema, tree.NewDString(rel.name),
+				"relation missing from information_schema.tables")
+		case !inPG:
+			err = report(rel.schema, tree.NewDString(rel.name),
+				"relation missing from pg_catalog.pg_class")
+		default:
+			pgIsView := relKind == string(*relKindView) || relKind == string(*relKindMaterializedView)
+			infoIsView := tableType == string(*tableTypeView)
+			if relKind == string(*relKindMaterializedView) {
+				infoIsView = tableType == string(*materializedViewTableType(p))
+			}
+			if pgIsView != infoIsView {
+				err = report(rel.schema, tree.NewDString(rel.name),
+					"relkind %q does not match table_type %q", relKind, tableType)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
@@ -22,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"golang.org/x/text/collate"
 )
 
 type createDatabaseNode struct {
@@ -62,11 +64,18 @@ func (p *planner) CreateDatabase(ctx context.Context, n *tree.CreateDatabase) (p
 	}
 
 	if col := n.Collate; col != "" {
-		// We only support C and C.UTF-8.
-		if col != "C" && col != "C.UTF-8" {
+		// We only support C and C.UTF-8, which are the default collation, and
+		// the collations that are always available.
+		if col != "C" && col != "C.UTF-8" && !isBuiltinCollation(col) {
 			return nil, unimplemented.NewWithIssueDetailf(16618, "create.db.collation",
 				"unsupported collation: %s", col)
 		}
+		if databaseDefaultCollation(col) != "" &&
+			!p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.DatabaseDefaultCollations) {
+			return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use collation %s as the default collation",
+				clusterversion.DatabaseDefaultCollations, col)
+		}
 	}
 
 	if ctype := n.CType; ctype != "" {
@@ -104,6 +113,31 @@ func (p *planner) CreateDatabase(ctx context.Context, n *tree.CreateDatabase) (p
 	return &createDatabaseNode{n: n}, nil
 }
 
+// databaseDefaultCollation returns the default collation to record in the
+// descriptor of a database created with the given LC_COLLATE option. It is
+// empty if the database uses the default collation.
+func databaseDefaultCollation(collate string) string {
+	switch collate {
+	case "C", "C.UTF-8", tree.DefaultCollationTag:
+		return ""
+	}
+	return collate
+}
+
+// isBuiltinCollation returns whether the given name is the name of one of the
+// collations that are always available.
+func isBuiltinCollation(name string) bool {
+	if name == tree.DefaultCollationTag {
+		return true
+	}
+	for _, tag := range collate.Supported() {
+		if tag.String() == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (n *createDatabaseNode) startExec(params runParams) error {
 	telemetry.Inc(sqltelemetry.SchemaChangeCreateCounter("database"))
 
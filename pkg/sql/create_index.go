@@ -157,11 +157,17 @@ func MakeIndexDescriptor(
 	if err := tableDesc.ValidateIndexNameIsUnique(string(n.Name)); err != nil {
 		return nil, err
 	}
+	if n.NotVisible {
+		if err := checkClusterSupportsNotVisibleIndexes(params.EvalContext()); err != nil {
+			return nil, err
+		}
+	}
 	indexDesc := descpb.IndexDescriptor{
 		Name:              string(n.Name),
 		Unique:            n.Unique,
 		StoreColumnNames:  n.Storing.ToStrings(),
 		CreatedExplicitly: true,
+		NotVisible:        n.NotVisible,
 	}
 
 	if n.Inverted {
@@ -279,6 +285,17 @@ func MakeIndexDescriptor(
 	return &indexDesc, nil
 }
 
+// checkClusterSupportsNotVisibleIndexes returns an error if NOT VISIBLE indexes
+// cannot be created until the cluster upgrade is finalized.
+func checkClusterSupportsNotVisibleIndexes(evalCtx *tree.EvalContext) error {
+	if !evalCtx.Settings.Version.IsActive(evalCtx.Context, clusterversion.NotVisibleIndexes) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use NOT VISIBLE indexes",
+			clusterversion.NotVisibleIndexes)
+	}
+	return nil
+}
+
 // validateIndexColumnsExists validates that the columns for an index exist
 // in the table and are not being dropped prior to attempting to add the index.
 func validateIndexColumnsExist(desc *tabledesc.Mutable, columns tree.IndexElemList) error {
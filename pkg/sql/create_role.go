@@ -119,6 +119,14 @@ func (n *CreateRoleNode) startExec(params runParams) error {
 		return pgerror.Newf(pgcode.ReservedName, "role name %q is reserved", security.PublicRole)
 	}
 
+	if err := params.p.checkPasswordPolicyOptions(params.ctx, n.roleOptions); err != nil {
+		return err
+	}
+	policy, err := params.p.getPasswordPolicy(params.ctx, normalizedUsername, n.roleOptions)
+	if err != nil {
+		return err
+	}
+
 	var hashedPassword []byte
 	if n.roleOptions.Contains(roleoption.PASSWORD) {
 		isNull, password, err := n.roleOptions.GetPassword()
@@ -138,11 +146,15 @@ func (n *CreateRoleNode) startExec(params runParams) error {
 		}
 
 		if !isNull {
+			if err := policy.checkPassword(password); err != nil {
+				return err
+			}
 			if hashedPassword, err = params.p.checkPasswordAndGetHash(params.ctx, password); err != nil {
 				return err
 			}
 		}
 	}
+	passwordSet := hashedPassword != nil
 
 	if hashedPassword == nil {
 		// v20.1 and below crash during authentication if they find a NULL value
@@ -229,6 +241,18 @@ func (n *CreateRoleNode) startExec(params runParams) error {
 		}
 	}
 
+	if passwordSet {
+		if err := params.p.onPasswordSet(
+			params.ctx, opName, normalizedUsername, policy, n.roleOptions.Contains(roleoption.VALIDUNTIL),
+		); err != nil {
+			return err
+		}
+	}
+
+	if err := params.p.bumpUsersTableVersion(params.ctx); err != nil {
+		return err
+	}
+
 	return params.p.logEvent(params.ctx,
 		0, /* no target */
 		&eventpb.CreateRole{RoleName: normalizedUsername.Normalized()})
@@ -126,6 +126,9 @@ func CreateUserDefinedSchemaDescriptor(
 	} else {
 		privs.SetOwner(user)
 	}
+	db.GetDefaultPrivileges().ApplyDefaultPrivileges(
+		privs.Owner(), 0 /* schemaID */, descpb.DefaultPrivilegesForRole_SCHEMAS, privs,
+	)
 
 	// Create the SchemaDescriptor.
 	desc := schemadesc.NewBuilder(&descpb.SchemaDescriptor{
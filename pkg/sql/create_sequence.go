@@ -99,7 +99,12 @@ func doCreateSequence(
 		return err
 	}
 
-	privs := CreateInheritedPrivilegesFromDBDesc(dbDesc, params.SessionData().User())
+	privs := CreateInheritedPrivilegesFromDBDesc(
+		dbDesc, params.SessionData().User(), privilege.Sequence,
+	)
+	dbDesc.GetDefaultPrivileges().ApplyDefaultPrivileges(
+		params.SessionData().User(), schemaID, descpb.DefaultPrivilegesForRole_SEQUENCES, privs,
+	)
 
 	if persistence.IsTemporary() {
 		telemetry.Inc(sqltelemetry.CreateTempSequenceCounter)
@@ -52,6 +52,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
+	"github.com/cockroachdb/cockroach/pkg/util/protoutil"
 	"github.com/cockroachdb/errors"
 	"github.com/lib/pq/oid"
 )
@@ -300,7 +301,12 @@ func (n *createTableNode) startExec(params runParams) error {
 		return err
 	}
 
-	privs := CreateInheritedPrivilegesFromDBDesc(n.dbDesc, params.SessionData().User())
+	privs := CreateInheritedPrivilegesFromDBDesc(
+		n.dbDesc, params.SessionData().User(), privilege.Table,
+	)
+	n.dbDesc.GetDefaultPrivileges().ApplyDefaultPrivileges(
+		params.SessionData().User(), schemaID, descpb.DefaultPrivilegesForRole_TABLES, privs,
+	)
 
 	var desc *tabledesc.Mutable
 	var affected map[descpb.ID]*tabledesc.Mutable
@@ -1007,6 +1013,30 @@ func ResolveFK(
 		}
 	}
 
+	// MATCH PARTIAL only differs from MATCH SIMPLE when some, but not all, of
+	// the referencing columns are NULL, which can't happen with a single
+	// column. Until the partial matching of composite keys is implemented,
+	// only single-column foreign keys may use it.
+	if d.Match == tree.MatchPartial && len(originCols) > 1 {
+		return unimplemented.NewWithIssueDetail(20305, "match partial",
+			"MATCH PARTIAL is not supported for foreign keys with more than one column")
+	}
+
+	if evalCtx.Settings != nil {
+		if d.Match == tree.MatchPartial &&
+			!evalCtx.Settings.Version.IsActive(ctx, clusterversion.MatchPartialForeignKeys) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use MATCH PARTIAL foreign keys",
+				clusterversion.MatchPartialForeignKeys)
+		}
+		if d.Deferrability.Deferrable &&
+			!evalCtx.Settings.Version.IsActive(ctx, clusterversion.DeferrableForeignKeys) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use DEFERRABLE foreign keys",
+				clusterversion.DeferrableForeignKeys)
+		}
+	}
+
 	// Check if the version is high enough to stop creating origin indexes.
 	if evalCtx.Settings != nil &&
 		!evalCtx.Settings.Version.IsActive(ctx, clusterversion.NoOriginFKIndexes) {
@@ -1066,6 +1096,8 @@ func ResolveFK(
 		OnDelete:            descpb.ForeignKeyReferenceActionValue[d.Actions.Delete],
 		OnUpdate:            descpb.ForeignKeyReferenceActionValue[d.Actions.Update],
 		Match:               descpb.CompositeKeyMatchMethodValue[d.Match],
+		Deferrable:          d.Deferrability.Deferrable,
+		InitiallyDeferred:   d.Deferrability.InitiallyDeferred,
 	}
 
 	if ts == NewTable {
@@ -1890,10 +1922,16 @@ func NewTableDesc(
 			if d.Name != "" && desc.ValidateIndexNameIsUnique(d.Name.String()) != nil {
 				return nil, pgerror.Newf(pgcode.DuplicateRelation, "duplicate index name: %q", d.Name)
 			}
+			if d.NotVisible {
+				if err := checkClusterSupportsNotVisibleIndexes(evalCtx); err != nil {
+					return nil, err
+				}
+			}
 			idx := descpb.IndexDescriptor{
 				Name:             string(d.Name),
 				StoreColumnNames: d.Storing.ToStrings(),
 				Version:          indexEncodingVersion,
+				NotVisible:       d.NotVisible,
 			}
 			if d.Inverted {
 				idx.Type = descpb.IndexDescriptor_INVERTED
@@ -1989,11 +2027,17 @@ func NewTableDesc(
 				// We will add the unique constraint below.
 				break
 			}
+			if d.NotVisible {
+				if err := checkClusterSupportsNotVisibleIndexes(evalCtx); err != nil {
+					return nil, err
+				}
+			}
 			idx := descpb.IndexDescriptor{
 				Name:             string(d.Name),
 				Unique:           true,
 				StoreColumnNames: d.Storing.ToStrings(),
 				Version:          indexEncodingVersion,
+				NotVisible:       d.NotVisible,
 			}
 			columns := d.Columns
 			if d.Sharded != nil {
@@ -2079,6 +2123,11 @@ func NewTableDesc(
 		case *tree.CheckConstraintTableDef, *tree.ForeignKeyConstraintTableDef, *tree.FamilyTableDef:
 			// pass, handled below.
 
+		case *tree.ExclusionConstraintTableDef:
+			return nil, unimplemented.NewWithIssueDetail(
+				46657, "exclusion constraint", "exclusion constraints are not supported",
+			)
+
 		default:
 			return nil, errors.Errorf("unsupported table def: %T", def)
 		}
@@ -2582,10 +2631,11 @@ func replaceLikeTableOpts(n *tree.CreateTable, params runParams) (tree.TableDefs
 		if opts.Has(tree.LikeTableOptIndexes) {
 			for _, idx := range td.NonDropIndexes() {
 				indexDef := tree.IndexTableDef{
-					Name:     tree.Name(idx.GetName()),
-					Inverted: idx.GetType() == descpb.IndexDescriptor_INVERTED,
-					Storing:  make(tree.NameList, 0, idx.NumStoredColumns()),
-					Columns:  make(tree.IndexElemList, 0, idx.NumColumns()),
+					Name:       tree.Name(idx.GetName()),
+					Inverted:   idx.GetType() == descpb.IndexDescriptor_INVERTED,
+					Storing:    make(tree.NameList, 0, idx.NumStoredColumns()),
+					Columns:    make(tree.IndexElemList, 0, idx.NumColumns()),
+					NotVisible: idx.IsNotVisible(),
 				}
 				numColumns := idx.NumColumns()
 				if idx.IsSharded() {
@@ -2767,9 +2817,10 @@ func incTelemetryForNewColumn(def *tree.ColumnTableDef, desc *descpb.ColumnDescr
 
 // CreateInheritedPrivilegesFromDBDesc creates privileges for a
 // table (or view/sequence) with the appropriate owner (node for system,
-// the restoring user otherwise.)
+// the restoring user otherwise.) The privileges which are not valid for
+// objectType are dropped.
 func CreateInheritedPrivilegesFromDBDesc(
-	dbDesc catalog.DatabaseDescriptor, user security.SQLUsername,
+	dbDesc catalog.DatabaseDescriptor, user security.SQLUsername, objectType privilege.ObjectType,
 ) *descpb.PrivilegeDescriptor {
 	// If a new system table is being created (which should only be doable by
 	// an internal user account), make sure it gets the correct privileges.
@@ -2777,11 +2828,11 @@ func CreateInheritedPrivilegesFromDBDesc(
 		return descpb.NewDefaultPrivilegeDescriptor(security.NodeUserName())
 	}
 
-	privs := dbDesc.GetPrivileges()
-	tablePrivBits := privilege.GetValidPrivilegesForObject(privilege.Table).ToBitField()
+	privs := protoutil.Clone(dbDesc.GetPrivileges()).(*descpb.PrivilegeDescriptor)
+	validPrivBits := privilege.GetValidPrivilegesForObject(objectType).ToBitField()
 	for i, u := range privs.Users {
-		// Remove privileges that are valid for databases but not for tables.
-		privs.Users[i].Privileges = u.Privileges & tablePrivBits
+		// Remove privileges that are valid for databases but not for the object.
+		privs.Users[i].Privileges = u.Privileges & validPrivBits
 	}
 
 	privs.SetOwner(user)
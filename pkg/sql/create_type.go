@@ -349,6 +349,11 @@ func (p *planner) createEnumWithID(
 
 	inheritUsagePrivilegeFromSchema(resolvedSchema, privs)
 	privs.Grant(params.p.User(), privilege.List{privilege.ALL})
+	if enumType == enumTypeUserDefined {
+		dbDesc.GetDefaultPrivileges().ApplyDefaultPrivileges(
+			params.p.User(), schemaID, descpb.DefaultPrivilegesForRole_TYPES, privs,
+		)
+	}
 
 	enumKind := descpb.TypeDescriptor_ENUM
 	var regionConfig *descpb.TypeDescriptor_RegionConfig
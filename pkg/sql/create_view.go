@@ -33,6 +33,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/log/eventpb"
@@ -46,13 +47,16 @@ type createViewNode struct {
 	viewName *tree.TableName
 	// viewQuery contains the view definition, with all table names fully
 	// qualified.
-	viewQuery    string
-	ifNotExists  bool
-	replace      bool
-	persistence  tree.Persistence
-	materialized bool
-	dbDesc       catalog.DatabaseDescriptor
-	columns      colinfo.ResultColumns
+	viewQuery       string
+	ifNotExists     bool
+	replace         bool
+	persistence     tree.Persistence
+	materialized    bool
+	checkOption     tree.ViewCheckOption
+	securityInvoker bool
+	securityBarrier bool
+	dbDesc          catalog.DatabaseDescriptor
+	columns         colinfo.ResultColumns
 
 	// planDeps tracks which tables and views the view being created
 	// depends on. This is collected during the construction of
@@ -71,6 +75,19 @@ type createViewNode struct {
 func (n *createViewNode) ReadingOwnWrites() {}
 
 func (n *createViewNode) startExec(params runParams) error {
+	if n.checkOption != tree.ViewCheckOptionNone &&
+		!params.p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.ViewCheckOption) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use WITH CHECK OPTION",
+			clusterversion.ViewCheckOption)
+	}
+	if (n.securityInvoker || n.securityBarrier) &&
+		!params.p.ExecCfg().Settings.Version.IsActive(params.ctx, clusterversion.ViewSecurityOptions) {
+		return pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use the security_invoker and security_barrier options",
+			clusterversion.ViewSecurityOptions)
+	}
+
 	tableType := tree.GetTableType(
 		false /* isSequence */, true /* isView */, n.materialized,
 	)
@@ -156,7 +173,12 @@ func (n *createViewNode) startExec(params runParams) error {
 		telemetry.Inc(sqltelemetry.CreateTempViewCounter)
 	}
 
-	privs := CreateInheritedPrivilegesFromDBDesc(n.dbDesc, params.SessionData().User())
+	privs := CreateInheritedPrivilegesFromDBDesc(
+		n.dbDesc, params.SessionData().User(), privilege.Table,
+	)
+	n.dbDesc.GetDefaultPrivileges().ApplyDefaultPrivileges(
+		params.SessionData().User(), schemaID, descpb.DefaultPrivilegesForRole_TABLES, privs,
+	)
 
 	var newDesc *tabledesc.Mutable
 	applyGlobalMultiRegionZoneConfig := false
@@ -208,6 +230,9 @@ func (n *createViewNode) startExec(params runParams) error {
 		if err != nil {
 			return err
 		}
+		desc.ViewCheckOption = viewCheckOptionToDesc(n.checkOption)
+		desc.ViewSecurityInvoker = n.securityInvoker
+		desc.ViewSecurityBarrier = n.securityBarrier
 
 		if n.materialized {
 			// Ensure all nodes are the correct version.
@@ -436,6 +461,58 @@ func replaceSeqNamesWithIDs(
 	return newStmt.String(), nil
 }
 
+// viewCheckOptionToDesc returns the descriptor representation of the given
+// view check option.
+func viewCheckOptionToDesc(opt tree.ViewCheckOption) descpb.ViewCheckOption {
+	switch opt {
+	case tree.ViewCheckOptionLocal:
+		return descpb.ViewCheckOption_LOCAL_CHECK_OPTION
+	case tree.ViewCheckOptionCascaded:
+		return descpb.ViewCheckOption_CASCADED_CHECK_OPTION
+	}
+	return descpb.ViewCheckOption_NO_CHECK_OPTION
+}
+
+// viewCheckOptionFromDesc is the inverse of viewCheckOptionToDesc.
+func viewCheckOptionFromDesc(opt descpb.ViewCheckOption) tree.ViewCheckOption {
+	switch opt {
+	case descpb.ViewCheckOption_LOCAL_CHECK_OPTION:
+		return tree.ViewCheckOptionLocal
+	case descpb.ViewCheckOption_CASCADED_CHECK_OPTION:
+		return tree.ViewCheckOptionCascaded
+	}
+	return tree.ViewCheckOptionNone
+}
+
+// viewOptionsFromDesc returns the options the given view was declared with,
+// in the "name=value" form used by pg_class.reloptions.
+func viewOptionsFromDesc(desc catalog.TableDescriptor) []string {
+	var opts []string
+	if desc.GetViewSecurityBarrier() {
+		opts = append(opts, "security_barrier=true")
+	}
+	if desc.GetViewSecurityInvoker() {
+		opts = append(opts, "security_invoker=true")
+	}
+	return opts
+}
+
+// viewOptionsDatum returns the options of the given view as a STRING[], or
+// NULL if the view was declared without options.
+func viewOptionsDatum(desc catalog.TableDescriptor) (tree.Datum, error) {
+	opts := viewOptionsFromDesc(desc)
+	if len(opts) == 0 {
+		return tree.DNull, nil
+	}
+	arr := tree.NewDArray(types.String)
+	for _, opt := range opts {
+		if err := arr.Append(tree.NewDString(opt)); err != nil {
+			return nil, err
+		}
+	}
+	return arr, nil
+}
+
 // replaceViewDesc modifies and returns the input view descriptor changed
 // to hold the new view represented by n. Note that back references from
 // tables that the new view depends on still need to be added. This function
@@ -451,6 +528,9 @@ func (p *planner) replaceViewDesc(
 ) (*tabledesc.Mutable, error) {
 	// Set the query to the new query.
 	toReplace.ViewQuery = n.viewQuery
+	toReplace.ViewCheckOption = viewCheckOptionToDesc(n.checkOption)
+	toReplace.ViewSecurityInvoker = n.securityInvoker
+	toReplace.ViewSecurityBarrier = n.securityBarrier
 
 	// If we're in 21.1, then sequences in views should be referenced
 	// by IDs, so walk the tree and replace sequence names with IDs.
@@ -123,6 +123,7 @@ func (p *planner) createDatabase(
 		string(database.Name),
 		p.SessionData().User(),
 		dbdesc.MaybeWithDatabaseRegionConfig(regionConfig),
+		dbdesc.WithDefaultCollation(databaseDefaultCollation(database.Collate)),
 	)
 
 	if err := p.createDescriptorWithID(ctx, dKey.Key(p.ExecCfg().Codec), id, desc, nil, jobDesc); err != nil {
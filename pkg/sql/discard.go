@@ -54,5 +54,8 @@ func resetSessionVars(ctx context.Context, m *sessionDataMutator) error {
 			}
 		}
 	}
+	for name := range m.data.CustomOptions {
+		m.SetCustomOption(name, "")
+	}
 	return nil
 }
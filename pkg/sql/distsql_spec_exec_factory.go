@@ -900,6 +900,9 @@ func (e *distSQLSpecExecFactory) ConstructCreateView(
 	replace bool,
 	persistence tree.Persistence,
 	materialized bool,
+	checkOption tree.ViewCheckOption,
+	securityInvoker bool,
+	securityBarrier bool,
 	viewQuery string,
 	columns colinfo.ResultColumns,
 	deps opt.ViewDeps,
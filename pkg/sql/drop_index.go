@@ -504,6 +504,9 @@ func (p *planner) dropIndexByName(
 	if err := p.removeIndexComment(ctx, tableDesc.ID, idx.ID); err != nil {
 		return err
 	}
+	if err := p.removeConstraintComment(ctx, tableDesc.ID, idx.ConstraintID); err != nil {
+		return err
+	}
 
 	if err := validateDescriptor(ctx, p, tableDesc); err != nil {
 		return err
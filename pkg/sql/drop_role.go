@@ -17,6 +17,7 @@ import (
 
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
@@ -85,6 +86,9 @@ func (n *DropRoleNode) startExec(params runParams) error {
 
 	// userNames maps users to the objects they own
 	userNames := make(map[security.SQLUsername][]objectAndType)
+	// defaultPrivileges maps users to the descriptions of the default
+	// privileges which are set for them or which grant them privileges.
+	defaultPrivileges := make(map[security.SQLUsername][]string)
 	for i := range names {
 		name := names[i]
 		normalizedUsername, err := NormalizeAndValidateUsername(name)
@@ -139,7 +143,26 @@ func (n *DropRoleNode) startExec(params runParams) error {
 					break
 				}
 			}
-			return nil
+			return forEachDefaultPrivilege(db, func(
+				entry *descpb.DefaultPrivilegesForRole, schemaName string,
+			) error {
+				desc := fmt.Sprintf("privileges for default privileges on new %s belonging to role %s in database %s",
+					defaultPrivilegesObjectTypeName(entry.ObjectType), entry.Role(), db.GetName())
+				if schemaName != "" {
+					desc += fmt.Sprintf(" in schema %s", schemaName)
+				}
+				dependents := make(map[security.SQLUsername]struct{})
+				dependents[entry.Role()] = struct{}{}
+				for _, u := range entry.Privileges.Users {
+					dependents[u.User()] = struct{}{}
+				}
+				for user := range dependents {
+					if _, ok := userNames[user]; ok {
+						defaultPrivileges[user] = append(defaultPrivileges[user], desc)
+					}
+				}
+				return nil
+			})
 		}); err != nil {
 		return err
 	}
@@ -243,11 +266,17 @@ func (n *DropRoleNode) startExec(params runParams) error {
 		name := security.MakeSQLUsernameFromPreNormalizedString(names[i])
 		// Did the user own any objects?
 		ownedObjects := userNames[name]
-		if len(ownedObjects) > 0 {
+		// Was the user referenced by any default privileges?
+		defaultPrivs := defaultPrivileges[name]
+		if len(ownedObjects) > 0 || len(defaultPrivs) > 0 {
 			objectsMsg := tree.NewFmtCtx(tree.FmtSimple)
 			for _, obj := range ownedObjects {
 				objectsMsg.WriteString(fmt.Sprintf("\nowner of %s %s", obj.ObjectType, obj.ObjectName))
 			}
+			sort.Strings(defaultPrivs)
+			for _, desc := range defaultPrivs {
+				objectsMsg.WriteString("\n" + desc)
+			}
 			objects := objectsMsg.CloseAndGetString()
 			return pgerror.Newf(pgcode.DependentObjectsStillExist,
 				"role %s cannot be dropped because some objects depend on it%s",
@@ -257,6 +286,7 @@ func (n *DropRoleNode) startExec(params runParams) error {
 
 	// All safe - do the work.
 	var numRoleMembershipsDeleted int
+	usersDeleted := false
 	for normalizedUsername := range userNames {
 		// Specifically reject special users and roles. Some (root, admin) would fail with
 		// "privileges still exist" first.
@@ -304,6 +334,7 @@ func (n *DropRoleNode) startExec(params runParams) error {
 		if numUsersDeleted == 0 && !n.ifExists {
 			return errors.Errorf("role/user %s does not exist", normalizedUsername)
 		}
+		usersDeleted = usersDeleted || numUsersDeleted > 0
 
 		// Drop all role memberships involving the user/role.
 		numRoleMembershipsDeleted, err = params.extendedEvalCtx.ExecCfg.InternalExecutor.Exec(
@@ -330,6 +361,17 @@ func (n *DropRoleNode) startExec(params runParams) error {
 		if err != nil {
 			return err
 		}
+
+		if err := params.p.resetLoginAttempts(params.ctx, opName, normalizedUsername); err != nil {
+			return err
+		}
+	}
+
+	if usersDeleted {
+		// Bump the users table version to force a refresh of the role cache.
+		if err := params.p.bumpUsersTableVersion(params.ctx); err != nil {
+			return err
+		}
 	}
 
 	if numRoleMembershipsDeleted > 0 {
@@ -351,6 +393,23 @@ func (n *DropRoleNode) startExec(params runParams) error {
 	return nil
 }
 
+// defaultPrivilegesObjectTypeName returns the name used by Postgres for the
+// objects of the given type in the descriptions of default privileges.
+func defaultPrivilegesObjectTypeName(objectType descpb.DefaultPrivilegesForRole_ObjectType) string {
+	switch objectType {
+	case descpb.DefaultPrivilegesForRole_TABLES:
+		return "relations"
+	case descpb.DefaultPrivilegesForRole_SEQUENCES:
+		return "sequences"
+	case descpb.DefaultPrivilegesForRole_TYPES:
+		return "types"
+	case descpb.DefaultPrivilegesForRole_SCHEMAS:
+		return "schemas"
+	default:
+		return objectType.String()
+	}
+}
+
 // Next implements the planNode interface.
 func (*DropRoleNode) Next(runParams) (bool, error) { return false, nil }
 
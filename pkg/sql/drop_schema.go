@@ -204,6 +204,9 @@ func (p *planner) dropSchemaImpl(
 		ID:      sc.GetID(),
 		Dropped: true,
 	}
+	if err := p.removeSchemaComment(ctx, sc.GetID()); err != nil {
+		return err
+	}
 	// Mark the descriptor as dropped.
 	sc.State = descpb.DescriptorState_DROP
 	return p.writeSchemaDesc(ctx, sc)
@@ -226,9 +226,15 @@ func constructVirtualScan(
 		return nil, newUnimplementedVirtualTableError(tn.Schema(), tn.Table())
 	}
 	indexDesc := index.(*optVirtualIndex).desc
+	// The ordinals of the columns of the virtual table are offset by the dummy
+	// column.
+	var neededColumns util.FastIntSet
+	for ord, ok := params.NeededCols.Next(1); ok; ord, ok = params.NeededCols.Next(ord + 1) {
+		neededColumns.Add(ord - 1)
+	}
 	columns, constructor := virtual.getPlanInfo(
 		table.(*optVirtualTable).desc,
-		indexDesc, params.IndexConstraint, p.execCfg.DistSQLPlanner.stopper)
+		indexDesc, params.IndexConstraint, neededColumns, p.execCfg.DistSQLPlanner.stopper)
 
 	n, err := delayedNodeCallback(&delayedNode{
 		name:            fmt.Sprintf("%s@%s", table.Name(), index.Name()),
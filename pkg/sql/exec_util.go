@@ -825,6 +825,13 @@ type ExecutorConfig struct {
 	// Role membership cache.
 	RoleMemberCache *MembershipCache
 
+	// Role metadata cache.
+	RoleCache *RoleCache
+
+	// ExternalPrincipals tracks the mapping of external principals onto roles
+	// by the external authentication methods.
+	ExternalPrincipals *ExternalPrincipals
+
 	// ProtectedTimestampProvider encapsulates the protected timestamp subsystem.
 	ProtectedTimestampProvider protectedts.Provider
 
@@ -2392,6 +2399,57 @@ func (m *sessionDataMutator) SetStubCatalogTablesEnabled(enabled bool) {
 	m.data.StubCatalogTablesEnabled = enabled
 }
 
+// SetIncludeHiddenColumnsInInformationSchema sets whether hidden columns are
+// listed in information_schema.columns.
+func (m *sessionDataMutator) SetIncludeHiddenColumnsInInformationSchema(include bool) {
+	m.data.ExcludeHiddenColumnsFromInformationSchema = !include
+}
+
+// SetIncludeNonPublicTablesInInformationSchema sets whether non-public tables
+// are listed in information_schema.tables.
+func (m *sessionDataMutator) SetIncludeNonPublicTablesInInformationSchema(val bool) {
+	m.data.IncludeNonPublicTablesInInformationSchema = val
+}
+
+// SetIncludeNotNullConstraintsInInformationSchema sets whether the synthetic
+// NOT NULL constraints are listed in information_schema.
+func (m *sessionDataMutator) SetIncludeNotNullConstraintsInInformationSchema(include bool) {
+	m.data.ExcludeNotNullConstraintsFromInformationSchema = !include
+}
+
+// SetIncludeOtherTempSchemasInInformationSchema sets whether the temporary
+// schemas of other sessions are listed in information_schema.schemata.
+func (m *sessionDataMutator) SetIncludeOtherTempSchemasInInformationSchema(include bool) {
+	m.data.ExcludeOtherTempSchemasFromInformationSchema = !include
+}
+
+// SetIncludeSystemTablesInInformationSchema sets whether virtual tables and
+// system tables are listed in information_schema.tables.
+func (m *sessionDataMutator) SetIncludeSystemTablesInInformationSchema(include bool) {
+	m.data.ExcludeSystemTablesFromInformationSchema = !include
+}
+
+// SetMaterializedViewsAsTablesInInformationSchema sets whether
+// materialized views are reported as base tables in information_schema.
+func (m *sessionDataMutator) SetMaterializedViewsAsTablesInInformationSchema(val bool) {
+	m.data.MaterializedViewsAsTablesInInformationSchema = val
+}
+
+// SetMySQLCompat sets whether the information_schema tables follow the MySQL
+// conventions.
+func (m *sessionDataMutator) SetMySQLCompat(val bool) {
+	m.data.MySQLCompat = val
+}
+
+// SetCustomOption sets the value of the custom option with the given name,
+// defining it in the session if needed.
+func (m *sessionDataMutator) SetCustomOption(name, val string) {
+	if m.data.CustomOptions == nil {
+		m.data.CustomOptions = make(map[string]string)
+	}
+	m.data.CustomOptions[name] = val
+}
+
 type sqlStatsCollector struct {
 	// sqlStats tracks per-application statistics for all applications on each
 	// node.
@@ -0,0 +1,130 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// ExternalPrincipals keeps track, on the local node, of the rules with which
+// the external authentication methods (e.g. OIDC) map the principals they
+// authenticate onto SQL roles, and of the last external principal which
+// logged in as each role. It backs crdb_internal.external_principal_mappings.
+type ExternalPrincipals struct {
+	mu struct {
+		syncutil.Mutex
+		// rules is keyed by authentication method.
+		rules map[string]ExternalPrincipalRule
+		// lastLogins is keyed by authentication method, then by role.
+		lastLogins map[string]map[security.SQLUsername]externalLogin
+	}
+}
+
+// ExternalPrincipalRule describes how an external authentication method maps
+// the principals it authenticates onto SQL roles.
+type ExternalPrincipalRule struct {
+	// Source describes where the principal is taken from, e.g. the claim of an
+	// OIDC token.
+	Source string
+	// Mapping is the rule transforming the principal into a role name, e.g.
+	// a regular expression.
+	Mapping string
+}
+
+// externalLogin is the last login of an external principal as a role.
+type externalLogin struct {
+	principal string
+	time      time.Time
+}
+
+// SetRule sets the mapping rule of an authentication method.
+func (ep *ExternalPrincipals) SetRule(method string, rule ExternalPrincipalRule) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	if ep.mu.rules == nil {
+		ep.mu.rules = make(map[string]ExternalPrincipalRule)
+	}
+	ep.mu.rules[method] = rule
+}
+
+// ClearRule removes the mapping rule of an authentication method which was
+// disabled. The logins recorded for the method are kept.
+func (ep *ExternalPrincipals) ClearRule(method string) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	delete(ep.mu.rules, method)
+}
+
+// RecordLogin records that the given external principal, authenticated by
+// the given method, logged in as the given role.
+func (ep *ExternalPrincipals) RecordLogin(
+	method string, principal string, role security.SQLUsername, now time.Time,
+) {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	if ep.mu.lastLogins == nil {
+		ep.mu.lastLogins = make(map[string]map[security.SQLUsername]externalLogin)
+	}
+	logins := ep.mu.lastLogins[method]
+	if logins == nil {
+		logins = make(map[security.SQLUsername]externalLogin)
+		ep.mu.lastLogins[method] = logins
+	}
+	logins[role] = externalLogin{principal: principal, time: now}
+}
+
+// externalPrincipalMapping is a row of
+// crdb_internal.external_principal_mappings.
+type externalPrincipalMapping struct {
+	method string
+	// rule is nil if the method is disabled.
+	rule *ExternalPrincipalRule
+	// role is empty if no principal logged in with the method.
+	role  security.SQLUsername
+	login externalLogin
+}
+
+// mappings returns one entry per role which an external principal logged in
+// as, along with one entry for each enabled method without logins, sorted by
+// method and role.
+func (ep *ExternalPrincipals) mappings() []externalPrincipalMapping {
+	ep.mu.Lock()
+	defer ep.mu.Unlock()
+	var res []externalPrincipalMapping
+	for method, rule := range ep.mu.rules {
+		if len(ep.mu.lastLogins[method]) == 0 {
+			rule := rule
+			res = append(res, externalPrincipalMapping{method: method, rule: &rule})
+		}
+	}
+	for method, logins := range ep.mu.lastLogins {
+		var rule *ExternalPrincipalRule
+		if r, ok := ep.mu.rules[method]; ok {
+			rule = &r
+		}
+		for role, login := range logins {
+			res = append(res, externalPrincipalMapping{
+				method: method, rule: rule, role: role, login: login,
+			})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].method != res[j].method {
+			return res[i].method < res[j].method
+		}
+		return res[i].role.Normalized() < res[j].role.Normalized()
+	})
+	return res
+}
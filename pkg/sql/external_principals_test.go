@@ -0,0 +1,55 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalPrincipals(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+
+	var ep ExternalPrincipals
+	require.Empty(t, ep.mappings())
+
+	rule := ExternalPrincipalRule{Source: "claim email", Mapping: "^([^@]+)@example.com$"}
+	ep.SetRule("oidc", rule)
+	require.Equal(t, []externalPrincipalMapping{
+		{method: "oidc", rule: &rule},
+	}, ep.mappings())
+
+	alice := security.MakeSQLUsernameFromPreNormalizedString("alice")
+	bob := security.MakeSQLUsernameFromPreNormalizedString("bob")
+	t1 := time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	ep.RecordLogin("oidc", "bob@example.com", bob, t1)
+	ep.RecordLogin("oidc", "alice@example.com", alice, t1)
+	ep.RecordLogin("oidc", "Alice@example.com", alice, t2)
+
+	// The rule is reported along with every role reached, and only the last
+	// login of each role is kept.
+	require.Equal(t, []externalPrincipalMapping{
+		{method: "oidc", rule: &rule, role: alice, login: externalLogin{principal: "Alice@example.com", time: t2}},
+		{method: "oidc", rule: &rule, role: bob, login: externalLogin{principal: "bob@example.com", time: t1}},
+	}, ep.mappings())
+
+	// The logins of a disabled method are still reported, without its rule.
+	ep.ClearRule("oidc")
+	require.Equal(t, []externalPrincipalMapping{
+		{method: "oidc", role: alice, login: externalLogin{principal: "Alice@example.com", time: t2}},
+		{method: "oidc", role: bob, login: externalLogin{principal: "bob@example.com", time: t1}},
+	}, ep.mappings())
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -193,6 +194,22 @@ func (ep *DummyEvalPlanner) MemberOfWithAdminOption(
 	return nil, errors.WithStack(errEvalPlanner)
 }
 
+// HasPrivilege is part of the EvalPlanner interface.
+func (ep *DummyEvalPlanner) HasPrivilege(
+	ctx context.Context,
+	specifier tree.HasPrivilegeSpecifier,
+	user security.SQLUsername,
+	priv privilege.Kind,
+	withGrantOpt bool,
+) (bool, error) {
+	return false, errors.WithStack(errEvalPlanner)
+}
+
+// DeferForeignKeyCheck is part of the EvalPlanner interface.
+func (ep *DummyEvalPlanner) DeferForeignKeyCheck(tableID int64, fkName string) bool {
+	return false
+}
+
 var _ tree.EvalPlanner = &DummyEvalPlanner{}
 
 var errEvalPlanner = pgerror.New(pgcode.ScalarOperationCannotRunWithoutFullSessionContext,
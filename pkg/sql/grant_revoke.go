@@ -13,7 +13,9 @@ package sql
 import (
 	"context"
 	"fmt"
+	"sort"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -46,6 +48,12 @@ func (p *planner) Grant(ctx context.Context, n *tree.Grant) (planNode, error) {
 	case n.Targets.Databases != nil:
 		sqltelemetry.IncIAMGrantPrivilegesCounter(sqltelemetry.OnDatabase)
 		grantOn = privilege.Database
+	case n.Targets.AllTablesInSchema:
+		sqltelemetry.IncIAMGrantPrivilegesCounter(sqltelemetry.OnTable)
+		grantOn = privilege.Table
+	case n.Targets.AllSequencesInSchema:
+		sqltelemetry.IncIAMGrantPrivilegesCounter(sqltelemetry.OnTable)
+		grantOn = privilege.Sequence
 	case n.Targets.Schemas != nil:
 		sqltelemetry.IncIAMGrantPrivilegesCounter(sqltelemetry.OnSchema)
 		grantOn = privilege.Schema
@@ -57,9 +65,22 @@ func (p *planner) Grant(ctx context.Context, n *tree.Grant) (planNode, error) {
 		grantOn = privilege.Table
 	}
 
-	if err := privilege.ValidatePrivileges(n.Privileges, grantOn); err != nil {
-		return nil, err
+	// Privileges on tables are validated once the targets are resolved, as
+	// sequences have their own set of privileges.
+	if grantOn != privilege.Table {
+		if err := privilege.ValidatePrivileges(n.Privileges, grantOn); err != nil {
+			return nil, err
+		}
+	}
+
+	if n.WithGrantOption && !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.GrantOptions) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use WITH GRANT OPTION",
+			clusterversion.GrantOptions)
 	}
+	// Grantors are only recorded once all the nodes preserve them when
+	// rewriting privilege descriptors.
+	recordGrants := p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.GrantOptions)
 
 	// TODO(solon): there are SQL identifiers (tree.Name) in n.Grantees,
 	// but we want SQL usernames. Do we normalize or not? For reference,
@@ -68,6 +89,10 @@ func (p *planner) Grant(ctx context.Context, n *tree.Grant) (planNode, error) {
 	grantees := make([]security.SQLUsername, len(n.Grantees))
 	for i, grantee := range n.Grantees {
 		grantees[i] = security.MakeSQLUsernameFromPreNormalizedString(string(grantee))
+		if n.WithGrantOption && grantees[i].IsPublicRole() {
+			return nil, pgerror.Newf(pgcode.InvalidGrantOperation,
+				"grant options can only be granted to roles")
+		}
 	}
 
 	return &changePrivilegesNode{
@@ -75,8 +100,21 @@ func (p *planner) Grant(ctx context.Context, n *tree.Grant) (planNode, error) {
 		targets:      n.Targets,
 		grantees:     grantees,
 		desiredprivs: n.Privileges,
-		changePrivilege: func(privDesc *descpb.PrivilegeDescriptor, grantee security.SQLUsername) {
+		changePrivilege: func(
+			privDesc *descpb.PrivilegeDescriptor,
+			grantee security.SQLUsername,
+			_ privilege.ObjectType,
+			authority privilegeChangeAuthority,
+		) {
 			privDesc.Grant(grantee, n.Privileges)
+			var grantOptions privilege.List
+			if n.WithGrantOption {
+				privDesc.GrantGrantOption(grantee, n.Privileges)
+				grantOptions = n.Privileges
+			}
+			if recordGrants {
+				privDesc.RecordGrant(grantee, authority.grantor, n.Privileges, grantOptions)
+			}
 		},
 		grantOn: grantOn,
 	}, nil
@@ -97,6 +135,12 @@ func (p *planner) Revoke(ctx context.Context, n *tree.Revoke) (planNode, error)
 	case n.Targets.Databases != nil:
 		sqltelemetry.IncIAMRevokePrivilegesCounter(sqltelemetry.OnDatabase)
 		grantOn = privilege.Database
+	case n.Targets.AllTablesInSchema:
+		sqltelemetry.IncIAMRevokePrivilegesCounter(sqltelemetry.OnTable)
+		grantOn = privilege.Table
+	case n.Targets.AllSequencesInSchema:
+		sqltelemetry.IncIAMRevokePrivilegesCounter(sqltelemetry.OnTable)
+		grantOn = privilege.Sequence
 	case n.Targets.Schemas != nil:
 		sqltelemetry.IncIAMRevokePrivilegesCounter(sqltelemetry.OnSchema)
 		grantOn = privilege.Schema
@@ -108,8 +152,18 @@ func (p *planner) Revoke(ctx context.Context, n *tree.Revoke) (planNode, error)
 		grantOn = privilege.Table
 	}
 
-	if err := privilege.ValidatePrivileges(n.Privileges, grantOn); err != nil {
-		return nil, err
+	// Privileges on tables are validated once the targets are resolved, as
+	// sequences have their own set of privileges.
+	if grantOn != privilege.Table {
+		if err := privilege.ValidatePrivileges(n.Privileges, grantOn); err != nil {
+			return nil, err
+		}
+	}
+
+	if n.GrantOptionFor && !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.GrantOptions) {
+		return nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use REVOKE GRANT OPTION FOR",
+			clusterversion.GrantOptions)
 	}
 
 	// TODO(solon): there are SQL identifiers (tree.Name) in n.Grantees,
@@ -126,8 +180,21 @@ func (p *planner) Revoke(ctx context.Context, n *tree.Revoke) (planNode, error)
 		targets:      n.Targets,
 		grantees:     grantees,
 		desiredprivs: n.Privileges,
-		changePrivilege: func(privDesc *descpb.PrivilegeDescriptor, grantee security.SQLUsername) {
-			privDesc.Revoke(grantee, n.Privileges, grantOn)
+		changePrivilege: func(
+			privDesc *descpb.PrivilegeDescriptor,
+			grantee security.SQLUsername,
+			objectType privilege.ObjectType,
+			authority privilegeChangeAuthority,
+		) {
+			if authority.revocable != nil {
+				privDesc.RevokeGrantedBy(grantee, n.Privileges, objectType, n.GrantOptionFor, authority.revocable)
+				return
+			}
+			if n.GrantOptionFor {
+				privDesc.RevokeGrantOption(grantee, n.Privileges, objectType)
+				return
+			}
+			privDesc.Revoke(grantee, n.Privileges, objectType)
 		},
 		grantOn: grantOn,
 	}, nil
@@ -138,10 +205,21 @@ type changePrivilegesNode struct {
 	targets         tree.TargetList
 	grantees        []security.SQLUsername
 	desiredprivs    privilege.List
-	changePrivilege func(*descpb.PrivilegeDescriptor, security.SQLUsername)
+	changePrivilege func(*descpb.PrivilegeDescriptor, security.SQLUsername, privilege.ObjectType, privilegeChangeAuthority)
 	grantOn         privilege.ObjectType
 }
 
+// privilegeChangeAuthority describes on whose behalf the privileges on a
+// descriptor are changed.
+type privilegeChangeAuthority struct {
+	// grantor is the role recorded as the grantor of the granted privileges.
+	grantor security.SQLUsername
+	// revocable, if set, restricts REVOKE to the privileges granted by the
+	// grantors for which it returns true. It is nil if the privileges may be
+	// revoked regardless of who granted them.
+	revocable func(grantor security.SQLUsername) bool
+}
+
 // ReadingOwnWrites implements the planNodeReadingOwnWrites interface.
 // This is because GRANT/REVOKE performs multiple KV operations on descriptors
 // and expects to see its own writes.
@@ -187,7 +265,18 @@ func (n *changePrivilegesNode) startExec(params runParams) error {
 	// First, update the descriptors. We want to catch all errors before
 	// we update them in KV below.
 	b := p.txn.NewBatch()
+	// A descriptor can be targeted several times, for instance through
+	// overlapping patterns such as s.* and s.t. Only change it once, so that
+	// each descriptor is written and has its version bumped a single time.
+	seen := make(map[descpb.ID]struct{}, len(descriptors))
+	// memberOf holds the roles of which the user is a member. It is only
+	// resolved if the user needs a grant option to change the privileges.
+	var memberOf map[security.SQLUsername]bool
 	for _, descriptor := range descriptors {
+		if _, ok := seen[descriptor.GetID()]; ok {
+			continue
+		}
+		seen[descriptor.GetID()] = struct{}{}
 		// Disallow privilege changes on system objects. For more context, see #43842.
 		op := "REVOKE"
 		if n.isGrant {
@@ -197,26 +286,55 @@ func (n *changePrivilegesNode) startExec(params runParams) error {
 			return pgerror.Newf(pgcode.InsufficientPrivilege, "cannot %s on system object", op)
 		}
 
-		if err := p.CheckPrivilege(ctx, descriptor, privilege.GRANT); err != nil {
+		objectType := n.grantOn
+		if tbl, ok := descriptor.(catalog.TableDescriptor); ok && tbl.IsSequence() {
+			objectType = privilege.Sequence
+		}
+		if err := privilege.ValidatePrivileges(n.desiredprivs, objectType); err != nil {
 			return err
 		}
+		// Older nodes validate the privileges of sequences as those of tables.
+		if n.isGrant && objectType == privilege.Sequence &&
+			privilege.ValidatePrivileges(n.desiredprivs, privilege.Table) != nil &&
+			!p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.SequencePrivileges) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to grant %s on sequences",
+				clusterversion.SequencePrivileges, n.desiredprivs)
+		}
 
-		// Only allow granting/revoking privileges that the requesting
-		// user themselves have on the descriptor.
-		for _, priv := range n.desiredprivs {
-			if err := p.CheckPrivilege(ctx, descriptor, priv); err != nil {
-				return err
+		// A user holding the GRANT privilege along with the desired privileges
+		// may grant them, and revoke them regardless of who granted them.
+		// Otherwise, the user must hold the grant option for all of them,
+		// either directly or through a role it is a member of, and may only
+		// revoke the privileges granted by itself or by such a role.
+		authority := privilegeChangeAuthority{grantor: p.User()}
+		if privErr := p.checkPrivilegesToChange(ctx, descriptor, n.desiredprivs); privErr != nil {
+			if memberOf == nil {
+				if memberOf, err = p.MemberOfWithAdminOption(ctx, p.User()); err != nil {
+					return err
+				}
+			}
+			holder, ok := grantOptionHolder(descriptor.GetPrivileges(), p.User(), memberOf, n.desiredprivs)
+			if !ok {
+				return privErr
+			}
+			authority = privilegeChangeAuthority{
+				grantor: holder,
+				revocable: func(grantor security.SQLUsername) bool {
+					_, isMember := memberOf[grantor]
+					return grantor == p.User() || isMember
+				},
 			}
 		}
 
 		privileges := descriptor.GetPrivileges()
 		for _, grantee := range n.grantees {
-			n.changePrivilege(privileges, grantee)
+			n.changePrivilege(privileges, grantee, objectType, authority)
 		}
 
 		// Validate privilege descriptors directly as the db/table level Validate
 		// may fix up the descriptor.
-		if err := privileges.Validate(descriptor.GetID(), n.grantOn); err != nil {
+		if err := privileges.Validate(descriptor.GetID(), objectType); err != nil {
 			return err
 		}
 
@@ -320,6 +438,49 @@ func (n *changePrivilegesNode) startExec(params runParams) error {
 	return nil
 }
 
+// checkPrivilegesToChange checks that the user holds the GRANT privilege on
+// the descriptor along with all of the given privileges.
+func (p *planner) checkPrivilegesToChange(
+	ctx context.Context, descriptor catalog.Descriptor, privs privilege.List,
+) error {
+	if err := p.CheckPrivilege(ctx, descriptor, privilege.GRANT); err != nil {
+		return err
+	}
+	// Only allow granting/revoking privileges that the requesting user
+	// themselves have on the descriptor.
+	for _, priv := range privs {
+		if err := p.CheckPrivilege(ctx, descriptor, priv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// grantOptionHolder returns the role which holds the grant option for all of
+// the given privileges, among the user and the roles it is a member of. The
+// user itself is preferred, and the roles are otherwise considered in order.
+func grantOptionHolder(
+	privDesc *descpb.PrivilegeDescriptor,
+	user security.SQLUsername,
+	memberOf map[security.SQLUsername]bool,
+	privs privilege.List,
+) (_ security.SQLUsername, ok bool) {
+	if privDesc.CheckGrantOptions(user, privs) {
+		return user, true
+	}
+	roles := make([]security.SQLUsername, 0, len(memberOf))
+	for role := range memberOf {
+		roles = append(roles, role)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].LessThan(roles[j]) })
+	for _, role := range roles {
+		if privDesc.CheckGrantOptions(role, privs) {
+			return role, true
+		}
+	}
+	return security.SQLUsername{}, false
+}
+
 func (*changePrivilegesNode) Next(runParams) (bool, error) { return false, nil }
 func (*changePrivilegesNode) Values() tree.Datums          { return tree.Datums{} }
 func (*changePrivilegesNode) Close(context.Context)        {}
@@ -0,0 +1,215 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/resolver"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/errors"
+)
+
+// This file resolves the privileges held by roles on objects, as reported by
+// the has_*_privilege builtins and by the privilege tables of
+// information_schema. Both go through the same role closure and the same
+// privilege descriptors, so that they never disagree.
+
+// userRoles returns the roles whose privileges the given user holds: the user
+// itself, the public role and every role the user is a direct or indirect
+// member of. The result is memoized for the rest of the statement, so that
+// checking the privileges of a user on many objects only expands its role
+// memberships once.
+//
+// The memberships of the current user are cached across statements by
+// MemberOfWithAdminOption. Those of the other users are expanded from the
+// memberships read once for the statement, so that checking the privileges of
+// many users doesn't look up system.role_members for each of them.
+func (p *planner) userRoles(
+	ctx context.Context, user security.SQLUsername,
+) (map[security.SQLUsername]bool, error) {
+	if roles, ok := p.userRolesCache[user]; ok {
+		return roles, nil
+	}
+	var memberOf map[security.SQLUsername]bool
+	if user == p.User() {
+		var err error
+		if memberOf, err = p.MemberOfWithAdminOption(ctx, user); err != nil {
+			return nil, err
+		}
+	} else {
+		memberships, err := p.getRoleMemberships(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if memberOf, err = memberships.memberOfWithAdminOption(ctx, user); err != nil {
+			return nil, err
+		}
+	}
+	roles := make(map[security.SQLUsername]bool, len(memberOf)+2)
+	for role := range memberOf {
+		roles[role] = true
+	}
+	roles[user] = true
+	roles[security.PublicRoleName()] = true
+	if p.userRolesCache == nil {
+		p.userRolesCache = make(map[security.SQLUsername]map[security.SQLUsername]bool)
+	}
+	p.userRolesCache[user] = roles
+	return roles, nil
+}
+
+// userHasPrivilege returns whether one of the given roles holds the privilege
+// in the given privilege descriptor. If withGrantOpt is set, the privilege
+// must also be grantable, as reported by the is_grantable columns of
+// information_schema.
+func userHasPrivilege(
+	roles map[security.SQLUsername]bool,
+	privs *descpb.PrivilegeDescriptor,
+	priv privilege.Kind,
+	withGrantOpt bool,
+) bool {
+	for _, u := range privs.Users {
+		if !roles[u.User()] {
+			continue
+		}
+		if u.Privileges&(privilege.ALL.Mask()|priv.Mask()) == 0 {
+			continue
+		}
+		if !withGrantOpt || u.CanGrant(priv) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPrivilege is part of the tree.EvalPlanner interface. It returns whether
+// the user holds the privilege on the specified object, directly or through
+// one of its roles, according to the same privileges as those listed in
+// information_schema.
+func (p *planner) HasPrivilege(
+	ctx context.Context,
+	specifier tree.HasPrivilegeSpecifier,
+	user security.SQLUsername,
+	priv privilege.Kind,
+	withGrantOpt bool,
+) (bool, error) {
+	privs, err := p.getPrivilegesForSpecifier(ctx, specifier)
+	if err != nil {
+		return false, err
+	}
+	roles, err := p.userRoles(ctx, user)
+	if err != nil {
+		return false, err
+	}
+	return userHasPrivilege(roles, privs, priv, withGrantOpt), nil
+}
+
+// getPrivilegesForSpecifier returns the privilege descriptor of the object
+// specified for a privilege inquiry.
+func (p *planner) getPrivilegesForSpecifier(
+	ctx context.Context, specifier tree.HasPrivilegeSpecifier,
+) (*descpb.PrivilegeDescriptor, error) {
+	switch {
+	case specifier.DatabaseName != nil:
+		_, db, err := p.Descriptors().GetImmutableDatabaseByName(ctx, p.txn,
+			*specifier.DatabaseName, tree.DatabaseLookupFlags{Required: true})
+		if err != nil {
+			return nil, err
+		}
+		return db.GetPrivileges(), nil
+
+	case specifier.SchemaName != nil:
+		_, db, err := p.Descriptors().GetImmutableDatabaseByName(ctx, p.txn,
+			*specifier.SchemaDatabaseName, tree.DatabaseLookupFlags{Required: true})
+		if err != nil {
+			return nil, err
+		}
+		scName := *specifier.SchemaName
+		if _, ok := p.getVirtualTabler().getEntries()[scName]; ok {
+			return schemaPrivileges(db, catalog.ResolvedSchema{
+				Name: scName, Kind: catalog.SchemaVirtual,
+			}), nil
+		}
+		_, sc, err := p.Descriptors().GetImmutableSchemaByName(ctx, p.txn, db.GetID(), scName,
+			tree.SchemaLookupFlags{Required: true})
+		if err != nil {
+			return nil, err
+		}
+		return schemaPrivileges(db, sc), nil
+
+	case specifier.TableName != nil:
+		desc, err := resolver.ResolveExistingTableObject(ctx, p, specifier.TableName,
+			tree.ObjectLookupFlagsWithRequired())
+		if err != nil {
+			return nil, err
+		}
+		return desc.GetPrivileges(), nil
+
+	default:
+		return nil, errors.AssertionFailedf("no object specified for privilege check")
+	}
+}
+
+// hasAnyPrivilege returns whether the current user holds any privilege on the
+// descriptor, directly or through one of its roles. It agrees with
+// CheckAnyPrivilege, but the result is memoized for the rest of the statement
+// as it is used to decide which descriptors are visible in the virtual tables.
+func (p *planner) hasAnyPrivilege(ctx context.Context, desc catalog.Descriptor) (bool, error) {
+	if hasAny, ok := p.anyPrivileges[desc.GetID()]; ok {
+		return hasAny, nil
+	}
+	if err := p.warmAnyPrivileges(ctx, []catalog.Descriptor{desc}); err != nil {
+		return false, err
+	}
+	return p.anyPrivileges[desc.GetID()], nil
+}
+
+// warmAnyPrivileges memoizes hasAnyPrivilege for all the given descriptors at
+// once. The role memberships of the current user are expanded a single time,
+// which makes reading the whole catalog considerably cheaper than checking
+// each descriptor in turn.
+func (p *planner) warmAnyPrivileges(ctx context.Context, descs []catalog.Descriptor) error {
+	roles, err := p.userRoles(ctx, p.User())
+	if err != nil {
+		return err
+	}
+	if p.anyPrivileges == nil {
+		p.anyPrivileges = make(map[descpb.ID]bool, len(descs))
+	}
+	for _, desc := range descs {
+		if _, ok := p.anyPrivileges[desc.GetID()]; ok {
+			continue
+		}
+		p.anyPrivileges[desc.GetID()] = anyRoleHasAnyPrivilege(roles, desc.GetPrivileges())
+	}
+	return nil
+}
+
+// anyRoleHasAnyPrivilege returns whether one of the given roles owns the
+// object or holds any privilege in the given privilege descriptor.
+func anyRoleHasAnyPrivilege(
+	roles map[security.SQLUsername]bool, privs *descpb.PrivilegeDescriptor,
+) bool {
+	if roles[privs.Owner()] {
+		return true
+	}
+	for _, u := range privs.Users {
+		if u.Privileges != 0 && roles[u.User()] {
+			return true
+		}
+	}
+	return false
+}
@@ -19,8 +19,12 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/build"
 	"github.com/cockroachdb/cockroach/pkg/docs"
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catalogkv"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catconstants"
@@ -28,10 +32,13 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/tabledesc"
 	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/sql/vtable"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/errors"
 	"golang.org/x/text/collate"
 )
@@ -64,7 +71,6 @@ var informationSchema = virtualSchema{
 		"domain_constraints",
 		"domain_udt_usage",
 		"domains",
-		"element_types",
 		"foreign_data_wrapper_options",
 		"foreign_data_wrappers",
 		"foreign_server_options",
@@ -76,7 +82,6 @@ var informationSchema = virtualSchema{
 		"role_routine_grants",
 		"role_udt_grants",
 		"role_usage_grants",
-		"routine_privileges",
 		"sql_features",
 		"sql_implementation_info",
 		"sql_languages",
@@ -88,7 +93,6 @@ var informationSchema = virtualSchema{
 		"triggered_update_columns",
 		"triggers",
 		"udt_privileges",
-		"usage_privileges",
 		"user_defined_types",
 		"user_mapping_options",
 		"user_mappings",
@@ -107,12 +111,19 @@ var informationSchema = virtualSchema{
 		catconstants.InformationSchemaColumnsTableID:                     informationSchemaColumnsTable,
 		catconstants.InformationSchemaColumnUDTUsageID:                   informationSchemaColumnUDTUsage,
 		catconstants.InformationSchemaConstraintColumnUsageTableID:       informationSchemaConstraintColumnUsageTable,
+		catconstants.InformationSchemaElementTypesID:                     informationSchemaElementTypes,
 		catconstants.InformationSchemaTypePrivilegesID:                   informationSchemaTypePrivilegesTable,
+		catconstants.InformationSchemaUsagePrivilegesID:                  informationSchemaUsagePrivileges,
 		catconstants.InformationSchemaEnabledRolesID:                     informationSchemaEnabledRoles,
+		catconstants.InformationSchemaEnginesID:                          informationSchemaEngines,
+		catconstants.InformationSchemaEventsID:                           informationSchemaEvents,
 		catconstants.InformationSchemaKeyColumnUsageTableID:              informationSchemaKeyColumnUsageTable,
 		catconstants.InformationSchemaParametersTableID:                  informationSchemaParametersTable,
+		catconstants.InformationSchemaPartitionsTableID:                  informationSchemaPartitionsTable,
+		catconstants.InformationSchemaPluginsID:                          informationSchemaPlugins,
 		catconstants.InformationSchemaReferentialConstraintsTableID:      informationSchemaReferentialConstraintsTable,
 		catconstants.InformationSchemaRoleTableGrantsID:                  informationSchemaRoleTableGrants,
+		catconstants.InformationSchemaRoutinePrivilegesID:                informationSchemaRoutinePrivileges,
 		catconstants.InformationSchemaRoutineTableID:                     informationSchemaRoutineTable,
 		catconstants.InformationSchemaSchemataTableID:                    informationSchemaSchemataTable,
 		catconstants.InformationSchemaSchemataTablePrivilegesID:          informationSchemaSchemataTablePrivileges,
@@ -248,15 +259,21 @@ https://www.postgresql.org/docs/9.5/infoschema-character-sets.html`,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		return forEachDatabaseDesc(ctx, p, nil /* all databases */, true, /* requiresPrivileges */
 			func(db catalog.DatabaseDescriptor) error {
+				// The default collation of a database is one of the collations
+				// that are always available, which belong to pg_catalog.
+				defaultCollation := db.GetDefaultCollation()
+				if defaultCollation == "" {
+					defaultCollation = tree.DefaultCollationTag
+				}
 				return addRow(
-					tree.DNull,                    // character_set_catalog
-					tree.DNull,                    // character_set_schema
-					tree.NewDString("UTF8"),       // character_set_name: UTF8 is the only available encoding
-					tree.NewDString("UCS"),        // character_repertoire: UCS for UTF8 encoding
-					tree.NewDString("UTF8"),       // form_of_use: same as the database encoding
-					tree.NewDString(db.GetName()), // default_collate_catalog
-					tree.DNull,                    // default_collate_schema
-					tree.DNull,                    // default_collate_name
+					tree.DNull,                        // character_set_catalog
+					tree.DNull,                        // character_set_schema
+					tree.NewDString("UTF8"),           // character_set_name: UTF8 is the only available encoding
+					tree.NewDString("UCS"),            // character_repertoire: UCS for UTF8 encoding
+					tree.NewDString("UTF8"),           // form_of_use: same as the database encoding
+					tree.NewDString(db.GetName()),     // default_collate_catalog
+					pgCatalogNameDString,              // default_collate_schema
+					tree.NewDString(defaultCollation), // default_collate_name
 				)
 			})
 	},
@@ -291,10 +308,11 @@ https://www.postgresql.org/docs/9.5/infoschema-check-constraints.html`,
 				// constraint expression in two pairs of parentheses.
 				chkExprStr := tree.NewDString(fmt.Sprintf("((%s))", con.Details))
 				if err := addRow(
-					dbNameStr,  // constraint_catalog
-					scNameStr,  // constraint_schema
-					conNameStr, // constraint_name
-					chkExprStr, // check_clause
+					dbNameStr,                      // constraint_catalog
+					scNameStr,                      // constraint_schema
+					conNameStr,                     // constraint_name
+					chkExprStr,                     // check_clause
+					yesOrNoDatum(!con.Unvalidated), // crdb_is_validated
 				); err != nil {
 					return err
 				}
@@ -302,36 +320,60 @@ https://www.postgresql.org/docs/9.5/infoschema-check-constraints.html`,
 
 			// Unlike with pg_catalog.pg_constraint, Postgres also includes NOT
 			// NULL column constraints in information_schema.check_constraints.
-			// Cockroach doesn't track these constraints as check constraints,
-			// but we can pull them off of the table's column descriptors.
-			for _, column := range table.PublicColumns() {
-				// Only visible, non-nullable columns are included.
-				if column.IsHidden() || column.IsNullable() {
-					continue
-				}
-				// Generate a unique name for each NOT NULL constraint. Postgres
-				// uses the format <namespace_oid>_<table_oid>_<col_idx>_not_null.
-				// We might as well do the same.
-				conNameStr := tree.NewDString(fmt.Sprintf(
-					"%s_%s_%d_not_null", h.NamespaceOid(db.GetID(), scName), tableOid(table.GetID()), column.Ordinal()+1,
-				))
+			return forEachNotNullConstraint(p, h, db, scName, table, func(
+				column catalog.Column, conNameStr *tree.DString,
+			) error {
 				chkExprStr := tree.NewDString(fmt.Sprintf(
 					"%s IS NOT NULL", column.GetName(),
 				))
-				if err := addRow(
-					dbNameStr,  // constraint_catalog
-					scNameStr,  // constraint_schema
-					conNameStr, // constraint_name
-					chkExprStr, // check_clause
-				); err != nil {
-					return err
-				}
-			}
-			return nil
+				return addRow(
+					dbNameStr,          // constraint_catalog
+					scNameStr,          // constraint_schema
+					conNameStr,         // constraint_name
+					chkExprStr,         // check_clause
+					yesOrNoDatum(true), // crdb_is_validated
+				)
+			})
 		})
 	},
 }
 
+// forEachNotNullConstraint calls fn for the synthetic CHECK constraint of each
+// NOT NULL column of the table, which information_schema.check_constraints and
+// table_constraints list like Postgres does. Cockroach doesn't track these
+// constraints as check constraints, so they are pulled off of the table's
+// column descriptors. Hidden columns are skipped, and nothing is listed if the
+// session excludes these constraints from information_schema.
+//
+// Postgres names the constraints <namespace_oid>_<table_oid>_<attnum>_not_null.
+// We do the same, using the attribute number reported in pg_attribute so that
+// names stay stable when other columns are dropped.
+func forEachNotNullConstraint(
+	p *planner,
+	h oidHasher,
+	db catalog.DatabaseDescriptor,
+	scName string,
+	table catalog.TableDescriptor,
+	fn func(column catalog.Column, conName *tree.DString) error,
+) error {
+	if p.SessionData().ExcludeNotNullConstraintsFromInformationSchema {
+		return nil
+	}
+	for _, column := range table.PublicColumns() {
+		if column.IsHidden() || column.IsNullable() {
+			continue
+		}
+		conName := tree.NewDString(fmt.Sprintf(
+			"%s_%s_%d_not_null",
+			h.NamespaceOid(db.GetID(), scName), tableOid(table.GetID()), column.GetPGAttributeNum(),
+		))
+		if err := fn(column, conName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 var informationSchemaColumnPrivileges = virtualSchemaTable{
 	comment: `column privilege grants (incomplete)
 ` + docs.URL("information-schema.html#column_privileges") + `
@@ -343,13 +385,13 @@ https://www.postgresql.org/docs/9.5/infoschema-column-privileges.html`,
 		) error {
 			dbNameStr := tree.NewDString(db.GetName())
 			scNameStr := tree.NewDString(scName)
-			columndata := privilege.List{privilege.SELECT, privilege.INSERT, privilege.UPDATE} // privileges for column level granularity
 			for _, u := range table.GetPrivileges().Users {
-				for _, priv := range columndata {
+				grantor := grantorDatum(u)
+				for _, priv := range columnPrivileges {
 					if priv.Mask()&u.Privileges != 0 {
 						for _, cd := range table.PublicColumns() {
 							if err := addRow(
-								tree.DNull,                             // grantor
+								grantor,                                // grantor
 								tree.NewDString(u.User().Normalized()), // grantee
 								dbNameStr,                              // table_catalog
 								scNameStr,                              // table_schema
@@ -369,137 +411,328 @@ https://www.postgresql.org/docs/9.5/infoschema-column-privileges.html`,
 	},
 }
 
+// columnPrivileges are the table privileges which apply to columns.
+var columnPrivileges = privilege.List{privilege.SELECT, privilege.INSERT, privilege.UPDATE}
+
 var informationSchemaColumnsTable = virtualSchemaTable{
 	comment: `table and view columns (incomplete)
 ` + docs.URL("information-schema.html#columns") + `
 https://www.postgresql.org/docs/9.5/infoschema-columns.html`,
-	schema: vtable.InformationSchemaColumns,
-	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		// Get the collations for all comments of current database.
-		comments, err := getComments(ctx, p)
-		if err != nil {
-			return err
+	schema:    vtable.InformationSchemaColumns,
+	populate:  makePopulateFromTableRows(informationSchemaColumnsTableRows),
+	tableRows: informationSchemaColumnsTableRows,
+	rowFilter: func(ctx context.Context, p *planner, row tree.Datums) (bool, error) {
+		if p.SessionData().ExcludeHiddenColumnsFromInformationSchema &&
+			tree.MustBeDString(row[informationSchemaColumnsIsHiddenIdx]) == "YES" {
+			return false, nil
 		}
-		// Push all comments of columns into map.
-		commentMap := make(map[tree.DInt]map[tree.DInt]string)
-		for _, comment := range comments {
-			objID := tree.MustBeDInt(comment[0])
-			objSubID := tree.MustBeDInt(comment[1])
-			description := comment[2].String()
-			commentType := tree.MustBeDInt(comment[3])
-			if commentType == 2 {
-				if commentMap[objID] == nil {
-					commentMap[objID] = make(map[tree.DInt]string)
-				}
-				commentMap[objID][objSubID] = description
-			}
+		return temporaryObjectRowFilter(ctx, p, row)
+	},
+	materializable: true,
+}
+
+// informationSchemaColumnsIsHiddenIdx is the ordinal of the is_hidden column of
+// information_schema.columns.
+const informationSchemaColumnsIsHiddenIdx = 45
+
+func informationSchemaColumnsTableRows(
+	ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor,
+) (addTableRowsFunc, error) {
+	// Get the comments of all columns.
+	commentMap, err := getCommentsByObject(ctx, p, keys.ColumnCommentType)
+	if err != nil {
+		return nil, err
+	}
+
+	// The MySQL columns are only populated in MySQL compatibility mode. The
+	// privileges they report are those of the current user and of the roles
+	// it is a member of.
+	mysqlCompat := p.SessionData().MySQLCompat
+	var userRoles []security.SQLUsername
+	if mysqlCompat {
+		userRoles = append(userRoles, security.PublicRoleName())
+		if err := p.forEachEnabledRole(ctx, p.SessionData().User(), func(role security.SQLUsername) error {
+			userRoles = append(userRoles, role)
+			return nil
+		}); err != nil {
+			return nil, err
 		}
+	}
 
-		return forEachTableDesc(ctx, p, dbContext, virtualMany, func(
-			db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor,
-		) error {
-			dbNameStr := tree.NewDString(db.GetName())
-			scNameStr := tree.NewDString(scName)
-			for _, column := range table.PublicColumns() {
-				collationCatalog := tree.DNull
-				collationSchema := tree.DNull
-				collationName := tree.DNull
-				if locale := column.GetType().Locale(); locale != "" {
-					collationCatalog = dbNameStr
-					collationSchema = pgCatalogNameDString
-					collationName = tree.NewDString(locale)
+	return func(
+		db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor,
+		addRow func(...tree.Datum) error,
+	) error {
+		dbNameStr := tree.NewDString(db.GetName())
+		scNameStr := tree.NewDString(scName)
+		var columnKeys map[descpb.ColumnID]string
+		mysqlPrivileges := tree.DNull
+		if mysqlCompat {
+			columnKeys = mysqlColumnKeys(table)
+			mysqlPrivileges = tree.NewDString(mysqlColumnPrivileges(table, userRoles))
+		}
+		// Virtual columns are not stored, so they belong to no family.
+		columnFamilies := make(map[descpb.ColumnID]tree.Datum)
+		_ = table.ForeachFamily(func(family *descpb.ColumnFamilyDescriptor) error {
+			famName := tree.NewDString(family.Name)
+			for _, colID := range family.ColumnIDs {
+				columnFamilies[colID] = famName
+			}
+			return nil
+		})
+		var viewUpdatable []bool
+		if table.IsView() {
+			var err error
+			if viewUpdatable, err = viewUpdatableColumns(ctx, p, table); err != nil {
+				return err
+			}
+		}
+		for i, column := range table.PublicColumns() {
+			columnFamily, ok := columnFamilies[column.GetID()]
+			if !ok {
+				columnFamily = tree.DNull
+			}
+			collationCatalog := tree.DNull
+			collationSchema := tree.DNull
+			collationName := tree.DNull
+			if locale := column.GetType().Locale(); locale != "" {
+				collationCatalog = dbNameStr
+				collationSchema = pgCatalogNameDString
+				collationName = tree.NewDString(locale)
+			}
+			collationVersion := tree.DNull
+			if v := column.GetCollationVersion(); v != "" {
+				collationVersion = tree.NewDString(v)
+			}
+			isUpdatable := yesOrNoDatum(table.IsTable() && !table.IsVirtualTable() && !column.IsComputed())
+			if table.IsView() {
+				isUpdatable = yesOrNoDatum(i < len(viewUpdatable) && viewUpdatable[i])
+			}
+			colDefault := tree.DNull
+			// Like in PostgreSQL, identity columns have no default: the
+			// DEFAULT expression which uses their sequence is an implementation
+			// detail.
+			if column.HasDefault() && !column.IsGeneratedAsIdentity() {
+				colExpr, err := schemaexpr.FormatExprForDisplay(ctx, table, column.GetDefaultExpr(), &p.semaCtx, tree.FmtParsable)
+				if err != nil {
+					return err
 				}
-				colDefault := tree.DNull
-				if column.HasDefault() {
-					colExpr, err := schemaexpr.FormatExprForDisplay(ctx, table, column.GetDefaultExpr(), &p.semaCtx, tree.FmtParsable)
+				colDefault = tree.NewDString(colExpr)
+			}
+			defaultSequences := tree.DNull
+			if column.NumUsesSequences() > 0 {
+				seqNames := tree.NewDArray(types.String)
+				for i := 0; i < column.NumUsesSequences(); i++ {
+					seq, err := p.LookupTableByID(ctx, column.GetUsesSequenceID(i))
 					if err != nil {
 						return err
 					}
-					colDefault = tree.NewDString(colExpr)
-				}
-				colComputed := emptyString
-				if column.IsComputed() {
-					colExpr, err := schemaexpr.FormatExprForDisplay(ctx, table, column.GetComputeExpr(), &p.semaCtx, tree.FmtSimple)
+					seqName, err := p.getQualifiedTableName(ctx, seq)
 					if err != nil {
 						return err
 					}
-					colComputed = tree.NewDString(colExpr)
+					if err := seqNames.Append(tree.NewDString(seqName.FQString())); err != nil {
+						return err
+					}
 				}
-
-				// Match the comment belonging to current column from map,using table id and column id
-				tableID := tree.DInt(table.GetID())
-				columnID := tree.DInt(column.GetID())
-				description := commentMap[tableID][columnID]
-
-				// udt_schema is set to pg_catalog for builtin types. If, however, the
-				// type is a user defined type, then we should fill this value based on
-				// the schema it is under.
-				udtSchema := pgCatalogNameDString
-				typeMetaName := column.GetType().TypeMeta.Name
-				if typeMetaName != nil {
-					udtSchema = tree.NewDString(typeMetaName.Schema)
+				defaultSequences = seqNames
+			}
+			isIdentity := noString
+			identityGeneration := tree.DNull
+			identityStart := tree.DNull
+			identityIncrement := tree.DNull
+			identityMaximum := tree.DNull
+			identityMinimum := tree.DNull
+			identityCycle := tree.DNull
+			if column.IsGeneratedAsIdentity() {
+				isIdentity = yesString
+				switch column.GetGeneratedAsIdentityType() {
+				case descpb.GeneratedAsIdentityType_GENERATED_ALWAYS:
+					identityGeneration = tree.NewDString("ALWAYS")
+				case descpb.GeneratedAsIdentityType_GENERATED_BY_DEFAULT:
+					identityGeneration = tree.NewDString("BY DEFAULT")
 				}
-
-				err := addRow(
-					dbNameStr,                         // table_catalog
-					scNameStr,                         // table_schema
-					tree.NewDString(table.GetName()),  // table_name
-					tree.NewDString(column.GetName()), // column_name
-					tree.NewDString(description),      // column_comment
-					tree.NewDInt(tree.DInt(column.GetPGAttributeNum())), // ordinal_position
-					colDefault,                        // column_default
-					yesOrNoDatum(column.IsNullable()), // is_nullable
-					tree.NewDString(column.GetType().InformationSchemaName()), // data_type
-					characterMaximumLength(column.GetType()),                  // character_maximum_length
-					characterOctetLength(column.GetType()),                    // character_octet_length
-					numericPrecision(column.GetType()),                        // numeric_precision
-					numericPrecisionRadix(column.GetType()),                   // numeric_precision_radix
-					numericScale(column.GetType()),                            // numeric_scale
-					datetimePrecision(column.GetType()),                       // datetime_precision
-					tree.DNull,                                                // interval_type
-					tree.DNull,                                                // interval_precision
-					tree.DNull,                                                // character_set_catalog
-					tree.DNull,                                                // character_set_schema
-					tree.DNull,                                                // character_set_name
-					collationCatalog,                                          // collation_catalog
-					collationSchema,                                           // collation_schema
-					collationName,                                             // collation_name
-					tree.DNull,                                                // domain_catalog
-					tree.DNull,                                                // domain_schema
-					tree.DNull,                                                // domain_name
-					dbNameStr,                                                 // udt_catalog
-					udtSchema,                                                 // udt_schema
-					tree.NewDString(column.GetType().PGName()), // udt_name
-					tree.DNull, // scope_catalog
-					tree.DNull, // scope_schema
-					tree.DNull, // scope_name
-					tree.DNull, // maximum_cardinality
-					tree.DNull, // dtd_identifier
-					tree.DNull, // is_self_referencing
-					//TODO: Need to update when supporting identiy columns (Issue #48532)
-					noString,                          // is_identity
-					tree.DNull,                        // identity_generation
-					tree.DNull,                        // identity_start
-					tree.DNull,                        // identity_increment
-					tree.DNull,                        // identity_maximum
-					tree.DNull,                        // identity_minimum
-					tree.DNull,                        // identity_cycle
-					yesOrNoDatum(column.IsComputed()), // is_generated
-					colComputed,                       // generation_expression
-					yesOrNoDatum(table.IsTable() &&
-						!table.IsVirtualTable() &&
-						!column.IsComputed(),
-					), // is_updatable
-					yesOrNoDatum(column.IsHidden()),               // is_hidden
-					tree.NewDString(column.GetType().SQLString()), // crdb_sql_type
-				)
+				if column.NumUsesSequences() > 0 {
+					seq, err := p.LookupTableByID(ctx, column.GetUsesSequenceID(0))
+					if err != nil {
+						return err
+					}
+					opts := seq.GetSequenceOpts()
+					identityStart = tree.NewDString(strconv.FormatInt(opts.Start, 10))
+					identityIncrement = tree.NewDString(strconv.FormatInt(opts.Increment, 10))
+					identityMaximum = tree.NewDString(strconv.FormatInt(opts.MaxValue, 10))
+					identityMinimum = tree.NewDString(strconv.FormatInt(opts.MinValue, 10))
+					identityCycle = yesOrNoDatum(opts.Cycle)
+				}
+			}
+			colComputed := emptyString
+			colStored := tree.DNull
+			if column.IsComputed() {
+				colExpr, err := schemaexpr.FormatExprForDisplay(ctx, table, column.GetComputeExpr(), &p.semaCtx, tree.FmtSimple)
 				if err != nil {
 					return err
 				}
+				colComputed = tree.NewDString(colExpr)
+				colStored = yesOrNoDatum(!column.IsVirtual())
 			}
-			return nil
-		})
-	},
+
+			// Match the comment belonging to current column from map,using table id and column id
+			description := ""
+			if d, ok := commentMap[table.GetID()][tree.DInt(column.GetID())]; ok {
+				description = d.String()
+			}
+
+			// udt_schema is set to pg_catalog for builtin types. If, however, the
+			// type is a user defined type, then we should fill this value based on
+			// the schema it is under.
+			udtSchema := pgCatalogNameDString
+			typeMetaName := column.GetType().TypeMeta.Name
+			if typeMetaName != nil {
+				udtSchema = tree.NewDString(typeMetaName.Schema)
+			}
+
+			columnType, columnKey, extra := tree.DNull, tree.DNull, tree.DNull
+			if mysqlCompat {
+				columnType = tree.NewDString(mysqlColumnType(column.GetType()))
+				columnKey = tree.NewDString(columnKeys[column.GetID()])
+				extra = tree.NewDString(mysqlColumnExtra(column))
+			}
+
+			err := addRow(
+				dbNameStr,                         // table_catalog
+				scNameStr,                         // table_schema
+				tree.NewDString(table.GetName()),  // table_name
+				tree.NewDString(column.GetName()), // column_name
+				tree.NewDString(description),      // column_comment
+				tree.NewDInt(tree.DInt(column.GetPGAttributeNum())), // ordinal_position
+				colDefault,                        // column_default
+				yesOrNoDatum(column.IsNullable()), // is_nullable
+				tree.NewDString(column.GetType().InformationSchemaName()), // data_type
+				characterMaximumLength(column.GetType()),                  // character_maximum_length
+				characterOctetLength(column.GetType()),                    // character_octet_length
+				numericPrecision(column.GetType()),                        // numeric_precision
+				numericPrecisionRadix(column.GetType()),                   // numeric_precision_radix
+				numericScale(column.GetType()),                            // numeric_scale
+				datetimePrecision(column.GetType()),                       // datetime_precision
+				tree.DNull,                                                // interval_type
+				tree.DNull,                                                // interval_precision
+				tree.DNull,                                                // character_set_catalog
+				tree.DNull,                                                // character_set_schema
+				characterSetName(column.GetType()),                        // character_set_name
+				collationCatalog,                                          // collation_catalog
+				collationSchema,                                           // collation_schema
+				collationName,                                             // collation_name
+				tree.DNull,                                                // domain_catalog
+				tree.DNull,                                                // domain_schema
+				tree.DNull,                                                // domain_name
+				dbNameStr,                                                 // udt_catalog
+				udtSchema,                                                 // udt_schema
+				tree.NewDString(column.GetType().PGName()), // udt_name
+				// There are no reference types, so the scope of a column is never
+				// defined and columns are never self-referencing.
+				tree.DNull, // scope_catalog
+				tree.DNull, // scope_schema
+				tree.DNull, // scope_name
+				// Array bounds are not enforced nor recorded, so arrays always have
+				// an unlimited maximum cardinality.
+				tree.DNull, // maximum_cardinality
+				tree.NewDString(columnDTDIdentifier(column)), // dtd_identifier
+				noString,                          // is_self_referencing
+				isIdentity,                        // is_identity
+				identityGeneration,                // identity_generation
+				identityStart,                     // identity_start
+				identityIncrement,                 // identity_increment
+				identityMaximum,                   // identity_maximum
+				identityMinimum,                   // identity_minimum
+				identityCycle,                     // identity_cycle
+				yesOrNoDatum(column.IsComputed()), // is_generated
+				colComputed,                       // generation_expression
+				isUpdatable,                       // is_updatable
+				yesOrNoDatum(column.IsHidden()),   // is_hidden
+				tree.NewDString(column.GetType().SQLString()), // crdb_sql_type
+				colStored,        // crdb_is_stored
+				columnFamily,     // crdb_column_family
+				collationVersion, // crdb_collation_version
+				defaultSequences, // crdb_default_sequences
+				columnType,       // column_type
+				columnKey,        // column_key
+				extra,            // extra
+				mysqlPrivileges,  // privileges
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+// mysqlColumnType returns the full type of a column as reported by MySQL in
+// the column_type column of information_schema.columns, e.g. varchar(10).
+func mysqlColumnType(typ *types.T) string {
+	if typ.UserDefined() {
+		return typ.SQLString()
+	}
+	return strings.ToLower(typ.SQLString())
+}
+
+// mysqlColumnKeys returns the value of the column_key column of
+// information_schema.columns for the indexed columns of a table. As in MySQL,
+// the columns of the primary key are reported as PRI, the columns which are the
+// only column of a unique index as UNI, and the first columns of the other
+// indexes as MUL.
+func mysqlColumnKeys(table catalog.TableDescriptor) map[descpb.ColumnID]string {
+	keys := make(map[descpb.ColumnID]string)
+	for _, idx := range table.PublicNonPrimaryIndexes() {
+		if idx.NumColumns() == 0 {
+			continue
+		}
+		colID := idx.GetColumnID(0)
+		if idx.IsUnique() && !idx.IsPartial() && idx.NumColumns() == 1 {
+			keys[colID] = "UNI"
+		} else if keys[colID] == "" {
+			keys[colID] = "MUL"
+		}
+	}
+	primaryIndex := table.GetPrimaryIndex()
+	for i := 0; i < primaryIndex.NumColumns(); i++ {
+		keys[primaryIndex.GetColumnID(i)] = "PRI"
+	}
+	return keys
+}
+
+// mysqlColumnExtra returns the value of the extra column of
+// information_schema.columns for a column. Columns whose values are generated
+// from a sequence or by unique_rowid() are reported as auto_increment, as they
+// are the closest equivalent of MySQL's AUTO_INCREMENT columns.
+func mysqlColumnExtra(column catalog.Column) string {
+	switch {
+	case column.IsComputed() && column.IsVirtual():
+		return "VIRTUAL GENERATED"
+	case column.IsComputed():
+		return "STORED GENERATED"
+	case column.IsGeneratedAsIdentity() || column.NumUsesSequences() > 0 ||
+		(column.HasDefault() && column.GetDefaultExpr() == "unique_rowid()"):
+		return "auto_increment"
+	}
+	return ""
+}
+
+// mysqlColumnPrivileges returns the value of the privileges column of
+// information_schema.columns for the columns of a table: the comma-separated
+// list of the column privileges held on the table by any of the given roles.
+func mysqlColumnPrivileges(table catalog.TableDescriptor, roles []security.SQLUsername) string {
+	privs := table.GetPrivileges()
+	var held []string
+	for _, priv := range columnPrivileges {
+		for _, role := range roles {
+			if IsOwner(table, role) || privs.CheckPrivilege(role, priv) {
+				held = append(held, strings.ToLower(priv.String()))
+				break
+			}
+		}
+	}
+	return strings.Join(held, ",")
 }
 
 var informationSchemaColumnUDTUsage = virtualSchemaTable{
@@ -535,37 +768,186 @@ https://www.postgresql.org/docs/current/infoschema-column-udt-usage.html`,
 	},
 }
 
-var informationSchemaEnabledRoles = virtualSchemaTable{
-	comment: `roles for the current user
-` + docs.URL("information-schema.html#enabled_roles") + `
-https://www.postgresql.org/docs/9.5/infoschema-enabled-roles.html`,
+// Data type descriptors are identified as in PostgreSQL: the descriptor of a
+// column is identified by the attribute number of the column, the descriptor
+// of a routine parameter by the ordinal position of the parameter, and the
+// descriptor of the element type of an array by the identifier of the array
+// prefixed with "a". These identifiers are only unique within their object.
+
+// columnDTDIdentifier returns the identifier of the data type descriptor of
+// the column.
+func columnDTDIdentifier(column catalog.Column) string {
+	return strconv.FormatUint(uint64(column.GetPGAttributeNum()), 10)
+}
+
+// parameterDTDIdentifier returns the identifier of the data type descriptor
+// of the routine parameter at the given (1-based) ordinal position.
+func parameterDTDIdentifier(ordinal int) string {
+	return strconv.Itoa(ordinal)
+}
+
+// elementDTDIdentifier returns the identifier of the data type descriptor of
+// the elements of the array identified by collectionIdentifier.
+func elementDTDIdentifier(collectionIdentifier string) string {
+	return "a" + collectionIdentifier
+}
+
+var (
+	objectTypeTable   = tree.NewDString("TABLE")
+	objectTypeRoutine = tree.NewDString("ROUTINE")
+)
+
+// Postgres: https://www.postgresql.org/docs/9.5/infoschema-element-types.html
+// MySQL:    missing
+var informationSchemaElementTypes = virtualSchemaTable{
+	comment: `element types of array columns and routine parameters
+https://www.postgresql.org/docs/9.5/infoschema-element-types.html`,
 	schema: `
-CREATE TABLE information_schema.enabled_roles (
-	ROLE_NAME STRING NOT NULL
+CREATE TABLE information_schema.element_types (
+	OBJECT_CATALOG             STRING NOT NULL,
+	OBJECT_SCHEMA              STRING NOT NULL,
+	OBJECT_NAME                STRING NOT NULL,
+	OBJECT_TYPE                STRING NOT NULL,
+	COLLECTION_TYPE_IDENTIFIER STRING NOT NULL,
+	DATA_TYPE                  STRING NOT NULL,
+	CHARACTER_MAXIMUM_LENGTH   INT,
+	CHARACTER_OCTET_LENGTH     INT,
+	CHARACTER_SET_CATALOG      STRING,
+	CHARACTER_SET_SCHEMA       STRING,
+	CHARACTER_SET_NAME         STRING,
+	COLLATION_CATALOG          STRING,
+	COLLATION_SCHEMA           STRING,
+	COLLATION_NAME             STRING,
+	NUMERIC_PRECISION          INT,
+	NUMERIC_PRECISION_RADIX    INT,
+	NUMERIC_SCALE              INT,
+	DATETIME_PRECISION         INT,
+	INTERVAL_TYPE              STRING,
+	INTERVAL_PRECISION         INT,
+	DOMAIN_DEFAULT             STRING,
+	UDT_CATALOG                STRING,
+	UDT_SCHEMA                 STRING,
+	UDT_NAME                   STRING,
+	SCOPE_CATALOG              STRING,
+	SCOPE_SCHEMA               STRING,
+	SCOPE_NAME                 STRING,
+	MAXIMUM_CARDINALITY        INT,
+	DTD_IDENTIFIER             STRING NOT NULL
 )`,
-	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		currentUser := p.SessionData().User()
-		memberMap, err := p.MemberOfWithAdminOption(ctx, currentUser)
-		if err != nil {
-			return err
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		addElementTypeRow := func(
+			dbNameStr, objSchema, objName, objType *tree.DString,
+			collectionIdentifier string, elemType *types.T,
+		) error {
+			collationCatalog := tree.DNull
+			collationSchema := tree.DNull
+			collationName := tree.DNull
+			if locale := elemType.Locale(); locale != "" {
+				collationCatalog = dbNameStr
+				collationSchema = pgCatalogNameDString
+				collationName = tree.NewDString(locale)
+			}
+			udtSchema := pgCatalogNameDString
+			if typeMetaName := elemType.TypeMeta.Name; typeMetaName != nil {
+				udtSchema = tree.NewDString(typeMetaName.Schema)
+			}
+			return addRow(
+				dbNameStr,                             // object_catalog
+				objSchema,                             // object_schema
+				objName,                               // object_name
+				objType,                               // object_type
+				tree.NewDString(collectionIdentifier), // collection_type_identifier
+				tree.NewDString(elemType.InformationSchemaName()), // data_type
+				characterMaximumLength(elemType),                  // character_maximum_length
+				characterOctetLength(elemType),                    // character_octet_length
+				tree.DNull,                                        // character_set_catalog
+				tree.DNull,                                        // character_set_schema
+				characterSetName(elemType),                        // character_set_name
+				collationCatalog,                                  // collation_catalog
+				collationSchema,                                   // collation_schema
+				collationName,                                     // collation_name
+				numericPrecision(elemType),                        // numeric_precision
+				numericPrecisionRadix(elemType),                   // numeric_precision_radix
+				numericScale(elemType),                            // numeric_scale
+				datetimePrecision(elemType),                       // datetime_precision
+				tree.DNull,                                        // interval_type
+				tree.DNull,                                        // interval_precision
+				tree.DNull,                                        // domain_default
+				dbNameStr,                                         // udt_catalog
+				udtSchema,                                         // udt_schema
+				tree.NewDString(elemType.PGName()),                // udt_name
+				tree.DNull,                                        // scope_catalog
+				tree.DNull,                                        // scope_schema
+				tree.DNull,                                        // scope_name
+				tree.DNull,                                        // maximum_cardinality
+				tree.NewDString(elementDTDIdentifier(collectionIdentifier)), // dtd_identifier
+			)
 		}
 
-		// The current user is always listed.
-		if err := addRow(
-			tree.NewDString(currentUser.Normalized()), // role_name: the current user
+		if err := forEachTableDesc(ctx, p, dbContext, virtualMany,
+			func(db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor) error {
+				dbNameStr := tree.NewDString(db.GetName())
+				scNameStr := tree.NewDString(scName)
+				tbNameStr := tree.NewDString(table.GetName())
+				for _, column := range table.PublicColumns() {
+					if column.GetType().Family() != types.ArrayFamily {
+						continue
+					}
+					if err := addElementTypeRow(
+						dbNameStr, scNameStr, tbNameStr, objectTypeTable,
+						columnDTDIdentifier(column), column.GetType().ArrayContents(),
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
 		); err != nil {
 			return err
 		}
 
-		for roleName := range memberMap {
-			if err := addRow(
-				tree.NewDString(roleName.Normalized()), // role_name
-			); err != nil {
-				return err
-			}
-		}
+		routines := builtinRoutines()
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				dbNameStr := tree.NewDString(db.GetName())
+				for _, r := range routines {
+					args, ok := r.overload.Types.(tree.ArgTypes)
+					if !ok {
+						continue
+					}
+					for i, arg := range args {
+						if arg.Typ.Family() != types.ArrayFamily {
+							continue
+						}
+						if err := addElementTypeRow(
+							dbNameStr, pgCatalogNameDString, r.specificName, objectTypeRoutine,
+							parameterDTDIdentifier(i+1), arg.Typ.ArrayContents(),
+						); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
+	},
+}
 
-		return nil
+var informationSchemaEnabledRoles = virtualSchemaTable{
+	comment: `roles for the current user
+` + docs.URL("information-schema.html#enabled_roles") + `
+https://www.postgresql.org/docs/9.5/infoschema-enabled-roles.html`,
+	schema: `
+CREATE TABLE information_schema.enabled_roles (
+	ROLE_NAME STRING NOT NULL
+)`,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		// The current user is always listed, along with the roles it is a
+		// direct or indirect member of.
+		return p.forEachEnabledRole(ctx, p.SessionData().User(), func(role security.SQLUsername) error {
+			return addRow(
+				tree.NewDString(role.Normalized()), // role_name
+			)
+		})
 	},
 }
 
@@ -601,6 +983,19 @@ func characterOctetLength(colType *types.T) tree.Datum {
 	})
 }
 
+var utf8DString = tree.NewDString("UTF8")
+
+// characterSetName returns the name of the character set of a datum if the
+// T is a character string. UTF8 is the only available character set; its
+// catalog and schema are NULL, as in information_schema.character_sets.
+func characterSetName(colType *types.T) tree.Datum {
+	switch colType.Family() {
+	case types.StringFamily, types.CollatedStringFamily:
+		return utf8DString
+	}
+	return tree.DNull
+}
+
 // numericPrecision returns the declared or implicit precision of numeric
 // data types. Returns false if the data type is not numeric, or if the precision
 // of the numeric type is not bounded.
@@ -669,6 +1064,40 @@ func datetimePrecision(colType *types.T) tree.Datum {
 	})
 }
 
+// makeConstraintNameIndex returns a virtual index on the constraint_name
+// column of a table describing constraints, given the function populating the
+// table with the rows of the constraints with a given name. Catalog tools
+// commonly join table_constraints, key_column_usage and
+// constraint_column_usage on the constraint names, and the index allows each
+// lookup to only generate the rows of the matching constraints rather than
+// the whole table.
+func makeConstraintNameIndex(
+	populate func(
+		ctx context.Context,
+		p *planner,
+		dbContext catalog.DatabaseDescriptor,
+		conNameFilter *string,
+		addRow func(...tree.Datum) error,
+	) error,
+) virtualIndex {
+	return virtualIndex{
+		populate: func(ctx context.Context, constraint tree.Datum, p *planner, db catalog.DatabaseDescriptor,
+			addRow func(...tree.Datum) error) (bool, error) {
+			d := tree.UnwrapDatum(p.EvalContext(), constraint)
+			if d == tree.DNull {
+				return false, nil
+			}
+			conName := string(tree.MustBeDString(d))
+			matched := false
+			err := populate(ctx, p, db, &conName, func(row ...tree.Datum) error {
+				matched = true
+				return addRow(row...)
+			})
+			return matched, err
+		},
+	}
+}
+
 var informationSchemaConstraintColumnUsageTable = virtualSchemaTable{
 	comment: `columns usage by constraints
 https://www.postgresql.org/docs/9.5/infoschema-constraint-column-usage.html`,
@@ -680,54 +1109,158 @@ CREATE TABLE information_schema.constraint_column_usage (
 	COLUMN_NAME        STRING NOT NULL,
 	CONSTRAINT_CATALOG STRING NOT NULL,
 	CONSTRAINT_SCHEMA  STRING NOT NULL,
-	CONSTRAINT_NAME    STRING NOT NULL
+	CONSTRAINT_NAME    STRING NOT NULL,
+	INDEX(constraint_name)
 )`,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return forEachTableDescWithTableLookup(ctx, p, dbContext, hideVirtual /* no constraints in virtual tables */, func(
-			db catalog.DatabaseDescriptor,
-			scName string,
-			table catalog.TableDescriptor,
-			tableLookup tableLookupFn,
-		) error {
-			conInfo, err := table.GetConstraintInfoWithLookup(tableLookup.getTableByID)
-			if err != nil {
-				return err
-			}
-			scNameStr := tree.NewDString(scName)
-			dbNameStr := tree.NewDString(db.GetName())
+		return populateConstraintColumnUsage(ctx, p, dbContext, nil /* conNameFilter */, addRow)
+	},
+	indexes: []virtualIndex{makeConstraintNameIndex(populateConstraintColumnUsage)},
+}
 
-			for conName, con := range conInfo {
-				conTable := table
-				conCols := con.Columns
-				conNameStr := tree.NewDString(conName)
-				if con.Kind == descpb.ConstraintTypeFK {
-					// For foreign key constraint, constraint_column_usage
-					// identifies the table/columns that the foreign key
-					// references.
-					conTable = tabledesc.NewBuilder(con.ReferencedTable).BuildImmutableTable()
-					conCols, err = conTable.NamesForColumnIDs(con.FK.ReferencedColumnIDs)
-					if err != nil {
-						return err
-					}
+// populateConstraintColumnUsage populates information_schema.constraint_column_usage.
+// If conNameFilter is non-nil, only the rows of the constraints with that name
+// are added.
+func populateConstraintColumnUsage(
+	ctx context.Context,
+	p *planner,
+	dbContext catalog.DatabaseDescriptor,
+	conNameFilter *string,
+	addRow func(...tree.Datum) error,
+) error {
+	return forEachTableDescWithTableLookup(ctx, p, dbContext, hideVirtual /* no constraints in virtual tables */, func(
+		db catalog.DatabaseDescriptor,
+		scName string,
+		table catalog.TableDescriptor,
+		tableLookup tableLookupFn,
+	) error {
+		conInfo, err := table.GetConstraintInfoWithLookup(tableLookup.getTableByID)
+		if err != nil {
+			return err
+		}
+		scNameStr := tree.NewDString(scName)
+		dbNameStr := tree.NewDString(db.GetName())
+
+		for conName, con := range conInfo {
+			if conNameFilter != nil && conName != *conNameFilter {
+				continue
+			}
+			conTable := table
+			conCols := con.Columns
+			conNameStr := tree.NewDString(conName)
+			if con.Kind == descpb.ConstraintTypeFK {
+				// For foreign key constraint, constraint_column_usage
+				// identifies the table/columns that the foreign key
+				// references.
+				conTable = tabledesc.NewBuilder(con.ReferencedTable).BuildImmutableTable()
+				conCols, err = conTable.NamesForColumnIDs(con.FK.ReferencedColumnIDs)
+				if err != nil {
+					return err
 				}
-				tableNameStr := tree.NewDString(conTable.GetName())
-				for _, col := range conCols {
-					if err := addRow(
-						dbNameStr,            // table_catalog
-						scNameStr,            // table_schema
-						tableNameStr,         // table_name
-						tree.NewDString(col), // column_name
-						dbNameStr,            // constraint_catalog
-						scNameStr,            // constraint_schema
-						conNameStr,           // constraint_name
-					); err != nil {
-						return err
-					}
+			}
+			tableNameStr := tree.NewDString(conTable.GetName())
+			for _, col := range conCols {
+				if err := addRow(
+					dbNameStr,            // table_catalog
+					scNameStr,            // table_schema
+					tableNameStr,         // table_name
+					tree.NewDString(col), // column_name
+					dbNameStr,            // constraint_catalog
+					scNameStr,            // constraint_schema
+					conNameStr,           // constraint_name
+				); err != nil {
+					return err
 				}
 			}
-			return nil
-		})
-	},
+		}
+		return nil
+	})
+}
+
+// MySQL: https://dev.mysql.com/doc/refman/8.0/en/information-schema-engines-table.html
+var informationSchemaEngines = virtualSchemaTable{
+	comment: `storage engines (MySQL compatibility; only the CockroachDB storage engine is listed)
+https://dev.mysql.com/doc/refman/8.0/en/information-schema-engines-table.html`,
+	schema: vtable.InformationSchemaEngines,
+	populate: func(_ context.Context, _ *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		// Every table is stored by the same engine, which is therefore
+		// reported as the default one.
+		return addRow(
+			tree.NewDString("Pebble"),  // engine
+			tree.NewDString("DEFAULT"), // support
+			tree.NewDString("CockroachDB storage engine, with distributed transactions and savepoints"), // comment
+			yesString, // transactions
+			noString,  // xa
+			yesString, // savepoints
+		)
+	},
+}
+
+var (
+	eventTypeOneTime    = tree.NewDString("ONE TIME")
+	eventTypeRecurring  = tree.NewDString("RECURRING")
+	eventStatusEnabled  = tree.NewDString("ENABLED")
+	eventStatusDisabled = tree.NewDString("DISABLED")
+)
+
+// MySQL: https://dev.mysql.com/doc/refman/8.0/en/information-schema-events-table.html
+var informationSchemaEvents = virtualSchemaTable{
+	comment: `scheduled jobs, e.g. backup schedules (MySQL compatibility; only the schedules owned by the current user are listed, unless it is an admin)
+https://dev.mysql.com/doc/refman/8.0/en/information-schema-events-table.html`,
+	schema: vtable.InformationSchemaEvents,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		isAdmin, err := p.HasAdminRole(ctx)
+		if err != nil {
+			return err
+		}
+		// Beware: we're querying system.scheduled_jobs as root; we need to be
+		// careful to filter out the schedules the current user cannot see.
+		query := `SELECT schedule_id, schedule_name, owner, created, next_run, schedule_expr, executor_type
+FROM system.scheduled_jobs`
+		var args []interface{}
+		if !isAdmin {
+			query += ` WHERE owner = $1`
+			args = append(args, p.User().Normalized())
+		}
+		rows, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.QueryBufferedEx(
+			ctx, "information-schema-events-table", p.txn,
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			query, args...)
+		if err != nil {
+			return err
+		}
+		for _, r := range rows {
+			id, name, owner, created, nextRun, expr, executorType := r[0], r[1], r[2], r[3], r[4], r[5], r[6]
+			// Schedules without a recurrence run once, at their next run.
+			eventType, executeAt := eventTypeRecurring, tree.Datum(tree.DNull)
+			if expr == tree.DNull {
+				eventType, executeAt = eventTypeOneTime, nextRun
+			}
+			// Paused schedules have no next run.
+			status := eventStatusEnabled
+			if nextRun == tree.DNull {
+				status = eventStatusDisabled
+			}
+			if err := addRow(
+				tree.DNull,   // event_catalog
+				tree.DNull,   // event_schema
+				name,         // event_name
+				owner,        // definer
+				eventType,    // event_type
+				executeAt,    // execute_at
+				expr,         // interval_value
+				tree.DNull,   // interval_field
+				status,       // status
+				created,      // created
+				id,           // crdb_schedule_id
+				executorType, // crdb_executor_type
+				nextRun,      // crdb_next_run
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
 }
 
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/key-column-usage-table.html
@@ -745,64 +1278,85 @@ CREATE TABLE information_schema.key_column_usage (
 	TABLE_NAME         STRING NOT NULL,
 	COLUMN_NAME        STRING NOT NULL,
 	ORDINAL_POSITION   INT NOT NULL,
-	POSITION_IN_UNIQUE_CONSTRAINT INT
+	POSITION_IN_UNIQUE_CONSTRAINT INT,
+	INDEX(constraint_name)
 )`,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return forEachTableDescWithTableLookup(ctx, p, dbContext, hideVirtual /* no constraints in virtual tables */, func(
-			db catalog.DatabaseDescriptor,
-			scName string,
-			table catalog.TableDescriptor,
-			tableLookup tableLookupFn,
-		) error {
-			conInfo, err := table.GetConstraintInfoWithLookup(tableLookup.getTableByID)
-			if err != nil {
-				return err
+		return populateKeyColumnUsage(ctx, p, dbContext, nil /* conNameFilter */, addRow)
+	},
+	indexes: []virtualIndex{makeConstraintNameIndex(populateKeyColumnUsage)},
+}
+
+// populateKeyColumnUsage populates information_schema.key_column_usage. If
+// conNameFilter is non-nil, only the rows of the constraints with that name
+// are added.
+func populateKeyColumnUsage(
+	ctx context.Context,
+	p *planner,
+	dbContext catalog.DatabaseDescriptor,
+	conNameFilter *string,
+	addRow func(...tree.Datum) error,
+) error {
+	return forEachTableDescWithTableLookup(ctx, p, dbContext, hideVirtual /* no constraints in virtual tables */, func(
+		db catalog.DatabaseDescriptor,
+		scName string,
+		table catalog.TableDescriptor,
+		tableLookup tableLookupFn,
+	) error {
+		conInfo, err := table.GetConstraintInfoWithLookup(tableLookup.getTableByID)
+		if err != nil {
+			return err
+		}
+		dbNameStr := tree.NewDString(db.GetName())
+		scNameStr := tree.NewDString(scName)
+		tbNameStr := tree.NewDString(table.GetName())
+		for conName, con := range conInfo {
+			if conNameFilter != nil && conName != *conNameFilter {
+				continue
+			}
+			// Only Primary Key, Foreign Key, and Unique constraints are included.
+			switch con.Kind {
+			case descpb.ConstraintTypePK:
+			case descpb.ConstraintTypeFK:
+			case descpb.ConstraintTypeUnique:
+			default:
+				continue
 			}
-			dbNameStr := tree.NewDString(db.GetName())
-			scNameStr := tree.NewDString(scName)
-			tbNameStr := tree.NewDString(table.GetName())
-			for conName, con := range conInfo {
-				// Only Primary Key, Foreign Key, and Unique constraints are included.
-				switch con.Kind {
-				case descpb.ConstraintTypePK:
-				case descpb.ConstraintTypeFK:
-				case descpb.ConstraintTypeUnique:
-				default:
-					continue
-				}
 
-				cstNameStr := tree.NewDString(conName)
+			cstNameStr := tree.NewDString(conName)
 
-				for pos, col := range con.Columns {
-					ordinalPos := tree.NewDInt(tree.DInt(pos + 1))
-					uniquePos := tree.DNull
-					if con.Kind == descpb.ConstraintTypeFK {
-						uniquePos = ordinalPos
-					}
-					if err := addRow(
-						dbNameStr,            // constraint_catalog
-						scNameStr,            // constraint_schema
-						cstNameStr,           // constraint_name
-						dbNameStr,            // table_catalog
-						scNameStr,            // table_schema
-						tbNameStr,            // table_name
-						tree.NewDString(col), // column_name
-						ordinalPos,           // ordinal_position, 1-indexed
-						uniquePos,            // position_in_unique_constraint
-					); err != nil {
-						return err
-					}
+			for pos, col := range con.Columns {
+				// As in Postgres, the ordinal position is the position of the
+				// column in the key, not its attribute number, so it is not
+				// affected by the columns dropped from the table.
+				ordinalPos := tree.NewDInt(tree.DInt(pos + 1))
+				uniquePos := tree.DNull
+				if con.Kind == descpb.ConstraintTypeFK {
+					uniquePos = ordinalPos
+				}
+				if err := addRow(
+					dbNameStr,            // constraint_catalog
+					scNameStr,            // constraint_schema
+					cstNameStr,           // constraint_name
+					dbNameStr,            // table_catalog
+					scNameStr,            // table_schema
+					tbNameStr,            // table_name
+					tree.NewDString(col), // column_name
+					ordinalPos,           // ordinal_position, 1-indexed
+					uniquePos,            // position_in_unique_constraint
+				); err != nil {
+					return err
 				}
 			}
-			return nil
-		})
-	},
+		}
+		return nil
+	})
 }
 
 // Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-parameters.html
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/parameters-table.html
 var informationSchemaParametersTable = virtualSchemaTable{
-	comment: `built-in function parameters (empty - introspection not yet supported)
+	comment: `built-in function parameters (incomplete; variadic parameters are not listed)
 https://www.postgresql.org/docs/9.5/infoschema-parameters.html`,
 	schema: `
 CREATE TABLE information_schema.parameters (
@@ -840,9 +1394,70 @@ CREATE TABLE information_schema.parameters (
 	PARAMETER_DEFAULT STRING
 )`,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+		routines := builtinRoutines()
+		inString := tree.NewDString("IN")
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				dbNameStr := tree.NewDString(db.GetName())
+				for _, r := range routines {
+					args, ok := r.overload.Types.(tree.ArgTypes)
+					if !ok {
+						continue
+					}
+					for i, arg := range args {
+						paramName := tree.DNull
+						if arg.Name != "" {
+							paramName = tree.NewDString(arg.Name)
+						}
+						collationCatalog := tree.DNull
+						collationSchema := tree.DNull
+						collationName := tree.DNull
+						if locale := arg.Typ.Locale(); locale != "" {
+							collationCatalog = dbNameStr
+							collationSchema = pgCatalogNameDString
+							collationName = tree.NewDString(locale)
+						}
+						if err := addRow(
+							dbNameStr,                    // specific_catalog
+							pgCatalogNameDString,         // specific_schema
+							r.specificName,               // specific_name
+							tree.NewDInt(tree.DInt(i+1)), // ordinal_position
+							inString,                     // parameter_mode
+							noString,                     // is_result
+							noString,                     // as_locator
+							paramName,                    // parameter_name
+							tree.NewDString(arg.Typ.InformationSchemaName()), // data_type
+							characterMaximumLength(arg.Typ),                  // character_maximum_length
+							characterOctetLength(arg.Typ),                    // character_octet_length
+							tree.DNull,                                       // character_set_catalog
+							tree.DNull,                                       // character_set_schema
+							characterSetName(arg.Typ),                        // character_set_name
+							collationCatalog,                                 // collation_catalog
+							collationSchema,                                  // collation_schema
+							collationName,                                    // collation_name
+							numericPrecision(arg.Typ),                        // numeric_precision
+							numericPrecisionRadix(arg.Typ),                   // numeric_precision_radix
+							numericScale(arg.Typ),                            // numeric_scale
+							datetimePrecision(arg.Typ),                       // datetime_precision
+							tree.DNull,                                       // interval_type
+							tree.DNull,                                       // interval_precision
+							dbNameStr,                                        // udt_catalog
+							pgCatalogNameDString,                             // udt_schema
+							tree.NewDString(arg.Typ.PGName()),                // udt_name
+							tree.DNull,                                       // scope_catalog
+							tree.DNull,                                       // scope_schema
+							tree.DNull,                                       // scope_name
+							tree.DNull,                                       // maximum_cardinality
+							tree.NewDString(parameterDTDIdentifier(i+1)), // dtd_identifier
+							tree.DNull, // parameter_default
+						); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
 	},
-	unimplemented: true,
 }
 
 var (
@@ -958,10 +1573,9 @@ CREATE TABLE information_schema.role_table_grants (
 	IS_GRANTABLE   STRING,
 	WITH_HIERARCHY STRING
 )`,
-	// This is the same as information_schema.table_privileges. In postgres, this virtual table does
-	// not show tables with grants provided through PUBLIC, but table_privileges does.
-	// Since we don't have the PUBLIC concept, the two virtual tables are identical.
-	populate: populateTablePrivileges,
+	// This is the same as information_schema.table_privileges, except that,
+	// as in postgres, it does not show grants provided through PUBLIC.
+	populate: makePopulateFromTableRows(roleTableGrantsTableRows),
 }
 
 // MySQL:    https://dev.mysql.com/doc/mysql-infoschema-excerpt/5.7/en/routines-table.html
@@ -1065,19 +1679,40 @@ var informationSchemaSchemataTable = virtualSchemaTable{
 https://www.postgresql.org/docs/9.5/infoschema-schemata.html`,
 	schema: vtable.InformationSchemaSchemata,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		commentMap, err := getCommentsByObject(ctx, p, keys.SchemaCommentType)
+		if err != nil {
+			return err
+		}
 		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
 			func(db catalog.DatabaseDescriptor) error {
 				return forEachSchema(ctx, p, db, func(sc catalog.ResolvedSchema) error {
+					schemaOwner := tree.DNull
+					if owner, ok := getSchemaOwner(sc); ok {
+						schemaOwner = tree.NewDString(owner.Normalized())
+					}
+					// Only user-defined schemas can be commented.
+					comment := tree.DNull
+					if sc.Kind == catalog.SchemaUserDefined {
+						if d, ok := commentMap[sc.ID][0]; ok {
+							comment = d
+						}
+					}
 					return addRow(
 						tree.NewDString(db.GetName()), // catalog_name
 						tree.NewDString(sc.Name),      // schema_name
+						schemaOwner,                   // schema_owner
 						tree.DNull,                    // default_character_set_name
 						tree.DNull,                    // sql_path
 						yesOrNoDatum(sc.Kind == catalog.SchemaUserDefined), // crdb_is_user_defined
+						comment, // crdb_comment
 					)
 				})
 			})
 	},
+	rowFilter: func(_ context.Context, p *planner, row tree.Datums) (bool, error) {
+		return !p.SessionData().ExcludeOtherTempSchemasFromInformationSchema ||
+			!isOtherSessionTemporarySchema(p, string(tree.MustBeDString(row[1]))), nil
+	},
 }
 
 // Custom; PostgreSQL has data_type_privileges, which only shows one row per type,
@@ -1092,64 +1727,65 @@ CREATE TABLE information_schema.type_privileges (
 	TYPE_CATALOG    STRING NOT NULL,
 	TYPE_SCHEMA     STRING NOT NULL,
 	TYPE_NAME       STRING NOT NULL,
-	PRIVILEGE_TYPE  STRING NOT NULL
+	PRIVILEGE_TYPE  STRING NOT NULL,
+	IS_GRANTABLE    STRING
 )`,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		visibility, err := makeGrantVisibility(ctx, p)
+		if err != nil {
+			return err
+		}
 		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
 			func(db catalog.DatabaseDescriptor) error {
 				dbNameStr := tree.NewDString(db.GetName())
 				pgCatalogStr := tree.NewDString("pg_catalog")
-
-				// Generate one for each existing type.
-				for _, typ := range types.OidToType {
-					for _, it := range []struct {
-						grantee   *tree.DString
-						privilege *tree.DString
-					}{
-						{tree.NewDString(security.RootUser), tree.NewDString(privilege.ALL.String())},
-						{tree.NewDString(security.AdminRole), tree.NewDString(privilege.ALL.String())},
-						{tree.NewDString(security.PublicRole), tree.NewDString(privilege.USAGE.String())},
-					} {
-						typeNameStr := tree.NewDString(typ.Name())
-						if err := addRow(
-							it.grantee,
-							dbNameStr,
-							pgCatalogStr,
-							typeNameStr,
-							it.privilege,
-						); err != nil {
-							return err
+				addTypeRows := func(scNameStr, typeNameStr tree.Datum, privs *descpb.PrivilegeDescriptor) error {
+					for _, u := range privs.Users {
+						if !visibility.isVisible(privs, u) {
+							continue
 						}
-					}
-				}
-
-				// And for all user defined types.
-				return forEachTypeDesc(ctx, p, db, func(db catalog.DatabaseDescriptor, sc string, typeDesc catalog.TypeDescriptor) error {
-					scNameStr := tree.NewDString(sc)
-					typeNameStr := tree.NewDString(typeDesc.GetName())
-					// TODO(knz): This should filter for the current user, see
-					// https://github.com/cockroachdb/cockroach/issues/35572
-					privs := typeDesc.GetPrivileges().Show(privilege.Type)
-					for _, u := range privs {
-						userNameStr := tree.NewDString(u.User.Normalized())
-						for _, priv := range u.Privileges {
+						userNameStr := tree.NewDString(u.User().Normalized())
+						for _, priv := range privilege.ListFromBitField(u.Privileges, privilege.Type).SortedNames() {
 							if err := addRow(
 								userNameStr,           // grantee
 								dbNameStr,             // type_catalog
 								scNameStr,             // type_schema
 								typeNameStr,           // type_name
 								tree.NewDString(priv), // privilege_type
+								yesOrNoDatum(u.CanGrant(privilege.ByName[priv])), // is_grantable
 							); err != nil {
 								return err
 							}
 						}
 					}
 					return nil
+				}
+
+				// Generate rows for each built-in type.
+				for _, typ := range types.OidToType {
+					if err := addTypeRows(
+						pgCatalogStr, tree.NewDString(typ.Name()), builtinTypePrivileges,
+					); err != nil {
+						return err
+					}
+				}
+
+				// And for all user defined types.
+				return forEachTypeDesc(ctx, p, db, func(db catalog.DatabaseDescriptor, sc string, typeDesc catalog.TypeDescriptor) error {
+					return addTypeRows(
+						tree.NewDString(sc), tree.NewDString(typeDesc.GetName()), typeDesc.GetPrivileges(),
+					)
 				})
 			})
 	},
 }
 
+// builtinTypePrivileges are the privileges on the built-in types, which have
+// no descriptor. Every user can use them, and they cannot be altered.
+var builtinTypePrivileges = descpb.NewPrivilegeDescriptor(
+	security.PublicRoleName(), privilege.List{privilege.USAGE}, security.NodeUserName(),
+)
+
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/schema-privileges-table.html
 var informationSchemaSchemataTablePrivileges = virtualSchemaTable{
 	comment: `schema privileges (incomplete; may contain excess users or roles)
@@ -1163,42 +1799,28 @@ CREATE TABLE information_schema.schema_privileges (
 	IS_GRANTABLE    STRING
 )`,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		visibility, err := makeGrantVisibility(ctx, p)
+		if err != nil {
+			return err
+		}
 		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
 			func(db catalog.DatabaseDescriptor) error {
 				return forEachSchema(ctx, p, db, func(sc catalog.ResolvedSchema) error {
-					var privs []descpb.UserPrivilegeString
-					if sc.Kind == catalog.SchemaUserDefined {
-						// User defined schemas have their own privileges.
-						privs = sc.Desc.GetPrivileges().Show(privilege.Schema)
-					} else {
-						// Other schemas inherit from the parent database.
-						privs = db.GetPrivileges().Show(privilege.Database)
-					}
+					privs := schemaPrivileges(db, sc)
 					dbNameStr := tree.NewDString(db.GetName())
 					scNameStr := tree.NewDString(sc.Name)
-					// TODO(knz): This should filter for the current user, see
-					// https://github.com/cockroachdb/cockroach/issues/35572
-					for _, u := range privs {
-						userNameStr := tree.NewDString(u.User.Normalized())
-						for _, priv := range u.Privileges {
-							privKind := privilege.ByName[priv]
-							// Non-user defined schemas inherit privileges from the database,
-							// but the USAGE privilege is conferred by having SELECT privilege
-							// on the database. (There is no SELECT privilege on schemas.)
-							if sc.Kind != catalog.SchemaUserDefined {
-								if privKind == privilege.SELECT {
-									priv = privilege.USAGE.String()
-								} else if !privilege.SchemaPrivileges.Contains(privKind) {
-									continue
-								}
-							}
-
+					for _, u := range privs.Users {
+						if !visibility.isVisible(privs, u) {
+							continue
+						}
+						userNameStr := tree.NewDString(u.User().Normalized())
+						for _, priv := range privilege.ListFromBitField(u.Privileges, privilege.Schema).SortedNames() {
 							if err := addRow(
 								userNameStr,           // grantee
 								dbNameStr,             // table_catalog
 								scNameStr,             // table_schema
 								tree.NewDString(priv), // privilege_type
-								tree.DNull,            // is_grantable
+								yesOrNoDatum(u.CanGrant(privilege.ByName[priv])), // is_grantable
 							); err != nil {
 								return err
 							}
@@ -1210,6 +1832,46 @@ CREATE TABLE information_schema.schema_privileges (
 	},
 }
 
+// schemaPrivileges returns the privileges on the given schema. User defined
+// schemas have their own privileges. The public and temporary schemas have no
+// descriptor: every user can use them, and creating objects in them requires
+// the CREATE privilege on the parent database, so their privileges are derived
+// from those on the database. Every user can use the virtual schemas, but no
+// user can create objects in them.
+func schemaPrivileges(
+	db catalog.DatabaseDescriptor, sc catalog.ResolvedSchema,
+) *descpb.PrivilegeDescriptor {
+	switch sc.Kind {
+	case catalog.SchemaUserDefined:
+		return sc.Desc.GetPrivileges()
+	case catalog.SchemaVirtual:
+		return descpb.NewPrivilegeDescriptor(
+			security.PublicRoleName(), privilege.List{privilege.USAGE}, security.NodeUserName(),
+		)
+	}
+	dbPrivs := db.GetPrivileges()
+	privs := &descpb.PrivilegeDescriptor{
+		OwnerProto: dbPrivs.OwnerProto,
+		Version:    dbPrivs.Version,
+	}
+	// The privileges on the database which carry over to its public schema.
+	// ALL on the database is ALL on the schema.
+	mask := privilege.List{privilege.ALL, privilege.GRANT, privilege.CREATE}.ToBitField()
+	for _, u := range dbPrivs.Users {
+		if u.Privileges&mask == 0 {
+			continue
+		}
+		privs.Users = append(privs.Users, descpb.UserPrivileges{
+			UserProto:       u.UserProto,
+			Privileges:      u.Privileges & mask,
+			WithGrantOption: u.WithGrantOption & mask,
+			GrantorProto:    u.GrantorProto,
+		})
+	}
+	privs.Grant(security.PublicRoleName(), privilege.List{privilege.USAGE})
+	return privs
+}
+
 var (
 	indexDirectionNA   = tree.NewDString("N/A")
 	indexDirectionAsc  = tree.NewDString(descpb.IndexDescriptor_ASC.String())
@@ -1243,32 +1905,234 @@ CREATE TABLE information_schema.sequences (
     MINIMUM_VALUE            STRING NOT NULL,
     MAXIMUM_VALUE            STRING NOT NULL,
     INCREMENT                STRING NOT NULL,
-    CYCLE_OPTION             STRING NOT NULL
+    CYCLE_OPTION             STRING NOT NULL,
+    CRDB_OWNED_BY_DATABASE   STRING, -- CockroachDB extension: the database of the OWNED BY column.
+    CRDB_OWNED_BY_SCHEMA     STRING, -- CockroachDB extension: the schema of the OWNED BY column.
+    CRDB_OWNED_BY_TABLE      STRING, -- CockroachDB extension: the table of the OWNED BY column.
+    CRDB_OWNED_BY_COLUMN     STRING  -- CockroachDB extension: the column the sequence is OWNED BY.
 )`,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* no sequences in virtual schemas */
-			func(db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor) error {
+		return forEachTableDescWithTableLookup(ctx, p, dbContext, hideVirtual, /* no sequences in virtual schemas */
+			func(db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor, lookup tableLookupFn) error {
 				if !table.IsSequence() {
 					return nil
 				}
+				typ := table.GetSequenceOpts().IntegerType()
+				ownerDB, ownerSchema, ownerTable, ownerColumn := sequenceOwnerDatums(table, lookup)
 				return addRow(
-					tree.NewDString(db.GetName()),    // catalog
-					tree.NewDString(scName),          // schema
-					tree.NewDString(table.GetName()), // name
-					tree.NewDString("bigint"),        // type
-					tree.NewDInt(64),                 // numeric precision
-					tree.NewDInt(2),                  // numeric precision radix
-					tree.NewDInt(0),                  // numeric scale
+					tree.NewDString(db.GetName()),          // catalog
+					tree.NewDString(scName),                // schema
+					tree.NewDString(table.GetName()),       // name
+					tree.NewDString(typ.SQLStandardName()), // type
+					tree.NewDInt(tree.DInt(typ.Width())),   // numeric precision
+					tree.NewDInt(2),                        // numeric precision radix
+					tree.NewDInt(0),                        // numeric scale
 					tree.NewDString(strconv.FormatInt(table.GetSequenceOpts().Start, 10)),     // start value
 					tree.NewDString(strconv.FormatInt(table.GetSequenceOpts().MinValue, 10)),  // min value
 					tree.NewDString(strconv.FormatInt(table.GetSequenceOpts().MaxValue, 10)),  // max value
 					tree.NewDString(strconv.FormatInt(table.GetSequenceOpts().Increment, 10)), // increment
-					noString, // cycle
+					yesOrNoDatum(table.GetSequenceOpts().Cycle),                               // cycle
+					ownerDB,     // crdb_owned_by_database
+					ownerSchema, // crdb_owned_by_schema
+					ownerTable,  // crdb_owned_by_table
+					ownerColumn, // crdb_owned_by_column
 				)
 			})
 	},
 }
 
+// sequenceOwnerDatums returns the database, schema, table and column names of
+// the column the given sequence is OWNED BY, or NULLs if it has no owner. An
+// owner that can no longer be found (see #50711) is reported as no owner.
+func sequenceOwnerDatums(
+	seq catalog.TableDescriptor, lookup tableLookupFn,
+) (db, schema, table, column tree.Datum) {
+	db, schema, table, column = tree.DNull, tree.DNull, tree.DNull, tree.DNull
+	if !seq.GetSequenceOpts().HasOwner() {
+		return db, schema, table, column
+	}
+	owner := seq.GetSequenceOpts().SequenceOwner
+	ownerDesc, err := lookup.getTableByID(owner.OwnerTableID)
+	if err != nil {
+		return db, schema, table, column
+	}
+	col, err := ownerDesc.FindColumnWithID(owner.OwnerColumnID)
+	if err != nil {
+		return db, schema, table, column
+	}
+	schemaName, err := lookup.getSchemaNameByID(ownerDesc.GetParentSchemaID())
+	if err != nil {
+		return db, schema, table, column
+	}
+	return tree.NewDString(lookup.getDatabaseName(ownerDesc)),
+		tree.NewDString(schemaName),
+		tree.NewDString(ownerDesc.GetName()),
+		tree.NewDString(col.GetName())
+}
+
+// builtinRoutine is an overload of a built-in function, as listed in the
+// routine tables.
+type builtinRoutine struct {
+	name, specificName *tree.DString
+	overload           *tree.Overload
+}
+
+// builtinRoutines returns the overloads of all the built-in functions. The
+// specific name of a routine is its name followed by its OID, as in postgres.
+func builtinRoutines() []builtinRoutine {
+	var routines []builtinRoutine
+	h := makeOidHasher()
+	for _, name := range builtins.AllBuiltinNames {
+		if isUppercaseBuiltinName(name) {
+			continue
+		}
+		_, overloads := builtins.GetBuiltinProperties(name)
+		for i := range overloads {
+			fnOid := h.BuiltinOid(name, &overloads[i])
+			routines = append(routines, builtinRoutine{
+				name:         tree.NewDString(name),
+				specificName: tree.NewDString(fmt.Sprintf("%s_%d", name, fnOid.DInt)),
+				overload:     &overloads[i],
+			})
+		}
+	}
+	return routines
+}
+
+// Postgres: https://www.postgresql.org/docs/9.5/infoschema-routine-privileges.html
+// MySQL:    missing
+var informationSchemaRoutinePrivileges = virtualSchemaTable{
+	comment: `routine privileges (incomplete; only built-in functions are listed)
+https://www.postgresql.org/docs/9.5/infoschema-routine-privileges.html`,
+	schema: `
+CREATE TABLE information_schema.routine_privileges (
+	GRANTOR          STRING,
+	GRANTEE          STRING NOT NULL,
+	SPECIFIC_CATALOG STRING NOT NULL,
+	SPECIFIC_SCHEMA  STRING NOT NULL,
+	SPECIFIC_NAME    STRING NOT NULL,
+	ROUTINE_CATALOG  STRING NOT NULL,
+	ROUTINE_SCHEMA   STRING NOT NULL,
+	ROUTINE_NAME     STRING NOT NULL,
+	PRIVILEGE_TYPE   STRING NOT NULL,
+	IS_GRANTABLE     STRING
+)`,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		visibility, err := makeGrantVisibility(ctx, p)
+		if err != nil {
+			return err
+		}
+
+		// Built-in functions cannot be granted on: root and admin hold all
+		// privileges on them, and EXECUTE is granted to public. There is no
+		// EXECUTE privilege to grant, as there are no user-defined functions.
+		type grant struct {
+			grantee     *tree.DString
+			privilege   *tree.DString
+			isGrantable tree.Datum
+		}
+		var grants []grant
+		for _, it := range []struct {
+			grantee     security.SQLUsername
+			privilege   string
+			isGrantable bool
+		}{
+			{security.RootUserName(), privilege.ALL.String(), true},
+			{security.AdminRoleName(), privilege.ALL.String(), true},
+			{security.PublicRoleName(), "EXECUTE", false},
+		} {
+			if !visibility.isGranteeVisible(it.grantee) {
+				continue
+			}
+			grants = append(grants, grant{
+				grantee:     tree.NewDString(it.grantee.Normalized()),
+				privilege:   tree.NewDString(it.privilege),
+				isGrantable: yesOrNoDatum(it.isGrantable),
+			})
+		}
+
+		routines := builtinRoutines()
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				dbNameStr := tree.NewDString(db.GetName())
+				for _, r := range routines {
+					for _, g := range grants {
+						if err := addRow(
+							tree.DNull,           // grantor
+							g.grantee,            // grantee
+							dbNameStr,            // specific_catalog
+							pgCatalogNameDString, // specific_schema
+							r.specificName,       // specific_name
+							dbNameStr,            // routine_catalog
+							pgCatalogNameDString, // routine_schema
+							r.name,               // routine_name
+							g.privilege,          // privilege_type
+							g.isGrantable,        // is_grantable
+						); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
+	},
+}
+
+// Postgres: https://www.postgresql.org/docs/9.5/infoschema-usage-privileges.html
+// MySQL:    missing
+var informationSchemaUsagePrivileges = virtualSchemaTable{
+	comment: `USAGE privileges granted on sequences
+https://www.postgresql.org/docs/9.5/infoschema-usage-privileges.html`,
+	schema: `
+CREATE TABLE information_schema.usage_privileges (
+	GRANTOR        STRING,
+	GRANTEE        STRING NOT NULL,
+	OBJECT_CATALOG STRING NOT NULL,
+	OBJECT_SCHEMA  STRING NOT NULL,
+	OBJECT_NAME    STRING NOT NULL,
+	OBJECT_TYPE    STRING NOT NULL,
+	PRIVILEGE_TYPE STRING NOT NULL,
+	IS_GRANTABLE   STRING
+)`,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		visibility, err := makeGrantVisibility(ctx, p)
+		if err != nil {
+			return err
+		}
+		sequenceStr := tree.NewDString("SEQUENCE")
+		usageStr := tree.NewDString(privilege.USAGE.String())
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* no sequences in virtual schemas */
+			func(db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor) error {
+				if !table.IsSequence() {
+					return nil
+				}
+				dbNameStr := tree.NewDString(db.GetName())
+				scNameStr := tree.NewDString(scName)
+				seqNameStr := tree.NewDString(table.GetName())
+				privs := table.GetPrivileges()
+				for _, u := range privs.Users {
+					if !privs.CheckPrivilege(u.User(), privilege.USAGE) || !visibility.isVisible(privs, u) {
+						continue
+					}
+					isGrantable := yesOrNoDatum(u.CanGrant(privilege.USAGE))
+					if err := addRow(
+						grantorDatum(u),                        // grantor
+						tree.NewDString(u.User().Normalized()), // grantee
+						dbNameStr,                              // object_catalog
+						scNameStr,                              // object_schema
+						seqNameStr,                             // object_name
+						sequenceStr,                            // object_type
+						usageStr,                               // privilege_type
+						isGrantable,                            // is_grantable
+					); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+	},
+}
+
 // Postgres: missing
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/statistics-table.html
 var informationSchemaStatisticsTable = virtualSchemaTable{
@@ -1286,9 +2150,14 @@ CREATE TABLE information_schema.statistics (
 	COLUMN_NAME   STRING NOT NULL,
 	"COLLATION"   STRING,
 	CARDINALITY   INT,
+	INDEX_TYPE    STRING NOT NULL,
 	DIRECTION     STRING NOT NULL,
 	STORING       STRING NOT NULL,
-	IMPLICIT      STRING NOT NULL
+	IMPLICIT      STRING NOT NULL,
+	IS_VISIBLE    STRING NOT NULL,
+	CRDB_PREDICATE  STRING, -- CockroachDB extension: the predicate of a partial index.
+	CRDB_EXPRESSION STRING, -- CockroachDB extension: the expression of a virtual computed key column.
+	CRDB_SHARD_BUCKETS INT  -- CockroachDB extension: the bucket count of a hash-sharded index.
 )`,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables have no indexes */
@@ -1298,22 +2167,27 @@ CREATE TABLE information_schema.statistics (
 				tbNameStr := tree.NewDString(table.GetName())
 
 				appendRow := func(index *descpb.IndexDescriptor, colName string, sequence int,
-					direction tree.Datum, isStored, isImplicit bool,
+					direction tree.Datum, isStored, isImplicit bool, predicate, shardBuckets, expression tree.Datum,
 				) error {
 					return addRow(
-						dbNameStr,                         // table_catalog
-						scNameStr,                         // table_schema
-						tbNameStr,                         // table_name
-						yesOrNoDatum(!index.Unique),       // non_unique
-						scNameStr,                         // index_schema
-						tree.NewDString(index.Name),       // index_name
-						tree.NewDInt(tree.DInt(sequence)), // seq_in_index
-						tree.NewDString(colName),          // column_name
-						tree.DNull,                        // collation
-						tree.DNull,                        // cardinality
-						direction,                         // direction
-						yesOrNoDatum(isStored),            // storing
-						yesOrNoDatum(isImplicit),          // implicit
+						dbNameStr,                            // table_catalog
+						scNameStr,                            // table_schema
+						tbNameStr,                            // table_name
+						yesOrNoDatum(!index.Unique),          // non_unique
+						scNameStr,                            // index_schema
+						tree.NewDString(index.Name),          // index_name
+						tree.NewDInt(tree.DInt(sequence)),    // seq_in_index
+						tree.NewDString(colName),             // column_name
+						tree.DNull,                           // collation
+						tree.DNull,                           // cardinality
+						tree.NewDString(index.Type.String()), // index_type
+						direction,                            // direction
+						yesOrNoDatum(isStored),               // storing
+						yesOrNoDatum(isImplicit),             // implicit
+						yesOrNoDatum(!index.NotVisible),      // is_visible
+						predicate,                            // crdb_predicate
+						expression,                           // crdb_expression
+						shardBuckets,                         // crdb_shard_buckets
 					)
 				}
 
@@ -1338,9 +2212,40 @@ CREATE TABLE information_schema.statistics (
 						}
 					}
 
+					predicate := tree.DNull
+					if index.IsPartial() {
+						pred, err := schemaexpr.FormatExprForDisplay(ctx, table, index.GetPredicate(), &p.semaCtx, tree.FmtSimple)
+						if err != nil {
+							return err
+						}
+						predicate = tree.NewDString(pred)
+					}
+					// The shard column of a hash-sharded index precedes the
+					// user-specified columns and is reported as implicit (see
+					// ExplicitColumnStartIdx); its bucket count is reported on
+					// every row of the index.
+					shardBuckets := tree.DNull
+					if index.IsSharded() {
+						shardBuckets = tree.NewDInt(tree.DInt(index.GetSharded().ShardBuckets))
+					}
+
 					sequence := 1
 					for i := 0; i < index.NumColumns(); i++ {
 						col := index.GetColumnName(i)
+						// Indexing an expression is done by indexing a virtual
+						// computed column, so report the expression it computes.
+						expression := tree.DNull
+						column, err := table.FindColumnWithID(index.GetColumnID(i))
+						if err != nil {
+							return err
+						}
+						if column.IsVirtual() {
+							expr, err := schemaexpr.FormatExprForDisplay(ctx, table, column.GetComputeExpr(), &p.semaCtx, tree.FmtSimple)
+							if err != nil {
+								return err
+							}
+							expression = tree.NewDString(expr)
+						}
 						// We add a row for each column of index.
 						dir := dStringForIndexDirection(index.GetColumnDirection(i))
 						if err := appendRow(
@@ -1350,6 +2255,9 @@ CREATE TABLE information_schema.statistics (
 							dir,
 							false,
 							i < index.ExplicitColumnStartIdx(),
+							predicate,
+							shardBuckets,
+							expression,
 						); err != nil {
 							return err
 						}
@@ -1360,7 +2268,7 @@ CREATE TABLE information_schema.statistics (
 						col := index.GetStoredColumnName(i)
 						// We add a row for each stored column of index.
 						if err := appendRow(index.IndexDesc(), col, sequence,
-							indexDirectionNA, true, false); err != nil {
+							indexDirectionNA, true, false, predicate, shardBuckets, tree.DNull); err != nil {
 							return err
 						}
 						sequence++
@@ -1378,7 +2286,7 @@ CREATE TABLE information_schema.statistics (
 							if _, isImplicit := implicitCols[col]; isImplicit {
 								// We add a row for each implicit column of index.
 								if err := appendRow(index.IndexDesc(), col, sequence,
-									indexDirectionAsc, false, true); err != nil {
+									indexDirectionAsc, false, true, predicate, shardBuckets, tree.DNull); err != nil {
 									return err
 								}
 								sequence++
@@ -1406,71 +2314,101 @@ CREATE TABLE information_schema.table_constraints (
 	TABLE_NAME         STRING NOT NULL,
 	CONSTRAINT_TYPE    STRING NOT NULL,
 	IS_DEFERRABLE      STRING NOT NULL,
-	INITIALLY_DEFERRED STRING NOT NULL
+	INITIALLY_DEFERRED STRING NOT NULL,
+	CRDB_COMMENT       STRING, -- CockroachDB extension: the comment on the constraint.
+	CRDB_IS_VALIDATED  STRING NOT NULL, -- CockroachDB extension: whether the constraint holds for all existing rows.
+	INDEX(constraint_name)
 )`,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		h := makeOidHasher()
-		return forEachTableDescWithTableLookup(ctx, p, dbContext, hideVirtual, /* virtual tables have no constraints */
-			func(
-				db catalog.DatabaseDescriptor,
-				scName string,
-				table catalog.TableDescriptor,
-				tableLookup tableLookupFn,
-			) error {
-				conInfo, err := table.GetConstraintInfoWithLookup(tableLookup.getTableByID)
-				if err != nil {
-					return err
-				}
+		return populateTableConstraints(ctx, p, dbContext, nil /* conNameFilter */, addRow)
+	},
+	indexes: []virtualIndex{makeConstraintNameIndex(populateTableConstraints)},
+}
 
-				dbNameStr := tree.NewDString(db.GetName())
-				scNameStr := tree.NewDString(scName)
-				tbNameStr := tree.NewDString(table.GetName())
+// populateTableConstraints populates information_schema.table_constraints. If
+// conNameFilter is non-nil, only the rows of the constraints with that name
+// are added.
+func populateTableConstraints(
+	ctx context.Context,
+	p *planner,
+	dbContext catalog.DatabaseDescriptor,
+	conNameFilter *string,
+	addRow func(...tree.Datum) error,
+) error {
+	h := makeOidHasher()
+	commentMap, err := getCommentsByObject(ctx, p, keys.ConstraintCommentType)
+	if err != nil {
+		return err
+	}
+	return forEachTableDescWithTableLookup(ctx, p, dbContext, hideVirtual, /* virtual tables have no constraints */
+		func(
+			db catalog.DatabaseDescriptor,
+			scName string,
+			table catalog.TableDescriptor,
+			tableLookup tableLookupFn,
+		) error {
+			conInfo, err := table.GetConstraintInfoWithLookup(tableLookup.getTableByID)
+			if err != nil {
+				return err
+			}
 
-				for conName, c := range conInfo {
-					if err := addRow(
-						dbNameStr,                       // constraint_catalog
-						scNameStr,                       // constraint_schema
-						tree.NewDString(conName),        // constraint_name
-						dbNameStr,                       // table_catalog
-						scNameStr,                       // table_schema
-						tbNameStr,                       // table_name
-						tree.NewDString(string(c.Kind)), // constraint_type
-						yesOrNoDatum(false),             // is_deferrable
-						yesOrNoDatum(false),             // initially_deferred
-					); err != nil {
-						return err
+			dbNameStr := tree.NewDString(db.GetName())
+			scNameStr := tree.NewDString(scName)
+			tbNameStr := tree.NewDString(table.GetName())
+
+			for conName, c := range conInfo {
+				if conNameFilter != nil && conName != *conNameFilter {
+					continue
+				}
+				deferrable, initiallyDeferred := false, false
+				if c.FK != nil {
+					deferrable, initiallyDeferred = c.FK.Deferrable, c.FK.InitiallyDeferred
+				}
+				comment := tree.DNull
+				if id := c.ConstraintID(); id != descpb.InvalidConstraintID {
+					if d, ok := commentMap[table.GetID()][tree.DInt(id)]; ok {
+						comment = d
 					}
 				}
+				if err := addRow(
+					dbNameStr,                       // constraint_catalog
+					scNameStr,                       // constraint_schema
+					tree.NewDString(conName),        // constraint_name
+					dbNameStr,                       // table_catalog
+					scNameStr,                       // table_schema
+					tbNameStr,                       // table_name
+					tree.NewDString(string(c.Kind)), // constraint_type
+					yesOrNoDatum(deferrable),        // is_deferrable
+					yesOrNoDatum(initiallyDeferred), // initially_deferred
+					comment,                         // crdb_comment
+					yesOrNoDatum(!c.Unvalidated),    // crdb_is_validated
+				); err != nil {
+					return err
+				}
+			}
 
-				// Unlike with pg_catalog.pg_constraint, Postgres also includes NOT
-				// NULL column constraints in information_schema.check_constraints.
-				// Cockroach doesn't track these constraints as check constraints,
-				// but we can pull them off of the table's column descriptors.
-				for _, col := range table.PublicColumns() {
-					if col.IsNullable() {
-						continue
-					}
-					// NOT NULL column constraints are implemented as a CHECK in postgres.
-					conNameStr := tree.NewDString(fmt.Sprintf(
-						"%s_%s_%d_not_null", h.NamespaceOid(db.GetID(), scName), tableOid(table.GetID()), col.Ordinal()+1,
-					))
-					if err := addRow(
-						dbNameStr,                // constraint_catalog
-						scNameStr,                // constraint_schema
-						conNameStr,               // constraint_name
-						dbNameStr,                // table_catalog
-						scNameStr,                // table_schema
-						tbNameStr,                // table_name
-						tree.NewDString("CHECK"), // constraint_type
-						yesOrNoDatum(false),      // is_deferrable
-						yesOrNoDatum(false),      // initially_deferred
-					); err != nil {
-						return err
-					}
+			// NOT NULL column constraints are implemented as a CHECK in postgres.
+			return forEachNotNullConstraint(p, h, db, scName, table, func(
+				_ catalog.Column, conNameStr *tree.DString,
+			) error {
+				if conNameFilter != nil && string(*conNameStr) != *conNameFilter {
+					return nil
 				}
-				return nil
+				return addRow(
+					dbNameStr,                // constraint_catalog
+					scNameStr,                // constraint_schema
+					conNameStr,               // constraint_name
+					dbNameStr,                // table_catalog
+					scNameStr,                // table_schema
+					tbNameStr,                // table_name
+					tree.NewDString("CHECK"), // constraint_type
+					yesOrNoDatum(false),      // is_deferrable
+					yesOrNoDatum(false),      // initially_deferred
+					tree.DNull,               // crdb_comment
+					yesOrNoDatum(true),       // crdb_is_validated
+				)
 			})
-	},
+		})
 }
 
 // Postgres: not provided
@@ -1486,78 +2424,258 @@ CREATE TABLE information_schema.user_privileges (
 	IS_GRANTABLE   STRING
 )`,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		visibility, err := makeGrantVisibility(ctx, p)
+		if err != nil {
+			return err
+		}
+		// The role options are the same for every database, so they are read
+		// only once.
+		type roleOption struct {
+			grantee, option, isGrantable tree.Datum
+		}
+		// The root user and the admin role hold every cluster-level privilege
+		// and can grant them, while the other roles hold the ones given by
+		// their role options.
+		var roleOptions []roleOption
+		for _, u := range []security.SQLUsername{security.AdminRoleName(), security.RootUserName()} {
+			if !visibility.isGranteeVisible(u) {
+				continue
+			}
+			for _, o := range clusterPrivilegeRoleOptions {
+				roleOptions = append(roleOptions, roleOption{
+					grantee:     tree.NewDString(u.Normalized()),
+					option:      tree.NewDString(o.String()),
+					isGrantable: yesString,
+				})
+			}
+		}
+		if err := forEachRoleOption(ctx, p, func(username security.SQLUsername, option string) error {
+			if !isClusterPrivilegeRoleOption(option) || !visibility.isGranteeVisible(username) {
+				return nil
+			}
+			roleOptions = append(roleOptions, roleOption{
+				grantee:     tree.NewDString(username.Normalized()),
+				option:      tree.NewDString(option),
+				isGrantable: noString,
+			})
+			return nil
+		}); err != nil {
+			return err
+		}
+
 		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
 			func(dbDesc catalog.DatabaseDescriptor) error {
 				dbNameStr := tree.NewDString(dbDesc.GetName())
-				for _, u := range []string{security.RootUser, security.AdminRole} {
-					grantee := tree.NewDString(u)
-					for _, p := range privilege.GetValidPrivilegesForObject(privilege.Table).SortedNames() {
+				for _, u := range dbDesc.GetPrivileges().Users {
+					if !visibility.isGranteeVisible(u.User()) {
+						continue
+					}
+					grantee := tree.NewDString(u.User().Normalized())
+					for _, priv := range privilege.ListFromBitField(u.Privileges, privilege.Database) {
 						if err := addRow(
-							grantee,            // grantee
-							dbNameStr,          // table_catalog
-							tree.NewDString(p), // privilege_type
-							tree.DNull,         // is_grantable
+							grantee,                        // grantee
+							dbNameStr,                      // table_catalog
+							tree.NewDString(priv.String()), // privilege_type
+							yesOrNoDatum(u.CanGrant(priv)), // is_grantable
 						); err != nil {
 							return err
 						}
 					}
 				}
+				for _, ro := range roleOptions {
+					if err := addRow(
+						ro.grantee,     // grantee
+						dbNameStr,      // table_catalog
+						ro.option,      // privilege_type
+						ro.isGrantable, // is_grantable
+					); err != nil {
+						return err
+					}
+				}
 				return nil
 			})
 	},
 }
 
-// MySQL:    https://dev.mysql.com/doc/refman/5.7/en/table-privileges-table.html
-var informationSchemaTablePrivileges = virtualSchemaTable{
-	comment: `privileges granted on table or views (incomplete; may contain excess users or roles)
-` + docs.URL("information-schema.html#table_privileges") + `
-https://www.postgresql.org/docs/9.5/infoschema-table-privileges.html`,
-	schema: `
-CREATE TABLE information_schema.table_privileges (
-	GRANTOR        STRING,
-	GRANTEE        STRING NOT NULL,
-	TABLE_CATALOG  STRING NOT NULL,
-	TABLE_SCHEMA   STRING NOT NULL,
-	TABLE_NAME     STRING NOT NULL,
-	PRIVILEGE_TYPE STRING NOT NULL,
-	IS_GRANTABLE   STRING,
-	WITH_HIERARCHY STRING NOT NULL
-)`,
-	populate: populateTablePrivileges,
+// clusterPrivilegeRoleOptions are the role options which grant cluster-level
+// privileges. They are reported in information_schema.user_privileges.
+// TODO(sql-experience): report the system-level privileges instead once they
+// can be granted.
+var clusterPrivilegeRoleOptions = []roleoption.Option{
+	roleoption.CREATEROLE,
+	roleoption.CREATELOGIN,
+	roleoption.CREATEDB,
+	roleoption.CONTROLJOB,
+	roleoption.CONTROLCHANGEFEED,
+	roleoption.VIEWACTIVITY,
+	roleoption.CANCELQUERY,
+	roleoption.MODIFYCLUSTERSETTING,
+}
+
+func isClusterPrivilegeRoleOption(option string) bool {
+	for _, o := range clusterPrivilegeRoleOptions {
+		if o.String() == option {
+			return true
+		}
+	}
+	return false
+}
+
+// MySQL:    https://dev.mysql.com/doc/refman/5.7/en/table-privileges-table.html
+var informationSchemaTablePrivileges = virtualSchemaTable{
+	comment: `privileges granted on table or views (incomplete; may contain excess users or roles)
+` + docs.URL("information-schema.html#table_privileges") + `
+https://www.postgresql.org/docs/9.5/infoschema-table-privileges.html`,
+	schema: `
+CREATE TABLE information_schema.table_privileges (
+	GRANTOR        STRING,
+	GRANTEE        STRING NOT NULL,
+	TABLE_CATALOG  STRING NOT NULL,
+	TABLE_SCHEMA   STRING NOT NULL,
+	TABLE_NAME     STRING NOT NULL,
+	PRIVILEGE_TYPE STRING NOT NULL,
+	IS_GRANTABLE   STRING,
+	WITH_HIERARCHY STRING NOT NULL
+)`,
+	populate:       makePopulateFromTableRows(tablePrivilegesTableRows),
+	tableRows:      tablePrivilegesTableRows,
+	materializable: true,
+}
+
+var (
+	tablePrivilegesTableRows = makeTablePrivilegesTableRows(true /* includePublic */)
+	roleTableGrantsTableRows = makeTablePrivilegesTableRows(false /* includePublic */)
+)
+
+// makeTablePrivilegesTableRows returns the rows function used to populate
+// both table_privileges and role_table_grants. The latter omits the grants
+// provided through the PUBLIC pseudo-role.
+func makeTablePrivilegesTableRows(includePublic bool) virtualTableRowsFunc {
+	return func(
+		ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor,
+	) (addTableRowsFunc, error) {
+		visibility, err := makeGrantVisibility(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		return func(
+			db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor,
+			addRow func(...tree.Datum) error,
+		) error {
+			return addTablePrivilegesRows(db, scName, table, visibility, includePublic, addRow)
+		}, nil
+	}
+}
+
+func addTablePrivilegesRows(
+	db catalog.DatabaseDescriptor,
+	scName string,
+	table catalog.TableDescriptor,
+	visibility grantVisibility,
+	includePublic bool,
+	addRow func(...tree.Datum) error,
+) error {
+	dbNameStr := tree.NewDString(db.GetName())
+	scNameStr := tree.NewDString(scName)
+	tbNameStr := tree.NewDString(table.GetName())
+	objectType := privilege.Table
+	if table.IsSequence() {
+		objectType = privilege.Sequence
+	}
+	privs := table.GetPrivileges()
+	for _, u := range privs.Users {
+		if !includePublic && u.User().IsPublicRole() {
+			continue
+		}
+		if !visibility.isVisible(privs, u) {
+			continue
+		}
+		grantor := grantorDatum(u)
+		grantee := tree.NewDString(u.User().Normalized())
+		for _, priv := range privilege.ListFromBitField(u.Privileges, objectType).SortedNames() {
+			privKind := privilege.ByName[priv]
+			if err := addRow(
+				grantor,                            // grantor
+				grantee,                            // grantee
+				dbNameStr,                          // table_catalog
+				scNameStr,                          // table_schema
+				tbNameStr,                          // table_name
+				privilegeNameDatums[privKind],      // privilege_type
+				yesOrNoDatum(u.CanGrant(privKind)), // is_grantable
+				yesOrNoDatum(privKind == privilege.SELECT), // with_hierarchy
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// privilegeNameDatums holds the name of each privilege as a datum, so that
+// the rows listing the privileges on many objects share them.
+var privilegeNameDatums = func() map[privilege.Kind]tree.Datum {
+	m := make(map[privilege.Kind]tree.Datum, len(privilege.ByName))
+	for name, kind := range privilege.ByName {
+		m[kind] = tree.NewDString(name)
+	}
+	return m
+}()
+
+// grantorDatum returns the role which last granted the given privileges, or
+// NULL if it is unknown.
+func grantorDatum(u descpb.UserPrivileges) tree.Datum {
+	grantor := u.Grantor()
+	if grantor.Undefined() {
+		return tree.DNull
+	}
+	return tree.NewDString(grantor.Normalized())
+}
+
+// grantVisibility determines which grants are visible to the current user. As
+// in postgres, these are the grants to PUBLIC and the grants to or by one of
+// the roles of the current user, while admins see all grants. Since the
+// privileges inherited by new objects have no grantor, the grants on the
+// objects owned by one of the roles of the current user are visible too.
+type grantVisibility struct {
+	all   bool
+	user  security.SQLUsername
+	roles map[security.SQLUsername]bool
+}
+
+func makeGrantVisibility(ctx context.Context, p *planner) (grantVisibility, error) {
+	isAdmin, err := p.HasAdminRole(ctx)
+	if err != nil || isAdmin {
+		return grantVisibility{all: isAdmin}, err
+	}
+	roles, err := p.userRoles(ctx, p.User())
+	if err != nil {
+		return grantVisibility{}, err
+	}
+	return grantVisibility{user: p.User(), roles: roles}, nil
+}
+
+// hasRole returns whether the current user is, or is a member of, the given
+// role.
+func (v grantVisibility) hasRole(role security.SQLUsername) bool {
+	if v.all || role == v.user {
+		return true
+	}
+	_, ok := v.roles[role]
+	return ok
 }
 
-// populateTablePrivileges is used to populate both table_privileges and role_table_grants.
-func populateTablePrivileges(
-	ctx context.Context,
-	p *planner,
-	dbContext catalog.DatabaseDescriptor,
-	addRow func(...tree.Datum) error,
-) error {
-	return forEachTableDesc(ctx, p, dbContext, virtualMany,
-		func(db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor) error {
-			dbNameStr := tree.NewDString(db.GetName())
-			scNameStr := tree.NewDString(scName)
-			tbNameStr := tree.NewDString(table.GetName())
-			// TODO(knz): This should filter for the current user, see
-			// https://github.com/cockroachdb/cockroach/issues/35572
-			for _, u := range table.GetPrivileges().Show(privilege.Table) {
-				for _, priv := range u.Privileges {
-					if err := addRow(
-						tree.DNull,                           // grantor
-						tree.NewDString(u.User.Normalized()), // grantee
-						dbNameStr,                            // table_catalog
-						scNameStr,                            // table_schema
-						tbNameStr,                            // table_name
-						tree.NewDString(priv),                // privilege_type
-						tree.DNull,                           // is_grantable
-						yesOrNoDatum(priv == "SELECT"),       // with_hierarchy
-					); err != nil {
-						return err
-					}
-				}
-			}
-			return nil
-		})
+// isGranteeVisible returns whether the grants to the given grantee are
+// visible to the current user regardless of their grantor.
+func (v grantVisibility) isGranteeVisible(grantee security.SQLUsername) bool {
+	return grantee.IsPublicRole() || v.hasRole(grantee)
+}
+
+// isVisible returns whether the given grant of privileges on an object with
+// the given privilege descriptor is visible to the current user.
+func (v grantVisibility) isVisible(
+	privs *descpb.PrivilegeDescriptor, u descpb.UserPrivileges,
+) bool {
+	return v.isGranteeVisible(u.User()) || v.hasRole(u.Grantor()) || v.hasRole(privs.Owner())
 }
 
 var (
@@ -1565,19 +2683,124 @@ var (
 	tableTypeBaseTable  = tree.NewDString("BASE TABLE")
 	tableTypeView       = tree.NewDString("VIEW")
 	tableTypeTemporary  = tree.NewDString("LOCAL TEMPORARY")
+
+	tableTypeMaterializedView = tree.NewDString("MATERIALIZED VIEW")
+
+	commitActionPreserve = tree.NewDString("PRESERVE")
+
+	tableLocalityGlobal          = tree.NewDString("GLOBAL")
+	tableLocalityRegionalByTable = tree.NewDString("REGIONAL BY TABLE")
+	tableLocalityRegionalByRow   = tree.NewDString("REGIONAL BY ROW")
 )
 
+// tableLocality returns the locality of a table in a multi-region database
+// along with its home region, or NULLs if the table has no locality. The rows
+// of REGIONAL BY ROW tables are homed in the regions they specify, so these
+// tables have no home region. GLOBAL tables are homed in the primary region.
+func tableLocality(
+	db catalog.DatabaseDescriptor, table catalog.TableDescriptor,
+) (locality, homeRegion tree.Datum, _ error) {
+	c := table.GetLocalityConfig()
+	if c == nil {
+		return tree.DNull, tree.DNull, nil
+	}
+	switch v := c.Locality.(type) {
+	case *descpb.TableDescriptor_LocalityConfig_Global_:
+		locality = tableLocalityGlobal
+	case *descpb.TableDescriptor_LocalityConfig_RegionalByTable_:
+		locality = tableLocalityRegionalByTable
+		if v.RegionalByTable.Region != nil {
+			return locality, tree.NewDString(string(*v.RegionalByTable.Region)), nil
+		}
+	case *descpb.TableDescriptor_LocalityConfig_RegionalByRow_:
+		return tableLocalityRegionalByRow, tree.DNull, nil
+	default:
+		return nil, nil, errors.AssertionFailedf("unknown locality: %T", v)
+	}
+	if !db.IsMultiRegion() {
+		return locality, tree.DNull, nil
+	}
+	primaryRegion, err := db.PrimaryRegionName()
+	if err != nil {
+		return nil, nil, err
+	}
+	return locality, tree.NewDString(string(primaryRegion)), nil
+}
+
+// materializedViewTableType returns the table type of materialized views in
+// information_schema.tables. They are reported as base tables if the
+// materialized_views_as_tables_in_information_schema session variable is
+// set, for compatibility with tools which expect them to be.
+func materializedViewTableType(p *planner) *tree.DString {
+	if p.SessionData().MaterializedViewsAsTablesInInformationSchema {
+		return tableTypeBaseTable
+	}
+	return tableTypeMaterializedView
+}
+
 var informationSchemaTablesTable = virtualSchemaTable{
 	comment: `tables and views
 ` + docs.URL("information-schema.html#tables") + `
 https://www.postgresql.org/docs/9.5/infoschema-tables.html`,
 	schema: vtable.InformationSchemaTables,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return forEachTableDesc(ctx, p, dbContext, virtualMany, addTablesTableRow(addRow))
+		forEach := forEachTableDesc
+		if p.SessionData().IncludeNonPublicTablesInInformationSchema {
+			forEach = forEachTableDescAllStates
+		}
+		estimatedRowCount, err := makeEstimatedRowCountFunc(
+			ctx, p, catconstants.InformationSchemaTablesTableID, "crdb_estimated_row_count",
+		)
+		if err != nil {
+			return err
+		}
+		addTableRow := addTablesTableRow(ctx, p, estimatedRowCount, addRow)
+		if p.SessionData().ExcludeSystemTablesFromInformationSchema {
+			return forEach(ctx, p, dbContext, hideVirtual, func(
+				db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor,
+			) error {
+				if table.GetParentID() == keys.SystemDatabaseID {
+					return nil
+				}
+				return addTableRow(db, scName, table)
+			})
+		}
+		return forEach(ctx, p, dbContext, virtualMany, addTableRow)
 	},
+	rowFilter: temporaryObjectRowFilter,
+}
+
+// temporaryObjectRowFilter hides the rows describing the temporary objects of
+// other sessions, whose table_schema is the second column, from non-admin
+// users. A session can always see its own temporary objects; admins can also
+// see the ones of other sessions, e.g. to debug leaked temporary tables.
+func temporaryObjectRowFilter(ctx context.Context, p *planner, row tree.Datums) (bool, error) {
+	if !isOtherSessionTemporarySchema(p, string(tree.MustBeDString(row[1]))) {
+		return true, nil
+	}
+	return p.HasAdminRole(ctx)
+}
+
+// isOtherSessionTemporarySchema returns whether the schema with the given
+// name is the temporary schema of another session.
+func isOtherSessionTemporarySchema(p *planner, scName string) bool {
+	return strings.HasPrefix(scName, sessiondata.PgTempSchemaName) && scName != p.TemporarySchemaName()
+}
+
+// temporaryObjectSessionID returns the ID of the session owning the temporary
+// schema with the given name, or NULL if the schema is not temporary.
+func temporaryObjectSessionID(scName string) tree.Datum {
+	isTemp, sessionID, err := temporarySchemaSessionID(scName)
+	if err != nil || !isTemp {
+		return tree.DNull
+	}
+	return tree.NewDString(sessionID.String())
 }
 
 func addTablesTableRow(
+	ctx context.Context,
+	p *planner,
+	estimatedRowCount estimatedRowCountFunc,
 	addRow func(...tree.Datum) error,
 ) func(
 	db catalog.DatabaseDescriptor,
@@ -1585,19 +2808,42 @@ func addTablesTableRow(
 	table catalog.TableDescriptor,
 ) error {
 	return func(db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor) error {
-		if table.IsSequence() {
+		// The database of a dropped table may have been dropped as well.
+		if table.IsSequence() || db == nil {
 			return nil
 		}
 		tableType := tableTypeBaseTable
 		insertable := yesString
+		commitAction := tree.DNull
 		if table.IsVirtualTable() {
 			tableType = tableTypeSystemView
 			insertable = noString
+		} else if table.MaterializedView() {
+			tableType = materializedViewTableType(p)
+			insertable = noString
 		} else if table.IsView() {
 			tableType = tableTypeView
-			insertable = noString
+			isUpdatable, err := viewIsUpdatable(ctx, p, table)
+			if err != nil {
+				return err
+			}
+			insertable = yesOrNoDatum(isUpdatable)
 		} else if table.IsTemporary() {
 			tableType = tableTypeTemporary
+			// Temporary tables are kept until the end of the session.
+			commitAction = commitActionPreserve
+		}
+		rowCount, err := estimatedRowCount(table)
+		if err != nil {
+			return err
+		}
+		locality, homeRegion, err := tableLocality(db, table)
+		if err != nil {
+			return err
+		}
+		offlineReason := tree.DNull
+		if table.Offline() && table.GetOfflineReason() != "" {
+			offlineReason = tree.NewDString(table.GetOfflineReason())
 		}
 		dbNameStr := tree.NewDString(db.GetName())
 		scNameStr := tree.NewDString(scName)
@@ -1609,8 +2855,241 @@ func addTablesTableRow(
 			tableType,  // table_type
 			insertable, // is_insertable_into
 			tree.NewDInt(tree.DInt(table.GetVersion())), // version
+			// There are no typed tables, so no table has a self-referencing column
+			// nor is of a user-defined type.
+			tree.DNull,                              // self_referencing_column_name
+			tree.DNull,                              // reference_generation
+			tree.DNull,                              // user_defined_type_catalog
+			tree.DNull,                              // user_defined_type_schema
+			tree.DNull,                              // user_defined_type_name
+			noString,                                // is_typed
+			commitAction,                            // commit_action
+			yesOrNoDatum(isTablePartitioned(table)), // crdb_is_partitioned
+			rowCount,                                // crdb_estimated_row_count
+			temporaryObjectSessionID(scName),        // crdb_temporary_session_id
+			descriptorTimestamp(table.GetCreateAsOfTime()),   // crdb_create_time
+			descriptorTimestamp(table.GetModificationTime()), // crdb_modification_time
+			locality,   // crdb_locality
+			homeRegion, // crdb_home_region
+			tree.NewDString(table.GetState().String()), // crdb_state
+			offlineReason, // crdb_offline_reason
+		)
+	}
+}
+
+// descriptorTimestamp returns the given descriptor timestamp as a TIMESTAMPTZ,
+// or NULL if it is not set, as is the case for virtual tables.
+func descriptorTimestamp(ts hlc.Timestamp) tree.Datum {
+	if ts.IsEmpty() {
+		return tree.DNull
+	}
+	return tree.MustMakeDTimestampTZ(ts.GoTime(), time.Microsecond)
+}
+
+// MySQL: https://dev.mysql.com/doc/refman/8.0/en/information-schema-partitions-table.html
+var informationSchemaPartitionsTable = virtualSchemaTable{
+	comment: `partitions of table indexes
+https://dev.mysql.com/doc/refman/8.0/en/information-schema-partitions-table.html`,
+	schema: vtable.InformationSchemaPartitions,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		mysqlCompat := p.SessionData().MySQLCompat
+		estimatedRowCount, err := makeEstimatedRowCountFunc(
+			ctx, p, catconstants.InformationSchemaPartitionsTableID, "table_rows",
 		)
+		if err != nil {
+			return err
+		}
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual tables have no partitions */
+			func(db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor) error {
+				dbNameStr := tree.NewDString(db.GetName())
+				scNameStr := tree.NewDString(scName)
+				tbNameStr := tree.NewDString(table.GetName())
+				if mysqlCompat && table.IsTable() && !isTablePartitioned(table) {
+					rowCount, err := estimatedRowCount(table)
+					if err != nil {
+						return err
+					}
+					// As in MySQL, a table which is not partitioned is listed
+					// with a single row for its primary index, without
+					// partition details.
+					return addRow(
+						dbNameStr, // table_catalog
+						scNameStr, // table_schema
+						tbNameStr, // table_name
+						tree.NewDString(table.GetPrimaryIndex().GetName()), // index_name
+						tree.DNull, // partition_name
+						tree.DNull, // parent_partition_name
+						tree.DNull, // partition_ordinal_position
+						tree.DNull, // partition_method
+						tree.DNull, // partition_expression
+						tree.DNull, // partition_description
+						rowCount,   // table_rows
+						tree.DNull, // crdb_zone_id
+						tree.DNull, // crdb_subzone_id
+					)
+				}
+				return catalog.ForEachIndex(table, catalog.IndexOpts{}, func(index catalog.Index) error {
+					idxNameStr := tree.NewDString(index.GetName())
+					// The partitions are numbered separately under each parent.
+					positions := make(map[string]int)
+					// The rows of crdb_internal.partitions are translated.
+					return addPartitioningRows(ctx, p, db.GetName(), table, index.IndexDesc(),
+						&index.IndexDesc().Partitioning, tree.DNull /* parentName */, 0, /* colOffset */
+						func(row ...tree.Datum) error {
+							parentName, colNames := row[2], row[5]
+							method, values := partitionMethodList, row[6]
+							if values == tree.DNull {
+								method, values = partitionMethodRange, row[7]
+							}
+							var parentKey string
+							if parentName != tree.DNull {
+								parentKey = string(tree.MustBeDString(parentName))
+							}
+							positions[parentKey]++
+							return addRow(
+								dbNameStr,  // table_catalog
+								scNameStr,  // table_schema
+								tbNameStr,  // table_name
+								idxNameStr, // index_name
+								row[3],     // partition_name
+								parentName, // parent_partition_name
+								tree.NewDInt(tree.DInt(positions[parentKey])), // partition_ordinal_position
+								method,   // partition_method
+								colNames, // partition_expression
+								values,   // partition_description
+								// Statistics are not collected per partition.
+								tree.DNull, // table_rows
+								row[8],     // crdb_zone_id
+								row[9],     // crdb_subzone_id
+							)
+						})
+				})
+			})
+	},
+}
+
+var (
+	partitionMethodList  = tree.NewDString("LIST")
+	partitionMethodRange = tree.NewDString("RANGE")
+)
+
+// isTablePartitioned returns whether any index of the table is partitioned.
+func isTablePartitioned(table catalog.TableDescriptor) bool {
+	return catalog.FindIndex(table, catalog.IndexOpts{}, func(idx catalog.Index) bool {
+		return idx.GetPartitioning().NumColumns > 0
+	}) != nil
+}
+
+// estimatedRowCountFunc returns the row count of the table estimated from the
+// latest statistics collected on it, or NULL if there are none or the table
+// stores no data.
+type estimatedRowCountFunc func(table catalog.TableDescriptor) (tree.Datum, error)
+
+// makeEstimatedRowCountFunc returns the estimatedRowCountFunc populating the
+// given column of the given virtual table. Reading the statistics of every
+// table is expensive, so the returned function only does it when the scan
+// populating the virtual table needs the column, and returns NULL otherwise.
+func makeEstimatedRowCountFunc(
+	ctx context.Context, p *planner, vtableID descpb.ID, column string,
+) (estimatedRowCountFunc, error) {
+	ords, err := virtualColumnOrdinals(p, vtableID, column)
+	if err != nil {
+		return nil, err
 	}
+	needed := isVirtualColumnNeeded(ctx, ords[0])
+	return func(table catalog.TableDescriptor) (tree.Datum, error) {
+		if !needed || !table.IsTable() || table.IsVirtualTable() {
+			return tree.DNull, nil
+		}
+		tableStats, err := p.ExecCfg().TableStatsCache.GetTableStats(ctx, table.GetID())
+		if err != nil {
+			return nil, err
+		}
+		if len(tableStats) == 0 {
+			return tree.DNull, nil
+		}
+		return tree.NewDInt(tree.DInt(tableStats[0].RowCount)), nil
+	}, nil
+}
+
+var (
+	pluginStatusActive          = tree.NewDString("ACTIVE")
+	pluginStatusInactive        = tree.NewDString("INACTIVE")
+	pluginTypeEnterprise        = tree.NewDString("ENTERPRISE")
+	pluginTypeExperimental      = tree.NewDString("EXPERIMENTAL FEATURE")
+	pluginEnterpriseName        = tree.NewDString("enterprise")
+	pluginEnterpriseDescription = tree.NewDString("Enterprise features, e.g. BACKUP, changefeeds and multi-region databases")
+)
+
+// isExperimentalFeatureSetting returns whether the cluster setting with the
+// given key is a flag enabling an experimental feature.
+func isExperimentalFeatureSetting(key string, setting settings.Setting) bool {
+	_, isBool := setting.(*settings.BoolSetting)
+	return isBool && strings.Contains(key, "experimental")
+}
+
+// MySQL: https://dev.mysql.com/doc/refman/8.0/en/information-schema-plugins-table.html
+var informationSchemaPlugins = virtualSchemaTable{
+	comment: `optional features of the cluster: enterprise features and experimental features enabled by cluster settings (MySQL compatibility)
+https://dev.mysql.com/doc/refman/8.0/en/information-schema-plugins-table.html`,
+	schema: vtable.InformationSchemaPlugins,
+	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		st := p.ExecCfg().Settings
+		// Every feature is part of the binary, so they all share its version.
+		version := tree.NewDString(build.GetInfo().Tag)
+
+		licenseType, err := base.LicenseType(st)
+		if err != nil {
+			return err
+		}
+		status := pluginStatusActive
+		if base.CheckEnterpriseEnabled(
+			st, p.ExecCfg().ClusterID(), ClusterOrganization.Get(&st.SV), "information_schema.plugins",
+		) != nil {
+			status = pluginStatusInactive
+		}
+		if err := addRow(
+			pluginEnterpriseName,                  // plugin_name
+			version,                               // plugin_version
+			status,                                // plugin_status
+			pluginTypeEnterprise,                  // plugin_type
+			pluginEnterpriseDescription,           // plugin_description
+			tree.NewDString(licenseType),          // plugin_license
+			tree.NewDString("enterprise.license"), // crdb_setting
+		); err != nil {
+			return err
+		}
+
+		hasAdmin, err := p.HasAdminRole(ctx)
+		if err != nil {
+			return err
+		}
+		for _, k := range settings.Keys() {
+			if !hasAdmin && settings.AdminOnly(k) {
+				continue
+			}
+			setting, _ := settings.Lookup(k, settings.LookupForLocalAccess)
+			if !isExperimentalFeatureSetting(k, setting) {
+				continue
+			}
+			status := pluginStatusInactive
+			if setting.(*settings.BoolSetting).Get(&st.SV) {
+				status = pluginStatusActive
+			}
+			if err := addRow(
+				tree.NewDString(k),                     // plugin_name
+				version,                                // plugin_version
+				status,                                 // plugin_status
+				pluginTypeExperimental,                 // plugin_type
+				tree.NewDString(setting.Description()), // plugin_description
+				tree.DNull,                             // plugin_license
+				tree.NewDString(k),                     // crdb_setting
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
 }
 
 // Postgres: https://www.postgresql.org/docs/9.6/static/infoschema-views.html
@@ -1630,7 +3109,8 @@ CREATE TABLE information_schema.views (
     IS_INSERTABLE_INTO         STRING NOT NULL,
     IS_TRIGGER_UPDATABLE       STRING NOT NULL,
     IS_TRIGGER_DELETABLE       STRING NOT NULL,
-    IS_TRIGGER_INSERTABLE_INTO STRING NOT NULL
+    IS_TRIGGER_INSERTABLE_INTO STRING NOT NULL,
+    CRDB_VIEW_OPTIONS          STRING[]  -- CockroachDB extension: the options the view was declared with.
 )`,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* virtual schemas have no views */
@@ -1638,25 +3118,31 @@ CREATE TABLE information_schema.views (
 				if !table.IsView() {
 					return nil
 				}
-				// Note that the view query printed will not include any column aliases
-				// specified outside the initial view query into the definition returned,
-				// unlike Postgres. For example, for the view created via
-				//  `CREATE VIEW (a) AS SELECT b FROM foo`
-				// we'll only print `SELECT b FROM foo` as the view definition here,
-				// while Postgres would more accurately print `SELECT b AS a FROM foo`.
-				// TODO(a-robinson): Insert column aliases into view query once we
-				// have a semantic query representation to work with (#10083).
+				// Materialized views reported as base tables are not listed as views.
+				if table.MaterializedView() && p.SessionData().MaterializedViewsAsTablesInInformationSchema {
+					return nil
+				}
+				isUpdatable, err := viewIsUpdatable(ctx, p, table)
+				if err != nil {
+					return err
+				}
+				checkOption := viewCheckOptionFromDesc(table.GetViewCheckOption())
+				viewOptions, err := viewOptionsDatum(table)
+				if err != nil {
+					return err
+				}
 				return addRow(
-					tree.NewDString(db.GetName()),         // table_catalog
-					tree.NewDString(scName),               // table_schema
-					tree.NewDString(table.GetName()),      // table_name
-					tree.NewDString(table.GetViewQuery()), // view_definition
-					tree.DNull,                            // check_option
-					noString,                              // is_updatable
-					noString,                              // is_insertable_into
-					noString,                              // is_trigger_updatable
-					noString,                              // is_trigger_deletable
-					noString,                              // is_trigger_insertable_into
+					tree.NewDString(db.GetName()),             // table_catalog
+					tree.NewDString(scName),                   // table_schema
+					tree.NewDString(table.GetName()),          // table_name
+					tree.NewDString(viewDefinition(p, table)), // view_definition
+					tree.NewDString(checkOption.String()),     // check_option
+					yesOrNoDatum(isUpdatable),                 // is_updatable
+					yesOrNoDatum(isUpdatable),                 // is_insertable_into
+					noString,                                  // is_trigger_updatable
+					noString,                                  // is_trigger_deletable
+					noString,                                  // is_trigger_insertable_into
+					viewOptions,                               // crdb_view_options
 				)
 			})
 	},
@@ -1729,17 +3215,13 @@ var informationSchemaSessionVariables = virtualSchemaTable{
 	comment: `exposes the session variables.`,
 	schema:  vtable.InformationSchemaSessionVariables,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		for _, vName := range varNames {
-			gen := varGen[vName]
+		return p.forEachSessionVar(func(vName string, gen sessionVar) error {
 			value := gen.Get(&p.extendedEvalCtx)
-			if err := addRow(
+			return addRow(
 				tree.NewDString(vName),
 				tree.NewDString(value),
-			); err != nil {
-				return err
-			}
-		}
-		return nil
+			)
+		})
 	},
 }
 
@@ -1871,12 +3353,10 @@ func forEachTypeDesc(
 	dbContext catalog.DatabaseDescriptor,
 	fn func(db catalog.DatabaseDescriptor, sc string, typ catalog.TypeDescriptor) error,
 ) error {
-	descs, err := p.Descriptors().GetAllDescriptors(ctx, p.txn)
+	_, lCtx, err := p.getCatalogSnapshot(ctx, dbContext)
 	if err != nil {
 		return err
 	}
-	lCtx := newInternalLookupCtx(ctx, descs, dbContext,
-		catalogkv.NewOneLevelUncachedDescGetter(p.txn, p.execCfg.Codec))
 	for _, id := range lCtx.typIDs {
 		typ := lCtx.typDescs[id]
 		dbDesc, err := lCtx.getDatabaseByID(typ.GetParentID())
@@ -1941,6 +3421,21 @@ const (
 	hideVirtual
 )
 
+// tableDescStates specifies which table descriptors are iterated over,
+// depending on their state.
+type tableDescStates int
+
+const (
+	// publicTableDescs iterates over public table descriptors only.
+	publicTableDescs tableDescStates = iota
+	// publicAndAddingTableDescs also iterates over newly added table
+	// descriptors.
+	publicAndAddingTableDescs
+	// allTableDescs iterates over table descriptors in any state, including
+	// offline ones and dropped ones awaiting garbage collection.
+	allTableDescs
+)
+
 // forEachTableDescAll does the same as forEachTableDesc but also
 // includes newly added non-public descriptors.
 func forEachTableDescAll(
@@ -1972,10 +3467,29 @@ func forEachTableDescAllWithTableLookup(
 	fn func(catalog.DatabaseDescriptor, string, catalog.TableDescriptor, tableLookupFn) error,
 ) error {
 	return forEachTableDescWithTableLookupInternal(
-		ctx, p, dbContext, virtualOpts, true /* allowAdding */, fn,
+		ctx, p, dbContext, virtualOpts, publicAndAddingTableDescs, fn,
 	)
 }
 
+// forEachTableDescAllStates does the same as forEachTableDesc but also
+// includes the descriptors of offline and dropped tables.
+func forEachTableDescAllStates(
+	ctx context.Context,
+	p *planner,
+	dbContext catalog.DatabaseDescriptor,
+	virtualOpts virtualOpts,
+	fn func(catalog.DatabaseDescriptor, string, catalog.TableDescriptor) error,
+) error {
+	return forEachTableDescWithTableLookupInternal(ctx, p, dbContext, virtualOpts, allTableDescs, func(
+		db catalog.DatabaseDescriptor,
+		scName string,
+		table catalog.TableDescriptor,
+		_ tableLookupFn,
+	) error {
+		return fn(db, scName, table)
+	})
+}
+
 // forEachTableDescWithTableLookup acts like forEachTableDesc, except it also provides a
 // tableLookupFn when calling fn to allow callers to lookup fetched table descriptors
 // on demand. This is important for callers dealing with objects like foreign keys, where
@@ -1993,7 +3507,7 @@ func forEachTableDescWithTableLookup(
 	fn func(catalog.DatabaseDescriptor, string, catalog.TableDescriptor, tableLookupFn) error,
 ) error {
 	return forEachTableDescWithTableLookupInternal(
-		ctx, p, dbContext, virtualOpts, false /* allowAdding */, fn,
+		ctx, p, dbContext, virtualOpts, publicTableDescs, fn,
 	)
 }
 
@@ -2023,11 +3537,48 @@ func getSchemaNames(
 	return ret, nil
 }
 
+// resolveTemporarySchemaNames adds to the lookup context the names of the
+// schemas of its temporary tables. Temporary schemas don't have descriptors,
+// so their names are read from the namespace table, once for each database
+// containing temporary tables whose schema isn't named yet.
+func resolveTemporarySchemaNames(ctx context.Context, p *planner, lCtx *internalLookupCtx) error {
+	var dbIDs []descpb.ID
+	seen := make(map[descpb.ID]struct{})
+	for _, tbID := range lCtx.tbIDs {
+		table := lCtx.tbDescs[tbID]
+		if !table.IsTemporary() {
+			continue
+		}
+		if _, ok := lCtx.schemaNames[table.GetParentSchemaID()]; ok {
+			continue
+		}
+		if _, ok := lCtx.dbDescs[table.GetParentID()]; !ok {
+			continue
+		}
+		if _, ok := seen[table.GetParentID()]; ok {
+			continue
+		}
+		seen[table.GetParentID()] = struct{}{}
+		dbIDs = append(dbIDs, table.GetParentID())
+	}
+	for _, dbID := range dbIDs {
+		names, err := p.Descriptors().GetSchemasForDatabase(ctx, p.txn, dbID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to look up the temporary schemas of database %d", dbID)
+		}
+		for id, name := range names {
+			if _, exists := lCtx.schemaNames[id]; !exists {
+				lCtx.schemaNames[id] = name
+			}
+		}
+	}
+	return nil
+}
+
 // forEachTableDescWithTableLookupInternal is the logic that supports
 // forEachTableDescWithTableLookup.
 //
-// The allowAdding argument if true includes newly added tables that
-// are not yet public.
+// The states argument specifies which non-public tables are included.
 // The validate argument if false turns off checking if the descriptor ids exist
 // and if they are valid.
 func forEachTableDescWithTableLookupInternal(
@@ -2035,27 +3586,31 @@ func forEachTableDescWithTableLookupInternal(
 	p *planner,
 	dbContext catalog.DatabaseDescriptor,
 	virtualOpts virtualOpts,
-	allowAdding bool,
+	states tableDescStates,
 	fn func(catalog.DatabaseDescriptor, string, catalog.TableDescriptor, tableLookupFn) error,
 ) error {
-	descs, err := p.Descriptors().GetAllDescriptors(ctx, p.txn)
+	descs, lCtx, err := p.getCatalogSnapshot(ctx, dbContext)
 	if err != nil {
 		return err
 	}
 	return forEachTableDescWithTableLookupInternalFromDescriptors(
-		ctx, p, dbContext, virtualOpts, allowAdding, descs, fn)
+		ctx, p, dbContext, virtualOpts, states, descs, lCtx, fn)
 }
 
+// forEachTypeDescWithTableLookupInternalFromDescriptors iterates over the type
+// descriptors of lCtx, which must have been built from descs.
 func forEachTypeDescWithTableLookupInternalFromDescriptors(
 	ctx context.Context,
 	p *planner,
 	dbContext catalog.DatabaseDescriptor,
 	allowAdding bool,
 	descs []catalog.Descriptor,
+	lCtx *internalLookupCtx,
 	fn func(catalog.DatabaseDescriptor, string, catalog.TypeDescriptor, tableLookupFn) error,
 ) error {
-	lCtx := newInternalLookupCtx(ctx, descs, dbContext,
-		catalogkv.NewOneLevelUncachedDescGetter(p.txn, p.execCfg.Codec))
+	if err := p.warmAnyPrivileges(ctx, descs); err != nil {
+		return err
+	}
 
 	for _, typID := range lCtx.typIDs {
 		typDesc := lCtx.typDescs[typID]
@@ -2084,17 +3639,21 @@ func forEachTypeDescWithTableLookupInternalFromDescriptors(
 	return nil
 }
 
+// forEachTableDescWithTableLookupInternalFromDescriptors iterates over the
+// table descriptors of lCtx, which must have been built from descs.
 func forEachTableDescWithTableLookupInternalFromDescriptors(
 	ctx context.Context,
 	p *planner,
 	dbContext catalog.DatabaseDescriptor,
 	virtualOpts virtualOpts,
-	allowAdding bool,
+	states tableDescStates,
 	descs []catalog.Descriptor,
+	lCtx *internalLookupCtx,
 	fn func(catalog.DatabaseDescriptor, string, catalog.TableDescriptor, tableLookupFn) error,
 ) error {
-	lCtx := newInternalLookupCtx(ctx, descs, dbContext,
-		catalogkv.NewOneLevelUncachedDescGetter(p.txn, p.execCfg.Codec))
+	if err := p.warmAnyPrivileges(ctx, descs); err != nil {
+		return err
+	}
 
 	if virtualOpts == virtualMany || virtualOpts == virtualCurrentDB {
 		// Virtual descriptors first.
@@ -2106,6 +3665,11 @@ func forEachTableDescWithTableLookupInternalFromDescriptors(
 				e := vEntries[virtSchemaName]
 				for _, tName := range e.orderedDefNames {
 					te := e.defs[tName]
+					// Secondary tenants cannot access host cluster state, so don't
+					// advertise the tables that expose it.
+					if !te.visibleToTenant(p.ExecCfg().Codec) {
+						continue
+					}
 					if err := fn(dbDesc, virtSchemaName, te.desc, lCtx); err != nil {
 						return err
 					}
@@ -2133,41 +3697,37 @@ func forEachTableDescWithTableLookupInternalFromDescriptors(
 	for _, tbID := range lCtx.tbIDs {
 		table := lCtx.tbDescs[tbID]
 		dbDesc, parentExists := lCtx.dbDescs[table.GetParentID()]
-		canSeeDescriptor, err := userCanSeeDescriptor(ctx, p, table, dbDesc, allowAdding)
+		var canSeeDescriptor bool
+		var err error
+		if states == allTableDescs {
+			canSeeDescriptor, err = userHasPrivilegesToSeeDescriptor(ctx, p, table, dbDesc)
+		} else {
+			canSeeDescriptor, err = userCanSeeDescriptor(
+				ctx, p, table, dbDesc, states == publicAndAddingTableDescs,
+			)
+		}
 		if err != nil {
 			return err
 		}
-		if table.Dropped() || !canSeeDescriptor {
+		if (table.Dropped() && states != allTableDescs) || !canSeeDescriptor {
 			continue
 		}
 		var scName string
 		if parentExists {
 			var ok bool
 			scName, ok = lCtx.schemaNames[table.GetParentSchemaID()]
-			// Look up the schemas for this database if we discover that there is a
-			// missing temporary schema name. The only schemas which do not have
-			// descriptors are the public schema and temporary schemas. The public
-			// schema does not have a descriptor but will appear in the map. Temporary
-			// schemas do, however, have namespace entries. The below code will go
-			// and lookup schema names from the namespace table if needed to qualify
-			// the name of a temporary table.
+			// A dropped table may outlive its schema until it is garbage collected,
+			// e.g. if it was dropped by DROP SCHEMA ... CASCADE.
+			if !ok && states == allTableDescs && table.Dropped() {
+				scName, ok = fmt.Sprintf("[%d]", table.GetParentSchemaID()), true
+			}
+			// The only schemas which do not have descriptors are the public schema
+			// and temporary schemas. The public schema appears in the map, and the
+			// names of the temporary schemas were added to it by
+			// resolveTemporarySchemaNames.
 			if !ok && !table.IsTemporary() {
 				return errors.AssertionFailedf("schema id %d not found", table.GetParentSchemaID())
 			}
-			if !ok { // && table.IsTemporary()
-				namesForSchema, err := getSchemaNames(ctx, p, dbDesc)
-				if err != nil {
-					return errors.Wrapf(err, "failed to look up schema id %d",
-						table.GetParentSchemaID())
-				}
-				for id, n := range namesForSchema {
-					if _, exists := lCtx.schemaNames[id]; exists {
-						continue
-					}
-					lCtx.schemaNames[id] = n
-					scName = lCtx.schemaNames[table.GetParentSchemaID()]
-				}
-			}
 		}
 		if err := fn(dbDesc, scName, table, lCtx); err != nil {
 			return err
@@ -2176,65 +3736,40 @@ func forEachTableDescWithTableLookupInternalFromDescriptors(
 	return nil
 }
 
+// forEachRole calls fn with every role stored in system.users.
 func forEachRole(
 	ctx context.Context,
 	p *planner,
 	fn func(username security.SQLUsername, isRole bool, noLogin bool, rolValidUntil *time.Time) error,
 ) error {
-	query := `
-SELECT
-	u.username,
-	"isRole",
-	EXISTS(
-		SELECT
-			option
-		FROM
-			system.role_options AS r
-		WHERE
-			r.username = u.username AND option = 'NOLOGIN'
-	)
-		AS nologin,
-	ro.value::TIMESTAMPTZ AS rolvaliduntil
-FROM
-	system.users AS u
-	LEFT JOIN system.role_options AS ro ON
-			ro.username = u.username
-			AND option = 'VALID UNTIL';
-`
-	// For some reason, using the iterator API here causes privilege_builtins
-	// logic test fail in 3node-tenant config with 'txn already encountered an
-	// error' (because of the context cancellation), so we buffer all roles
-	// first.
-	rows, err := p.ExtendedEvalContext().ExecCfg.InternalExecutor.QueryBuffered(
-		ctx, "read-roles", p.txn, query,
-	)
+	roles, err := p.getRoles(ctx)
 	if err != nil {
 		return err
 	}
-
-	for _, row := range rows {
-		usernameS := tree.MustBeDString(row[0])
-		isRole, ok := row[1].(*tree.DBool)
-		if !ok {
-			return errors.Errorf("isRole should be a boolean value, found %s instead", row[1].ResolvedType())
-		}
-		noLogin, ok := row[2].(*tree.DBool)
-		if !ok {
-			return errors.Errorf("noLogin should be a boolean value, found %s instead", row[2].ResolvedType())
-		}
-		var rolValidUntil *time.Time
-		if rolValidUntilDatum, ok := row[3].(*tree.DTimestampTZ); ok {
-			rolValidUntil = &rolValidUntilDatum.Time
-		} else if row[3] != tree.DNull {
-			return errors.Errorf("rolValidUntil should be a timestamp or null value, found %s instead", row[3].ResolvedType())
-		}
-		// system tables already contain normalized usernames.
-		username := security.MakeSQLUsernameFromPreNormalizedString(string(usernameS))
-		if err := fn(username, bool(*isRole), bool(*noLogin), rolValidUntil); err != nil {
+	for i := range roles {
+		role := &roles[i]
+		if err := fn(role.username, role.isRole, role.noLogin, role.validUntil); err != nil {
 			return err
 		}
 	}
+	return nil
+}
 
+// forEachRoleOption calls fn with every option stored in system.role_options.
+func forEachRoleOption(
+	ctx context.Context, p *planner, fn func(username security.SQLUsername, option string) error,
+) error {
+	roles, err := p.getRoles(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range roles {
+		for _, opt := range roles[i].options {
+			if err := fn(roles[i].username, opt.option); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
@@ -2276,18 +3811,43 @@ func userCanSeeDescriptor(
 	if !descriptorIsVisible(desc, allowAdding) {
 		return false, nil
 	}
+	return userHasPrivilegesToSeeDescriptor(ctx, p, desc, parentDBDesc)
+}
 
+// userHasPrivilegesToSeeDescriptor is like userCanSeeDescriptor, but it does
+// not take the state of the descriptor into account.
+func userHasPrivilegesToSeeDescriptor(
+	ctx context.Context, p *planner, desc, parentDBDesc catalog.Descriptor,
+) (bool, error) {
 	// TODO(richardjcai): We may possibly want to remove the ability to view
 	// the descriptor if they have any privilege on the descriptor and only
 	// allow the descriptor to be viewed if they have CONNECT on the DB. #59827.
-	canSeeDescriptor := p.CheckAnyPrivilege(ctx, desc) == nil
+	canSeeDescriptor, err := p.hasAnyPrivilege(ctx, desc)
+	if err != nil {
+		return false, err
+	}
 	// Users can see objects in the database if they have connect privilege.
-	if parentDBDesc != nil {
-		canSeeDescriptor = canSeeDescriptor || p.CheckPrivilege(ctx, parentDBDesc, privilege.CONNECT) == nil
+	if !canSeeDescriptor && parentDBDesc != nil {
+		canSeeDescriptor = p.hasConnectPrivilege(ctx, parentDBDesc)
 	}
 	return canSeeDescriptor, nil
 }
 
+// hasConnectPrivilege returns whether the current user holds the CONNECT
+// privilege on the given database. The result is memoized for the rest of the
+// statement.
+func (p *planner) hasConnectPrivilege(ctx context.Context, db catalog.Descriptor) bool {
+	if hasConnect, ok := p.connectPrivileges[db.GetID()]; ok {
+		return hasConnect
+	}
+	hasConnect := p.CheckPrivilege(ctx, db, privilege.CONNECT) == nil
+	if p.connectPrivileges == nil {
+		p.connectPrivileges = make(map[descpb.ID]bool)
+	}
+	p.connectPrivileges[db.GetID()] = hasConnect
+	return hasConnect
+}
+
 func descriptorIsVisible(desc catalog.Descriptor, allowAdding bool) bool {
 	return desc.Public() || (allowAdding && desc.Adding())
 }
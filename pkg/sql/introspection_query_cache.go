@@ -0,0 +1,380 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+)
+
+// introspectionQueryCacheEnabled controls whether the results of the
+// introspection queries are cached.
+var introspectionQueryCacheEnabled = settings.RegisterBoolSetting(
+	"sql.catalog.introspection_query_cache.enabled",
+	"if enabled, the results of the read-only queries over pg_catalog and "+
+		"information_schema, such as the introspection queries issued by drivers and ORMs "+
+		"on connection setup, are cached and may be stale by up to "+
+		"sql.catalog.introspection_query_cache.max_staleness",
+	false,
+)
+
+// introspectionQueryCacheMaxStaleness bounds the age of the cached results
+// served to queries.
+var introspectionQueryCacheMaxStaleness = settings.RegisterDurationSetting(
+	"sql.catalog.introspection_query_cache.max_staleness",
+	"the maximum staleness of a cached introspection query result",
+	10*time.Second,
+	settings.NonNegativeDuration,
+)
+
+const (
+	// introspectionQueryCacheMaxEntries bounds the number of results held by
+	// the cache.
+	introspectionQueryCacheMaxEntries = 512
+	// introspectionQueryCacheMaxRows is the maximum number of rows of a cached
+	// result. Larger results are not cached.
+	introspectionQueryCacheMaxRows = 10000
+)
+
+// introspectionQueryKey identifies a cached result. Besides the statement and
+// its placeholder values, the key includes the session parameters which the
+// contents of the catalog tables depend on.
+type introspectionQueryKey struct {
+	sql                     string
+	placeholders            string
+	user                    security.SQLUsername
+	database                string
+	searchPath              string
+	temporarySchema         string
+	location                string
+	catalog                 catalogSessionSettings
+	excludeOtherTempSchemas bool
+}
+
+// introspectionQueryResult is a cached result.
+type introspectionQueryResult struct {
+	columns colinfo.ResultColumns
+	rows    []tree.Datums
+	// asOf is the read timestamp of the transaction which computed the
+	// result.
+	asOf hlc.Timestamp
+}
+
+// introspectionQueryCache is the node-wide cache of the results of
+// introspection queries.
+//
+// Drivers and ORMs (JDBC's DatabaseMetaData, ActiveRecord, SQLAlchemy, npgsql,
+// ...) issue the same multi-join queries over pg_catalog and
+// information_schema on every connection, and these queries dominate the
+// connection setup latency. Rather than recognizing the exact text of each
+// driver's queries, which changes across driver versions, any read-only query
+// which only reads pg_catalog and information_schema tables and contains only
+// immutable expressions is considered an introspection query. The tables which
+// describe the state of the session or of the node rather than the catalog are
+// excluded (see introspectionQueryExcludedTables).
+//
+// The cache is invalidated as a whole when a transaction which executed DDL or
+// changed roles commits on the local node, and when the rangefeed on
+// system.descriptor run for the virtual table snapshots observes a descriptor
+// change. The role changes made on other nodes are observed through the latter,
+// as they bump the versions of the descriptors of system.users and
+// system.role_members. The other changes to the catalog which don't change
+// descriptors (e.g. comments) made on other nodes are only observed once the
+// results expire.
+type introspectionQueryCache struct {
+	mu struct {
+		syncutil.Mutex
+		// generation is incremented by every invalidation, so that results
+		// computed concurrently with an invalidation are not stored.
+		generation int64
+		results    map[introspectionQueryKey]*introspectionQueryResult
+	}
+}
+
+// introspectionQuery is a statement which may be served from the cache, or
+// whose result may be stored in it.
+type introspectionQuery struct {
+	key introspectionQueryKey
+	// generation is the generation of the cache when the statement started
+	// planning.
+	generation int64
+}
+
+func (c *introspectionQueryCache) init() {
+	c.mu.results = make(map[introspectionQueryKey]*introspectionQueryResult)
+}
+
+// invalidate discards all the cached results.
+func (c *introspectionQueryCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.generation++
+	c.mu.results = make(map[introspectionQueryKey]*introspectionQueryResult)
+}
+
+// get returns the cached result of the given query if it can serve a
+// transaction reading at readTS.
+func (c *introspectionQueryCache) get(
+	q *introspectionQuery, readTS hlc.Timestamp, maxStaleness time.Duration,
+) *introspectionQueryResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	res, ok := c.mu.results[q.key]
+	if !ok || readTS.Less(res.asOf) || readTS.GoTime().Sub(res.asOf.GoTime()) > maxStaleness {
+		return nil
+	}
+	return res
+}
+
+// put stores the result of the given query, unless the cache was invalidated
+// since the query started planning.
+func (c *introspectionQueryCache) put(
+	q *introspectionQuery, res *introspectionQueryResult, maxStaleness time.Duration,
+) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.mu.generation != q.generation {
+		return
+	}
+	if len(c.mu.results) >= introspectionQueryCacheMaxEntries {
+		// Make room by discarding the expired results.
+		for k, prev := range c.mu.results {
+			if res.asOf.GoTime().Sub(prev.asOf.GoTime()) > maxStaleness {
+				delete(c.mu.results, k)
+			}
+		}
+		if len(c.mu.results) >= introspectionQueryCacheMaxEntries {
+			return
+		}
+	}
+	c.mu.results[q.key] = res
+}
+
+// changesRoles returns whether the statement changes roles or role
+// memberships. Such statements are not all DDL, but they change the contents
+// of catalog tables such as pg_roles and pg_auth_members.
+func changesRoles(stmt tree.Statement) bool {
+	switch stmt.(type) {
+	case *tree.CreateRole, *tree.AlterRole, *tree.DropRole, *tree.GrantRole, *tree.RevokeRole:
+		return true
+	}
+	return false
+}
+
+// makeIntrospectionQuery returns the introspection query for the planner's
+// statement, or nil if the statement can't be served from the cache. The
+// statement is only known to be an introspection query once planned (see
+// isIntrospectionMemo).
+func (p *planner) makeIntrospectionQuery() *introspectionQuery {
+	if !introspectionQueryCacheEnabled.Get(&p.ExecCfg().Settings.SV) {
+		return nil
+	}
+	if _, ok := p.stmt.AST.(*tree.Select); !ok {
+		return nil
+	}
+	// A transaction must always observe its own schema changes, and historical
+	// reads can't be served from the cache.
+	if p.txn == nil || p.txnHasDDL || p.semaCtx.AsOfTimestamp != nil ||
+		p.Descriptors().HasUncommittedTables() || p.Descriptors().HasUncommittedTypes() {
+		return nil
+	}
+	sd := p.SessionData()
+	key := introspectionQueryKey{
+		sql:                     p.stmt.SQL,
+		user:                    sd.User(),
+		database:                sd.Database,
+		searchPath:              sd.SearchPath.String(),
+		temporarySchema:         sd.SearchPath.GetTemporarySchemaName(),
+		location:                sd.GetLocation().String(),
+		catalog:                 makeCatalogSessionSettings(sd),
+		excludeOtherTempSchemas: sd.ExcludeOtherTempSchemasFromInformationSchema,
+	}
+	if placeholders := p.EvalContext().Placeholders; placeholders != nil {
+		var sb strings.Builder
+		for i, v := range placeholders.Values {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(tree.AsStringWithFlags(v, tree.FmtCheckEquivalence))
+		}
+		key.placeholders = sb.String()
+	}
+	cache := &p.ExecCfg().VirtualSchemas.introspectionQueries
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	return &introspectionQuery{key: key, generation: cache.mu.generation}
+}
+
+// maybeServeIntrospectionQuery plans the planner's statement as a scan of
+// the rows of its cached result, if any. It returns the introspection query
+// for the statement, which is nil if the statement can't be served from the
+// cache, and whether it was served.
+func (p *planner) maybeServeIntrospectionQuery(ctx context.Context) (*introspectionQuery, bool) {
+	q := p.makeIntrospectionQuery()
+	if q == nil {
+		return nil, false
+	}
+	maxStaleness := introspectionQueryCacheMaxStaleness.Get(&p.ExecCfg().Settings.SV)
+	res := p.ExecCfg().VirtualSchemas.introspectionQueries.get(q, p.txn.ReadTimestamp(), maxStaleness)
+	if res == nil {
+		return q, false
+	}
+	if p.stmt.ExpectedTypes != nil && !p.stmt.ExpectedTypes.TypesEqual(res.columns) {
+		return nil, false
+	}
+	log.VEventf(ctx, 2, "serving introspection query from cache")
+	tuples := make([][]tree.TypedExpr, len(res.rows))
+	for i, row := range res.rows {
+		tuples[i] = make([]tree.TypedExpr, len(row))
+		for j, d := range row {
+			tuples[i][j] = d
+		}
+	}
+	// The columns of a valuesNode can be renamed in place.
+	columns := append(colinfo.ResultColumns(nil), res.columns...)
+	p.curPlan.main = planMaybePhysical{planNode: &valuesNode{columns: columns, tuples: tuples}}
+	return q, true
+}
+
+// maybeRecordIntrospectionQuery arranges for the result of the planned
+// statement to be stored in the cache once fully read, if the statement is an
+// introspection query.
+func (p *planner) maybeRecordIntrospectionQuery(
+	ctx context.Context, q *introspectionQuery, mem *memo.Memo,
+) {
+	if q == nil || !isIntrospectionMemo(mem) {
+		return
+	}
+	m := &p.curPlan.main
+	if m.isPhysicalPlan() || len(p.curPlan.cascades) > 0 || len(p.curPlan.checkPlans) > 0 {
+		return
+	}
+	// The cached results are invalidated by the descriptor changes observed by
+	// the rangefeed run for the virtual table snapshots.
+	p.ExecCfg().VirtualSchemas.snapshots.maybeWatchDescriptors(ctx, p.ExecCfg(), p.txn.ReadTimestamp())
+	m.planNode = &introspectionQueryRecorderNode{
+		source:       m.planNode,
+		query:        q,
+		cache:        &p.ExecCfg().VirtualSchemas.introspectionQueries,
+		asOf:         p.txn.ReadTimestamp(),
+		maxStaleness: introspectionQueryCacheMaxStaleness.Get(&p.ExecCfg().Settings.SV),
+	}
+}
+
+// introspectionQueryExcludedTables are the catalog tables whose contents
+// describe the state of the session or of the node, so that the queries
+// reading them are never cached.
+var introspectionQueryExcludedTables = map[descpb.ID]struct{}{
+	catconstants.PgCatalogCursorsTableID:            {},
+	catconstants.PgCatalogLocksTableID:              {},
+	catconstants.PgCatalogPreparedStatementsTableID: {},
+	catconstants.PgCatalogPreparedXactsTableID:      {},
+	catconstants.PgCatalogSettingsTableID:           {},
+	catconstants.PgCatalogStatActivityTableID:       {},
+	catconstants.InformationSchemaSessionVariables:  {},
+}
+
+// isIntrospectionMemo returns whether the planned query only reads pg_catalog
+// and information_schema tables other than introspectionQueryExcludedTables,
+// and contains only immutable expressions. Stable expressions, such as now()
+// or current_setting(), may depend on the state of the session.
+func isIntrospectionMemo(mem *memo.Memo) bool {
+	tables := mem.Metadata().AllTables()
+	if len(tables) == 0 {
+		return false
+	}
+	for i := range tables {
+		vt, ok := tables[i].Table.(*optVirtualTable)
+		if !ok {
+			return false
+		}
+		switch vt.desc.GetParentSchemaID() {
+		case catconstants.PgCatalogID, catconstants.InformationSchemaID:
+		default:
+			return false
+		}
+		if _, ok := introspectionQueryExcludedTables[vt.desc.GetID()]; ok {
+			return false
+		}
+	}
+	root, ok := mem.RootExpr().(memo.RelExpr)
+	if !ok {
+		return false
+	}
+	volatility := root.Relational().VolatilitySet
+	return !volatility.HasStable() && !volatility.HasVolatile()
+}
+
+// introspectionQueryRecorderNode passes the rows of an introspection query
+// through, and stores them in the cache once they have all been read.
+type introspectionQueryRecorderNode struct {
+	source       planNode
+	query        *introspectionQuery
+	cache        *introspectionQueryCache
+	asOf         hlc.Timestamp
+	maxStaleness time.Duration
+	// rows is set to nil once the result is known not to be cacheable.
+	rows []tree.Datums
+	// done is set once the source has been exhausted.
+	done bool
+}
+
+func (n *introspectionQueryRecorderNode) startExec(params runParams) error {
+	n.rows = make([]tree.Datums, 0)
+	return nil
+}
+
+// Next is part of the planNode interface.
+func (n *introspectionQueryRecorderNode) Next(params runParams) (bool, error) {
+	next, err := n.source.Next(params)
+	if err != nil || !next {
+		n.done = err == nil
+		return next, err
+	}
+	if n.rows != nil {
+		if len(n.rows) == introspectionQueryCacheMaxRows {
+			n.rows = nil
+		} else {
+			// The source is allowed to reuse its row.
+			n.rows = append(n.rows, append(tree.Datums(nil), n.source.Values()...))
+		}
+	}
+	return true, nil
+}
+
+// Values is part of the planNode interface.
+func (n *introspectionQueryRecorderNode) Values() tree.Datums {
+	return n.source.Values()
+}
+
+// Close is part of the planNode interface.
+func (n *introspectionQueryRecorderNode) Close(ctx context.Context) {
+	if n.done && n.rows != nil {
+		n.cache.put(n.query, &introspectionQueryResult{
+			columns: append(colinfo.ResultColumns(nil), planColumns(n.source)...),
+			rows:    n.rows,
+			asOf:    n.asOf,
+		}, n.maxStaleness)
+	}
+	n.source.Close(ctx)
+}
@@ -250,13 +250,13 @@ import (
 //  - user <username>
 //    Changes the user for subsequent statements or queries.
 //
-//  - skipif <mysql/mssql/postgresql/cockroachdb>
+//  - skipif <mysql/mssql/postgresql/cockroachdb/config CONFIG>
 //    Skips the following `statement` or `query` if the argument is postgresql
-//    or cockroachdb.
+//    or cockroachdb, or if the test is running in the given configuration.
 //
-//  - onlyif <mysql/mssql/postgresql/cockroachdb>
+//  - onlyif <mysql/mssql/postgresql/cockroachdb/config CONFIG>
 //    Skips the following `statement` or query if the argument is not postgresql
-//    or cockroachdb.
+//    or cockroachdb, or if the test is not running in the given configuration.
 //
 //  - traceon <file>
 //    Enables tracing to the given file.
@@ -2332,6 +2332,14 @@ func (t *logicTest) processSubtest(
 			case "postgresql", "cockroachdb":
 				s.skip = true
 				continue
+			case "config":
+				if len(fields) < 3 {
+					return errors.Errorf("skipif config command requires a config name, found: %v", fields)
+				}
+				if t.cfg.name == fields[2] {
+					s.skip = true
+					continue
+				}
 			default:
 				return errors.Errorf("unimplemented test statement: %s", s.Text())
 			}
@@ -2350,6 +2358,14 @@ func (t *logicTest) processSubtest(
 			case "mssql":
 				s.skip = true
 				continue
+			case "config":
+				if len(fields) < 3 {
+					return errors.Errorf("onlyif config command requires a config name, found: %v", fields)
+				}
+				if t.cfg.name != fields[2] {
+					s.skip = true
+					continue
+				}
 			default:
 				return errors.Errorf("unimplemented test statement: %s", s.Text())
 			}
@@ -76,6 +76,8 @@ func buildOpaque(
 
 func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode, error) {
 	switch n := stmt.(type) {
+	case *tree.AlterDefaultPrivileges:
+		return p.AlterDefaultPrivileges(ctx, n)
 	case *tree.AlterDatabaseOwner:
 		return p.AlterDatabaseOwner(ctx, n)
 	case *tree.AlterDatabaseAddRegion:
@@ -106,10 +108,14 @@ func planOpaque(ctx context.Context, p *planner, stmt tree.Statement) (planNode,
 		return p.AlterSequence(ctx, n)
 	case *tree.CommentOnColumn:
 		return p.CommentOnColumn(ctx, n)
+	case *tree.CommentOnConstraint:
+		return p.CommentOnConstraint(ctx, n)
 	case *tree.CommentOnDatabase:
 		return p.CommentOnDatabase(ctx, n)
 	case *tree.CommentOnIndex:
 		return p.CommentOnIndex(ctx, n)
+	case *tree.CommentOnSchema:
+		return p.CommentOnSchema(ctx, n)
 	case *tree.CommentOnTable:
 		return p.CommentOnTable(ctx, n)
 	case *tree.CreateDatabase:
@@ -219,6 +225,7 @@ func init() {
 		&tree.AlterDatabaseOwner{},
 		&tree.AlterDatabasePrimaryRegion{},
 		&tree.AlterDatabaseSurvivalGoal{},
+		&tree.AlterDefaultPrivileges{},
 		&tree.AlterIndex{},
 		&tree.AlterSchema{},
 		&tree.AlterTable{},
@@ -229,8 +236,10 @@ func init() {
 		&tree.AlterSequence{},
 		&tree.AlterRole{},
 		&tree.CommentOnColumn{},
+		&tree.CommentOnConstraint{},
 		&tree.CommentOnDatabase{},
 		&tree.CommentOnIndex{},
+		&tree.CommentOnSchema{},
 		&tree.CommentOnTable{},
 		&tree.CreateDatabase{},
 		&tree.CreateExtension{},
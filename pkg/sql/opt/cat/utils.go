@@ -196,7 +196,11 @@ func formatCatalogIndex(tab Table, ord int, tp treeprinter.Node) {
 	if IsMutationIndex(tab, ord) {
 		mutation = " (mutation)"
 	}
-	child := tp.Childf("%sINDEX %s%s", idxType, idx.Name(), mutation)
+	notVisible := ""
+	if idx.IsNotVisible() {
+		notVisible = " (not visible)"
+	}
+	child := tp.Childf("%sINDEX %s%s%s", idxType, idx.Name(), mutation, notVisible)
 
 	var buf bytes.Buffer
 	colCount := idx.ColumnCount()
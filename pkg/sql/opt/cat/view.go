@@ -38,6 +38,16 @@ type View interface {
 	// IsSystemView returns true if this view is a system view (like
 	// crdb_internal.ranges).
 	IsSystemView() bool
+
+	// CheckOption returns the check option the view was declared with, which
+	// requires rows inserted or updated through the view to be visible through
+	// it.
+	CheckOption() tree.ViewCheckOption
+
+	// IsSecurityInvoker returns true if the view was declared with the
+	// security_invoker option, in which case the relations it references are
+	// accessed with the privileges of the user querying the view.
+	IsSecurityInvoker() bool
 }
 
 // FormatView nicely formats a catalog view using a treeprinter for debugging
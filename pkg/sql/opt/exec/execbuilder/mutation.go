@@ -847,8 +847,22 @@ func (b *Builder) buildFKChecks(checks memo.FKChecksExpr) error {
 		if err != nil {
 			return err
 		}
+		var fk cat.ForeignKeyConstraint
+		if c.FKOutbound {
+			fk = md.TableMeta(c.OriginTable).Table.OutboundForeignKey(c.FKOrdinal)
+		} else {
+			fk = md.TableMeta(c.ReferencedTable).Table.InboundForeignKey(c.FKOrdinal)
+		}
+		// Violations of an initially deferred constraint are not reported right
+		// away; instead, the constraint is validated when the transaction
+		// commits. Like in Postgres, RESTRICT actions are never deferred.
+		deferred := fk.InitiallyDeferred() && (c.FKOutbound ||
+			(fk.DeleteReferenceAction() != tree.Restrict && fk.UpdateReferenceAction() != tree.Restrict))
 		// Wrap the query in an error node.
 		mkErr := func(row tree.Datums) error {
+			if deferred && b.evalCtx.Planner.DeferForeignKeyCheck(int64(fk.OriginTableID()), fk.Name()) {
+				return nil
+			}
 			keyVals := make(tree.Datums, len(c.KeyCols))
 			for i, col := range c.KeyCols {
 				keyVals[i] = row[query.getNodeColumnOrdinal(col)]
@@ -62,6 +62,9 @@ func (b *Builder) buildCreateView(cv *memo.CreateViewExpr) (execPlan, error) {
 		cv.Replace,
 		cv.Persistence,
 		cv.Materialized,
+		cv.CheckOption,
+		cv.SecurityInvoker,
+		cv.SecurityBarrier,
 		cv.ViewQuery,
 		cols,
 		cv.Deps,
@@ -706,6 +706,11 @@ func (h *hasher) HashPersistence(val tree.Persistence) {
 	h.hash *= prime64
 }
 
+func (h *hasher) HashViewCheckOption(val tree.ViewCheckOption) {
+	h.hash ^= internHash(val)
+	h.hash *= prime64
+}
+
 // ----------------------------------------------------------------------
 //
 // Equality functions
@@ -1146,6 +1151,10 @@ func (h *hasher) IsPersistenceEqual(l, r tree.Persistence) bool {
 	return l == r
 }
 
+func (h *hasher) IsViewCheckOptionEqual(l, r tree.ViewCheckOption) bool {
+	return l == r
+}
+
 // encodeDatum turns the given datum into an encoded string of bytes. If two
 // datums are equivalent, then their encoded bytes will be identical.
 // Conversely, if two datums are not equivalent, then their encoded bytes will
@@ -135,6 +135,19 @@ type Builder struct {
 	// using AST annotations.
 	qualifyDataSourceNamesInAST bool
 
+	// viewChecks accumulates the check options of the updatable views a
+	// mutation statement targets, while the statement is rewritten to target
+	// the underlying table. They are handed over to the mutationBuilder of that
+	// table.
+	viewChecks viewChecks
+
+	// viewSubqueries maps the subqueries of a mutation statement targeting an
+	// updatable view to the name of the view. They are not rewritten along with
+	// the statement, so their outer column references would resolve against the
+	// table underlying the view; subquery.buildSubquery rejects them if they are
+	// correlated.
+	viewSubqueries map[*tree.Subquery]tree.Name
+
 	// isCorrelated is set to true if we already reported to telemetry that the
 	// query contains a correlated subquery.
 	isCorrelated bool
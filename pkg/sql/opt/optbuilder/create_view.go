@@ -11,10 +11,15 @@
 package optbuilder
 
 import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
 	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/util"
+	"github.com/cockroachdb/errors"
 )
 
 func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope *scope) {
@@ -74,19 +79,49 @@ func (b *Builder) buildCreateView(cv *tree.CreateView, inScope *scope) (outScope
 		}
 	}
 
+	viewQuery := tree.AsStringWithFlags(cv.AsSource, tree.FmtParsable)
+
+	// A check option only makes sense on views that rows can be inserted or
+	// updated through.
+	if cv.CheckOption != tree.ViewCheckOptionNone {
+		query, err := schemaexpr.ParseUpdatableView(viewQuery, b.semaCtx.SearchPath)
+		if err != nil {
+			panic(err)
+		}
+		if query == nil {
+			panic(errors.WithHint(
+				pgerror.New(pgcode.FeatureNotSupported,
+					"WITH CHECK OPTION is supported only on automatically updatable views"),
+				"Views that do not select from a single table or view, or which use WITH, "+
+					"DISTINCT, GROUP BY, HAVING, LIMIT, OFFSET, set operations, or aggregate, "+
+					"window or set-returning functions, are not automatically updatable.",
+			))
+		}
+	}
+
+	var opts paramparse.ViewStorageParamObserver
+	if err := paramparse.ApplyStorageParameters(
+		b.ctx, b.semaCtx, b.evalCtx, cv.Options, &opts,
+	); err != nil {
+		panic(err)
+	}
+
 	outScope = b.allocScope()
 	outScope.expr = b.factory.ConstructCreateView(
 		&memo.CreateViewPrivate{
-			Schema:       schID,
-			ViewName:     &viewName,
-			IfNotExists:  cv.IfNotExists,
-			Replace:      cv.Replace,
-			Persistence:  cv.Persistence,
-			Materialized: cv.Materialized,
-			ViewQuery:    tree.AsStringWithFlags(cv.AsSource, tree.FmtParsable),
-			Columns:      p,
-			Deps:         b.viewDeps,
-			TypeDeps:     b.viewTypeDeps,
+			Schema:          schID,
+			ViewName:        &viewName,
+			IfNotExists:     cv.IfNotExists,
+			Replace:         cv.Replace,
+			Persistence:     cv.Persistence,
+			Materialized:    cv.Materialized,
+			CheckOption:     cv.CheckOption,
+			SecurityInvoker: opts.SecurityInvoker,
+			SecurityBarrier: opts.SecurityBarrier,
+			ViewQuery:       viewQuery,
+			Columns:         p,
+			Deps:            b.viewDeps,
+			TypeDeps:        b.viewTypeDeps,
 		},
 	)
 	return outScope
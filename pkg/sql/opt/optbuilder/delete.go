@@ -38,6 +38,11 @@ func (b *Builder) buildDelete(del *tree.Delete, inScope *scope) (outScope *scope
 			"DELETE statement requires LIMIT when ORDER BY is used"))
 	}
 
+	// Deleting from an updatable view deletes the rows of its underlying table.
+	if vm := b.resolveViewForMutation(del.Table, privilege.DELETE); vm != nil {
+		return b.buildDelete(vm.rewriteDelete(del), inScope)
+	}
+
 	// Find which table we're working on, check the permissions.
 	tab, depName, alias, refColumns := b.resolveTableForMutation(del.Table, privilege.DELETE)
 
@@ -180,6 +180,12 @@ func init() {
 // ON CONFLICT clause is present, since it joins a new set of rows to the input
 // and thereby scrambles the input ordering.
 func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope) {
+	// Inserting into an updatable view inserts into its underlying table.
+	if vm := b.resolveViewForMutation(ins.Table, privilege.INSERT); vm != nil {
+		vm.addChecks(&b.viewChecks)
+		return b.buildInsert(vm.rewriteInsert(ins), inScope)
+	}
+
 	// Find which table we're working on, check the permissions.
 	tab, depName, alias, refColumns := b.resolveTableForMutation(ins.Table, privilege.INSERT)
 
@@ -218,6 +224,7 @@ func (b *Builder) buildInsert(ins *tree.Insert, inScope *scope) (outScope *scope
 	} else {
 		mb.init(b, "insert", tab, alias)
 	}
+	mb.viewChecks, b.viewChecks = b.viewChecks.checks, viewChecks{}
 
 	// Compute target columns in two cases:
 	//
@@ -655,6 +662,9 @@ func (mb *mutationBuilder) buildInsert(returning tree.ReturningExprs) {
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols()
 
+	// Filter the input through the check options of the targeted views.
+	mb.buildViewChecks()
+
 	// Project partial index PUT boolean columns.
 	mb.projectPartialIndexPutCols()
 
@@ -178,6 +178,10 @@ type mutationBuilder struct {
 	// made accessible to the RETURNING clause.
 	extraAccessibleCols []scopeColumn
 
+	// viewChecks are the check options that the new rows must satisfy when the
+	// statement targets an updatable view; see buildViewChecks.
+	viewChecks []viewCheck
+
 	// fkCheckHelper is used to prevent allocating the helper separately.
 	fkCheckHelper fkCheckHelper
 
@@ -666,8 +666,11 @@ func (h *fkCheckHelper) buildInsertionCheck() memo.FKChecksItem {
 		// filter out any rows where all the columns are NULL (rows which have
 		// NULLs a subset of columns are let through and will generate FK errors
 		// because they will never have a match in the anti join).
+		//
+		// MATCH PARTIAL is only allowed for single-column foreign keys, where it
+		// is equivalent to SIMPLE.
 		switch m := h.fk.MatchMethod(); m {
-		case tree.MatchSimple:
+		case tree.MatchSimple, tree.MatchPartial:
 			// Filter out any rows which have a NULL; build filters of the form
 			//   (a IS NOT NULL) AND (b IS NOT NULL) ...
 			filters := make(memo.FiltersExpr, 0, numCols-notNullWithScanCols.Len())
@@ -275,7 +275,9 @@ func (b *Builder) buildView(
 	// underlying tables as well would defeat the purpose of having separate
 	// SELECT privileges on the view, which is intended to allow for exposing
 	// some subset of a restricted table's data to less privileged users.
-	if !b.skipSelectPrivilegeChecks {
+	// Views declared with the security_invoker option are the exception: the
+	// underlying tables are checked against the privileges of the current user.
+	if !b.skipSelectPrivilegeChecks && !view.IsSecurityInvoker() {
 		b.skipSelectPrivilegeChecks = true
 		defer func() { b.skipSelectPrivilegeChecks = false }()
 	}
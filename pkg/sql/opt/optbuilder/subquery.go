@@ -245,6 +245,15 @@ func (s *subquery) buildSubquery(desiredTypes []*types.T) {
 		outScope = projScope
 	}
 
+	if view, ok := s.scope.builder.viewSubqueries[s.Subquery]; ok && !s.outerCols.Empty() {
+		panic(errors.WithDetail(
+			pgerror.Newf(pgcode.FeatureNotSupported,
+				"correlated subqueries are not supported in statements targeting view %q", view),
+			"The subqueries of a statement targeting a view cannot reference the columns of "+
+				"the view, nor of the other tables of the statement.",
+		))
+	}
+
 	s.cols = outScope.cols
 	s.node = outScope.expr.(memo.RelExpr)
 	s.ordering = ord
@@ -73,6 +73,12 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 		panic(pgerror.DangerousStatementf("UPDATE without WHERE clause"))
 	}
 
+	// Updating an updatable view updates the rows of its underlying table.
+	if vm := b.resolveViewForMutation(upd.Table, privilege.UPDATE); vm != nil {
+		vm.addChecks(&b.viewChecks)
+		return b.buildUpdate(vm.rewriteUpdate(upd), inScope)
+	}
+
 	// Find which table we're working on, check the permissions.
 	tab, depName, alias, refColumns := b.resolveTableForMutation(upd.Table, privilege.UPDATE)
 
@@ -86,6 +92,7 @@ func (b *Builder) buildUpdate(upd *tree.Update, inScope *scope) (outScope *scope
 
 	var mb mutationBuilder
 	mb.init(b, "update", tab, alias)
+	mb.viewChecks, b.viewChecks = b.viewChecks.checks, viewChecks{}
 
 	// Build the input expression that selects the rows that will be updated:
 	//
@@ -332,6 +339,9 @@ func (mb *mutationBuilder) buildUpdate(returning tree.ReturningExprs) {
 	// Add any check constraint boolean columns to the input.
 	mb.addCheckConstraintCols()
 
+	// Filter the input through the check options of the targeted views.
+	mb.buildViewChecks()
+
 	// Add the partial index predicate expressions to the table metadata.
 	// These expressions are used to prune fetch columns during
 	// normalization.
@@ -0,0 +1,502 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package optbuilder
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/colinfo"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/memo"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
+	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/errors"
+)
+
+// viewMutation is an automatically updatable view targeted by an INSERT,
+// UPDATE or DELETE statement. Such a statement is rewritten into one which
+// targets the table or view the view selects from, in which the references to
+// the columns of the view are replaced by the corresponding targets of the
+// view query. UPDATE and DELETE statements are also restricted to the rows
+// which pass the filter of the view.
+//
+// Unlike Postgres, which checks the privileges of the owner of the view on the
+// underlying table, the current user needs privileges on both the view and the
+// table.
+type viewMutation struct {
+	view cat.View
+
+	// alias is the alias of the view in the mutation statement, if any.
+	alias tree.Name
+
+	// query is the parsed query of the view.
+	query *schemaexpr.UpdatableView
+
+	// cols are the names of the columns of the view.
+	cols tree.NameList
+
+	// fromNames are the names of the tables in the FROM clause of an UPDATE
+	// statement, by which its expressions may qualify their columns.
+	fromNames []tree.Name
+
+	// subqueries records the subqueries of the statement, which must not be
+	// correlated. It is shared with the Builder.
+	subqueries map[*tree.Subquery]tree.Name
+
+	searchPath sessiondata.SearchPath
+}
+
+// viewCheck is a condition that rows inserted or updated through a view
+// declared WITH CHECK OPTION must satisfy: the filter of the view, or of one of
+// the views below it, rewritten in terms of the columns of the mutated table.
+type viewCheck struct {
+	// view is the name of the view the filter belongs to.
+	view tree.Name
+	expr tree.Expr
+}
+
+// viewChecks accumulates the checks of the views a mutation statement targets,
+// from the top-most view down.
+type viewChecks struct {
+	// cascaded is set once a view declared WITH CASCADED CHECK OPTION has been
+	// traversed, after which the filters of all the views below it are checked
+	// as well, whatever their own check option.
+	cascaded bool
+	checks   []viewCheck
+}
+
+// resolveViewForMutation returns the view targeted by a mutation statement
+// requiring the given privilege, or nil if the target is not a view. It raises
+// an error if the view is not updatable.
+func (b *Builder) resolveViewForMutation(n tree.TableExpr, priv privilege.Kind) *viewMutation {
+	var alias tree.Name
+	if ate, ok := n.(*tree.AliasedTableExpr); ok {
+		n = ate.Expr
+		alias = ate.As.Alias
+	}
+	tn, ok := n.(*tree.TableName)
+	if !ok {
+		return nil
+	}
+	ds, _, err := b.catalog.ResolveDataSource(b.ctx, cat.Flags{}, tn)
+	if err != nil {
+		panic(err)
+	}
+	view, ok := ds.(cat.View)
+	if !ok {
+		return nil
+	}
+	depName := opt.DepByName(tn)
+	b.checkPrivilege(depName, view, priv)
+	if priv != privilege.INSERT {
+		// Check Select permission as well, since existing values must be read.
+		b.checkPrivilege(depName, view, privilege.SELECT)
+	}
+
+	var op string
+	switch priv {
+	case privilege.INSERT:
+		op = "insert into"
+	case privilege.UPDATE:
+		op = "update"
+	default:
+		op = "delete from"
+	}
+	var query *schemaexpr.UpdatableView
+	if !view.IsSystemView() {
+		if query, err = schemaexpr.ParseUpdatableView(view.Query(), b.semaCtx.SearchPath); err != nil {
+			panic(err)
+		}
+	}
+	if query == nil {
+		panic(errors.WithDetail(
+			pgerror.Newf(pgcode.ObjectNotInPrerequisiteState, "cannot %s view %q", op, view.Name()),
+			"Only views which select from a single table or view, without WITH, DISTINCT, "+
+				"GROUP BY, HAVING, LIMIT, OFFSET, set operations, nor aggregate, window or "+
+				"set-returning functions, are automatically updatable.",
+		))
+	}
+
+	if b.viewSubqueries == nil {
+		b.viewSubqueries = make(map[*tree.Subquery]tree.Name)
+	}
+	vm := &viewMutation{
+		view:       view,
+		alias:      alias,
+		query:      query,
+		cols:       make(tree.NameList, len(query.Targets)),
+		subqueries: b.viewSubqueries,
+		searchPath: b.semaCtx.SearchPath,
+	}
+	for i := range vm.cols {
+		if view.ColumnNameCount() > 0 {
+			vm.cols[i] = view.ColumnName(i)
+			continue
+		}
+		name, err := tree.GetRenderColName(vm.searchPath, query.Targets[i])
+		if err != nil {
+			panic(err)
+		}
+		vm.cols[i] = tree.Name(name)
+	}
+	return vm
+}
+
+// rewriteInsert rewrites an INSERT statement targeting the view.
+func (vm *viewMutation) rewriteInsert(ins *tree.Insert) *tree.Insert {
+	if ins.OnConflict != nil {
+		panic(unimplemented.Newf("view upsert",
+			"UPSERT and INSERT ... ON CONFLICT into view %q are not supported", vm.view.Name()))
+	}
+	newIns := *ins
+	newIns.Table = vm.source()
+	if !ins.DefaultValues() {
+		cols := ins.Columns
+		if len(cols) == 0 {
+			// The inserted values are assigned to the columns of the view in
+			// order; VALUES rows may omit the trailing ones.
+			cols = vm.cols
+			if values, ok := ins.Rows.Select.(*tree.ValuesClause); ok && len(values.Rows[0]) < len(cols) {
+				cols = cols[:len(values.Rows[0])]
+			}
+		}
+		newIns.Columns = vm.sourceColumns(cols, "insert into")
+	}
+	newIns.Returning = vm.rewriteReturning(ins.Returning)
+	return &newIns
+}
+
+// rewriteUpdate rewrites an UPDATE statement targeting the view.
+func (vm *viewMutation) rewriteUpdate(upd *tree.Update) *tree.Update {
+	newUpd := *upd
+	newUpd.Table = vm.source()
+	vm.fromNames = vm.fromNames[:0]
+	for _, t := range upd.From.Tables {
+		vm.addFromNames(t)
+	}
+	newUpd.Exprs = make(tree.UpdateExprs, len(upd.Exprs))
+	for i, expr := range upd.Exprs {
+		newUpd.Exprs[i] = &tree.UpdateExpr{
+			Tuple: expr.Tuple,
+			Names: vm.sourceColumns(expr.Names, "update"),
+			Expr:  vm.rewriteExpr(expr.Expr),
+		}
+	}
+	newUpd.Where = vm.rewriteWhere(upd.Where)
+	newUpd.OrderBy = vm.rewriteOrderBy(upd.OrderBy)
+	newUpd.Returning = vm.rewriteReturning(upd.Returning)
+	return &newUpd
+}
+
+// rewriteDelete rewrites a DELETE statement targeting the view.
+func (vm *viewMutation) rewriteDelete(del *tree.Delete) *tree.Delete {
+	newDel := *del
+	newDel.Table = vm.source()
+	newDel.Where = vm.rewriteWhere(del.Where)
+	newDel.OrderBy = vm.rewriteOrderBy(del.OrderBy)
+	newDel.Returning = vm.rewriteReturning(del.Returning)
+	return &newDel
+}
+
+// addChecks rewrites the pending checks of the views above this one in terms
+// of the source of the view, and adds the filter of the view itself if it must
+// be checked. As in Postgres, a view declared WITH LOCAL CHECK OPTION only
+// checks its own filter, while one declared WITH CASCADED CHECK OPTION also
+// checks the filters of all the views below it.
+func (vm *viewMutation) addChecks(vc *viewChecks) {
+	for i := range vc.checks {
+		vc.checks[i].expr = vm.rewriteExpr(vc.checks[i].expr)
+	}
+	switch vm.view.CheckOption() {
+	case tree.ViewCheckOptionNone:
+		if !vc.cascaded {
+			return
+		}
+	case tree.ViewCheckOptionCascaded:
+		vc.cascaded = true
+	}
+	if vm.query.Where != nil {
+		vc.checks = append(vc.checks, viewCheck{view: vm.view.Name(), expr: vm.query.Where.Expr})
+	}
+}
+
+// source returns the table or view the view selects from, as the target of
+// the rewritten statement.
+func (vm *viewMutation) source() tree.TableExpr {
+	tn := vm.query.Source.ToTableName()
+	return &tree.AliasedTableExpr{Expr: &tn, As: tree.AliasClause{Alias: vm.query.SourceAlias}}
+}
+
+// sourceColumns returns the columns of the source of the view which the given
+// columns of the view reference. It raises an error if one of the columns is
+// not updatable.
+func (vm *viewMutation) sourceColumns(names tree.NameList, op string) tree.NameList {
+	res := make(tree.NameList, len(names))
+	for i, name := range names {
+		ord := vm.columnOrdinal(name)
+		if ord == -1 {
+			panic(pgerror.Newf(pgcode.UndefinedColumn,
+				"column %q of view %q does not exist", name, vm.view.Name()))
+		}
+		var ok bool
+		if res[i], ok = vm.query.SourceColumn(ord); !ok {
+			panic(errors.WithDetail(
+				pgerror.Newf(pgcode.FeatureNotSupported,
+					"cannot %s column %q of view %q", op, name, vm.view.Name()),
+				"View columns that are not columns of their base relation are not updatable.",
+			))
+		}
+	}
+	return res
+}
+
+// columnOrdinal returns the ordinal of the column of the view with the given
+// name, or -1 if there is none.
+func (vm *viewMutation) columnOrdinal(name tree.Name) int {
+	for i := range vm.cols {
+		if vm.cols[i] == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// isViewQualifier returns whether the given table name refers to the view in
+// the mutation statement.
+func (vm *viewMutation) isViewQualifier(name string) bool {
+	if vm.alias != "" {
+		return name == string(vm.alias)
+	}
+	return name == string(vm.view.Name())
+}
+
+// addFromNames adds the names by which the columns of the given table
+// expression of the FROM clause of an UPDATE statement can be qualified.
+func (vm *viewMutation) addFromNames(t tree.TableExpr) {
+	switch t := t.(type) {
+	case *tree.AliasedTableExpr:
+		if t.As.Alias != "" {
+			vm.fromNames = append(vm.fromNames, t.As.Alias)
+		} else if tn, ok := t.Expr.(*tree.TableName); ok {
+			vm.fromNames = append(vm.fromNames, tn.ObjectName)
+		} else {
+			vm.addFromNames(t.Expr)
+		}
+	case *tree.TableName:
+		vm.fromNames = append(vm.fromNames, t.ObjectName)
+	case *tree.ParenTableExpr:
+		vm.addFromNames(t.Expr)
+	case *tree.JoinTableExpr:
+		vm.addFromNames(t.Left)
+		vm.addFromNames(t.Right)
+	}
+}
+
+// isFromQualifier returns whether the given table name refers to a table in
+// the FROM clause of the statement.
+func (vm *viewMutation) isFromQualifier(name string) bool {
+	for _, n := range vm.fromNames {
+		if name == string(n) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkQualifier raises an error if the given table name refers neither to
+// the view nor to a table in the FROM clause of the statement.
+func (vm *viewMutation) checkQualifier(name string) error {
+	if vm.isViewQualifier(name) || vm.isFromQualifier(name) {
+		return nil
+	}
+	return pgerror.Newf(pgcode.UndefinedTable,
+		"no data source matches prefix: %s in this context", name)
+}
+
+// rewriteExpr replaces the references to the columns of the view in the given
+// expression by the corresponding targets of the view query.
+//
+// The rewritten expression is resolved against the source of the view, so any
+// other column reference is rejected here, lest it resolve to a column of the
+// source which the view does not expose. Only the references to the tables of
+// the FROM clause of an UPDATE statement are left untouched, which must be
+// qualified. Subqueries are not rewritten: they are recorded so that the
+// builder rejects them if they are correlated, since their outer column
+// references would be resolved against the source of the view.
+func (vm *viewMutation) rewriteExpr(expr tree.Expr) tree.Expr {
+	newExpr, err := tree.SimpleVisit(expr, func(e tree.Expr) (recurse bool, newExpr tree.Expr, err error) {
+		var name *tree.UnresolvedName
+		switch t := e.(type) {
+		case *tree.Subquery:
+			// A subquery may be rewritten again for each view down to the table;
+			// report the view the statement targets.
+			if _, ok := vm.subqueries[t]; !ok {
+				vm.subqueries[t] = vm.view.Name()
+			}
+			return false, e, nil
+		case *tree.AllColumnsSelector:
+			return false, e, vm.checkQualifier(t.TableName.Parts[0])
+		case *tree.UnresolvedName:
+			name = t
+		default:
+			return true, e, nil
+		}
+		if name.NumParts > 1 {
+			if err := vm.checkQualifier(name.Parts[1]); err != nil || name.Star ||
+				vm.isFromQualifier(name.Parts[1]) {
+				return false, e, err
+			}
+		} else if name.Star {
+			return false, e, nil
+		}
+		ord := vm.columnOrdinal(tree.Name(name.Parts[0]))
+		if ord == -1 {
+			return false, nil, colinfo.NewUndefinedColumnError(tree.ErrString(name))
+		}
+		return false, &tree.ParenExpr{Expr: vm.query.Targets[ord].Expr}, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	return newExpr
+}
+
+// rewriteWhere returns the filter of the rewritten statement, which restricts
+// it to the rows of the view.
+func (vm *viewMutation) rewriteWhere(where *tree.Where) *tree.Where {
+	if where == nil {
+		return vm.query.Where
+	}
+	expr := vm.rewriteExpr(where.Expr)
+	if vm.query.Where != nil {
+		expr = &tree.AndExpr{
+			Left:  &tree.ParenExpr{Expr: vm.query.Where.Expr},
+			Right: &tree.ParenExpr{Expr: expr},
+		}
+	}
+	return tree.NewWhere(tree.AstWhere, expr)
+}
+
+// rewriteOrderBy rewrites the ORDER BY clause of an UPDATE or DELETE
+// statement.
+func (vm *viewMutation) rewriteOrderBy(orderBy tree.OrderBy) tree.OrderBy {
+	if orderBy == nil {
+		return nil
+	}
+	res := make(tree.OrderBy, len(orderBy))
+	for i, o := range orderBy {
+		newOrder := *o
+		if o.OrderType == tree.OrderByColumn {
+			newOrder.Expr = vm.rewriteExpr(o.Expr)
+		}
+		res[i] = &newOrder
+	}
+	return res
+}
+
+// rewriteReturning rewrites the RETURNING clause of the statement. The
+// returned columns keep the names they have in the original statement.
+func (vm *viewMutation) rewriteReturning(returning tree.ReturningClause) tree.ReturningClause {
+	exprs, ok := returning.(*tree.ReturningExprs)
+	if !ok {
+		return returning
+	}
+	res := make(tree.ReturningExprs, 0, len(*exprs))
+	for _, expr := range *exprs {
+		if vm.isViewStar(expr.Expr) {
+			for i := range vm.cols {
+				res = append(res, tree.SelectExpr{
+					Expr: vm.query.Targets[i].Expr,
+					As:   tree.UnrestrictedName(vm.cols[i]),
+				})
+			}
+			continue
+		}
+		as := expr.As
+		if as == "" {
+			name, err := tree.GetRenderColName(vm.searchPath, expr)
+			if err != nil {
+				panic(err)
+			}
+			as = tree.UnrestrictedName(name)
+		}
+		res = append(res, tree.SelectExpr{Expr: vm.rewriteExpr(expr.Expr), As: as})
+	}
+	return &res
+}
+
+// isViewStar returns whether the given expression is a star expanding to the
+// columns of the view.
+func (vm *viewMutation) isViewStar(expr tree.Expr) bool {
+	switch t := expr.(type) {
+	case tree.UnqualifiedStar:
+		return true
+	case *tree.UnresolvedName:
+		return t.Star && (t.NumParts == 1 || (t.NumParts == 2 && vm.isViewQualifier(t.Parts[1])))
+	case *tree.AllColumnsSelector:
+		return vm.isViewQualifier(t.TableName.Parts[0])
+	}
+	return false
+}
+
+// buildViewChecks wraps the input expression in a Select operator that raises
+// an error for any new row which does not satisfy the view checks of the
+// statement. The filter never removes any row: it calls the
+// crdb_internal.check_view_option function on each check condition, which
+// errors out if the condition is not true.
+func (mb *mutationBuilder) buildViewChecks() {
+	if len(mb.viewChecks) == 0 {
+		return
+	}
+
+	// Build a scope in which the columns of the table, as named in the
+	// statement, refer to the new values of the rows.
+	checkScope := mb.outScope.replace()
+	checkScope.expr = mb.outScope.expr
+	for i, n := 0, mb.tab.ColumnCount(); i < n; i++ {
+		col := mb.tab.Column(i)
+		colID := mb.mapToReturnColID(i)
+		if col.Kind() != cat.Ordinary || colID == 0 {
+			continue
+		}
+		checkScope.cols = append(checkScope.cols, scopeColumn{
+			name:       col.ColName(),
+			table:      mb.alias,
+			typ:        col.DatumType(),
+			id:         colID,
+			visibility: col.Visibility(),
+		})
+	}
+
+	props, overloads := builtins.GetBuiltinProperties("crdb_internal.check_view_option")
+	private := &memo.FunctionPrivate{
+		Name:       "crdb_internal.check_view_option",
+		Typ:        types.Bool,
+		Properties: props,
+		Overload:   &overloads[0],
+	}
+	filters := make(memo.FiltersExpr, len(mb.viewChecks))
+	for i, check := range mb.viewChecks {
+		texpr := checkScope.resolveAndRequireType(check.expr, types.Bool)
+		cond := mb.b.buildScalar(texpr, checkScope, nil /* outScope */, nil /* outCol */, nil /* colRefs */)
+		view := mb.b.factory.ConstructConstVal(tree.NewDString(string(check.view)), types.String)
+		filters[i] = mb.b.factory.ConstructFiltersItem(
+			mb.b.factory.ConstructFunction(memo.ScalarListExpr{cond, view}, private),
+		)
+	}
+	mb.outScope.expr = mb.b.factory.ConstructSelect(mb.outScope.expr, filters)
+}
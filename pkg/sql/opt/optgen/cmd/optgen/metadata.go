@@ -244,6 +244,7 @@ func newMetadata(compiled *lang.CompiledExpr, pkg string) *metadata {
 		"SpanExpression":      {fullName: "inverted.SpanExpression", isPointer: true, usePointerIntern: true},
 		"InvertedSpans":       {fullName: "inverted.Spans", passByVal: true},
 		"Persistence":         {fullName: "tree.Persistence", passByVal: true},
+		"ViewCheckOption":     {fullName: "tree.ViewCheckOption", passByVal: true},
 		"PreFiltererState":    {fullName: "invertedexpr.PreFiltererStateForInvertedFilterer", isPointer: true, usePointerIntern: true},
 	}
 
@@ -483,6 +483,7 @@ func (tc *Catalog) resolveFK(tab *Table, d *tree.ForeignKeyConstraintTableDef) {
 		matchMethod:              d.Match,
 		deleteAction:             d.Actions.Delete,
 		updateAction:             d.Actions.Update,
+		initiallyDeferred:        d.Deferrability.InitiallyDeferred,
 	}
 	tab.outboundFKs = append(tab.outboundFKs, fk)
 	targetTable.inboundFKs = append(targetTable.inboundFKs, fk)
@@ -599,12 +600,13 @@ func (tt *Table) addIndexWithVersion(
 	}
 
 	idx := &Index{
-		IdxName:  tt.makeIndexName(def.Name, typ),
-		Unique:   typ != nonUniqueIndex,
-		Inverted: def.Inverted,
-		IdxZone:  &zonepb.ZoneConfig{},
-		table:    tt,
-		version:  version,
+		IdxName:    tt.makeIndexName(def.Name, typ),
+		Unique:     typ != nonUniqueIndex,
+		Inverted:   def.Inverted,
+		NotVisible: def.NotVisible,
+		IdxZone:    &zonepb.ZoneConfig{},
+		table:      tt,
+		version:    version,
 	}
 
 	// Look for name suffixes indicating this is a mutation index.
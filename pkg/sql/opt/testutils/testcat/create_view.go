@@ -10,7 +10,13 @@
 
 package testcat
 
-import "github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+import (
+	"context"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/paramparse"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
 
 // CreateView creates a test view from a parsed DDL statement and adds it to the
 // catalog.
@@ -21,11 +27,22 @@ func (tc *Catalog) CreateView(stmt *tree.CreateView) *View {
 	fmtCtx := tree.NewFmtCtx(tree.FmtParsable)
 	stmt.AsSource.Format(fmtCtx)
 
+	var opts paramparse.ViewStorageParamObserver
+	semaCtx := tree.MakeSemaContext()
+	evalCtx := tree.MakeTestingEvalContext(cluster.MakeTestingClusterSettings())
+	if err := paramparse.ApplyStorageParameters(
+		context.Background(), &semaCtx, &evalCtx, stmt.Options, &opts,
+	); err != nil {
+		panic(err)
+	}
+
 	view := &View{
 		ViewID:      tc.nextStableID(),
 		ViewName:    stmt.Name,
 		QueryText:   fmtCtx.CloseAndGetString(),
 		ColumnNames: stmt.ColumnNames,
+		CheckOpt:    stmt.CheckOption,
+		Invoker:     opts.SecurityInvoker,
 	}
 
 	// Add the new view to the catalog.
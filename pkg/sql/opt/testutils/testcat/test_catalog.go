@@ -511,6 +511,8 @@ type View struct {
 	ViewName    cat.DataSourceName
 	QueryText   string
 	ColumnNames tree.NameList
+	CheckOpt    tree.ViewCheckOption
+	Invoker     bool
 
 	// If Revoked is true, then the user has had privileges on the view revoked.
 	Revoked bool
@@ -573,6 +575,16 @@ func (tv *View) ColumnName(i int) tree.Name {
 	return tv.ColumnNames[i]
 }
 
+// CheckOption is part of the cat.View interface.
+func (tv *View) CheckOption() tree.ViewCheckOption {
+	return tv.CheckOpt
+}
+
+// IsSecurityInvoker is part of the cat.View interface.
+func (tv *View) IsSecurityInvoker() bool {
+	return tv.Invoker
+}
+
 // CollectTypes is part of the cat.DataSource interface.
 func (tv *View) CollectTypes(ord int) (descpb.IDs, error) {
 	return nil, nil
@@ -818,6 +830,9 @@ type Index struct {
 	// Inverted is true when this index is an inverted index.
 	Inverted bool
 
+	// NotVisible is true when this index is not visible to the optimizer.
+	NotVisible bool
+
 	Columns []cat.IndexColumn
 
 	// IdxZone is the zone associated with the index. This may be inherited from
@@ -879,6 +894,11 @@ func (ti *Index) IsInverted() bool {
 	return ti.Inverted
 }
 
+// IsNotVisible is part of the cat.Index interface.
+func (ti *Index) IsNotVisible() bool {
+	return ti.NotVisible
+}
+
 // ColumnCount is part of the cat.Index interface.
 func (ti *Index) ColumnCount() int {
 	return len(ti.Columns)
@@ -1098,10 +1118,11 @@ type ForeignKeyConstraint struct {
 	originColumnOrdinals     []int
 	referencedColumnOrdinals []int
 
-	validated    bool
-	matchMethod  tree.CompositeKeyMatchMethod
-	deleteAction tree.ReferenceAction
-	updateAction tree.ReferenceAction
+	validated         bool
+	matchMethod       tree.CompositeKeyMatchMethod
+	deleteAction      tree.ReferenceAction
+	updateAction      tree.ReferenceAction
+	initiallyDeferred bool
 }
 
 var _ cat.ForeignKeyConstraint = &ForeignKeyConstraint{}
@@ -1169,6 +1190,11 @@ func (fk *ForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction {
 	return fk.updateAction
 }
 
+// InitiallyDeferred is part of the cat.ForeignKeyConstraint interface.
+func (fk *ForeignKeyConstraint) InitiallyDeferred() bool {
+	return fk.initiallyDeferred
+}
+
 // UniqueConstraint implements cat.UniqueConstraint. See that interface
 // for more information on the fields.
 type UniqueConstraint struct {
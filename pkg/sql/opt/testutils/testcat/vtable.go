@@ -68,6 +68,7 @@ var pgCatalogTables = []string{
 	vtable.PGCatalogRoles,
 	vtable.PGCatalogSecLabels,
 	vtable.PGCatalogSequence,
+	vtable.PGCatalogSequences,
 	vtable.PGCatalogSettings,
 	vtable.PGCatalogShdepend,
 	vtable.PGCatalogTables,
@@ -200,6 +200,8 @@ type enumerateIndexFunc func(
 // filters are reduced during partial index implication, the remaining filters
 // are passed to the callback f.
 //
+// Indexes that are not visible are skipped, unless they are forced.
+//
 // If the ForceIndex flag is set on the scanPrivate, then all indexes except the
 // forced index are skipped. The index forced by the ForceIndex flag is not
 // guaranteed to be iterated on - it will be skipped if it is rejected by the
@@ -226,6 +228,11 @@ func (it *scanIndexIter) ForEachStartingAfter(ord int, f enumerateIndexFunc) {
 
 		index := it.tabMeta.Table.Index(ord)
 
+		// Skip over not visible indexes, unless the index is forced.
+		if index.IsNotVisible() && !it.scanPrivate.Flags.ForceIndex {
+			continue
+		}
+
 		// Skip over inverted indexes if rejectInvertedIndexes is set.
 		if it.hasRejectFlags(rejectInvertedIndexes) && index.IsInverted() {
 			continue
@@ -62,6 +62,11 @@ type optCatalog struct {
 
 	// tn is a temporary name used during resolution to avoid heap allocation.
 	tn tree.TableName
+
+	// catalogCountsCache memoizes, for the current query, the number of
+	// catalog objects in each database, which the statistics of the virtual
+	// tables are estimated from. See catalogCounts.
+	catalogCountsCache map[descpb.ID]*catalogCounts
 }
 
 var _ cat.Catalog = &optCatalog{}
@@ -84,6 +89,7 @@ func (oc *optCatalog) reset() {
 	}
 
 	oc.cfg = oc.planner.execCfg.SystemConfig.GetSystemConfig()
+	oc.catalogCountsCache = nil
 }
 
 // optSchema represents the parent database and schema for an object. It
@@ -533,6 +539,16 @@ func (ov *optView) ColumnName(i int) tree.Name {
 	return ov.desc.PublicColumns()[i].ColName()
 }
 
+// CheckOption is part of the cat.View interface.
+func (ov *optView) CheckOption() tree.ViewCheckOption {
+	return viewCheckOptionFromDesc(ov.desc.GetViewCheckOption())
+}
+
+// IsSecurityInvoker is part of the cat.View interface.
+func (ov *optView) IsSecurityInvoker() bool {
+	return ov.desc.GetViewSecurityInvoker()
+}
+
 // CollectTypes is part of the cat.DataSource interface.
 func (ov *optView) CollectTypes(ord int) (descpb.IDs, error) {
 	col := ov.desc.AllColumns()[ord]
@@ -842,6 +858,7 @@ func newOptTable(
 			match:             fk.Match,
 			deleteAction:      fk.OnDelete,
 			updateAction:      fk.OnUpdate,
+			initiallyDeferred: fk.InitiallyDeferred,
 		})
 	}
 	for i := range ot.desc.GetInboundFKs() {
@@ -856,6 +873,7 @@ func newOptTable(
 			match:             fk.Match,
 			deleteAction:      fk.OnDelete,
 			updateAction:      fk.OnUpdate,
+			initiallyDeferred: fk.InitiallyDeferred,
 		})
 	}
 
@@ -1286,6 +1304,11 @@ func (oi *optIndex) IsInverted() bool {
 	return oi.desc.Type == descpb.IndexDescriptor_INVERTED
 }
 
+// IsNotVisible is part of the cat.Index interface.
+func (oi *optIndex) IsNotVisible() bool {
+	return oi.desc.NotVisible
+}
+
 // ColumnCount is part of the cat.Index interface.
 func (oi *optIndex) ColumnCount() int {
 	return oi.numCols
@@ -1636,10 +1659,11 @@ type optForeignKeyConstraint struct {
 	referencedTable   cat.StableID
 	referencedColumns []descpb.ColumnID
 
-	validity     descpb.ConstraintValidity
-	match        descpb.ForeignKeyReference_Match
-	deleteAction descpb.ForeignKeyReference_Action
-	updateAction descpb.ForeignKeyReference_Action
+	validity          descpb.ConstraintValidity
+	match             descpb.ForeignKeyReference_Match
+	deleteAction      descpb.ForeignKeyReference_Action
+	updateAction      descpb.ForeignKeyReference_Action
+	initiallyDeferred bool
 }
 
 var _ cat.ForeignKeyConstraint = &optForeignKeyConstraint{}
@@ -1711,6 +1735,11 @@ func (fk *optForeignKeyConstraint) UpdateReferenceAction() tree.ReferenceAction
 	return descpb.ForeignKeyReferenceActionType[fk.updateAction]
 }
 
+// InitiallyDeferred is part of the cat.ForeignKeyConstraint interface.
+func (fk *optForeignKeyConstraint) InitiallyDeferred() bool {
+	return fk.initiallyDeferred
+}
+
 // optVirtualTable is similar to optTable but is used with virtual tables.
 type optVirtualTable struct {
 	desc catalog.TableDescriptor
@@ -1747,6 +1776,10 @@ type optVirtualTable struct {
 	// colMap is a mapping from unique ColumnID to column ordinal within the
 	// table. This is a common lookup that needs to be fast.
 	colMap catalog.TableColMap
+
+	// stats are the statistics synthesized from the size of the catalog (see
+	// makeVirtualTableStats).
+	stats []virtualTableStat
 }
 
 var _ cat.Table = &optVirtualTable{}
@@ -1756,6 +1789,7 @@ func newOptVirtualTable(
 ) (*optVirtualTable, error) {
 	// Calculate the stable ID (see the comment for optVirtualTable.id).
 	id := cat.StableID(desc.GetID())
+	var dbID descpb.ID
 	if name.Catalog() != "" {
 		// TODO(radu): it's unfortunate that we have to lookup the schema again.
 		_, prefixI, err := oc.planner.LookupSchema(ctx, name.Catalog(), name.Schema())
@@ -1774,9 +1808,11 @@ func newOptVirtualTable(
 			// virtual tables do not "contain" the same information in
 			// both cases.
 			id |= cat.StableID(math.MaxUint32) << 32
+			dbID = math.MaxUint32
 		} else {
 			prefix := prefixI.(*catalog.ResolvedObjectPrefix)
 			id |= cat.StableID(prefix.Database.GetID()) << 32
+			dbID = prefix.Database.GetID()
 		}
 	}
 
@@ -1853,6 +1889,8 @@ func newOptVirtualTable(
 		}
 	}
 
+	ot.stats = oc.makeVirtualTableStats(ctx, ot, dbID)
+
 	return ot, nil
 }
 
@@ -1941,12 +1979,12 @@ func (ot *optVirtualTable) Index(i cat.IndexOrdinal) cat.Index {
 
 // StatisticCount is part of the cat.Table interface.
 func (ot *optVirtualTable) StatisticCount() int {
-	return 0
+	return len(ot.stats)
 }
 
 // Statistic is part of the cat.Table interface.
 func (ot *optVirtualTable) Statistic(i int) cat.TableStatistic {
-	panic(errors.AssertionFailedf("no stats"))
+	return &ot.stats[i]
 }
 
 // CheckCount is part of the cat.Table interface.
@@ -2045,6 +2083,11 @@ func (oi *optVirtualIndex) IsInverted() bool {
 	return false
 }
 
+// IsNotVisible is part of the cat.Index interface.
+func (oi *optVirtualIndex) IsNotVisible() bool {
+	return false
+}
+
 // ColumnCount is part of the cat.Index interface.
 func (oi *optVirtualIndex) ColumnCount() int {
 	return oi.numCols
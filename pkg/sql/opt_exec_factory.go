@@ -1668,6 +1668,9 @@ func (ef *execFactory) ConstructCreateView(
 	replace bool,
 	persistence tree.Persistence,
 	materialized bool,
+	checkOption tree.ViewCheckOption,
+	securityInvoker bool,
+	securityBarrier bool,
 	viewQuery string,
 	columns colinfo.ResultColumns,
 	deps opt.ViewDeps,
@@ -1711,16 +1714,19 @@ func (ef *execFactory) ConstructCreateView(
 	})
 
 	return &createViewNode{
-		viewName:     viewName,
-		ifNotExists:  ifNotExists,
-		replace:      replace,
-		materialized: materialized,
-		persistence:  persistence,
-		viewQuery:    viewQuery,
-		dbDesc:       schema.(*optSchema).database,
-		columns:      columns,
-		planDeps:     planDeps,
-		typeDeps:     typeDepSet,
+		viewName:        viewName,
+		ifNotExists:     ifNotExists,
+		replace:         replace,
+		materialized:    materialized,
+		checkOption:     checkOption,
+		securityInvoker: securityInvoker,
+		securityBarrier: securityBarrier,
+		persistence:     persistence,
+		viewQuery:       viewQuery,
+		dbDesc:          schema.(*optSchema).database,
+		columns:         columns,
+		planDeps:        planDeps,
+		typeDeps:        typeDepSet,
 	}, nil
 }
 
@@ -89,6 +89,19 @@ func applyFillFactorStorageParam(evalCtx *tree.EvalContext, key string, datum tr
 	return nil
 }
 
+// datumAsBool returns the boolean value of a storage parameter, which may be
+// given either as a boolean or as a string such as "on" or "off".
+func datumAsBool(evalCtx *tree.EvalContext, key string, datum tree.Datum) (bool, error) {
+	if stringVal, err := DatumAsString(evalCtx, key, datum); err == nil {
+		return ParseBoolVar(key, stringVal)
+	}
+	s, err := GetSingleBool(key, datum)
+	if err != nil {
+		return false, err
+	}
+	return bool(*s), nil
+}
+
 // RunPostChecks implements the StorageParamObserver interface.
 func (a *TableStorageParamObserver) RunPostChecks() error {
 	return nil
@@ -102,18 +115,9 @@ func (a *TableStorageParamObserver) Apply(
 	case `fillfactor`:
 		return applyFillFactorStorageParam(evalCtx, key, datum)
 	case `autovacuum_enabled`:
-		var boolVal bool
-		if stringVal, err := DatumAsString(evalCtx, key, datum); err == nil {
-			boolVal, err = ParseBoolVar(key, stringVal)
-			if err != nil {
-				return err
-			}
-		} else {
-			s, err := GetSingleBool(key, datum)
-			if err != nil {
-				return err
-			}
-			boolVal = bool(*s)
+		boolVal, err := datumAsBool(evalCtx, key, datum)
+		if err != nil {
+			return err
 		}
 		if !boolVal && evalCtx != nil {
 			evalCtx.ClientNoticeSender.BufferClientNotice(
@@ -281,3 +285,36 @@ func (a *IndexStorageParamObserver) RunPostChecks() error {
 	}
 	return nil
 }
+
+// ViewStorageParamObserver observes the options of views.
+type ViewStorageParamObserver struct {
+	// SecurityInvoker is set if the underlying relations of the view are to be
+	// accessed with the privileges of the user querying the view.
+	SecurityInvoker bool
+	// SecurityBarrier is set if the view is meant to prevent the leakage of
+	// rows it filters out.
+	SecurityBarrier bool
+}
+
+var _ StorageParamObserver = (*ViewStorageParamObserver)(nil)
+
+// Apply implements the StorageParamObserver interface.
+func (a *ViewStorageParamObserver) Apply(
+	evalCtx *tree.EvalContext, key string, datum tree.Datum,
+) error {
+	var err error
+	switch key {
+	case `security_invoker`:
+		a.SecurityInvoker, err = datumAsBool(evalCtx, key, datum)
+		return err
+	case `security_barrier`:
+		a.SecurityBarrier, err = datumAsBool(evalCtx, key, datum)
+		return err
+	}
+	return errors.Errorf("invalid view option %q", key)
+}
+
+// RunPostChecks implements the StorageParamObserver interface.
+func (a *ViewStorageParamObserver) RunPostChecks() error {
+	return nil
+}
@@ -74,6 +74,10 @@ func TestContextualHelp(t *testing.T) {
 		{`ALTER SCHEMA x RENAME ??`, `ALTER SCHEMA`},
 		{`ALTER SCHEMA x OWNER ??`, `ALTER SCHEMA`},
 
+		{`ALTER DEFAULT PRIVILEGES ??`, `ALTER DEFAULT PRIVILEGES`},
+		{`ALTER DEFAULT PRIVILEGES FOR ROLE foo ??`, `ALTER DEFAULT PRIVILEGES`},
+		{`ALTER DEFAULT PRIVILEGES GRANT SELECT ON ??`, `ALTER DEFAULT PRIVILEGES`},
+
 		{`ALTER USER IF ??`, `ALTER ROLE`},
 		{`ALTER USER foo WITH PASSWORD ??`, `ALTER ROLE`},
 
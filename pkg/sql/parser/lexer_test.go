@@ -27,6 +27,8 @@ func TestLexer(t *testing.T) {
 		{`NOT IN`, []int{NOT_LA, IN}},
 		{`NOT SIMILAR`, []int{NOT_LA, SIMILAR}},
 		{`AS OF SYSTEM TIME`, []int{AS_LA, OF, SYSTEM, TIME}},
+		{`GENERATED ALWAYS`, []int{GENERATED_ALWAYS, ALWAYS}},
+		{`GENERATED BY DEFAULT`, []int{GENERATED_BY_DEFAULT, BY, DEFAULT}},
 	}
 	for i, d := range testData {
 		s := makeScanner(d.sql)
@@ -436,6 +436,12 @@ func (u *sqlSymUnion) idxElem() tree.IndexElem {
 func (u *sqlSymUnion) idxElems() tree.IndexElemList {
     return u.val.(tree.IndexElemList)
 }
+func (u *sqlSymUnion) exclusionElem() tree.ExclusionConstraintElem {
+    return u.val.(tree.ExclusionConstraintElem)
+}
+func (u *sqlSymUnion) exclusionElems() tree.ExclusionConstraintElemList {
+    return u.val.(tree.ExclusionConstraintElemList)
+}
 func (u *sqlSymUnion) dropBehavior() tree.DropBehavior {
     return u.val.(tree.DropBehavior)
 }
@@ -523,6 +529,9 @@ func (u *sqlSymUnion) compositeKeyMatchMethod() tree.CompositeKeyMatchMethod {
 func (u *sqlSymUnion) referenceAction() tree.ReferenceAction {
     return u.val.(tree.ReferenceAction)
 }
+func (u *sqlSymUnion) constraintDeferrability() tree.ConstraintDeferrability {
+    return u.val.(tree.ConstraintDeferrability)
+}
 func (u *sqlSymUnion) referenceActions() tree.ReferenceActions {
     return u.val.(tree.ReferenceActions)
 }
@@ -574,6 +583,9 @@ func (u *sqlSymUnion) executorType() tree.ScheduledJobExecutorType {
 func (u *sqlSymUnion) refreshDataOption() tree.RefreshDataOption {
   return u.val.(tree.RefreshDataOption)
 }
+func (u *sqlSymUnion) viewCheckOption() tree.ViewCheckOption {
+  return u.val.(tree.ViewCheckOption)
+}
 func (u *sqlSymUnion) locality() *tree.Locality {
   return u.val.(*tree.Locality)
 }
@@ -586,6 +598,15 @@ func (u *sqlSymUnion) objectNamePrefix() tree.ObjectNamePrefix {
 func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
     return u.val.(tree.ObjectNamePrefixList)
 }
+func (u *sqlSymUnion) abbreviatedGrant() tree.AbbreviatedGrant {
+  return u.val.(tree.AbbreviatedGrant)
+}
+func (u *sqlSymUnion) abbreviatedRevoke() tree.AbbreviatedRevoke {
+  return u.val.(tree.AbbreviatedRevoke)
+}
+func (u *sqlSymUnion) alterDefaultPrivilegesTargetObject() tree.AlterDefaultPrivilegesTargetObject {
+  return u.val.(tree.AlterDefaultPrivilegesTargetObject)
+}
 %}
 
 // NB: the %token definitions must come before the %type definitions in this
@@ -613,7 +634,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %token <str> BUCKET_COUNT
 %token <str> BOOLEAN BOTH BOX2D BUNDLE BY
 
-%token <str> CACHE CANCEL CANCELQUERY CASCADE CASE CAST CBRT CHANGEFEED CHAR
+%token <str> CACHE CANCEL CANCELQUERY CASCADE CASCADED CASE CAST CBRT CHANGEFEED CHAR
 %token <str> CHARACTER CHARACTERISTICS CHECK CLOSE
 %token <str> CLUSTER COALESCE COLLATE COLLATION COLUMN COLUMNS COMMENT COMMENTS COMMIT
 %token <str> COMMITTED COMPACT COMPLETE CONCAT CONCURRENTLY CONFIGURATION CONFIGURATIONS CONFIGURE
@@ -657,7 +678,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %token <str> LANGUAGE LAST LATERAL LATEST LC_CTYPE LC_COLLATE
 %token <str> LEADING LEASE LEAST LEFT LESS LEVEL LIKE LIMIT
 %token <str> LINESTRING LINESTRINGM LINESTRINGZ LINESTRINGZM
-%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOOKUP LOW LSHIFT
+%token <str> LIST LOCAL LOCALITY LOCALTIME LOCALTIMESTAMP LOCKED LOGIN LOGINATTEMPTS LOOKUP LOW LSHIFT
 
 %token <str> MATCH MATERIALIZED MERGE MINVALUE MAXVALUE METHOD MINUTE MODIFYCLUSTERSETTING MONTH
 %token <str> MULTILINESTRING MULTILINESTRINGM MULTILINESTRINGZ MULTILINESTRINGZM
@@ -671,7 +692,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %token <str> OF OFF OFFSET OID OIDS OIDVECTOR ON ONLY OPT OPTION OPTIONS OR
 %token <str> ORDER ORDINALITY OTHERS OUT OUTER OVER OVERLAPS OVERLAY OWNED OWNER OPERATOR
 
-%token <str> PARENT PARTIAL PARTITION PARTITIONS PASSWORD PAUSE PAUSED PHYSICAL PLACING
+%token <str> PARENT PARTIAL PARTITION PARTITIONS PASSWORD PASSWORDCOMPLEXITY PASSWORDLIFETIME PAUSE PAUSED PHYSICAL PLACING
 %token <str> PLAN PLANS POINT POINTM POINTZ POINTZM POLYGON POLYGONM POLYGONZ POLYGONZM
 %token <str> POSITION PRECEDING PRECISION PREPARE PRESERVE PRIMARY PRIORITY PRIVILEGES
 %token <str> PROCEDURAL PUBLIC PUBLICATION
@@ -718,8 +739,9 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 // NOT, at least with respect to their left-hand subexpression. WITH_LA is
 // needed to make the grammar LALR(1). GENERATED_ALWAYS is needed to support
 // the Postgres syntax for computed columns along with our family related
-// extensions (CREATE FAMILY/CREATE FAMILY family_name).
-%token NOT_LA NULLS_LA WITH_LA AS_LA GENERATED_ALWAYS
+// extensions (CREATE FAMILY/CREATE FAMILY family_name). GENERATED_BY_DEFAULT
+// is needed to support the Postgres syntax for identity columns.
+%token NOT_LA NULLS_LA WITH_LA AS_LA GENERATED_ALWAYS GENERATED_BY_DEFAULT
 
 %union {
   id    int32
@@ -744,6 +766,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <tree.Statement> alter_role_stmt
 %type <tree.Statement> alter_type_stmt
 %type <tree.Statement> alter_schema_stmt
+%type <tree.Statement> alter_default_privileges_stmt
 %type <tree.Statement> alter_unsupported_stmt
 
 // ALTER RANGE
@@ -990,10 +1013,11 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 
 %type <str> name opt_name opt_name_parens
 %type <str> privilege savepoint_name
-%type <tree.KVOption> role_option password_clause valid_until_clause
+%type <tree.KVOption> role_option password_clause valid_until_clause password_policy_clause
 %type <tree.Operator> subquery_op
 %type <*tree.UnresolvedName> func_name func_name_no_crdb_extra
 %type <str> opt_class opt_collate
+%type <str> opt_exclusion_using exclusion_op
 
 %type <str> cursor_name database_name index_name opt_index_name column_name insert_column_item statistics_name window_name
 %type <str> family_name opt_family_name table_alias_name constraint_name target_name zone_name partition_name collation_name
@@ -1037,6 +1061,8 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <tree.OrderBy> sort_clause single_sort_clause opt_sort_clause
 %type <[]*tree.Order> sortby_list
 %type <tree.IndexElemList> index_params create_as_params
+%type <tree.ExclusionConstraintElemList> exclusion_elems
+%type <tree.ExclusionConstraintElem> exclusion_elem
 %type <tree.NameList> name_list privilege_list
 %type <[]int32> opt_array_bounds
 %type <tree.From> from_clause
@@ -1058,6 +1084,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <tree.ReturningClause> returning_clause
 %type <empty> opt_using_clause
 %type <tree.RefreshDataOption> opt_clear_data
+%type <tree.ViewCheckOption> opt_view_check_option
 
 %type <[]tree.SequenceOption> sequence_option_list opt_sequence_option_list
 %type <tree.SequenceOption> sequence_option_elem
@@ -1078,7 +1105,13 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <types.IntervalTypeMetadata> opt_interval_qualifier interval_qualifier interval_second
 %type <tree.Expr> overlay_placing
 
-%type <bool> opt_unique opt_concurrently opt_cluster opt_without_index
+%type <bool> opt_unique opt_concurrently opt_cluster opt_without_index opt_index_visible
+%type <bool> opt_with_grant_option
+%type <[]security.SQLUsername> opt_for_roles
+%type <tree.ObjectNamePrefixList> opt_in_schemas
+%type <tree.AlterDefaultPrivilegesTargetObject> alter_default_privileges_target_object
+%type <tree.AbbreviatedGrant> abbreviated_grant_stmt
+%type <tree.AbbreviatedRevoke> abbreviated_revoke_stmt
 %type <bool> opt_index_access_method
 
 %type <*tree.Limit> limit_clause offset_clause opt_limit_clause
@@ -1097,7 +1130,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <tree.NameList> opt_storing
 %type <*tree.ColumnTableDef> column_def
 %type <tree.TableDef> table_elem
-%type <tree.Expr> where_clause opt_where_clause
+%type <tree.Expr> where_clause opt_where_clause opt_exclusion_where
 %type <*tree.ArraySubscript> array_subscript
 %type <tree.Expr> opt_slice_bound
 %type <*tree.IndexFlags> opt_index_flags
@@ -1186,6 +1219,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 %type <tree.ColumnQualification> col_qualification_elem create_as_col_qualification_elem
 %type <tree.CompositeKeyMatchMethod> key_match
 %type <tree.ReferenceActions> reference_actions
+%type <tree.ConstraintDeferrability> opt_fk_deferrable
 %type <tree.ReferenceAction> reference_action reference_on_delete reference_on_update
 
 %type <tree.Expr> func_application func_expr_common_subexpr special_function
@@ -1209,7 +1243,7 @@ func (u *sqlSymUnion) objectNamePrefixList() tree.ObjectNamePrefixList {
 
 %type <[]tree.ColumnID> opt_tableref_col_list tableref_col_list
 
-%type <tree.TargetList> targets targets_roles target_types changefeed_targets
+%type <tree.TargetList> targets targets_roles target_types changefeed_targets all_in_schema_targets
 %type <*tree.TargetList> opt_on_targets_roles opt_backup_targets
 %type <tree.NameList> for_grantee_clause
 %type <privilege.List> privileges
@@ -1334,7 +1368,7 @@ stmt:
 
 // %Help: ALTER
 // %Category: Group
-// %Text: ALTER TABLE, ALTER INDEX, ALTER VIEW, ALTER SEQUENCE, ALTER DATABASE, ALTER USER, ALTER ROLE
+// %Text: ALTER TABLE, ALTER INDEX, ALTER VIEW, ALTER SEQUENCE, ALTER DATABASE, ALTER USER, ALTER ROLE, ALTER DEFAULT PRIVILEGES
 alter_stmt:
   alter_ddl_stmt      // help texts in sub-rule
 | alter_role_stmt     // EXTEND WITH HELP: ALTER ROLE
@@ -1351,6 +1385,7 @@ alter_ddl_stmt:
 | alter_partition_stmt // EXTEND WITH HELP: ALTER PARTITION
 | alter_schema_stmt    // EXTEND WITH HELP: ALTER SCHEMA
 | alter_type_stmt      // EXTEND WITH HELP: ALTER TYPE
+| alter_default_privileges_stmt // EXTEND WITH HELP: ALTER DEFAULT PRIVILEGES
 
 // %Help: ALTER TABLE - change the definition of a table
 // %Category: DDL
@@ -1455,6 +1490,7 @@ alter_view_stmt:
 // %Category: DDL
 // %Text:
 // ALTER SEQUENCE [IF EXISTS] <name>
+//   [AS <integer_type>]
 //   [INCREMENT <increment>]
 //   [MINVALUE <minvalue> | NO MINVALUE]
 //   [MAXVALUE <maxvalue> | NO MAXVALUE]
@@ -1583,6 +1619,7 @@ alter_range_stmt:
 //   ALTER INDEX ... UNSPLIT AT <selectclause>
 //   ALTER INDEX ... UNSPLIT ALL
 //   ALTER INDEX ... SCATTER [ FROM ( <exprs...> ) TO ( <exprs...> ) ]
+//   ALTER INDEX ... [VISIBLE | NOT VISIBLE]
 //
 // Zone configurations:
 //   DISCARD
@@ -2086,6 +2123,14 @@ alter_index_cmd:
       PartitionByIndex: $1.partitionByIndex(),
     }
   }
+| VISIBLE
+  {
+    $$.val = &tree.AlterIndexVisible{NotVisible: false}
+  }
+| NOT VISIBLE
+  {
+    $$.val = &tree.AlterIndexVisible{NotVisible: true}
+  }
 
 alter_column_default:
   SET DEFAULT a_expr
@@ -2797,10 +2842,6 @@ alter_unsupported_stmt:
   {
     return unimplemented(sqllex, "alter aggregate")
   }
-| ALTER DEFAULT error
-  {
-    return unimplemented(sqllex, "alter default privileges")
-  }
 
 
 // %Help: IMPORT - load data from file in a distributed manner
@@ -3153,6 +3194,10 @@ comment_stmt:
   {
     $$.val = &tree.CommentOnDatabase{Name: tree.Name($4), Comment: $6.strPtr()}
   }
+| COMMENT ON SCHEMA qualifiable_schema_name IS comment_text
+  {
+    $$.val = &tree.CommentOnSchema{Name: $4.objectNamePrefix(), Comment: $6.strPtr()}
+  }
 | COMMENT ON TABLE table_name IS comment_text
   {
     $$.val = &tree.CommentOnTable{Table: $4.unresolvedObjectName(), Comment: $6.strPtr()}
@@ -3174,6 +3219,10 @@ comment_stmt:
   {
     $$.val = &tree.CommentOnIndex{Index: $4.tableIndexName(), Comment: $6.strPtr()}
   }
+| COMMENT ON CONSTRAINT constraint_name ON table_name IS comment_text
+  {
+    $$.val = &tree.CommentOnConstraint{Constraint: tree.Name($4), Table: $6.unresolvedObjectName(), Comment: $8.strPtr()}
+  }
 | COMMENT ON EXTENSION error { return unimplemented(sqllex, "comment on extension") }
 
 comment_text:
@@ -4000,7 +4049,7 @@ deallocate_stmt:
 // %Category: Priv
 // %Text:
 // Grant privileges:
-//   GRANT {ALL [PRIVILEGES] | <privileges...> } ON <targets...> TO <grantees...>
+//   GRANT {ALL [PRIVILEGES] | <privileges...> } ON <targets...> TO <grantees...> [WITH GRANT OPTION]
 // Grant role membership:
 //   GRANT <roles...> TO <grantees...> [WITH ADMIN OPTION]
 //
@@ -4012,12 +4061,13 @@ deallocate_stmt:
 //   [TABLE] [<databasename> .] { <tablename> | * } [, ...]
 //   TYPE <typename> [, <typename>]...
 //   SCHEMA [<databasename> .]<schemaname> [, [<databasename> .]<schemaname>]...
+//   ALL { TABLES | SEQUENCES } IN SCHEMA [<databasename> .]<schemaname> [, ...]
 //
 // %SeeAlso: REVOKE, WEBDOCS/grant.html
 grant_stmt:
-  GRANT privileges ON targets TO name_list
+  GRANT privileges ON targets TO name_list opt_with_grant_option
   {
-    $$.val = &tree.Grant{Privileges: $2.privilegeList(), Grantees: $6.nameList(), Targets: $4.targetList()}
+    $$.val = &tree.Grant{Privileges: $2.privilegeList(), Grantees: $6.nameList(), Targets: $4.targetList(), WithGrantOption: $7.bool()}
   }
 | GRANT privilege_list TO name_list
   {
@@ -4027,11 +4077,11 @@ grant_stmt:
   {
     $$.val = &tree.GrantRole{Roles: $2.nameList(), Members: $4.nameList(), AdminOption: true}
   }
-| GRANT privileges ON TYPE target_types TO name_list
+| GRANT privileges ON TYPE target_types TO name_list opt_with_grant_option
   {
-    $$.val = &tree.Grant{Privileges: $2.privilegeList(), Targets: $5.targetList(), Grantees: $7.nameList()}
+    $$.val = &tree.Grant{Privileges: $2.privilegeList(), Targets: $5.targetList(), Grantees: $7.nameList(), WithGrantOption: $8.bool()}
   }
-| GRANT privileges ON SCHEMA schema_name_list TO name_list
+| GRANT privileges ON SCHEMA schema_name_list TO name_list opt_with_grant_option
   {
     $$.val = &tree.Grant{
       Privileges: $2.privilegeList(),
@@ -4039,23 +4089,28 @@ grant_stmt:
         Schemas: $5.objectNamePrefixList(),
       },
       Grantees: $7.nameList(),
+      WithGrantOption: $8.bool(),
     }
   }
-| GRANT privileges ON SCHEMA schema_name_list TO name_list WITH error
+| GRANT privileges ON all_in_schema_targets TO name_list opt_with_grant_option
   {
-    return unimplemented(sqllex, "grant privileges on schema with")
+    $$.val = &tree.Grant{Privileges: $2.privilegeList(), Targets: $4.targetList(), Grantees: $6.nameList(), WithGrantOption: $7.bool()}
   }
 | GRANT privileges ON SEQUENCE error
   {
     return unimplemented(sqllex, "grant privileges on sequence")
   }
+| GRANT privileges ON FUNCTION error
+  {
+    return unimplementedWithIssueDetail(sqllex, 17511, "grant privileges on function")
+  }
 | GRANT error // SHOW HELP: GRANT
 
 // %Help: REVOKE - remove access privileges and role memberships
 // %Category: Priv
 // %Text:
 // Revoke privileges:
-//   REVOKE {ALL | <privileges...> } ON <targets...> FROM <grantees...>
+//   REVOKE [GRANT OPTION FOR] {ALL | <privileges...> } ON <targets...> FROM <grantees...>
 // Revoke role membership:
 //   REVOKE [ADMIN OPTION FOR] <roles...> FROM <grantees...>
 //
@@ -4067,6 +4122,7 @@ grant_stmt:
 //   [TABLE] [<databasename> .] { <tablename> | * } [, ...]
 //   TYPE <typename> [, <typename>]...
 //   SCHEMA [<databasename> .]<schemaname> [, [<databasename> .]<schemaname]...
+//   ALL { TABLES | SEQUENCES } IN SCHEMA [<databasename> .]<schemaname> [, ...]
 //
 // %SeeAlso: GRANT, WEBDOCS/revoke.html
 revoke_stmt:
@@ -4074,6 +4130,10 @@ revoke_stmt:
   {
     $$.val = &tree.Revoke{Privileges: $2.privilegeList(), Grantees: $6.nameList(), Targets: $4.targetList()}
   }
+| REVOKE GRANT OPTION FOR privileges ON targets FROM name_list
+  {
+    $$.val = &tree.Revoke{Privileges: $5.privilegeList(), Grantees: $9.nameList(), Targets: $7.targetList(), GrantOptionFor: true}
+  }
 | REVOKE privilege_list FROM name_list
   {
     $$.val = &tree.RevokeRole{Roles: $2.nameList(), Members: $4.nameList(), AdminOption: false }
@@ -4086,6 +4146,10 @@ revoke_stmt:
   {
     $$.val = &tree.Revoke{Privileges: $2.privilegeList(), Targets: $5.targetList(), Grantees: $7.nameList()}
   }
+| REVOKE GRANT OPTION FOR privileges ON TYPE target_types FROM name_list
+  {
+    $$.val = &tree.Revoke{Privileges: $5.privilegeList(), Targets: $8.targetList(), Grantees: $10.nameList(), GrantOptionFor: true}
+  }
 | REVOKE privileges ON SCHEMA schema_name_list FROM name_list
   {
     $$.val = &tree.Revoke{
@@ -4096,12 +4160,57 @@ revoke_stmt:
       Grantees: $7.nameList(),
     }
   }
+| REVOKE GRANT OPTION FOR privileges ON SCHEMA schema_name_list FROM name_list
+  {
+    $$.val = &tree.Revoke{
+      Privileges: $5.privilegeList(),
+      Targets: tree.TargetList{
+        Schemas: $8.objectNamePrefixList(),
+      },
+      Grantees: $10.nameList(),
+      GrantOptionFor: true,
+    }
+  }
+| REVOKE privileges ON all_in_schema_targets FROM name_list
+  {
+    $$.val = &tree.Revoke{Privileges: $2.privilegeList(), Targets: $4.targetList(), Grantees: $6.nameList()}
+  }
+| REVOKE GRANT OPTION FOR privileges ON all_in_schema_targets FROM name_list
+  {
+    $$.val = &tree.Revoke{Privileges: $5.privilegeList(), Targets: $7.targetList(), Grantees: $9.nameList(), GrantOptionFor: true}
+  }
 | REVOKE privileges ON SEQUENCE error
   {
     return unimplemented(sqllex, "revoke privileges on sequence")
   }
+| REVOKE privileges ON FUNCTION error
+  {
+    return unimplementedWithIssueDetail(sqllex, 17511, "revoke privileges on function")
+  }
 | REVOKE error // SHOW HELP: REVOKE
 
+// all_in_schema_targets are the targets of GRANT and REVOKE covering every
+// table or sequence of the given schemas.
+all_in_schema_targets:
+  ALL TABLES IN SCHEMA schema_name_list
+  {
+    $$.val = tree.TargetList{Schemas: $5.objectNamePrefixList(), AllTablesInSchema: true}
+  }
+| ALL SEQUENCES IN SCHEMA schema_name_list
+  {
+    $$.val = tree.TargetList{Schemas: $5.objectNamePrefixList(), AllSequencesInSchema: true}
+  }
+
+opt_with_grant_option:
+  WITH GRANT OPTION
+  {
+    $$.val = true
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
 // ALL can either be by itself, or with the optional PRIVILEGES keyword (which no-ops)
 privileges:
   ALL opt_privileges_clause
@@ -5859,6 +5968,113 @@ alter_schema_stmt:
   }
 | ALTER SCHEMA error // SHOW HELP: ALTER SCHEMA
 
+// %Help: ALTER DEFAULT PRIVILEGES - alter default privileges on an object
+// %Category: Priv
+// %Text:
+//
+// Commands:
+//   ALTER DEFAULT PRIVILEGES [ FOR { ROLE | USER } target_roles... ] [ IN SCHEMA schema_name...] abbreviated_grant_or_revoke
+//
+// Abbreviated grant or revoke:
+//   GRANT { ALL [ PRIVILEGES ] | <privileges...> } ON { TABLES | SEQUENCES | TYPES | SCHEMAS }
+//     TO <grantees...> [ WITH GRANT OPTION ]
+//   REVOKE [ GRANT OPTION FOR ] { ALL [ PRIVILEGES ] | <privileges...> } ON { TABLES | SEQUENCES | TYPES | SCHEMAS }
+//     FROM <grantees...>
+//
+// %SeeAlso: GRANT, REVOKE, WEBDOCS/alter-default-privileges.html
+alter_default_privileges_stmt:
+  ALTER DEFAULT PRIVILEGES opt_for_roles opt_in_schemas abbreviated_grant_stmt
+  {
+    $$.val = &tree.AlterDefaultPrivileges{
+      Roles: $4.users(),
+      Schemas: $5.objectNamePrefixList(),
+      IsGrant: true,
+      Grant: $6.abbreviatedGrant(),
+    }
+  }
+| ALTER DEFAULT PRIVILEGES opt_for_roles opt_in_schemas abbreviated_revoke_stmt
+  {
+    $$.val = &tree.AlterDefaultPrivileges{
+      Roles: $4.users(),
+      Schemas: $5.objectNamePrefixList(),
+      IsGrant: false,
+      Revoke: $6.abbreviatedRevoke(),
+    }
+  }
+| ALTER DEFAULT PRIVILEGES error // SHOW HELP: ALTER DEFAULT PRIVILEGES
+
+abbreviated_grant_stmt:
+  GRANT privileges ON alter_default_privileges_target_object TO name_list opt_with_grant_option
+  {
+    $$.val = tree.AbbreviatedGrant{
+      Privileges: $2.privilegeList(),
+      Target: $4.alterDefaultPrivilegesTargetObject(),
+      Grantees: $6.nameList(),
+      WithGrantOption: $7.bool(),
+    }
+  }
+
+abbreviated_revoke_stmt:
+  REVOKE privileges ON alter_default_privileges_target_object FROM name_list
+  {
+    $$.val = tree.AbbreviatedRevoke{
+      Privileges: $2.privilegeList(),
+      Target: $4.alterDefaultPrivilegesTargetObject(),
+      Grantees: $6.nameList(),
+    }
+  }
+| REVOKE GRANT OPTION FOR privileges ON alter_default_privileges_target_object FROM name_list
+  {
+    $$.val = tree.AbbreviatedRevoke{
+      Privileges: $5.privilegeList(),
+      Target: $7.alterDefaultPrivilegesTargetObject(),
+      Grantees: $9.nameList(),
+      GrantOptionFor: true,
+    }
+  }
+
+alter_default_privileges_target_object:
+  TABLES
+  {
+    $$.val = tree.Tables
+  }
+| SEQUENCES
+  {
+    $$.val = tree.Sequences
+  }
+| TYPES
+  {
+    $$.val = tree.Types
+  }
+| SCHEMAS
+  {
+    $$.val = tree.Schemas
+  }
+
+opt_for_roles:
+  FOR ROLE role_spec_list
+  {
+    $$.val = $3.users()
+  }
+| FOR USER role_spec_list
+  {
+    $$.val = $3.users()
+  }
+| /* EMPTY */
+  {
+    $$.val = []security.SQLUsername(nil)
+  }
+
+opt_in_schemas:
+  IN SCHEMA schema_name_list
+  {
+    $$.val = $3.objectNamePrefixList()
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.ObjectNamePrefixList(nil)
+  }
+
 // %Help: CREATE TABLE - create a new table
 // %Category: DDL
 // %Text:
@@ -5884,6 +6100,7 @@ alter_schema_stmt:
 //   REFERENCES <tablename> [( <colnames...> )] [ON DELETE {NO ACTION | RESTRICT}] [ON UPDATE {NO ACTION | RESTRICT}]
 //   COLLATE <collationname>
 //   AS ( <expr> ) STORED
+//   GENERATED {ALWAYS | BY DEFAULT} AS IDENTITY [( <opt_sequence_option_list> )]
 //
 // Interleave clause:
 //    INTERLEAVE IN PARENT <tablename> ( <colnames...> ) [CASCADE | RESTRICT]
@@ -6432,6 +6649,28 @@ col_qualification_elem:
     sqllex.Error("use AS ( <expr> ) STORED or AS ( <expr> ) VIRTUAL")
     return 1
  }
+| generated_always_as IDENTITY '(' opt_sequence_option_list ')'
+ {
+    $$.val = &tree.GeneratedAsIdentityConstraint{
+      GeneratedAsIdentityType: tree.GeneratedAlways,
+      SeqOptions: $4.seqOpts(),
+    }
+ }
+| generated_by_default_as IDENTITY '(' opt_sequence_option_list ')'
+ {
+    $$.val = &tree.GeneratedAsIdentityConstraint{
+      GeneratedAsIdentityType: tree.GeneratedByDefault,
+      SeqOptions: $4.seqOpts(),
+    }
+ }
+| generated_always_as IDENTITY
+ {
+    $$.val = &tree.GeneratedAsIdentityConstraint{GeneratedAsIdentityType: tree.GeneratedAlways}
+ }
+| generated_by_default_as IDENTITY
+ {
+    $$.val = &tree.GeneratedAsIdentityConstraint{GeneratedAsIdentityType: tree.GeneratedByDefault}
+ }
 
 opt_without_index:
   WITHOUT INDEX
@@ -6447,11 +6686,17 @@ opt_without_index:
 // GENERATED ALWAYS is a noise word for compatibility with Postgres.
 generated_as:
   AS {}
-| GENERATED_ALWAYS ALWAYS AS {}
+| generated_always_as {}
+
+generated_always_as:
+  GENERATED_ALWAYS ALWAYS AS {}
+
+generated_by_default_as:
+  GENERATED_BY_DEFAULT BY DEFAULT AS {}
 
 
 index_def:
-  INDEX opt_index_name '(' index_params ')' opt_hash_sharded opt_storing opt_interleave opt_partition_by_index opt_with_storage_parameter_list opt_where_clause
+  INDEX opt_index_name '(' index_params ')' opt_hash_sharded opt_storing opt_interleave opt_partition_by_index opt_with_storage_parameter_list opt_where_clause opt_index_visible
   {
     $$.val = &tree.IndexTableDef{
       Name:             tree.Name($2),
@@ -6462,9 +6707,10 @@ index_def:
       PartitionByIndex: $9.partitionByIndex(),
       StorageParams:    $10.storageParams(),
       Predicate:        $11.expr(),
+      NotVisible:       $12.bool(),
     }
   }
-| UNIQUE INDEX opt_index_name '(' index_params ')' opt_hash_sharded opt_storing opt_interleave opt_partition_by_index opt_with_storage_parameter_list opt_where_clause
+| UNIQUE INDEX opt_index_name '(' index_params ')' opt_hash_sharded opt_storing opt_interleave opt_partition_by_index opt_with_storage_parameter_list opt_where_clause opt_index_visible
   {
     $$.val = &tree.UniqueConstraintTableDef{
       IndexTableDef: tree.IndexTableDef {
@@ -6476,10 +6722,11 @@ index_def:
         PartitionByIndex: $10.partitionByIndex(),
         StorageParams:    $11.storageParams(),
         Predicate:        $12.expr(),
+        NotVisible:       $13.bool(),
       },
     }
   }
-| INVERTED INDEX opt_name '(' index_params ')' opt_partition_by_index opt_with_storage_parameter_list opt_where_clause
+| INVERTED INDEX opt_name '(' index_params ')' opt_partition_by_index opt_with_storage_parameter_list opt_where_clause opt_index_visible
   {
     $$.val = &tree.IndexTableDef{
       Name:             tree.Name($3),
@@ -6488,9 +6735,24 @@ index_def:
       PartitionByIndex: $7.partitionByIndex(),
       StorageParams:    $8.storageParams(),
       Predicate:        $9.expr(),
+      NotVisible:       $10.bool(),
     }
   }
 
+opt_index_visible:
+  NOT VISIBLE
+  {
+    $$.val = true
+  }
+| VISIBLE
+  {
+    $$.val = false
+  }
+| /* EMPTY */
+  {
+    $$.val = false
+  }
+
 family_def:
   FAMILY opt_family_name '(' name_list ')'
   {
@@ -6547,7 +6809,7 @@ constraint_elem:
     }
   }
 | FOREIGN KEY '(' name_list ')' REFERENCES table_name
-    opt_column_list key_match reference_actions opt_deferrable
+    opt_column_list key_match reference_actions opt_fk_deferrable
   {
     name := $7.unresolvedObjectName().ToTableName()
     $$.val = &tree.ForeignKeyConstraintTableDef{
@@ -6556,11 +6818,65 @@ constraint_elem:
       ToCols: $8.nameList(),
       Match: $9.compositeKeyMatchMethod(),
       Actions: $10.referenceActions(),
+      Deferrability: $11.constraintDeferrability(),
     }
   }
-| EXCLUDE USING error
+| EXCLUDE opt_exclusion_using '(' exclusion_elems ')' opt_exclusion_where
+  {
+    $$.val = &tree.ExclusionConstraintTableDef{
+      Using: $2,
+      Elems: $4.exclusionElems(),
+      Predicate: $6.expr(),
+    }
+  }
+
+opt_exclusion_using:
+  USING name
+  {
+    $$ = $2
+  }
+| /* EMPTY */
+  {
+    $$ = ""
+  }
+
+exclusion_elems:
+  exclusion_elem
+  {
+    $$.val = tree.ExclusionConstraintElemList{$1.exclusionElem()}
+  }
+| exclusion_elems ',' exclusion_elem
+  {
+    $$.val = append($1.exclusionElems(), $3.exclusionElem())
+  }
+
+exclusion_elem:
+  index_elem WITH exclusion_op
+  {
+    $$.val = tree.ExclusionConstraintElem{Elem: $1.idxElem(), Operator: $3}
+  }
+
+// exclusion_op lists the operators that commonly appear in exclusion
+// constraints produced by pg_dump.
+exclusion_op:
+  '='            { $$ = "=" }
+| NOT_EQUALS     { $$ = "<>" }
+| '<'            { $$ = "<" }
+| '>'            { $$ = ">" }
+| LESS_EQUALS    { $$ = "<=" }
+| GREATER_EQUALS { $$ = ">=" }
+| AND_AND        { $$ = "&&" }
+| CONTAINS       { $$ = "@>" }
+| CONTAINED_BY   { $$ = "<@" }
+
+opt_exclusion_where:
+  WHERE '(' a_expr ')'
+  {
+    $$.val = $3.expr()
+  }
+| /* EMPTY */
   {
-    return unimplementedWithIssueDetail(sqllex, 46657, "add constraint exclude using")
+    $$.val = tree.Expr(nil)
   }
 
 
@@ -6673,6 +6989,34 @@ opt_deferrable:
 | INITIALLY DEFERRED { return unimplementedWithIssueDetail(sqllex, 31632, "initially deferred") }
 | INITIALLY IMMEDIATE { return unimplementedWithIssueDetail(sqllex, 31632, "initially immediate") }
 
+// opt_fk_deferrable is the form of opt_deferrable accepted by foreign key
+// constraints, which can be checked at the end of the transaction.
+opt_fk_deferrable:
+  /* EMPTY */
+  {
+    $$.val = tree.ConstraintDeferrability{}
+  }
+| DEFERRABLE
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true}
+  }
+| DEFERRABLE INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true, InitiallyDeferred: true}
+  }
+| DEFERRABLE INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true}
+  }
+| INITIALLY DEFERRED
+  {
+    $$.val = tree.ConstraintDeferrability{Deferrable: true, InitiallyDeferred: true}
+  }
+| INITIALLY IMMEDIATE
+  {
+    $$.val = tree.ConstraintDeferrability{}
+  }
+
 storing:
   COVERING
 | STORING
@@ -6744,7 +7088,7 @@ key_match:
   }
 | MATCH PARTIAL
   {
-    return unimplementedWithIssueDetail(sqllex, 20305, "match partial")
+    $$.val = tree.MatchPartial
   }
 | /* EMPTY */
   {
@@ -6816,12 +7160,13 @@ reference_action:
 // %Category: DDL
 // %Text:
 // CREATE [TEMPORARY | TEMP] SEQUENCE <seqname>
+//   [AS <integer_type>]
 //   [INCREMENT <increment>]
 //   [MINVALUE <minvalue> | NO MINVALUE]
 //   [MAXVALUE <maxvalue> | NO MAXVALUE]
 //   [START [WITH] <start>]
 //   [CACHE <cache>]
-//   [NO CYCLE]
+//   [[NO] CYCLE]
 //   [VIRTUAL]
 //
 // %SeeAlso: CREATE TABLE
@@ -6855,9 +7200,14 @@ sequence_option_list:
 | sequence_option_list sequence_option_elem  { $$.val = append($1.seqOpts(), $2.seqOpt()) }
 
 sequence_option_elem:
-  AS typename                  { return unimplementedWithIssueDetail(sqllex, 25110, $2.typeReference().SQLString()) }
-| CYCLE                        { /* SKIP DOC */
-                                 $$.val = tree.SequenceOption{Name: tree.SeqOptCycle} }
+  AS typename                  { typ, ok := tree.GetStaticallyKnownType($2.typeReference())
+                                 if !ok || typ.Family() != types.IntFamily {
+                                   sqllex.Error(fmt.Sprintf("sequence type must be smallint, integer, or bigint, not %s",
+                                     $2.typeReference().SQLString()))
+                                   return 1
+                                 }
+                                 $$.val = tree.SequenceOption{Name: tree.SeqOptAs, AsIntegerType: typ} }
+| CYCLE                        { $$.val = tree.SequenceOption{Name: tree.SeqOptCycle} }
 | NO CYCLE                     { $$.val = tree.SequenceOption{Name: tree.SeqOptNoCycle} }
 | OWNED BY NONE                { $$.val = tree.SequenceOption{Name: tree.SeqOptOwnedBy, ColumnItemVal: nil} }
 | OWNED BY column_path         { varName, err := $3.unresolvedName().NormalizeVarName()
@@ -6958,45 +7308,53 @@ role_or_group_or_user:
 
 // %Help: CREATE VIEW - create a new view
 // %Category: DDL
-// %Text: CREATE [TEMPORARY | TEMP] [MATERIALIZED] VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )] AS <source>
+// %Text: CREATE [TEMPORARY | TEMP] [MATERIALIZED] VIEW [IF NOT EXISTS] <viewname> [( <colnames...> )]
+//   [WITH ( <view_option_name> = <value> [, ...] )] AS <source>
+//   [WITH [CASCADED | LOCAL] CHECK OPTION]
 // %SeeAlso: CREATE TABLE, SHOW CREATE, WEBDOCS/create-view.html
 create_view_stmt:
-  CREATE opt_temp opt_view_recursive VIEW view_name opt_column_list AS select_stmt
+  CREATE opt_temp opt_view_recursive VIEW view_name opt_column_list opt_with_storage_parameter_list AS select_stmt opt_view_check_option
   {
     name := $5.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
       Name: name,
       ColumnNames: $6.nameList(),
-      AsSource: $8.slct(),
+      AsSource: $9.slct(),
       Persistence: $2.persistence(),
       IfNotExists: false,
       Replace: false,
+      CheckOption: $10.viewCheckOption(),
+      Options: $7.storageParams(),
     }
   }
 // We cannot use a rule like opt_or_replace here as that would cause a conflict
 // with the opt_temp rule.
-| CREATE OR REPLACE opt_temp opt_view_recursive VIEW view_name opt_column_list AS select_stmt
+| CREATE OR REPLACE opt_temp opt_view_recursive VIEW view_name opt_column_list opt_with_storage_parameter_list AS select_stmt opt_view_check_option
   {
     name := $7.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
       Name: name,
       ColumnNames: $8.nameList(),
-      AsSource: $10.slct(),
+      AsSource: $11.slct(),
       Persistence: $4.persistence(),
       IfNotExists: false,
       Replace: true,
+      CheckOption: $12.viewCheckOption(),
+      Options: $9.storageParams(),
     }
   }
-| CREATE opt_temp opt_view_recursive VIEW IF NOT EXISTS view_name opt_column_list AS select_stmt
+| CREATE opt_temp opt_view_recursive VIEW IF NOT EXISTS view_name opt_column_list opt_with_storage_parameter_list AS select_stmt opt_view_check_option
   {
     name := $8.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateView{
       Name: name,
       ColumnNames: $9.nameList(),
-      AsSource: $11.slct(),
+      AsSource: $12.slct(),
       Persistence: $2.persistence(),
       IfNotExists: true,
       Replace: false,
+      CheckOption: $13.viewCheckOption(),
+      Options: $10.storageParams(),
     }
   }
 | CREATE MATERIALIZED VIEW view_name opt_column_list AS select_stmt
@@ -7097,6 +7455,7 @@ role_option:
   }
 | password_clause
 | valid_until_clause
+| password_policy_clause
 
 
 role_options:
@@ -7129,10 +7488,54 @@ valid_until_clause:
     $$.val = tree.KVOption{Key: tree.Name(fmt.Sprintf("%s_%s",$1, $2)), Value: tree.DNull}
   }
 
+password_policy_clause:
+  PASSWORDCOMPLEXITY string_or_placeholder
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: $2.expr()}
+  }
+| PASSWORDCOMPLEXITY NULL
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.DNull}
+  }
+| PASSWORDLIFETIME string_or_placeholder
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: $2.expr()}
+  }
+| PASSWORDLIFETIME NULL
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.DNull}
+  }
+| LOGINATTEMPTS string_or_placeholder
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: $2.expr()}
+  }
+| LOGINATTEMPTS NULL
+  {
+    $$.val = tree.KVOption{Key: tree.Name($1), Value: tree.DNull}
+  }
+
 opt_view_recursive:
   /* EMPTY */ { /* no error */ }
 | RECURSIVE { return unimplemented(sqllex, "create recursive view") }
 
+opt_view_check_option:
+  WITH CHECK OPTION
+  {
+    $$.val = tree.ViewCheckOptionCascaded
+  }
+| WITH CASCADED CHECK OPTION
+  {
+    $$.val = tree.ViewCheckOptionCascaded
+  }
+| WITH LOCAL CHECK OPTION
+  {
+    $$.val = tree.ViewCheckOptionLocal
+  }
+| /* EMPTY */
+  {
+    $$.val = tree.ViewCheckOptionNone
+  }
+
 
 // %Help: CREATE TYPE -- create a type
 // %Category: DDL
@@ -7196,6 +7599,7 @@ enum_val_list:
 //        [USING HASH WITH BUCKET_COUNT = <shard_buckets>] [STORING ( <colnames...> )] [<interleave>]
 //        [PARTITION BY <partition params>]
 //        [WITH <storage_parameter_list] [WHERE <where_conds...>]
+//        [VISIBLE | NOT VISIBLE]
 //
 // Interleave clause:
 //    INTERLEAVE IN PARENT <tablename> ( <colnames...> ) [CASCADE | RESTRICT]
@@ -7203,7 +7607,7 @@ enum_val_list:
 // %SeeAlso: CREATE TABLE, SHOW INDEXES, SHOW CREATE,
 // WEBDOCS/create-index.html
 create_index_stmt:
-  CREATE opt_unique INDEX opt_concurrently opt_index_name ON table_name opt_index_access_method '(' index_params ')' opt_hash_sharded opt_storing opt_interleave opt_partition_by_index opt_with_storage_parameter_list opt_where_clause
+  CREATE opt_unique INDEX opt_concurrently opt_index_name ON table_name opt_index_access_method '(' index_params ')' opt_hash_sharded opt_storing opt_interleave opt_partition_by_index opt_with_storage_parameter_list opt_where_clause opt_index_visible
   {
     table := $7.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateIndex{
@@ -7219,9 +7623,10 @@ create_index_stmt:
       Predicate:        $17.expr(),
       Inverted:         $8.bool(),
       Concurrently:     $4.bool(),
+      NotVisible:       $18.bool(),
     }
   }
-| CREATE opt_unique INDEX opt_concurrently IF NOT EXISTS index_name ON table_name opt_index_access_method '(' index_params ')' opt_hash_sharded opt_storing opt_interleave opt_partition_by_index opt_with_storage_parameter_list opt_where_clause
+| CREATE opt_unique INDEX opt_concurrently IF NOT EXISTS index_name ON table_name opt_index_access_method '(' index_params ')' opt_hash_sharded opt_storing opt_interleave opt_partition_by_index opt_with_storage_parameter_list opt_where_clause opt_index_visible
   {
     table := $10.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateIndex{
@@ -7238,9 +7643,10 @@ create_index_stmt:
       StorageParams:    $19.storageParams(),
       Predicate:        $20.expr(),
       Concurrently:     $4.bool(),
+      NotVisible:       $21.bool(),
     }
   }
-| CREATE opt_unique INVERTED INDEX opt_concurrently opt_index_name ON table_name '(' index_params ')' opt_storing opt_interleave opt_partition_by_index opt_with_storage_parameter_list opt_where_clause
+| CREATE opt_unique INVERTED INDEX opt_concurrently opt_index_name ON table_name '(' index_params ')' opt_storing opt_interleave opt_partition_by_index opt_with_storage_parameter_list opt_where_clause opt_index_visible
   {
     table := $8.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateIndex{
@@ -7255,9 +7661,10 @@ create_index_stmt:
       StorageParams:    $15.storageParams(),
       Predicate:        $16.expr(),
       Concurrently:     $5.bool(),
+      NotVisible:       $17.bool(),
     }
   }
-| CREATE opt_unique INVERTED INDEX opt_concurrently IF NOT EXISTS index_name ON table_name '(' index_params ')' opt_storing opt_interleave opt_partition_by_index opt_with_storage_parameter_list opt_where_clause
+| CREATE opt_unique INVERTED INDEX opt_concurrently IF NOT EXISTS index_name ON table_name '(' index_params ')' opt_storing opt_interleave opt_partition_by_index opt_with_storage_parameter_list opt_where_clause opt_index_visible
   {
     table := $11.unresolvedObjectName().ToTableName()
     $$.val = &tree.CreateIndex{
@@ -7273,6 +7680,7 @@ create_index_stmt:
       StorageParams:    $18.storageParams(),
       Predicate:        $19.expr(),
       Concurrently:     $5.bool(),
+      NotVisible:       $20.bool(),
     }
   }
 | CREATE opt_unique INDEX error // SHOW HELP: CREATE INDEX
@@ -12349,6 +12757,7 @@ unreserved_keyword:
 | CANCEL
 | CANCELQUERY
 | CASCADE
+| CASCADED
 | CHANGEFEED
 | CLOSE
 | CLUSTER
@@ -12473,6 +12882,7 @@ unreserved_keyword:
 | LOCAL
 | LOCKED
 | LOGIN
+| LOGINATTEMPTS
 | LOCALITY
 | LOOKUP
 | LOW
@@ -12534,6 +12944,8 @@ unreserved_keyword:
 | PARTITION
 | PARTITIONS
 | PASSWORD
+| PASSWORDCOMPLEXITY
+| PASSWORDLIFETIME
 | PAUSE
 | PAUSED
 | PHYSICAL
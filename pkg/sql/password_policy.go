@@ -0,0 +1,416 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"unicode"
+
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/duration"
+	"github.com/cockroachdb/errors"
+)
+
+// This file implements the password policies of roles, which are configured
+// with the following role options:
+//
+// - PASSWORDCOMPLEXITY: the minimum number of character classes (lowercase
+//   letters, uppercase letters, digits and other characters) of the passwords
+//   set in cleartext for the role.
+// - PASSWORDLIFETIME: the interval after which a password set for the role
+//   expires. Setting a password sets VALID UNTIL accordingly, unless the
+//   statement sets VALID UNTIL itself, and the expiry is then enforced at
+//   login like any VALID UNTIL.
+// - LOGINATTEMPTS: the number of consecutive failed logins after which the
+//   role is locked. Only the logins which fail because of a wrong password
+//   are counted, in system.login_attempts, and the count is reset by a
+//   successful login. A locked role is unlocked by ALTER ROLE ... LOGIN or by
+//   setting its password.
+//
+// The root user is not subject to LOGINATTEMPTS, so that it can't be locked
+// out of the cluster.
+
+// maxPasswordComplexity is the number of character classes considered by
+// PASSWORDCOMPLEXITY.
+const maxPasswordComplexity = 4
+
+// passwordPolicy is the password policy of a role.
+type passwordPolicy struct {
+	// complexity is the value of PASSWORDCOMPLEXITY, or zero if not set.
+	complexity int64
+	// lifetime is the value of PASSWORDLIFETIME, or nil if not set.
+	lifetime *tree.DInterval
+}
+
+// checkPasswordPolicyOptions validates the values of the password policy
+// options of a CREATE or ALTER ROLE statement.
+func (p *planner) checkPasswordPolicyOptions(
+	ctx context.Context, roleOptions roleoption.List,
+) error {
+	for _, ro := range roleOptions {
+		switch ro.Option {
+		case roleoption.PASSWORDCOMPLEXITY, roleoption.PASSWORDLIFETIME, roleoption.LOGINATTEMPTS:
+		default:
+			continue
+		}
+		// Older nodes don't enforce the policies, nor do they know about
+		// system.login_attempts.
+		if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.PasswordPolicies) {
+			return pgerror.Newf(pgcode.FeatureNotSupported,
+				"version %v must be finalized to use the %s option",
+				clusterversion.PasswordPolicies, ro.Option)
+		}
+		isNull, val, err := ro.Value()
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		switch ro.Option {
+		case roleoption.PASSWORDCOMPLEXITY:
+			complexity, err := strconv.ParseInt(val, 10, 64)
+			if err != nil || complexity < 1 || complexity > maxPasswordComplexity {
+				return pgerror.Newf(pgcode.InvalidParameterValue,
+					"PASSWORDCOMPLEXITY must be an integer between 1 and %d", maxPasswordComplexity)
+			}
+		case roleoption.PASSWORDLIFETIME:
+			lifetime, err := tree.ParseDInterval(val)
+			if err != nil {
+				return pgerror.Wrap(err, pgcode.InvalidParameterValue, "invalid PASSWORDLIFETIME")
+			}
+			if lifetime.Duration.Compare(duration.Duration{}) <= 0 {
+				return pgerror.New(pgcode.InvalidParameterValue,
+					"PASSWORDLIFETIME must be a positive interval")
+			}
+		case roleoption.LOGINATTEMPTS:
+			attempts, err := strconv.ParseInt(val, 10, 64)
+			if err != nil || attempts < 1 {
+				return pgerror.New(pgcode.InvalidParameterValue,
+					"LOGINATTEMPTS must be a positive integer")
+			}
+		}
+	}
+	return nil
+}
+
+// getPasswordPolicy returns the password policy of a role, as stored in
+// system.role_options and overridden by the options of the CREATE or ALTER
+// ROLE statement being executed. The options must have been validated with
+// checkPasswordPolicyOptions.
+func (p *planner) getPasswordPolicy(
+	ctx context.Context, username security.SQLUsername, roleOptions roleoption.List,
+) (passwordPolicy, error) {
+	var policy passwordPolicy
+	rows, err := p.ExecCfg().InternalExecutor.QueryBufferedEx(
+		ctx, "get-password-policy", p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`SELECT option, value FROM system.public.role_options `+
+			`WHERE username = $1 AND option IN ('PASSWORDCOMPLEXITY', 'PASSWORDLIFETIME')`,
+		username,
+	)
+	if err != nil {
+		return policy, err
+	}
+	for _, row := range rows {
+		var value *string
+		if row[1] != tree.DNull {
+			v := string(tree.MustBeDString(row[1]))
+			value = &v
+		}
+		if err := policy.set(string(tree.MustBeDString(row[0])), value); err != nil {
+			return policy, err
+		}
+	}
+	for _, ro := range roleOptions {
+		var option string
+		switch ro.Option {
+		case roleoption.PASSWORDCOMPLEXITY:
+			option = "PASSWORDCOMPLEXITY"
+		case roleoption.PASSWORDLIFETIME:
+			option = "PASSWORDLIFETIME"
+		default:
+			continue
+		}
+		isNull, val, err := ro.Value()
+		if err != nil {
+			return policy, err
+		}
+		var value *string
+		if !isNull {
+			value = &val
+		}
+		if err := policy.set(option, value); err != nil {
+			return policy, err
+		}
+	}
+	return policy, nil
+}
+
+// set sets a field of the policy from the value of the corresponding role
+// option, as stored in system.role_options.
+func (pp *passwordPolicy) set(option string, value *string) error {
+	switch option {
+	case "PASSWORDCOMPLEXITY":
+		pp.complexity = 0
+		if value != nil {
+			complexity, err := strconv.ParseInt(*value, 10, 64)
+			if err != nil {
+				return errors.Wrapf(err, "invalid PASSWORDCOMPLEXITY value %q", *value)
+			}
+			pp.complexity = complexity
+		}
+	case "PASSWORDLIFETIME":
+		pp.lifetime = nil
+		if value != nil {
+			lifetime, err := tree.ParseDInterval(*value)
+			if err != nil {
+				return errors.Wrapf(err, "invalid PASSWORDLIFETIME value %q", *value)
+			}
+			pp.lifetime = lifetime
+		}
+	}
+	return nil
+}
+
+// checkPassword returns an error if the password doesn't satisfy the
+// PASSWORDCOMPLEXITY of the policy.
+func (pp *passwordPolicy) checkPassword(password string) error {
+	if pp.complexity == 0 {
+		return nil
+	}
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	classes := 0
+	for _, b := range []bool{lower, upper, digit, other} {
+		if b {
+			classes++
+		}
+	}
+	if int64(classes) < pp.complexity {
+		return errors.WithHintf(
+			pgerror.New(pgcode.InvalidPassword, "password does not satisfy the password complexity policy"),
+			"Passwords must contain characters from at least %d of the following classes: "+
+				"lowercase letters, uppercase letters, digits and other characters.", pp.complexity)
+	}
+	return nil
+}
+
+// onPasswordSet updates the state of a role whose password was set by a
+// CREATE or ALTER ROLE statement: its failed logins are forgotten and, if its
+// policy has a PASSWORDLIFETIME, the new password expires after that
+// interval. validUntilSet indicates whether the statement sets VALID UNTIL
+// itself, in which case it is left untouched.
+func (p *planner) onPasswordSet(
+	ctx context.Context,
+	opName string,
+	username security.SQLUsername,
+	policy passwordPolicy,
+	validUntilSet bool,
+) error {
+	if err := p.resetLoginAttempts(ctx, opName, username); err != nil {
+		return err
+	}
+	if policy.lifetime == nil || validUntilSet {
+		return nil
+	}
+	_, err := p.ExecCfg().InternalExecutor.ExecEx(
+		ctx, opName, p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`UPSERT INTO system.public.role_options (username, option, value) `+
+			`VALUES ($1, 'VALID UNTIL', (now() + $2::INTERVAL)::STRING)`,
+		username, policy.lifetime.Duration.String(),
+	)
+	return err
+}
+
+// resetLoginAttempts forgets the failed logins of a role, which unlocks it.
+func (p *planner) resetLoginAttempts(
+	ctx context.Context, opName string, username security.SQLUsername,
+) error {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.PasswordPolicies) {
+		// The system.login_attempts table may not exist yet, and no failed login
+		// can have been counted anyway.
+		return nil
+	}
+	_, err := p.ExecCfg().InternalExecutor.ExecEx(
+		ctx, opName, p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`DELETE FROM system.public.login_attempts WHERE username = $1`,
+		username,
+	)
+	return err
+}
+
+// LoginAttempts describes the failed logins of a user, as counted for the
+// LOGINATTEMPTS role option.
+type LoginAttempts struct {
+	// Limit is the value of LOGINATTEMPTS, or zero if the role has no limit.
+	Limit int64
+	// Failed is the number of consecutive failed logins of the user.
+	Failed int64
+}
+
+// Locked returns whether the user has reached the limit of failed logins, in
+// which case it cannot log in anymore until it is unlocked.
+func (a LoginAttempts) Locked() bool {
+	return a.Limit > 0 && a.Failed >= a.Limit
+}
+
+// loginAttemptsLimit returns the value of the LOGINATTEMPTS option of the
+// role, or zero if it has none.
+func (r *roleMetadata) loginAttemptsLimit() (int64, error) {
+	for _, opt := range r.options {
+		if opt.option != "LOGINATTEMPTS" || opt.value == nil {
+			continue
+		}
+		limit, err := strconv.ParseInt(*opt.value, 10, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "invalid LOGINATTEMPTS value %q", *opt.value)
+		}
+		return limit, nil
+	}
+	return 0, nil
+}
+
+// GetLoginAttempts returns the failed logins of the given user. The
+// LOGINATTEMPTS option is served from the RoleCache, and the failed logins
+// are only looked up for the roles which have it. The root user is never
+// subject to LOGINATTEMPTS.
+//
+// The caller is responsible for normalizing the username.
+func GetLoginAttempts(
+	ctx context.Context, execCfg *ExecutorConfig, username security.SQLUsername,
+) (LoginAttempts, error) {
+	var attempts LoginAttempts
+	if username.IsRootUser() {
+		return attempts, nil
+	}
+	ie := execCfg.InternalExecutor
+	err := runWithUserLoginTimeout(ctx, ie, false /* isRoot */, func(ctx context.Context) error {
+		var roles []roleMetadata
+		if err := execCfg.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+			descsCol := descs.NewCollection(execCfg.Settings, execCfg.LeaseManager, nil /* hydratedTables */)
+			defer descsCol.ReleaseAll(ctx)
+			var err error
+			roles, err = getCachedRoles(ctx, execCfg, txn, descsCol, tree.ObjectLookupFlags{
+				CommonLookupFlags: tree.CommonLookupFlags{Required: true},
+			})
+			return err
+		}); err != nil {
+			return err
+		}
+		i := sort.Search(len(roles), func(i int) bool {
+			return roles[i].username.Normalized() >= username.Normalized()
+		})
+		if i == len(roles) || roles[i].username != username {
+			return nil
+		}
+		limit, err := roles[i].loginAttemptsLimit()
+		if err != nil || limit == 0 {
+			return err
+		}
+		attempts.Limit = limit
+		// Use fully qualified table name to avoid looking up "".system.login_attempts.
+		row, err := ie.QueryRowEx(
+			ctx, "get-login-attempts", nil, /* txn */
+			sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+			`SELECT failed FROM system.public.login_attempts WHERE username = $1`,
+			username,
+		)
+		if err != nil || row == nil {
+			return err
+		}
+		attempts.Failed = int64(tree.MustBeDInt(row[0]))
+		return nil
+	})
+	if err != nil {
+		return LoginAttempts{}, errors.Wrapf(err, "error looking up login attempts of user %s", username)
+	}
+	return attempts, nil
+}
+
+// RecordLoginAttempt records the outcome of a login of the given user, whose
+// failed logins are as returned by GetLoginAttempts. A failed login is only
+// counted if the role of the user has a LOGINATTEMPTS limit, and a successful
+// one resets the count of failed logins. Only the logins which fail because
+// of a wrong password should be recorded as failed.
+func RecordLoginAttempt(
+	ctx context.Context,
+	ie *InternalExecutor,
+	username security.SQLUsername,
+	attempts LoginAttempts,
+	success bool,
+) error {
+	var stmt string
+	switch {
+	case success && attempts.Failed > 0:
+		stmt = `DELETE FROM system.public.login_attempts WHERE username = $1`
+	case !success && attempts.Limit > 0:
+		stmt = `INSERT INTO system.public.login_attempts (username, failed) VALUES ($1, 1) ` +
+			`ON CONFLICT (username) DO UPDATE SET failed = login_attempts.failed + 1`
+	default:
+		return nil
+	}
+	_, err := ie.ExecEx(
+		ctx, "record-login-attempt", nil, /* txn */
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		stmt, username,
+	)
+	return err
+}
+
+// getFailedLoginAttempts returns the number of consecutive failed logins of
+// every user which has some.
+func (p *planner) getFailedLoginAttempts(
+	ctx context.Context,
+) (map[security.SQLUsername]int64, error) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.PasswordPolicies) {
+		// The system.login_attempts table may not exist yet.
+		return nil, nil
+	}
+	rows, err := p.ExecCfg().InternalExecutor.QueryBufferedEx(
+		ctx, "get-failed-login-attempts", p.txn,
+		sessiondata.InternalExecutorOverride{User: security.RootUserName()},
+		`SELECT username, failed FROM system.public.login_attempts`,
+	)
+	if err != nil {
+		return nil, err
+	}
+	failed := make(map[security.SQLUsername]int64, len(rows))
+	for _, row := range rows {
+		// system tables already contain normalized usernames.
+		username := security.MakeSQLUsernameFromPreNormalizedString(string(tree.MustBeDString(row[0])))
+		failed[username] = int64(tree.MustBeDInt(row[1]))
+	}
+	return failed, nil
+}
@@ -20,6 +20,7 @@ import (
 	"strings"
 	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security"
@@ -34,6 +35,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
@@ -88,7 +90,6 @@ var pgCatalog = virtualSchema{
 		"pg_policy",
 		"pg_replication_origin_status",
 		"pg_replication_slots",
-		"pg_sequences",
 		"pg_stat_all_indexes",
 		"pg_stat_all_tables",
 		"pg_stat_archiver",
@@ -179,6 +180,7 @@ var pgCatalog = virtualSchema{
 		catconstants.PgCatalogRulesTableID:                      pgCatalogRulesTable,
 		catconstants.PgCatalogSecLabelsTableID:                  pgCatalogSecLabelsTable,
 		catconstants.PgCatalogSecurityLabelTableID:              pgCatalogSecurityLabelTable,
+		catconstants.PgCatalogSequenceTableID:                   pgCatalogSequenceTable,
 		catconstants.PgCatalogSequencesTableID:                  pgCatalogSequencesTable,
 		catconstants.PgCatalogSettingsTableID:                   pgCatalogSettingsTable,
 		catconstants.PgCatalogShadowTableID:                     pgCatalogShadowTable,
@@ -358,6 +360,16 @@ https://www.postgresql.org/docs/12/catalog-pg-attribute.html`,
 			} else {
 				isColumnComputed = ""
 			}
+			// Sets the attidentity column to 'a' if the column is an identity
+			// column generated always, 'd' if generated by default, zero byte
+			// otherwise.
+			var identity string
+			switch column.GeneratedAsIdentityType {
+			case descpb.GeneratedAsIdentityType_GENERATED_ALWAYS:
+				identity = "a"
+			case descpb.GeneratedAsIdentityType_GENERATED_BY_DEFAULT:
+				identity = "d"
+			}
 			return addRow(
 				attRelID,                        // attrelid
 				tree.NewDName(column.Name),      // attname
@@ -373,15 +385,15 @@ https://www.postgresql.org/docs/12/catalog-pg-attribute.html`,
 				tree.DNull, // attalign
 				tree.MakeDBool(tree.DBool(!column.Nullable)),          // attnotnull
 				tree.MakeDBool(tree.DBool(column.DefaultExpr != nil)), // atthasdef
-				tree.NewDString(""),               // attidentity
-				tree.NewDString(isColumnComputed), // attgenerated
-				tree.DBoolFalse,                   // attisdropped
-				tree.DBoolTrue,                    // attislocal
-				zeroVal,                           // attinhcount
-				typColl(colTyp, h),                // attcollation
-				tree.DNull,                        // attacl
-				tree.DNull,                        // attoptions
-				tree.DNull,                        // attfdwoptions
+				tree.NewDString(identity),                             // attidentity
+				tree.NewDString(isColumnComputed),                     // attgenerated
+				tree.DBoolFalse,                                       // attisdropped
+				tree.DBoolTrue,                                        // attislocal
+				zeroVal,                                               // attinhcount
+				typColl(colTyp, h),                                    // attcollation
+				tree.DNull,                                            // attacl
+				tree.DNull,                                            // attoptions
+				tree.DNull,                                            // attfdwoptions
 				// These columns were automatically created by pg_catalog_test's missing column generator.
 				tree.DNull, // atthasmissing
 			)
@@ -502,6 +514,19 @@ func getOwnerName(desc catalog.Descriptor) tree.Datum {
 	return tree.NewDName(owner.Normalized())
 }
 
+// getSchemaOwner returns the owner of a schema, if it has one. Only
+// user-defined schemas and the public schema have an owner.
+func getSchemaOwner(sc catalog.ResolvedSchema) (security.SQLUsername, bool) {
+	switch sc.Kind {
+	case catalog.SchemaUserDefined:
+		return getOwnerOfDesc(sc.Desc), true
+	case catalog.SchemaPublic:
+		// admin is the owner of the public schema.
+		return security.AdminRoleName(), true
+	}
+	return security.SQLUsername{}, false
+}
+
 var (
 	relKindTable            = tree.NewDString("r")
 	relKindIndex            = tree.NewDString("i")
@@ -537,6 +562,24 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 		if table.IsTemporary() {
 			relPersistence = relPersistenceTemporary
 		}
+		// Virtual tables have the default privileges, which Postgres represents
+		// with a NULL ACL.
+		relACL := tree.DNull
+		if !table.IsVirtualTable() {
+			objectType := privilege.Table
+			if table.IsSequence() {
+				objectType = privilege.Sequence
+			}
+			var err error
+			if relACL, err = makeACL(table, objectType); err != nil {
+				return err
+			}
+		}
+		// Only views have options, which are empty for other relations.
+		relOptions, err := viewOptionsDatum(table)
+		if err != nil {
+			return err
+		}
 		namespaceOid := h.NamespaceOid(db.GetID(), scName)
 		if err := addRow(
 			tableOid(table.GetID()),        // oid
@@ -565,8 +608,8 @@ https://www.postgresql.org/docs/9.5/catalog-pg-class.html`,
 			tree.DBoolFalse, // relhastriggers
 			tree.DBoolFalse, // relhassubclass
 			zeroVal,         // relfrozenxid
-			tree.DNull,      // relacl
-			tree.DNull,      // reloptions
+			relACL,          // relacl
+			relOptions,      // reloptions
 			// These columns were automatically created by pg_catalog_test's missing column generator.
 			tree.DNull, // relforcerowsecurity
 			tree.DNull, // relispartition
@@ -641,7 +684,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-collation.html`,
 		h := makeOidHasher()
 		return forEachDatabaseDesc(ctx, p, dbContext, false /* requiresPrivileges */, func(db catalog.DatabaseDescriptor) error {
 			namespaceOid := h.NamespaceOid(db.GetID(), pgCatalogName)
-			add := func(collName string) error {
+			add := func(collName string, collVersion tree.Datum) error {
 				return addRow(
 					h.CollationOid(collName),  // oid
 					tree.NewDString(collName), // collname
@@ -653,17 +696,21 @@ https://www.postgresql.org/docs/9.5/catalog-pg-collation.html`,
 					tree.DNull, // collcollate
 					tree.DNull, // collctype
 					// These columns were automatically created by pg_catalog_test's missing column generator.
-					tree.DNull, // collprovider
-					tree.DNull, // collversion
-					tree.DNull, // collisdeterministic
+					tree.DNull,  // collprovider
+					collVersion, // collversion
+					tree.DNull,  // collisdeterministic
 				)
 			}
-			if err := add(tree.DefaultCollationTag); err != nil {
+			if err := add(tree.DefaultCollationTag, tree.DNull); err != nil {
 				return err
 			}
+			// The version of the collation tables can be compared to the version
+			// recorded for collated string columns (see
+			// information_schema.columns.crdb_collation_version).
+			collVersion := tree.NewDString(tree.CollationVersion)
 			for _, tag := range collate.Supported() {
 				collName := tag.String()
-				if err := add(collName); err != nil {
+				if err := add(collName, collVersion); err != nil {
 					return err
 				}
 			}
@@ -726,8 +773,10 @@ func populateTableConstraints(
 	namespaceOid := h.NamespaceOid(db.GetID(), scName)
 	tblOid := tableOid(table.GetID())
 	for conName, con := range conInfo {
-		oid := tree.DNull
+		oid := h.ConstraintOid(db.GetID(), scName, table.GetID(), con)
 		contype := tree.DNull
+		condeferrable := tree.DBoolFalse
+		condeferred := tree.DBoolFalse
 		conindid := oidZero
 		confrelid := oidZero
 		confupdtype := tree.DNull
@@ -743,18 +792,16 @@ func populateTableConstraints(
 		var err error
 		switch con.Kind {
 		case descpb.ConstraintTypePK:
-			oid = h.PrimaryKeyConstraintOid(db.GetID(), scName, table.GetID(), con.Index)
 			contype = conTypePKey
 			conindid = h.IndexOid(table.GetID(), con.Index.ID)
 
 			var err error
-			if conkey, err = colIDArrayToDatum(con.Index.ColumnIDs); err != nil {
+			if conkey, err = colIDArrayToDatum(table, con.Index.ColumnIDs); err != nil {
 				return err
 			}
 			condef = tree.NewDString(table.PrimaryKeyString())
 
 		case descpb.ConstraintTypeFK:
-			oid = h.ForeignKeyConstraintOid(db.GetID(), scName, table.GetID(), con.FK)
 			contype = conTypeFK
 			// Foreign keys don't have a single linked index. Pick the first one
 			// that matches on the referenced table.
@@ -781,10 +828,12 @@ func populateTableConstraints(
 			if r, ok := fkMatchMap[con.FK.Match]; ok {
 				confmatchtype = r
 			}
-			if conkey, err = colIDArrayToDatum(con.FK.OriginColumnIDs); err != nil {
+			condeferrable = tree.MakeDBool(tree.DBool(con.FK.Deferrable))
+			condeferred = tree.MakeDBool(tree.DBool(con.FK.InitiallyDeferred))
+			if conkey, err = colIDArrayToDatum(table, con.FK.OriginColumnIDs); err != nil {
 				return err
 			}
-			if confkey, err = colIDArrayToDatum(con.FK.ReferencedColumnIDs); err != nil {
+			if confkey, err = colIDArrayToDatum(referencedTable, con.FK.ReferencedColumnIDs); err != nil {
 				return err
 			}
 			var buf bytes.Buffer
@@ -802,10 +851,9 @@ func populateTableConstraints(
 			contype = conTypeUnique
 			f := tree.NewFmtCtx(tree.FmtSimple)
 			if con.Index != nil {
-				oid = h.UniqueConstraintOid(db.GetID(), scName, table.GetID(), con.Index.ID)
 				conindid = h.IndexOid(table.GetID(), con.Index.ID)
 				var err error
-				if conkey, err = colIDArrayToDatum(con.Index.ColumnIDs); err != nil {
+				if conkey, err = colIDArrayToDatum(table, con.Index.ColumnIDs); err != nil {
 					return err
 				}
 				f.WriteString("UNIQUE (")
@@ -819,9 +867,6 @@ func populateTableConstraints(
 					f.WriteString(fmt.Sprintf(" WHERE (%s)", pred))
 				}
 			} else if con.UniqueWithoutIndexConstraint != nil {
-				oid = h.UniqueWithoutIndexConstraintOid(
-					db.GetID(), scName, table.GetID(), con.UniqueWithoutIndexConstraint,
-				)
 				f.WriteString("UNIQUE WITHOUT INDEX (")
 				colNames, err := table.NamesForColumnIDs(con.UniqueWithoutIndexConstraint.ColumnIDs)
 				if err != nil {
@@ -849,9 +894,8 @@ func populateTableConstraints(
 			condef = tree.NewDString(f.CloseAndGetString())
 
 		case descpb.ConstraintTypeCheck:
-			oid = h.CheckConstraintOid(db.GetID(), scName, table.GetID(), con.CheckConstraint)
 			contype = conTypeCheck
-			if conkey, err = colIDArrayToDatum(con.CheckConstraint.ColumnIDs); err != nil {
+			if conkey, err = colIDArrayToDatum(table, con.CheckConstraint.ColumnIDs); err != nil {
 				return err
 			}
 			displayExpr, err := schemaexpr.FormatExprForDisplay(ctx, table, con.Details, &p.semaCtx, tree.FmtPGCatalog)
@@ -872,8 +916,8 @@ func populateTableConstraints(
 			dNameOrNull(conName), // conname
 			namespaceOid,         // connamespace
 			contype,              // contype
-			tree.DBoolFalse,      // condeferrable
-			tree.DBoolFalse,      // condeferred
+			condeferrable,        // condeferrable
+			condeferred,          // condeferred
 			tree.MakeDBool(tree.DBool(!con.Unvalidated)), // convalidated
 			tblOid,         // conrelid
 			oidZero,        // contypid
@@ -1022,25 +1066,40 @@ https://www.postgresql.org/docs/9.5/catalog-pg-constraint.html`,
 	false,       /* includesIndexEntries */
 	populateTableConstraints)
 
-// colIDArrayToDatum returns an int[] containing the ColumnIDs, or NULL if there
-// are no ColumnIDs.
-func colIDArrayToDatum(arr []descpb.ColumnID) (tree.Datum, error) {
+// columnAttributeNum returns the attribute number of the column of the table
+// with the given ID, which is how pg_attribute and information_schema.columns
+// identify the column. Attribute numbers are stable: they are neither
+// compacted after a column is dropped nor reused, as described in
+// catalog.Column.GetPGAttributeNum.
+func columnAttributeNum(table catalog.TableDescriptor, id descpb.ColumnID) uint32 {
+	col, err := table.FindColumnWithID(id)
+	if err != nil {
+		return uint32(id)
+	}
+	return col.GetPGAttributeNum()
+}
+
+// colIDArrayToDatum returns an int[] containing the attribute numbers of the
+// columns of the table with the given ColumnIDs, or NULL if there are no
+// ColumnIDs.
+func colIDArrayToDatum(table catalog.TableDescriptor, arr []descpb.ColumnID) (tree.Datum, error) {
 	if len(arr) == 0 {
 		return tree.DNull, nil
 	}
 	d := tree.NewDArray(types.Int2)
 	for _, val := range arr {
-		if err := d.Append(tree.NewDInt(tree.DInt(val))); err != nil {
+		if err := d.Append(tree.NewDInt(tree.DInt(columnAttributeNum(table, val)))); err != nil {
 			return nil, err
 		}
 	}
 	return d, nil
 }
 
-// colIDArrayToVector returns an INT2VECTOR containing the ColumnIDs, or NULL if
-// there are no ColumnIDs.
-func colIDArrayToVector(arr []descpb.ColumnID) (tree.Datum, error) {
-	dArr, err := colIDArrayToDatum(arr)
+// colIDArrayToVector returns an INT2VECTOR containing the attribute numbers of
+// the columns of the table with the given ColumnIDs, or NULL if there are no
+// ColumnIDs.
+func colIDArrayToVector(table catalog.TableDescriptor, arr []descpb.ColumnID) (tree.Datum, error) {
+	dArr, err := colIDArrayToDatum(table, arr)
 	if err != nil {
 		return nil, err
 	}
@@ -1067,6 +1126,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-database.html`,
 	populate: func(ctx context.Context, p *planner, _ catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
 		return forEachDatabaseDesc(ctx, p, nil /*all databases*/, false, /* requiresPrivileges */
 			func(db catalog.DatabaseDescriptor) error {
+				datACL, err := makeACL(db, privilege.Database)
+				if err != nil {
+					return err
+				}
 				return addRow(
 					dbOid(db.GetID()),           // oid
 					tree.NewDName(db.GetName()), // datname
@@ -1083,20 +1146,99 @@ https://www.postgresql.org/docs/9.5/catalog-pg-database.html`,
 					tree.DNull,                 // datfrozenxid
 					tree.DNull,                 // datminmxid
 					oidZero,                    // dattablespace
-					tree.DNull,                 // datacl
+					datACL,                     // datacl
 				)
 			})
 	},
 }
 
 var pgCatalogDefaultACLTable = virtualSchemaTable{
-	comment: `default ACLs (empty - unimplemented)
+	comment: `default ACLs; these are the privileges that will be assigned to newly created objects
 https://www.postgresql.org/docs/9.6/catalog-pg-default-acl.html`,
 	schema: vtable.PGCatalogDefaultACL,
 	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
-		return nil
+		h := makeOidHasher()
+		return forEachDatabaseDesc(ctx, p, dbContext, true, /* requiresPrivileges */
+			func(db catalog.DatabaseDescriptor) error {
+				return forEachDefaultPrivilege(db, func(
+					defaultPrivs *descpb.DefaultPrivilegesForRole, schemaName string,
+				) error {
+					namespaceOid := oidZero
+					if schemaName != "" {
+						namespaceOid = h.NamespaceOid(db.GetID(), schemaName)
+					}
+					role := defaultPrivs.Role()
+					objectType := defaultPrivs.ObjectType.ToPrivilegeObjectType()
+					objType := defaultACLObjectTypes[defaultPrivs.ObjectType]
+					acl := tree.NewDArray(types.String)
+					for _, u := range defaultPrivs.Privileges.Users {
+						item := makeACLItem(u, role, objectType)
+						if err := acl.Append(tree.NewDString(item.String())); err != nil {
+							return err
+						}
+					}
+					aclOid := h.DefaultACLOid(db.GetID(), defaultPrivs)
+					return addRow(
+						aclOid,          // oid
+						h.UserOid(role), // defaclrole
+						namespaceOid,    // defaclnamespace
+						objType,         // defaclobjtype
+						acl,             // defaclacl
+					)
+				})
+			})
 	},
-	unimplemented: true,
+}
+
+// defaultACLObjectTypes maps the object types of default privileges to the
+// values of pg_default_acl.defaclobjtype.
+var defaultACLObjectTypes = map[descpb.DefaultPrivilegesForRole_ObjectType]tree.Datum{
+	descpb.DefaultPrivilegesForRole_TABLES:    tree.NewDString("r"),
+	descpb.DefaultPrivilegesForRole_SEQUENCES: tree.NewDString("S"),
+	descpb.DefaultPrivilegesForRole_TYPES:     tree.NewDString("T"),
+	descpb.DefaultPrivilegesForRole_SCHEMAS:   tree.NewDString("n"),
+}
+
+// makeACLItem returns the aclitem of the given privileges of a user. The
+// privileges which have no recorded grantor are considered granted by the
+// owner of the object.
+func makeACLItem(
+	u descpb.UserPrivileges, owner security.SQLUsername, objectType privilege.ObjectType,
+) privilege.ACLItem {
+	var grantee string
+	if !u.User().IsPublicRole() {
+		grantee = u.User().Normalized()
+	}
+	grantor := u.Grantor()
+	if grantor.Undefined() {
+		grantor = owner
+	}
+	grantOptions := u.WithGrantOption
+	if u.Privileges&(privilege.ALL.Mask()|privilege.GRANT.Mask()) != 0 {
+		// Holding ALL or GRANT allows granting every privilege held, see
+		// UserPrivileges.CanGrant.
+		grantOptions = privilege.ALL.Mask()
+	}
+	return privilege.MakeACLItem(
+		grantee, grantor.Normalized(), u.Privileges, grantOptions, objectType,
+	)
+}
+
+// makeACL returns the ACL of the given descriptor, as an array of aclitems.
+// Users which only hold privileges without a Postgres equivalent are omitted.
+func makeACL(desc catalog.Descriptor, objectType privilege.ObjectType) (tree.Datum, error) {
+	owner := getOwnerOfDesc(desc)
+	acl := tree.NewDArray(types.String)
+	for _, u := range desc.GetPrivileges().Users {
+		item := makeACLItem(u, owner, objectType)
+		if item.Privileges == 0 {
+			continue
+		}
+		if err := acl.Append(tree.NewDString(item.String())); err != nil {
+			return nil, err
+		}
+	}
+	return acl, nil
 }
 
 var (
@@ -1152,7 +1294,13 @@ https://www.postgresql.org/docs/9.5/catalog-pg-depend.html`,
 			if table.IsSequence() &&
 				!table.GetSequenceOpts().SequenceOwner.Equal(descpb.TableDescriptor_SequenceOpts_SequenceOwner{}) {
 				refObjID := tableOid(table.GetSequenceOpts().SequenceOwner.OwnerTableID)
-				refObjSubID := tree.NewDInt(tree.DInt(table.GetSequenceOpts().SequenceOwner.OwnerColumnID))
+				ownerTable, err := tableLookup.getTableByID(table.GetSequenceOpts().SequenceOwner.OwnerTableID)
+				if err != nil {
+					return err
+				}
+				refObjSubID := tree.NewDInt(tree.DInt(columnAttributeNum(
+					ownerTable, table.GetSequenceOpts().SequenceOwner.OwnerColumnID,
+				)))
 				objID := tableOid(table.GetID())
 				return addRow(
 					pgConstraintTableOid, // classid
@@ -1172,14 +1320,15 @@ https://www.postgresql.org/docs/9.5/catalog-pg-depend.html`,
 			// Issue #57417: https://github.com/cockroachdb/cockroach/issues/57417
 			reportViewDependency := func(dep *descpb.TableDescriptor_Reference) error {
 				for _, colID := range dep.ColumnIDs {
+					refObjSubID := tree.NewDInt(tree.DInt(columnAttributeNum(table, colID)))
 					if err := addRow(
-						pgClassTableOid,                //classid
-						tableOid(dep.ID),               //objid
-						zeroVal,                        //objsubid
-						pgClassTableOid,                //refclassid
-						tableOid(table.GetID()),        //refobjid
-						tree.NewDInt(tree.DInt(colID)), //refobjsubid
-						depTypeNormal,                  //deptype
+						pgClassTableOid,         //classid
+						tableOid(dep.ID),        //objid
+						zeroVal,                 //objsubid
+						pgClassTableOid,         //refclassid
+						tableOid(table.GetID()), //refobjid
+						refObjSubID,             //refobjsubid
+						depTypeNormal,           //deptype
 					); err != nil {
 						return err
 					}
@@ -1238,23 +1387,31 @@ https://www.postgresql.org/docs/9.5/catalog-pg-depend.html`,
 	},
 }
 
+// constraintOidByID returns the pg_constraint OID of the constraint of the
+// table with the given ID, or nil if the table has no such constraint.
+func constraintOidByID(
+	tableLookup *internalLookupCtx, table catalog.TableDescriptor, constraintID descpb.ConstraintID,
+) (*tree.DOid, error) {
+	conInfo, err := table.GetConstraintInfo()
+	if err != nil {
+		return nil, err
+	}
+	for _, con := range conInfo {
+		if con.ConstraintID() != constraintID {
+			continue
+		}
+		scName, err := tableLookup.getSchemaNameByID(table.GetParentSchemaID())
+		if err != nil {
+			return nil, err
+		}
+		return makeOidHasher().ConstraintOid(table.GetParentID(), scName, table.GetID(), con), nil
+	}
+	return nil, nil
+}
+
 // getComments returns all comments in the database. A comment is represented
 // as a datum row, containing object id, sub id (column id in the case of
 // columns), comment text, and comment type (keys.FooCommentType).
-func getComments(ctx context.Context, p *planner) ([]tree.Datums, error) {
-	return p.extendedEvalCtx.ExecCfg.InternalExecutor.QueryBuffered(
-		ctx,
-		"select-comments",
-		p.EvalContext().Txn,
-		`SELECT COALESCE(pc.object_id, sc.object_id) AS object_id,
-              COALESCE(pc.sub_id, sc.sub_id) AS sub_id,
-              COALESCE(pc.comment, sc.comment) AS comment,
-              COALESCE(pc.type, sc.type) AS type
-         FROM (SELECT * FROM system.comments) AS sc
-    FULL JOIN (SELECT * FROM crdb_internal.predefined_comments) AS pc
-           ON (pc.object_id = sc.object_id AND pc.sub_id = sc.sub_id AND pc.type = sc.type)`)
-}
-
 var pgCatalogDescriptionTable = virtualSchemaTable{
 	comment: `object comments
 https://www.postgresql.org/docs/9.5/catalog-pg-description.html`,
@@ -1272,6 +1429,10 @@ https://www.postgresql.org/docs/9.5/catalog-pg-description.html`,
 		if err != nil {
 			return err
 		}
+		_, tableLookup, err := p.getCatalogSnapshot(ctx, dbContext)
+		if err != nil {
+			return err
+		}
 		for _, comment := range comments {
 			objID := comment[0]
 			objSubID := comment[1]
@@ -1284,7 +1445,18 @@ https://www.postgresql.org/docs/9.5/catalog-pg-description.html`,
 			case keys.DatabaseCommentType:
 				// Database comments are exported in pg_shdescription.
 				continue
-			case keys.ColumnCommentType, keys.TableCommentType:
+			case keys.ColumnCommentType:
+				// Columns are identified by their attribute number, which may
+				// differ from the column ID stored along with the comment.
+				table, err := tableLookup.getTableByID(descpb.ID(tree.MustBeDInt(objID)))
+				if err != nil {
+					return err
+				}
+				colID := descpb.ColumnID(tree.MustBeDInt(objSubID))
+				objSubID = tree.NewDInt(tree.DInt(columnAttributeNum(table, colID)))
+				objID = tree.NewDOid(tree.MustBeDInt(objID))
+				classOid = tree.NewDOid(catconstants.PgCatalogClassTableID)
+			case keys.TableCommentType:
 				objID = tree.NewDOid(tree.MustBeDInt(objID))
 				classOid = tree.NewDOid(catconstants.PgCatalogClassTableID)
 			case keys.IndexCommentType:
@@ -1293,6 +1465,33 @@ https://www.postgresql.org/docs/9.5/catalog-pg-description.html`,
 					descpb.IndexID(tree.MustBeDInt(objSubID)))
 				objSubID = tree.DZero
 				classOid = tree.NewDOid(catconstants.PgCatalogClassTableID)
+			case keys.ConstraintCommentType:
+				table, err := tableLookup.getTableByID(descpb.ID(tree.MustBeDInt(objID)))
+				if err != nil {
+					return err
+				}
+				conOid, err := constraintOidByID(
+					tableLookup, table, descpb.ConstraintID(tree.MustBeDInt(objSubID)),
+				)
+				if err != nil {
+					return err
+				}
+				if conOid == nil {
+					// The constraint was dropped implicitly, e.g. along with one
+					// of its columns, without its comment being removed.
+					continue
+				}
+				objID = conOid
+				objSubID = tree.DZero
+				classOid = tree.NewDOid(catconstants.PgCatalogConstraintTableID)
+			case keys.SchemaCommentType:
+				// Schemas are identified by the OID they are given in pg_namespace.
+				sc, err := tableLookup.getSchemaByID(descpb.ID(tree.MustBeDInt(objID)))
+				if err != nil {
+					return err
+				}
+				objID = makeOidHasher().NamespaceOid(sc.GetParentID(), sc.GetName())
+				classOid = tree.NewDOid(catconstants.PgCatalogNamespaceTableID)
 			}
 			if err := addRow(
 				objID,
@@ -1482,7 +1681,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-index.html`,
 					colIDs = append(colIDs, index.IndexDesc().StoreColumnIDs...)
 					// indnatts is the number of attributes with INCLUDED columns.
 					indnatts := len(colIDs)
-					indkey, err := colIDArrayToVector(colIDs)
+					indkey, err := colIDArrayToVector(table, colIDs)
 					if err != nil {
 						return err
 					}
@@ -1675,22 +1874,14 @@ https://www.postgresql.org/docs/9.6/view-pg-matviews.html`,
 				if !desc.MaterializedView() {
 					return nil
 				}
-				// Note that the view query printed will not include any column aliases
-				// specified outside the initial view query into the definition
-				// returned, unlike postgres. For example, for the view created via
-				//  `CREATE VIEW (a) AS SELECT b FROM foo`
-				// we'll only print `SELECT b FROM foo` as the view definition here,
-				// while postgres would more accurately print `SELECT b AS a FROM foo`.
-				// TODO(SQL Features): Insert column aliases into view query once we
-				// have a semantic query representation to work with (#10083).
 				return addRow(
 					tree.NewDName(scName),         // schemaname
 					tree.NewDName(desc.GetName()), // matviewname
 					getOwnerName(desc),            // matviewowner
 					tree.DNull,                    // tablespace
 					tree.MakeDBool(len(desc.PublicNonPrimaryIndexes()) > 0), // hasindexes
-					tree.DBoolTrue,                       // ispopulated,
-					tree.NewDString(desc.GetViewQuery()), // definition
+					tree.DBoolTrue,                           // ispopulated,
+					tree.NewDString(viewDefinition(p, desc)), // definition
 				)
 			})
 	},
@@ -1706,17 +1897,21 @@ https://www.postgresql.org/docs/9.5/catalog-pg-namespace.html`,
 			func(db catalog.DatabaseDescriptor) error {
 				return forEachSchema(ctx, p, db, func(sc catalog.ResolvedSchema) error {
 					ownerOID := tree.DNull
+					if owner, ok := getSchemaOwner(sc); ok {
+						ownerOID = h.UserOid(owner)
+					}
+					nspACL := tree.DNull
 					if sc.Kind == catalog.SchemaUserDefined {
-						ownerOID = getOwnerOID(sc.Desc)
-					} else if sc.Kind == catalog.SchemaPublic {
-						// admin is the owner of the public schema.
-						ownerOID = h.UserOid(security.MakeSQLUsernameFromPreNormalizedString("admin"))
+						var err error
+						if nspACL, err = makeACL(sc.Desc, privilege.Schema); err != nil {
+							return err
+						}
 					}
 					return addRow(
 						h.NamespaceOid(db.GetID(), sc.Name), // oid
 						tree.NewDString(sc.Name),            // nspname
 						ownerOID,                            // nspowner
-						tree.DNull,                          // nspacl
+						nspACL,                              // nspacl
 					)
 				})
 			})
@@ -1914,14 +2109,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-proc.html`,
 			func(db catalog.DatabaseDescriptor) error {
 				nspOid := h.NamespaceOid(db.GetID(), pgCatalogName)
 				for _, name := range builtins.AllBuiltinNames {
-					// parser.Builtins contains duplicate uppercase and lowercase keys.
-					// Only return the lowercase ones for compatibility with postgres.
-					var first rune
-					for _, c := range name {
-						first = c
-						break
-					}
-					if unicode.IsUpper(first) {
+					if isUppercaseBuiltinName(name) {
 						continue
 					}
 					props, overloads := builtins.GetBuiltinProperties(name)
@@ -2036,6 +2224,15 @@ https://www.postgresql.org/docs/9.5/catalog-pg-proc.html`,
 	},
 }
 
+// isUppercaseBuiltinName returns whether the given name of a built-in
+// function starts with an uppercase letter. parser.Builtins contains
+// duplicate uppercase and lowercase keys, and only the lowercase ones are
+// returned for compatibility with postgres.
+func isUppercaseBuiltinName(name string) bool {
+	first, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(first)
+}
+
 var pgCatalogRangeTable = virtualSchemaTable{
 	comment: `range types (empty - feature does not exist)
 https://www.postgresql.org/docs/9.5/catalog-pg-range.html`,
@@ -2114,7 +2311,7 @@ https://www.postgresql.org/docs/9.6/view-pg-seclabels.html`,
 	unimplemented: true,
 }
 
-var pgCatalogSequencesTable = virtualSchemaTable{
+var pgCatalogSequenceTable = virtualSchemaTable{
 	comment: `sequences (see also information_schema.sequences)
 https://www.postgresql.org/docs/9.5/catalog-pg-sequence.html`,
 	schema: vtable.PGCatalogSequence,
@@ -2126,14 +2323,68 @@ https://www.postgresql.org/docs/9.5/catalog-pg-sequence.html`,
 				}
 				opts := table.GetSequenceOpts()
 				return addRow(
-					tableOid(table.GetID()),                 // seqrelid
-					tree.NewDOid(tree.DInt(oid.T_int8)),     // seqtypid
-					tree.NewDInt(tree.DInt(opts.Start)),     // seqstart
-					tree.NewDInt(tree.DInt(opts.Increment)), // seqincrement
-					tree.NewDInt(tree.DInt(opts.MaxValue)),  // seqmax
-					tree.NewDInt(tree.DInt(opts.MinValue)),  // seqmin
-					tree.NewDInt(1),                         // seqcache
-					tree.DBoolFalse,                         // seqcycle
+					tableOid(table.GetID()),                            // seqrelid
+					tree.NewDOid(tree.DInt(opts.IntegerType().Oid())),  // seqtypid
+					tree.NewDInt(tree.DInt(opts.Start)),                // seqstart
+					tree.NewDInt(tree.DInt(opts.Increment)),            // seqincrement
+					tree.NewDInt(tree.DInt(opts.MaxValue)),             // seqmax
+					tree.NewDInt(tree.DInt(opts.MinValue)),             // seqmin
+					tree.NewDInt(tree.DInt(opts.EffectiveCacheSize())), // seqcache
+					tree.MakeDBool(tree.DBool(opts.Cycle)),             // seqcycle
+				)
+			})
+	},
+}
+
+var pgCatalogSequencesTable = virtualSchemaTable{
+	comment: `sequences with their current state (see also information_schema.sequences)
+https://www.postgresql.org/docs/13/view-pg-sequences.html`,
+	schema: vtable.PGCatalogSequences,
+	populate: func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+		return forEachTableDesc(ctx, p, dbContext, hideVirtual, /* no sequences in virtual schemas */
+			func(db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor) error {
+				if !table.IsSequence() {
+					return nil
+				}
+				opts := table.GetSequenceOpts()
+				typ := opts.IntegerType()
+				// As in Postgres, the state of a sequence is only reported to users
+				// that can read it. Virtual sequences do not have any state.
+				lastValue, isCalled := tree.Datum(tree.DNull), tree.Datum(tree.DNull)
+				if !opts.Virtual && (p.CheckPrivilege(ctx, table, privilege.SELECT) == nil ||
+					p.CheckPrivilege(ctx, table, privilege.USAGE) == nil) {
+					val, err := p.GetSequenceValue(ctx, p.ExecCfg().Codec, table)
+					if err != nil {
+						return err
+					}
+					// Sequences do not store whether they were called: a sequence that
+					// was never called holds the value it was created with, which is
+					// one increment before its start value, and setval(s, v, false)
+					// stores the value one increment before v. The sequence is thus
+					// deemed not called whenever it holds the value one increment
+					// before its start value, including after a call to
+					// setval(s, start - increment, true) and after a cycling sequence
+					// wrapped around to that value.
+					called := val != opts.Start-opts.Increment
+					isCalled = tree.MakeDBool(tree.DBool(called))
+					if called {
+						lastValue = tree.NewDInt(tree.DInt(val))
+					}
+				}
+				dataType := tree.NewDOidWithName(tree.DInt(typ.Oid()), types.RegType, typ.SQLStandardName())
+				return addRow(
+					tree.NewDName(scName),                              // schemaname
+					tree.NewDName(table.GetName()),                     // sequencename
+					getOwnerName(table),                                // sequenceowner
+					dataType,                                           // data_type
+					tree.NewDInt(tree.DInt(opts.Start)),                // start_value
+					tree.NewDInt(tree.DInt(opts.MinValue)),             // min_value
+					tree.NewDInt(tree.DInt(opts.MaxValue)),             // max_value
+					tree.NewDInt(tree.DInt(opts.Increment)),            // increment_by
+					tree.MakeDBool(tree.DBool(opts.Cycle)),             // cycle
+					tree.NewDInt(tree.DInt(opts.EffectiveCacheSize())), // cache_size
+					lastValue, // last_value
+					isCalled,  // crdb_is_called
 				)
 			})
 	},
@@ -2144,6 +2395,29 @@ var (
 	settingsCtxUser = tree.NewDString("user")
 )
 
+// sessionVarDefaultDatums returns the boot and reset values of the given
+// session variable, whose current value is value. The boot value is the
+// global default of the variable, and the reset value is the value RESET
+// restores, which the client may have overridden when opening the session.
+// Either is NULL if the variable has none.
+func sessionVarDefaultDatums(
+	p *planner, vName string, gen sessionVar, value tree.Datum,
+) (boot, reset tree.Datum) {
+	if gen.readOnly() {
+		// RESET/SET will leave the variable unchanged. Announce the
+		// current value as boot/reset value.
+		return value, value
+	}
+	boot, reset = tree.DNull, tree.DNull
+	if gen.GlobalDefault != nil {
+		boot = tree.NewDString(gen.GlobalDefault(&p.EvalContext().Settings.SV))
+	}
+	if hasDefault, defVal := getSessionVarDefaultString(vName, gen, p.sessionDataMutator); hasDefault {
+		reset = tree.NewDString(defVal)
+	}
+	return boot, reset
+}
+
 var pgCatalogSettingsTable = virtualSchemaTable{
 	comment: `session variables (incomplete)
 https://www.postgresql.org/docs/9.5/catalog-pg-settings.html`,
@@ -2156,22 +2430,7 @@ https://www.postgresql.org/docs/9.5/catalog-pg-settings.html`,
 			}
 			value := gen.Get(&p.extendedEvalCtx)
 			valueDatum := tree.NewDString(value)
-			var bootDatum tree.Datum = tree.DNull
-			var resetDatum tree.Datum = tree.DNull
-			if gen.Set == nil && gen.RuntimeSet == nil {
-				// RESET/SET will leave the variable unchanged. Announce the
-				// current value as boot/reset value.
-				bootDatum = valueDatum
-				resetDatum = bootDatum
-			} else {
-				if gen.GlobalDefault != nil {
-					globalDefVal := gen.GlobalDefault(&p.EvalContext().Settings.SV)
-					bootDatum = tree.NewDString(globalDefVal)
-				}
-				if hasDefault, defVal := getSessionVarDefaultString(vName, gen, p.sessionDataMutator); hasDefault {
-					resetDatum = tree.NewDString(defVal)
-				}
-			}
+			bootDatum, resetDatum := sessionVarDefaultDatums(p, vName, gen, valueDatum)
 			if err := addRow(
 				tree.NewDString(strings.ToLower(vName)), // name
 				valueDatum,                              // setting
@@ -2896,19 +3155,11 @@ https://www.postgresql.org/docs/9.5/view-pg-views.html`,
 				if !desc.IsView() || desc.MaterializedView() {
 					return nil
 				}
-				// Note that the view query printed will not include any column aliases
-				// specified outside the initial view query into the definition
-				// returned, unlike postgres. For example, for the view created via
-				//  `CREATE VIEW (a) AS SELECT b FROM foo`
-				// we'll only print `SELECT b FROM foo` as the view definition here,
-				// while postgres would more accurately print `SELECT b AS a FROM foo`.
-				// TODO(SQL Features): Insert column aliases into view query once we
-				// have a semantic query representation to work with (#10083).
 				return addRow(
-					tree.NewDName(scName),                // schemaname
-					tree.NewDName(desc.GetName()),        // viewname
-					getOwnerName(desc),                   // viewowner
-					tree.NewDString(desc.GetViewQuery()), // definition
+					tree.NewDName(scName),                    // schemaname
+					tree.NewDName(desc.GetName()),            // viewname
+					getOwnerName(desc),                       // viewowner
+					tree.NewDString(viewDefinition(p, desc)), // definition
 				)
 			})
 	},
@@ -3091,6 +3342,7 @@ const (
 	collationTypeTag
 	operatorTypeTag
 	enumEntryTypeTag
+	defaultACLTypeTag
 )
 
 func (h oidHasher) writeTypeTag(tag oidTypeTag) {
@@ -3210,6 +3462,29 @@ func (h oidHasher) UniqueConstraintOid(
 	return h.getOid()
 }
 
+// ConstraintOid returns the OID of the constraint described by con, as
+// reported in pg_constraint.
+func (h oidHasher) ConstraintOid(
+	dbID descpb.ID, scName string, tableID descpb.ID, con descpb.ConstraintDetail,
+) *tree.DOid {
+	switch con.Kind {
+	case descpb.ConstraintTypePK:
+		return h.PrimaryKeyConstraintOid(dbID, scName, tableID, con.Index)
+	case descpb.ConstraintTypeFK:
+		return h.ForeignKeyConstraintOid(dbID, scName, tableID, con.FK)
+	case descpb.ConstraintTypeUnique:
+		if con.Index != nil {
+			return h.UniqueConstraintOid(dbID, scName, tableID, con.Index.ID)
+		}
+		if con.UniqueWithoutIndexConstraint != nil {
+			return h.UniqueWithoutIndexConstraintOid(dbID, scName, tableID, con.UniqueWithoutIndexConstraint)
+		}
+	case descpb.ConstraintTypeCheck:
+		return h.CheckConstraintOid(dbID, scName, tableID, con.CheckConstraint)
+	}
+	return nil
+}
+
 func (h oidHasher) BuiltinOid(name string, builtin *tree.Overload) *tree.DOid {
 	h.writeTypeTag(functionTypeTag)
 	h.writeStr(name)
@@ -3254,6 +3529,17 @@ func (h oidHasher) EnumEntryOid(typOID *tree.DOid, physicalRep []byte) *tree.DOi
 	return h.getOid()
 }
 
+func (h oidHasher) DefaultACLOid(
+	dbID descpb.ID, defaultPrivs *descpb.DefaultPrivilegesForRole,
+) *tree.DOid {
+	h.writeTypeTag(defaultACLTypeTag)
+	h.writeDB(dbID)
+	h.writeStr(defaultPrivs.Role().Normalized())
+	h.writeUInt32(uint32(defaultPrivs.SchemaID))
+	h.writeUInt32(uint32(defaultPrivs.ObjectType))
+	return h.getOid()
+}
+
 func tableOid(id descpb.ID) *tree.DOid {
 	return tree.NewDOid(tree.DInt(id))
 }
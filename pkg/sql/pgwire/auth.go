@@ -108,6 +108,20 @@ func (c *conn) handleAuthentication(
 			"%s does not have login privilege", c.sessionArgs.User))
 	}
 
+	// Check that the user isn't locked by its LOGINATTEMPTS policy.
+	attempts, err := sql.GetLoginAttempts(ctx, execCfg, c.sessionArgs.User)
+	if err != nil {
+		log.Warningf(ctx, "login attempts retrieval failed for user=%q: %+v", c.sessionArgs.User, err)
+		ac.LogAuthFailed(ctx, eventpb.AuthFailReason_USER_RETRIEVAL_ERROR, err)
+		return nil, sendError(err)
+	}
+	if attempts.Locked() {
+		ac.LogAuthFailed(ctx, eventpb.AuthFailReason_LOGIN_DISABLED, nil)
+		return nil, sendError(errors.WithHint(errors.Errorf(
+			"%s is locked after %d failed login attempts", c.sessionArgs.User, attempts.Failed),
+			"An administrator can unlock it with ALTER ROLE ... LOGIN or by setting its password."))
+	}
+
 	// Retrieve the authentication method.
 	tlsState, hbaEntry, methodFn, err := c.findAuthenticationMethod(authOpt)
 	if err != nil {
@@ -129,8 +143,21 @@ func (c *conn) handleAuthentication(
 
 	if connClose, err = authenticationHook(c.sessionArgs.User, true /* public */); err != nil {
 		ac.LogAuthFailed(ctx, eventpb.AuthFailReason_CREDENTIALS_INVALID, err)
+		// Only a wrong password counts towards the LOGINATTEMPTS policy.
+		if errors.Is(err, security.ErrInvalidPassword) {
+			if recErr := sql.RecordLoginAttempt(
+				ctx, authOpt.ie, c.sessionArgs.User, attempts, false, /* success */
+			); recErr != nil {
+				log.Warningf(ctx, "failed to record login attempt for user=%q: %+v", c.sessionArgs.User, recErr)
+			}
+		}
 		return connClose, sendError(err)
 	}
+	if err := sql.RecordLoginAttempt(
+		ctx, authOpt.ie, c.sessionArgs.User, attempts, true, /* success */
+	); err != nil {
+		log.Warningf(ctx, "failed to record login attempt for user=%q: %+v", c.sessionArgs.User, err)
+	}
 
 	ac.LogAuthOK(ctx)
 	c.msgBuilder.initMsg(pgwirebase.ServerMsgAuth)
@@ -135,6 +135,8 @@ func getPlanColumns(plan planNode, mut bool) colinfo.ResultColumns {
 		return getPlanColumns(n.plan, mut)
 	case *filterNode:
 		return getPlanColumns(n.source.plan, mut)
+	case *introspectionQueryRecorderNode:
+		return getPlanColumns(n.source, mut)
 	case *max1RowNode:
 		return getPlanColumns(n.plan, mut)
 	case *limitNode:
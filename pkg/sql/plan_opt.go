@@ -55,7 +55,8 @@ func (p *planner) prepareUsingOptimizer(ctx context.Context) (planFlags, error)
 	case *tree.AlterIndex, *tree.AlterTable, *tree.AlterSequence,
 		*tree.Analyze,
 		*tree.BeginTransaction,
-		*tree.CommentOnColumn, *tree.CommentOnDatabase, *tree.CommentOnIndex, *tree.CommentOnTable,
+		*tree.CommentOnColumn, *tree.CommentOnConstraint, *tree.CommentOnDatabase, *tree.CommentOnIndex,
+		*tree.CommentOnSchema, *tree.CommentOnTable,
 		*tree.CommitTransaction,
 		*tree.CopyFrom, *tree.CreateDatabase, *tree.CreateIndex, *tree.CreateView,
 		*tree.CreateSequence,
@@ -188,6 +189,11 @@ func (p *planner) prepareUsingOptimizer(ctx context.Context) (planFlags, error)
 func (p *planner) makeOptimizerPlan(ctx context.Context) error {
 	p.curPlan.init(&p.stmt, &p.instrumentation)
 
+	introspectionQuery, served := p.maybeServeIntrospectionQuery(ctx)
+	if served {
+		return nil
+	}
+
 	opc := &p.optPlanningCtx
 	opc.reset()
 
@@ -259,14 +265,18 @@ func (p *planner) makeOptimizerPlan(ctx context.Context) error {
 		)
 	}
 	// If we got here, we did not create a plan above.
-	return opc.runExecBuilder(
+	if err := opc.runExecBuilder(
 		&p.curPlan,
 		&p.stmt,
 		newExecFactory(p),
 		execMemo,
 		p.EvalContext(),
 		p.autoCommit,
-	)
+	); err != nil {
+		return err
+	}
+	p.maybeRecordIntrospectionQuery(ctx, introspectionQuery, execMemo)
+	return nil
 }
 
 type optPlanningCtx struct {
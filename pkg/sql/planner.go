@@ -94,11 +94,23 @@ type extendedEvalContext struct {
 	// SchemaChangeJobCache refers to schemaChangeJobsCache in extraTxnState.
 	SchemaChangeJobCache map[descpb.ID]*jobs.Job
 
+	// DeferredFKChecks refers to deferredFKChecks in extraTxnState. It is nil
+	// if foreign key checks cannot be deferred until the transaction commits.
+	DeferredFKChecks map[deferredFKCheck]struct{}
+
 	schemaAccessors *schemaInterface
 
 	sqlStatsCollector *sqlStatsCollector
 
 	SchemaChangerState *SchemaChangerState
+
+	// comments refers to the comment cache in extraTxnState. It is nil for
+	// internal planners, which don't cache the comments.
+	comments *commentCache
+
+	// catalogSnapshot refers to the descriptor snapshot in extraTxnState. It is
+	// nil for internal planners, which don't pin the descriptors.
+	catalogSnapshot *catalogSnapshot
 }
 
 // copy returns a deep copy of ctx.
@@ -226,6 +238,30 @@ type planner struct {
 	// the type resolution steps will disallow resolution of types that have a
 	// parentID != contextDatabaseID when it is set.
 	contextDatabaseID descpb.ID
+
+	// connectPrivileges memoizes, for the current statement, whether the
+	// current user holds the CONNECT privilege on a database. It is used to
+	// decide which descriptors are visible in the virtual tables, which
+	// otherwise check the privilege once per descriptor.
+	connectPrivileges map[descpb.ID]bool
+
+	// anyPrivileges memoizes, for the current statement, whether the current
+	// user, through any of its roles, holds some privilege on a descriptor. See
+	// hasAnyPrivilege.
+	anyPrivileges map[descpb.ID]bool
+
+	// userRolesCache memoizes, for the current statement, the roles whose
+	// privileges a user holds. See userRoles.
+	userRolesCache map[security.SQLUsername]map[security.SQLUsername]bool
+
+	// roleMemberships memoizes, for the current statement, all the memberships
+	// stored in system.role_members. See getRoleMemberships.
+	roleMemberships roleMembershipGraph
+
+	// txnHasDDL is set if the transaction executed DDL statements before the
+	// current statement. Such transactions can't be served cached results of
+	// introspection queries.
+	txnHasDDL bool
 }
 
 func (evalCtx *extendedEvalContext) setSessionID(sessionID ClusterWideID) {
@@ -0,0 +1,238 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package privilege
+
+import (
+	"strings"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
+	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+)
+
+// ACLItem is the equivalent of a Postgres aclitem: the privileges a grantee
+// holds on an object, along with the role which granted them. It is used to
+// display privileges in the ACL columns of pg_catalog, and to decode them
+// again in aclexplode.
+type ACLItem struct {
+	// Grantee is the normalized name of the role holding the privileges. It is
+	// empty for the public role.
+	Grantee string
+	// Grantor is the normalized name of the role which granted the privileges.
+	Grantor string
+	// Privileges and GrantOptions are bitfields of the privileges held and of
+	// the privileges which can be granted further. Only the privileges which
+	// have an equivalent in Postgres are set.
+	Privileges   uint32
+	GrantOptions uint32
+}
+
+// aclPrivileges lists the privileges which have an abbreviation in the
+// Postgres aclitem representation, in the order Postgres displays them.
+// Privileges without an equivalent in Postgres are omitted.
+var aclPrivileges = []struct {
+	kind Kind
+	abbr byte
+}{
+	{INSERT, 'a'},
+	{SELECT, 'r'},
+	{UPDATE, 'w'},
+	{DELETE, 'd'},
+	{USAGE, 'U'},
+	{CREATE, 'C'},
+	{CONNECT, 'c'},
+}
+
+// aclObjectPrivileges lists, for each object type, the privileges which are
+// displayed in its ACL. These are the privileges Postgres supports on the
+// object type, so that tools which decode ACLs do not encounter unexpected
+// privileges such as CREATE on tables.
+var aclObjectPrivileges = map[ObjectType]List{
+	Database: {CREATE, CONNECT},
+	Schema:   {CREATE, USAGE},
+	Table:    {SELECT, INSERT, DELETE, UPDATE},
+	Sequence: {SELECT, UPDATE, USAGE},
+	Type:     {USAGE},
+}
+
+// MakeACLItem returns the ACLItem for the given privilege bitfields. ALL is
+// expanded to the privileges which are valid for the object type, and the
+// privileges which Postgres does not support on the object type are omitted.
+func MakeACLItem(
+	grantee, grantor string, privs, grantOptions uint32, objectType ObjectType,
+) ACLItem {
+	if privs&ALL.Mask() != 0 {
+		privs = GetValidPrivilegesForObject(objectType).ToBitField()
+	}
+	if grantOptions&ALL.Mask() != 0 {
+		grantOptions = privs
+	}
+	mask := aclPrivilegeMask
+	if objectPrivs, ok := aclObjectPrivileges[objectType]; ok {
+		mask = objectPrivs.ToBitField()
+	}
+	return ACLItem{
+		Grantee:      grantee,
+		Grantor:      grantor,
+		Privileges:   privs & mask,
+		GrantOptions: grantOptions & privs & mask,
+	}
+}
+
+// aclPrivilegeMask is the bitfield of all the privileges in aclPrivileges.
+var aclPrivilegeMask = func() uint32 {
+	var mask uint32
+	for _, p := range aclPrivileges {
+		mask |= p.kind.Mask()
+	}
+	return mask
+}()
+
+// Kinds returns the privileges of the item in the order Postgres lists them.
+func (a ACLItem) Kinds() List {
+	var ret List
+	for _, p := range aclPrivileges {
+		if a.Privileges&p.kind.Mask() != 0 {
+			ret = append(ret, p.kind)
+		}
+	}
+	return ret
+}
+
+// CanGrant returns true if the grantee can grant the given privilege further.
+func (a ACLItem) CanGrant(priv Kind) bool {
+	return a.GrantOptions&priv.Mask() != 0
+}
+
+// String returns the text representation of the item, of the form
+// grantee=privileges/grantor. Each privilege which can be granted further is
+// followed by a '*'.
+func (a ACLItem) String() string {
+	var buf strings.Builder
+	writeACLName(&buf, a.Grantee)
+	buf.WriteByte('=')
+	for _, p := range aclPrivileges {
+		if a.Privileges&p.kind.Mask() == 0 {
+			continue
+		}
+		buf.WriteByte(p.abbr)
+		if a.GrantOptions&p.kind.Mask() != 0 {
+			buf.WriteByte('*')
+		}
+	}
+	buf.WriteByte('/')
+	writeACLName(&buf, a.Grantor)
+	return buf.String()
+}
+
+// writeACLName writes a role name, quoting it like Postgres does if it
+// contains characters other than letters, digits and underscores.
+func writeACLName(buf *strings.Builder, name string) {
+	needsQuotes := false
+	for i := 0; i < len(name); i++ {
+		if !isACLNameChar(name[i]) {
+			needsQuotes = true
+			break
+		}
+	}
+	if !needsQuotes {
+		buf.WriteString(name)
+		return
+	}
+	buf.WriteByte('"')
+	buf.WriteString(strings.ReplaceAll(name, `"`, `""`))
+	buf.WriteByte('"')
+}
+
+func isACLNameChar(c byte) bool {
+	return c == '_' || c >= 0x80 ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// ParseACLItem parses the text representation of an aclitem, as produced by
+// ACLItem.String.
+func ParseACLItem(s string) (ACLItem, error) {
+	var a ACLItem
+	var err error
+	rest := strings.TrimSpace(s)
+	if a.Grantee, rest, err = parseACLName(rest); err != nil {
+		return ACLItem{}, err
+	}
+	if !strings.HasPrefix(rest, "=") {
+		return ACLItem{}, pgerror.Newf(pgcode.InvalidTextRepresentation,
+			"invalid aclitem %q: missing \"=\" sign", s)
+	}
+	rest = rest[1:]
+	for len(rest) > 0 && rest[0] != '/' {
+		kind, ok := aclPrivilegeForAbbr(rest[0])
+		if !ok {
+			return ACLItem{}, pgerror.Newf(pgcode.InvalidTextRepresentation,
+				"invalid aclitem %q: invalid mode character %q", s, rest[0])
+		}
+		a.Privileges |= kind.Mask()
+		rest = rest[1:]
+		if len(rest) > 0 && rest[0] == '*' {
+			a.GrantOptions |= kind.Mask()
+			rest = rest[1:]
+		}
+	}
+	if !strings.HasPrefix(rest, "/") {
+		return ACLItem{}, pgerror.Newf(pgcode.InvalidTextRepresentation,
+			"invalid aclitem %q: missing \"/\" sign", s)
+	}
+	if a.Grantor, rest, err = parseACLName(rest[1:]); err != nil {
+		return ACLItem{}, err
+	}
+	if a.Grantor == "" {
+		return ACLItem{}, pgerror.Newf(pgcode.InvalidTextRepresentation,
+			"invalid aclitem %q: a name must follow the \"/\" sign", s)
+	}
+	if strings.TrimSpace(rest) != "" {
+		return ACLItem{}, pgerror.Newf(pgcode.InvalidTextRepresentation,
+			"invalid aclitem %q: extra garbage at the end", s)
+	}
+	return a, nil
+}
+
+// parseACLName parses a possibly quoted role name at the start of s, and
+// returns it along with the remainder of s.
+func parseACLName(s string) (name string, rest string, err error) {
+	if !strings.HasPrefix(s, `"`) {
+		i := 0
+		for i < len(s) && isACLNameChar(s[i]) {
+			i++
+		}
+		return s[:i], s[i:], nil
+	}
+	var buf strings.Builder
+	for i := 1; i < len(s); i++ {
+		if s[i] != '"' {
+			buf.WriteByte(s[i])
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == '"' {
+			buf.WriteByte('"')
+			i++
+			continue
+		}
+		return buf.String(), s[i+1:], nil
+	}
+	return "", "", pgerror.Newf(pgcode.InvalidTextRepresentation,
+		"invalid aclitem name %q: unterminated quoted name", s)
+}
+
+func aclPrivilegeForAbbr(abbr byte) (Kind, bool) {
+	for _, p := range aclPrivileges {
+		if p.abbr == abbr {
+			return p.kind, true
+		}
+	}
+	return 0, false
+}
@@ -56,6 +56,8 @@ const (
 	Schema ObjectType = "schema"
 	// Table represents a table object.
 	Table ObjectType = "table"
+	// Sequence represents a sequence object.
+	Sequence ObjectType = "sequence"
 	// Type represents a type object.
 	Type ObjectType = "type"
 )
@@ -69,6 +71,10 @@ var (
 	TablePrivileges  = List{ALL, CREATE, DROP, GRANT, SELECT, INSERT, DELETE, UPDATE, ZONECONFIG}
 	SchemaPrivileges = List{ALL, GRANT, CREATE, USAGE}
 	TypePrivileges   = List{ALL, GRANT, USAGE}
+	// SequencePrivileges are the privileges of sequences. As in postgres,
+	// USAGE allows currval and nextval, SELECT allows currval and UPDATE allows
+	// nextval and setval.
+	SequencePrivileges = List{ALL, CREATE, DROP, GRANT, SELECT, UPDATE, USAGE, ZONECONFIG}
 )
 
 // Mask returns the bitmask for a given privilege.
@@ -225,6 +231,8 @@ func GetValidPrivilegesForObject(objectType ObjectType) List {
 	switch objectType {
 	case Table:
 		return TablePrivileges
+	case Sequence:
+		return SequencePrivileges
 	case Schema:
 		return SchemaPrivileges
 	case Database:
@@ -56,3 +56,46 @@ func TestPrivilegeDecode(t *testing.T) {
 		}
 	}
 }
+
+func TestACLItem(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	testCases := []struct {
+		item privilege.ACLItem
+		str  string
+	}{
+		{privilege.MakeACLItem("", "root", privilege.SELECT.Mask(), 0, privilege.Table), "=r/root"},
+		{
+			privilege.MakeACLItem("testuser", "root", privilege.ALL.Mask(), privilege.ALL.Mask(), privilege.Table),
+			"testuser=a*r*w*d*/root",
+		},
+		{
+			privilege.MakeACLItem("testuser", "root", privilege.ALL.Mask(), privilege.USAGE.Mask(), privilege.Sequence),
+			"testuser=rwU*/root",
+		},
+		{
+			privilege.MakeACLItem("test user", `a"b`, privilege.List{privilege.CREATE, privilege.CONNECT}.ToBitField(), 0, privilege.Database),
+			`"test user"=Cc/"a""b"`,
+		},
+		// Privileges without an equivalent in Postgres are not displayed.
+		{privilege.MakeACLItem("testuser", "root", privilege.ZONECONFIG.Mask(), 0, privilege.Table), "testuser=/root"},
+	}
+
+	for _, tc := range testCases {
+		if s := tc.item.String(); s != tc.str {
+			t.Fatalf("%+v: expected %q, got %q", tc.item, tc.str, s)
+		}
+		parsed, err := privilege.ParseACLItem(tc.str)
+		if err != nil {
+			t.Fatalf("%q: %v", tc.str, err)
+		}
+		if parsed != tc.item {
+			t.Fatalf("%q: expected %+v, got %+v", tc.str, tc.item, parsed)
+		}
+	}
+
+	for _, s := range []string{"foo", "foo=r", "foo=q/root", "foo=r/", `"foo=r/root`, "foo=r/root bar"} {
+		if _, err := privilege.ParseACLItem(s); err == nil {
+			t.Fatalf("%q: expected error", s)
+		}
+	}
+}
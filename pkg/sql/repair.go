@@ -140,6 +140,9 @@ func (p *planner) UnsafeUpsertDescriptor(
 		objectType = privilege.Database
 	case catalog.Table:
 		objectType = privilege.Table
+		if tbl, ok := existing.(catalog.TableDescriptor); ok && tbl.IsSequence() {
+			objectType = privilege.Sequence
+		}
 	case catalog.Type:
 		objectType = privilege.Type
 	case catalog.Schema:
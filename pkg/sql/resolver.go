@@ -404,6 +404,45 @@ func getDescriptorsFromTargetListForPrivilegeChange(
 		return descs, nil
 	}
 
+	if targets.AllTablesInSchema || targets.AllSequencesInSchema {
+		if len(targets.Schemas) == 0 {
+			return nil, errNoSchema
+		}
+		var descs []catalog.Descriptor
+		for i := range targets.Schemas {
+			// Expand the schemas like the <schema>.* pattern, which also resolves
+			// the schema and checks that it exists.
+			objectNames, objectIDs, err := expandTableGlob(ctx, p, &tree.AllTablesSelector{
+				ObjectNamePrefix: targets.Schemas[i],
+			})
+			if err != nil {
+				return nil, err
+			}
+			for j, id := range objectIDs {
+				descriptor, err := p.Descriptors().GetMutableDescriptorByID(ctx, id, p.txn)
+				if errors.Is(err, catalog.ErrDescriptorNotFound) {
+					// Virtual tables have no descriptor. Resolving them by name reports
+					// that they cannot be modified; the resolution is required to
+					// succeed, so that no table is silently skipped.
+					descriptor, err = resolver.ResolveMutableExistingTableObject(ctx, p,
+						&objectNames[j], true /* required */, tree.ResolveAnyTableKind)
+				}
+				if err != nil {
+					return nil, err
+				}
+				tbl, ok := descriptor.(catalog.TableDescriptor)
+				// As in postgres, ALL TABLES covers views but not sequences.
+				if !ok || tbl.IsSequence() != targets.AllSequencesInSchema {
+					continue
+				}
+				descs = append(descs, descriptor)
+			}
+		}
+		// Unlike the other targets, a schema without any table or sequence is not
+		// an error.
+		return descs, nil
+	}
+
 	if targets.Schemas != nil {
 		if len(targets.Schemas) == 0 {
 			return nil, errNoSchema
@@ -438,6 +477,14 @@ func getDescriptorsFromTargetListForPrivilegeChange(
 			switch resSchema.Kind {
 			case catalog.SchemaUserDefined:
 				descs = append(descs, resSchema.Desc)
+			case catalog.SchemaPublic:
+				// The privileges on the public schema are derived from those on its
+				// database, see schemaPrivileges.
+				return nil, errors.WithHint(
+					pgerror.Newf(pgcode.InvalidSchemaName,
+						"cannot change privileges on schema %q", resSchema.Name),
+					"the privileges on the public schema are those on its database; "+
+						"use GRANT ... ON DATABASE instead")
 			default:
 				return nil, pgerror.Newf(pgcode.InvalidSchemaName,
 					"cannot change privileges on schema %q", resSchema.Name)
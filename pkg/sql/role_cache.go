@@ -0,0 +1,218 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descs"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/errors"
+)
+
+// RoleCache is a shared cache of the roles stored in system.users along with
+// their options stored in system.role_options. It is invalidated by the
+// statements which modify either table through a bump of the version of the
+// descriptor of system.users (see bumpUsersTableVersion). Like the
+// MembershipCache, it does not observe direct writes to the system tables.
+type RoleCache struct {
+	syncutil.Mutex
+	tableVersion descpb.DescriptorVersion
+	// roles is sorted by username. It is nil if the roles haven't been loaded
+	// since the last version change.
+	roles []roleMetadata
+}
+
+// roleMetadata describes a role stored in system.users.
+type roleMetadata struct {
+	username security.SQLUsername
+	isRole   bool
+	// hasPassword is set if the role has a non-empty password.
+	hasPassword bool
+	// noLogin is set if the role has the NOLOGIN option.
+	noLogin bool
+	// validUntil is the value of the VALID UNTIL option, if set.
+	validUntil *time.Time
+	// options are the options of the role stored in system.role_options,
+	// sorted by name.
+	options []storedRoleOption
+}
+
+// storedRoleOption is a row of system.role_options.
+type storedRoleOption struct {
+	option string
+	// value is nil for the options which take no value.
+	value *string
+}
+
+// getRoles returns the roles stored in system.users, sorted by username. The
+// returned slice is shared and must not be modified.
+// Requires a valid transaction to be open.
+func (p *planner) getRoles(ctx context.Context) ([]roleMetadata, error) {
+	if p.txn == nil || !p.txn.IsOpen() {
+		return nil, errors.AssertionFailedf("cannot use getRoles without a txn")
+	}
+	return getCachedRoles(
+		ctx, p.ExecCfg(), p.txn, p.Descriptors(),
+		p.ObjectLookupFlags(true /*required*/, false /*requireMutable*/),
+	)
+}
+
+// getCachedRoles is like getRoles, for callers which don't have a planner.
+// The version of system.users is looked up in the given transaction and
+// descriptor collection.
+func getCachedRoles(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	txn *kv.Txn,
+	descsCol *descs.Collection,
+	flags tree.ObjectLookupFlags,
+) ([]roleMetadata, error) {
+	roleCache := execCfg.RoleCache
+
+	// Lookup table version.
+	_, tableDesc, err := descsCol.GetImmutableTableByName(ctx, txn, userTableName, flags)
+	if err != nil {
+		return nil, err
+	}
+	tableVersion := tableDesc.GetVersion()
+	if tableDesc.IsUncommittedVersion() {
+		// The roles were modified by the current transaction.
+		return loadRoles(ctx, execCfg, txn)
+	}
+
+	// We loop in case the table version changes while we're loading the roles.
+	for {
+		roleCache.Lock()
+		if roleCache.tableVersion != tableVersion {
+			// Update version and drop the roles.
+			roleCache.tableVersion = tableVersion
+			roleCache.roles = nil
+		}
+		roles := roleCache.roles
+		roleCache.Unlock()
+
+		if roles != nil {
+			return roles, nil
+		}
+
+		// Load the roles outside the lock.
+		roles, err := loadRoles(ctx, execCfg, nil /* txn */)
+		if err != nil {
+			return nil, err
+		}
+
+		roleCache.Lock()
+		if roleCache.tableVersion != tableVersion {
+			// Table version has changed while we were loading, unlock and start over.
+			tableVersion = roleCache.tableVersion
+			roleCache.Unlock()
+			continue
+		}
+		roleCache.roles = roles
+		roleCache.Unlock()
+		return roles, nil
+	}
+}
+
+// loadRoles reads the roles and their options from system.users and
+// system.role_options. All the options are read along with the roles in a
+// single query rather than with one lookup per role. The rows are streamed
+// rather than buffered, so that only the resulting roles are held in memory.
+//
+// The roles are loaded before the virtual tables which list them push any row,
+// so the iterator is never left open while a populate function is blocked on
+// its consumer. Closing it then, upon the cancellation of the populate
+// function, could fail the transaction used to read the roles.
+func loadRoles(
+	ctx context.Context, execCfg *ExecutorConfig, txn *kv.Txn,
+) (_ []roleMetadata, retErr error) {
+	query := `
+SELECT
+	u.username,
+	u."isRole",
+	u."hashedPassword" IS NOT NULL AND length(u."hashedPassword") > 0,
+	ro.option,
+	ro.value,
+	CASE WHEN ro.option = 'VALID UNTIL' THEN ro.value::TIMESTAMPTZ END
+FROM
+	system.users AS u
+	LEFT JOIN system.role_options AS ro ON ro.username = u.username
+ORDER BY
+	u.username, ro.option
+`
+	it, err := execCfg.InternalExecutor.QueryIterator(ctx, "read-roles", txn, query)
+	if err != nil {
+		return nil, err
+	}
+	// We have to make sure to close the iterator since we might return from the
+	// for loop early (before Next() returns false).
+	defer func() { retErr = errors.CombineErrors(retErr, it.Close()) }()
+
+	var roles []roleMetadata
+	var ok bool
+	for ok, err = it.Next(ctx); ok; ok, err = it.Next(ctx) {
+		row := it.Cur()
+		// system tables already contain normalized usernames.
+		username := security.MakeSQLUsernameFromPreNormalizedString(string(tree.MustBeDString(row[0])))
+		// The rows are sorted by username, so all the options of a role are
+		// contiguous.
+		if len(roles) == 0 || roles[len(roles)-1].username != username {
+			isRole, ok := row[1].(*tree.DBool)
+			if !ok {
+				return nil, errors.Errorf("isRole should be a boolean value, found %s instead", row[1].ResolvedType())
+			}
+			hasPassword, ok := row[2].(*tree.DBool)
+			if !ok {
+				return nil, errors.Errorf("hasPassword should be a boolean value, found %s instead", row[2].ResolvedType())
+			}
+			roles = append(roles, roleMetadata{
+				username:    username,
+				isRole:      bool(*isRole),
+				hasPassword: bool(*hasPassword),
+			})
+		}
+		if row[3] == tree.DNull {
+			// The role has no options.
+			continue
+		}
+		role := &roles[len(roles)-1]
+		opt := storedRoleOption{option: string(tree.MustBeDString(row[3]))}
+		if row[4] != tree.DNull {
+			value := string(tree.MustBeDString(row[4]))
+			opt.value = &value
+		}
+		role.options = append(role.options, opt)
+		switch opt.option {
+		case "NOLOGIN":
+			role.noLogin = true
+		case "VALID UNTIL":
+			if validUntil, ok := row[5].(*tree.DTimestampTZ); ok {
+				role.validUntil = &validUntil.Time
+			} else if row[5] != tree.DNull {
+				return nil, errors.Errorf("rolValidUntil should be a timestamp or null value, found %s instead", row[5].ResolvedType())
+			}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if roles == nil {
+		// The cache relies on a nil slice to denote roles which aren't loaded.
+		roles = []roleMetadata{}
+	}
+	return roles, nil
+}
@@ -28,11 +28,14 @@ func _() {
 	_ = x[NOCANCELQUERY-18]
 	_ = x[MODIFYCLUSTERSETTING-19]
 	_ = x[NOMODIFYCLUSTERSETTING-20]
+	_ = x[PASSWORDCOMPLEXITY-21]
+	_ = x[PASSWORDLIFETIME-22]
+	_ = x[LOGINATTEMPTS-23]
 }
 
-const _Option_name = "CREATEROLENOCREATEROLEPASSWORDLOGINNOLOGINVALIDUNTILCONTROLJOBNOCONTROLJOBCONTROLCHANGEFEEDNOCONTROLCHANGEFEEDCREATEDBNOCREATEDBCREATELOGINNOCREATELOGINVIEWACTIVITYNOVIEWACTIVITYCANCELQUERYNOCANCELQUERYMODIFYCLUSTERSETTINGNOMODIFYCLUSTERSETTING"
+const _Option_name = "CREATEROLENOCREATEROLEPASSWORDLOGINNOLOGINVALIDUNTILCONTROLJOBNOCONTROLJOBCONTROLCHANGEFEEDNOCONTROLCHANGEFEEDCREATEDBNOCREATEDBCREATELOGINNOCREATELOGINVIEWACTIVITYNOVIEWACTIVITYCANCELQUERYNOCANCELQUERYMODIFYCLUSTERSETTINGNOMODIFYCLUSTERSETTINGPASSWORDCOMPLEXITYPASSWORDLIFETIMELOGINATTEMPTS"
 
-var _Option_index = [...]uint8{0, 10, 22, 30, 35, 42, 52, 62, 74, 91, 110, 118, 128, 139, 152, 164, 178, 189, 202, 222, 244}
+var _Option_index = [...]uint8{0, 10, 22, 30, 35, 42, 52, 62, 74, 91, 110, 118, 128, 139, 152, 164, 178, 189, 202, 222, 244, 262, 278, 291}
 
 func (i Option) String() string {
 	i -= 1
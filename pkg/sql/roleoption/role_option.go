@@ -55,6 +55,9 @@ const (
 	NOCANCELQUERY
 	MODIFYCLUSTERSETTING
 	NOMODIFYCLUSTERSETTING
+	PASSWORDCOMPLEXITY
+	PASSWORDLIFETIME
+	LOGINATTEMPTS
 )
 
 // toSQLStmts is a map of Kind -> SQL statement string for applying the
@@ -79,6 +82,9 @@ var toSQLStmts = map[Option]string{
 	NOCANCELQUERY:          `DELETE FROM system.role_options WHERE username = $1 AND option = 'CANCELQUERY'`,
 	MODIFYCLUSTERSETTING:   `UPSERT INTO system.role_options (username, option) VALUES ($1, 'MODIFYCLUSTERSETTING')`,
 	NOMODIFYCLUSTERSETTING: `DELETE FROM system.role_options WHERE username = $1 AND option = 'MODIFYCLUSTERSETTING'`,
+	PASSWORDCOMPLEXITY:     `UPSERT INTO system.role_options (username, option, value) VALUES ($1, 'PASSWORDCOMPLEXITY', $2::int8::string)`,
+	PASSWORDLIFETIME:       `UPSERT INTO system.role_options (username, option, value) VALUES ($1, 'PASSWORDLIFETIME', $2::interval::string)`,
+	LOGINATTEMPTS:          `UPSERT INTO system.role_options (username, option, value) VALUES ($1, 'LOGINATTEMPTS', $2::int8::string)`,
 }
 
 // Mask returns the bitmask for a given role option.
@@ -108,6 +114,9 @@ var ByName = map[string]Option{
 	"NOCANCELQUERY":          NOCANCELQUERY,
 	"MODIFYCLUSTERSETTING":   MODIFYCLUSTERSETTING,
 	"NOMODIFYCLUSTERSETTING": NOMODIFYCLUSTERSETTING,
+	"PASSWORDCOMPLEXITY":     PASSWORDCOMPLEXITY,
+	"PASSWORDLIFETIME":       PASSWORDLIFETIME,
+	"LOGINATTEMPTS":          LOGINATTEMPTS,
 }
 
 // ToOption takes a string and returns the corresponding Option.
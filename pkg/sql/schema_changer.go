@@ -1163,6 +1163,11 @@ func (sc *SchemaChanger) done(ctx context.Context) error {
 							return err
 						}
 					}
+					// The refresh time is only recorded once all the nodes preserve
+					// it when rewriting the descriptor.
+					if sc.settings.Version.IsActive(ctx, clusterversion.MaterializedViewRefreshTime) {
+						scTable.RefreshAsOfTime = refresh.AsOf
+					}
 				} else if mutation.Direction == descpb.DescriptorMutation_DROP {
 					// Otherwise, the refresh job ran into an error and is being rolled
 					// back. So, we need to GC all of the indexes that were going to be
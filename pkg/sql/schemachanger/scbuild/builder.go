@@ -188,6 +188,9 @@ func (b *Builder) alterTableAddColumn(
 	if d.IsSerial {
 		return &notImplementedError{n: t.ColumnDef, detail: "contains serial data type"}
 	}
+	if d.IsGeneratedAsIdentity() {
+		return &notImplementedError{n: t.ColumnDef, detail: "contains identity column"}
+	}
 	// Some of the building for the index exists below but end-to-end support is
 	// not complete so we return an error.
 	if d.Unique.IsUnique {
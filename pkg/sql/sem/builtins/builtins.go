@@ -4678,6 +4678,29 @@ may increase either contention or retry errors, or both.`,
 			Volatility: tree.VolatilityStable,
 		},
 	),
+	"crdb_internal.check_view_option": makeBuiltin(
+		tree.FunctionProperties{
+			Category:     categorySystemInfo,
+			NullableArgs: true,
+		},
+		tree.Overload{
+			Types: tree.ArgTypes{
+				{"ok", types.Bool},
+				{"view", types.String},
+			},
+			ReturnType: tree.FixedReturnType(types.Bool),
+			Fn: func(_ *tree.EvalContext, args tree.Datums) (tree.Datum, error) {
+				// A NULL condition fails the check, like it does in Postgres.
+				if args[0] != tree.DBoolTrue {
+					return nil, pgerror.Newf(pgcode.WithCheckOptionViolation,
+						"new row violates check option for view %q", tree.MustBeDString(args[1]))
+				}
+				return tree.DBoolTrue, nil
+			},
+			Info:       "This function is used internally to enforce the check options of views during mutations.",
+			Volatility: tree.VolatilityVolatile,
+		},
+	),
 	"crdb_internal.completed_migrations": makeBuiltin(
 		tree.FunctionProperties{
 			Category: categorySystemInfo,
@@ -23,6 +23,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/lex"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/protoreflect"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
@@ -81,14 +82,93 @@ var aclexplodeGeneratorType = types.MakeLabeledTuple(
 	[]string{"grantor", "grantee", "privilege_type", "is_grantable"},
 )
 
-// aclExplodeGenerator supports the execution of aclexplode.
-type aclexplodeGenerator struct{}
+// aclexplodeGenerator supports the execution of aclexplode.
+type aclexplodeGenerator struct {
+	rows   []tree.Datums
+	rowIdx int
+}
+
+func makeAclexplodeGenerator(
+	ctx *tree.EvalContext, args tree.Datums,
+) (tree.ValueGenerator, error) {
+	g := &aclexplodeGenerator{}
+	if args[0] == tree.DNull {
+		return g, nil
+	}
+	// The OIDs of the roles are looked up once per role.
+	roleOids := make(map[string]tree.Datum)
+	roleOid := func(name string) (tree.Datum, error) {
+		if oid, ok := roleOids[name]; ok {
+			return oid, nil
+		}
+		// The public role is represented by OID 0.
+		oid := tree.Datum(tree.NewDOid(0))
+		if name != "" {
+			r, err := ctx.InternalExecutor.QueryRow(
+				ctx.Ctx(), "aclexplode", ctx.Txn,
+				"SELECT oid FROM pg_catalog.pg_roles WHERE rolname = $1", name,
+			)
+			if err != nil {
+				return nil, err
+			}
+			if r == nil {
+				return nil, pgerror.Newf(pgcode.UndefinedObject, "role %q does not exist", name)
+			}
+			oid = r[0]
+		}
+		roleOids[name] = oid
+		return oid, nil
+	}
+	for _, d := range tree.MustBeDArray(args[0]).Array {
+		if d == tree.DNull {
+			continue
+		}
+		item, err := privilege.ParseACLItem(string(tree.MustBeDString(d)))
+		if err != nil {
+			return nil, err
+		}
+		grantor, err := roleOid(item.Grantor)
+		if err != nil {
+			return nil, err
+		}
+		grantee, err := roleOid(item.Grantee)
+		if err != nil {
+			return nil, err
+		}
+		for _, kind := range item.Kinds() {
+			g.rows = append(g.rows, tree.Datums{
+				grantor,
+				grantee,
+				tree.NewDString(kind.String()),
+				tree.MakeDBool(tree.DBool(item.CanGrant(kind))),
+			})
+		}
+	}
+	return g, nil
+}
 
-func (aclexplodeGenerator) ResolvedType() *types.T                   { return aclexplodeGeneratorType }
-func (aclexplodeGenerator) Start(_ context.Context, _ *kv.Txn) error { return nil }
-func (aclexplodeGenerator) Close(_ context.Context)                  {}
-func (aclexplodeGenerator) Next(_ context.Context) (bool, error)     { return false, nil }
-func (aclexplodeGenerator) Values() (tree.Datums, error)             { return nil, nil }
+// ResolvedType implements the tree.ValueGenerator interface.
+func (*aclexplodeGenerator) ResolvedType() *types.T { return aclexplodeGeneratorType }
+
+// Start implements the tree.ValueGenerator interface.
+func (g *aclexplodeGenerator) Start(_ context.Context, _ *kv.Txn) error {
+	g.rowIdx = -1
+	return nil
+}
+
+// Close implements the tree.ValueGenerator interface.
+func (*aclexplodeGenerator) Close(_ context.Context) {}
+
+// Next implements the tree.ValueGenerator interface.
+func (g *aclexplodeGenerator) Next(_ context.Context) (bool, error) {
+	g.rowIdx++
+	return g.rowIdx < len(g.rows), nil
+}
+
+// Values implements the tree.ValueGenerator interface.
+func (g *aclexplodeGenerator) Values() (tree.Datums, error) {
+	return g.rows[g.rowIdx], nil
+}
 
 // generators is a map from name to slice of Builtins for all built-in
 // generators.
@@ -101,11 +181,9 @@ var generators = map[string]builtinDefinition{
 		makeGeneratorOverload(
 			tree.ArgTypes{{"aclitems", types.StringArray}},
 			aclexplodeGeneratorType,
-			func(ctx *tree.EvalContext, args tree.Datums) (tree.ValueGenerator, error) {
-				return aclexplodeGenerator{}, nil
-			},
-			"Produces a virtual table containing aclitem stuff ("+
-				"returns no rows as this feature is unsupported in CockroachDB)",
+			makeAclexplodeGenerator,
+			"Produces a row for each privilege of the given aclitems, with the OIDs of "+
+				"the grantor and grantee, the privilege type and whether it is grantable.",
 			tree.VolatilityStable,
 		),
 	),
@@ -365,6 +443,21 @@ var generators = map[string]builtinDefinition{
 			tree.VolatilityVolatile,
 		),
 	),
+	"crdb_internal.collation_version_mismatches": makeBuiltin(
+		tree.FunctionProperties{
+			Class:    tree.GeneratorClass,
+			Category: categorySystemInfo,
+		},
+		makeGeneratorOverload(
+			tree.ArgTypes{},
+			collationVersionMismatchesGeneratorType,
+			makeCollationVersionMismatchesGenerator,
+			"Returns the collated string columns and the indexes on collated string "+
+				"columns of the current database whose recorded collation version "+
+				"differs from the version of the collation tables in use.",
+			tree.VolatilityVolatile,
+		),
+	),
 	"crdb_internal.show_create_all_tables": makeBuiltin(
 		tree.FunctionProperties{
 			Class: tree.GeneratorClass,
@@ -1637,6 +1730,73 @@ func (p *payloadsForTraceGenerator) Close(_ context.Context) {
 	}
 }
 
+var collationVersionMismatchesGeneratorType = types.MakeLabeledTuple(
+	[]*types.T{types.String, types.String, types.String, types.String, types.String, types.String},
+	[]string{"object_type", "database_name", "schema_name", "table_name", "object_name", "collation_version"},
+)
+
+// collationVersionMismatchesGenerator is a value generator that iterates over
+// the columns and indexes whose recorded collation version differs from the
+// version of the collation tables in use.
+type collationVersionMismatchesGenerator struct {
+	it sqlutil.InternalRows
+}
+
+func makeCollationVersionMismatchesGenerator(
+	ctx *tree.EvalContext, args tree.Datums,
+) (tree.ValueGenerator, error) {
+	const query = `
+SELECT 'column', table_catalog, table_schema, table_name, column_name, crdb_collation_version
+  FROM information_schema.columns
+ WHERE crdb_collation_version != $1
+UNION ALL
+SELECT 'index', t.database_name, t.schema_name, t.name, i.index_name, i.collation_version
+  FROM crdb_internal.table_indexes AS i
+  JOIN crdb_internal.tables AS t ON t.table_id = i.descriptor_id
+ WHERE i.collation_version != $1`
+
+	ie := ctx.InternalExecutor.(sqlutil.InternalExecutor)
+	it, err := ie.QueryIteratorEx(
+		ctx.Ctx(),
+		"crdb_internal.collation_version_mismatches",
+		ctx.Txn,
+		sessiondata.NoSessionDataOverride,
+		query,
+		tree.CollationVersion,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &collationVersionMismatchesGenerator{it: it}, nil
+}
+
+// ResolvedType implements the tree.ValueGenerator interface.
+func (g *collationVersionMismatchesGenerator) ResolvedType() *types.T {
+	return collationVersionMismatchesGeneratorType
+}
+
+// Start implements the tree.ValueGenerator interface.
+func (g *collationVersionMismatchesGenerator) Start(_ context.Context, _ *kv.Txn) error {
+	return nil
+}
+
+// Next implements the tree.ValueGenerator interface.
+func (g *collationVersionMismatchesGenerator) Next(ctx context.Context) (bool, error) {
+	return g.it.Next(ctx)
+}
+
+// Values implements the tree.ValueGenerator interface.
+func (g *collationVersionMismatchesGenerator) Values() (tree.Datums, error) {
+	return g.it.Cur(), nil
+}
+
+// Close implements the tree.ValueGenerator interface.
+func (g *collationVersionMismatchesGenerator) Close(_ context.Context) {
+	// As for payloadsForTraceGenerator, the iterator's error cannot be
+	// surfaced here.
+	_ = g.it.Close()
+}
+
 var showCreateAllTablesGeneratorType = types.String
 
 // Phase is used to determine if CREATE statements or ALTER statements
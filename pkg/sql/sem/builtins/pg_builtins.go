@@ -513,61 +513,23 @@ func parsePrivilegeStr(arg tree.Datum, availOpts pgPrivList) (tree.Datum, error)
 	return tree.DBoolTrue, nil
 }
 
-// evalPrivilegeCheck performs a privilege check for the specified privilege.
-// The function takes an information_schema table name for which to run a query
-// against, along with an arbitrary predicate to run against the table and the
-// user to perform the check on. It also takes a flag as to whether the
-// privilege check should also test whether the privilege is held with grant
-// option.
+// evalPrivilegeCheck performs a privilege check for the specified privilege
+// on the specified object, for the given user. It also takes a flag as to
+// whether the privilege check should also test whether the privilege is held
+// with grant option. The check is resolved by the planner against the same
+// privileges as those reported by information_schema.
 func evalPrivilegeCheck(
 	ctx *tree.EvalContext,
-	schema string,
-	infoTable string,
+	specifier tree.HasPrivilegeSpecifier,
 	user security.SQLUsername,
-	pred string,
 	priv privilege.Kind,
 	withGrantOpt bool,
 ) (tree.Datum, error) {
-	privChecks := []privilege.Kind{priv}
-	if withGrantOpt {
-		privChecks = append(privChecks, privilege.GRANT)
-	}
-
-	allRoleMemberships, err := ctx.Planner.MemberOfWithAdminOption(ctx.Context, user)
+	ok, err := ctx.Planner.HasPrivilege(ctx.Context, specifier, user, priv, withGrantOpt)
 	if err != nil {
 		return nil, err
 	}
-
-	// Slice containing all roles user is a direct and indirect member of.
-	allRoles := []string{security.PublicRole, user.Normalized()}
-	for role := range allRoleMemberships {
-		allRoles = append(allRoles, role.Normalized())
-	}
-
-	for _, p := range privChecks {
-		query := fmt.Sprintf(`
-			SELECT bool_or(privilege_type IN ('%s', '%s')) IS TRUE
-			FROM %s.%s WHERE grantee = ANY ($1) AND %s`,
-			privilege.ALL, p, schema, infoTable, pred)
-		r, err := ctx.InternalExecutor.QueryRow(
-			ctx.Ctx(), "eval-privilege-check", ctx.Txn, query, allRoles,
-		)
-		if err != nil {
-			return nil, err
-		}
-		if r == nil {
-			return nil, errors.AssertionFailedf("failed to evaluate privilege check")
-		}
-		switch r[0] {
-		case tree.DBoolFalse:
-			return tree.DBoolFalse, nil
-		case tree.DBoolTrue:
-			continue
-		default:
-			return nil, errors.AssertionFailedf("unexpected privilege check result %v", r[0])
-		}
-	}
-	return tree.DBoolTrue, nil
+	return tree.MakeDBool(tree.DBool(ok)), nil
 }
 
 func makeCreateRegDef(typ *types.T) builtinDefinition {
@@ -1202,16 +1164,14 @@ SELECT description
 			if err != nil {
 				return nil, err
 			}
-			pred := ""
+			var specifier tree.HasPrivilegeSpecifier
 			retNull := false
 			if tn == nil {
 				// Postgres returns NULL if no matching table is found
 				// when given an OID.
 				retNull = true
 			} else {
-				pred = fmt.Sprintf(
-					"table_catalog = '%s' AND table_schema = '%s' AND table_name = '%s'",
-					tn.CatalogName, tn.SchemaName, tn.ObjectName)
+				specifier.TableName = tn
 			}
 
 			return parsePrivilegeStr(args[1], pgPrivList{
@@ -1219,29 +1179,25 @@ SELECT description
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred, privilege.SELECT, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.SELECT, withGrantOpt)
 				},
 				"INSERT": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred, privilege.INSERT, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.INSERT, withGrantOpt)
 				},
 				"UPDATE": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred, privilege.UPDATE, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.UPDATE, withGrantOpt)
 				},
 				"REFERENCES": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred, privilege.SELECT, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.SELECT, withGrantOpt)
 				},
 			})
 		},
@@ -1256,16 +1212,14 @@ SELECT description
 			if err != nil {
 				return nil, err
 			}
-			pred := ""
+			var specifier tree.HasPrivilegeSpecifier
 			retNull := false
 			if tn == nil {
 				// Postgres returns NULL if no matching table is found
 				// when given an OID.
 				retNull = true
 			} else {
-				pred = fmt.Sprintf(
-					"table_catalog = '%s' AND table_schema = '%s' AND table_name = '%s'",
-					tn.CatalogName, tn.SchemaName, tn.ObjectName)
+				specifier.TableName = tn
 
 				// Verify that the column exists in the table.
 				var colPred string
@@ -1301,29 +1255,25 @@ SELECT description
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred, privilege.SELECT, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.SELECT, withGrantOpt)
 				},
 				"INSERT": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred, privilege.INSERT, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.INSERT, withGrantOpt)
 				},
 				"UPDATE": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred, privilege.UPDATE, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.UPDATE, withGrantOpt)
 				},
 				"REFERENCES": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred, privilege.SELECT, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.SELECT, withGrantOpt)
 				},
 			})
 		},
@@ -1351,40 +1301,31 @@ SELECT description
 				}
 			}
 
-			schemaPrivilegePred := fmt.Sprintf("table_catalog = '%s'", db)
-			databasePrivilegePred := fmt.Sprintf("database_name = '%s'", db)
+			specifier := tree.HasPrivilegeSpecifier{DatabaseName: &db}
 			return parsePrivilegeStr(args[1], pgPrivList{
 				"CREATE": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "crdb_internal",
-						"cluster_database_privileges", user, databasePrivilegePred,
-						privilege.CREATE, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.CREATE, withGrantOpt)
 				},
 				"CONNECT": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "crdb_internal",
-						"cluster_database_privileges", user, databasePrivilegePred,
-						privilege.CONNECT, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.CONNECT, withGrantOpt)
 				},
 				"TEMPORARY": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"schema_privileges", user, schemaPrivilegePred,
-						privilege.CREATE, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.CREATE, withGrantOpt)
 				},
 				"TEMP": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"schema_privileges", user, schemaPrivilegePred,
-						privilege.CREATE, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.CREATE, withGrantOpt)
 				},
 			})
 		},
@@ -1454,7 +1395,8 @@ SELECT description
 					if retNull {
 						return tree.DNull, nil
 					}
-					// All users have access to all functions.
+					// EXECUTE is granted to public on all built-in functions. See
+					// information_schema.routine_privileges.
 					return tree.DBoolTrue, nil
 				},
 			})
@@ -1521,24 +1463,20 @@ SELECT description
 				retNull = true
 			}
 
-			pred := fmt.Sprintf("table_catalog = '%s' AND table_schema = '%s'",
-				ctx.SessionData.Database, schema)
+			db := ctx.SessionData.Database
+			specifier := tree.HasPrivilegeSpecifier{SchemaDatabaseName: &db, SchemaName: &schema}
 			return parsePrivilegeStr(args[1], pgPrivList{
 				"CREATE": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"schema_privileges", user, pred,
-						privilege.CREATE, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.CREATE, withGrantOpt)
 				},
 				"USAGE": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"schema_privileges", user, pred,
-						privilege.USAGE, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.USAGE, withGrantOpt)
 				},
 			})
 		},
@@ -1553,7 +1491,7 @@ SELECT description
 			if err != nil {
 				return nil, err
 			}
-			pred := ""
+			var specifier tree.HasPrivilegeSpecifier
 			retNull := false
 			if tn == nil {
 				// Postgres returns NULL if no matching table is found
@@ -1573,9 +1511,7 @@ SELECT description
 						"%s is not a sequence", seqArg)
 				}
 
-				pred = fmt.Sprintf(
-					"table_catalog = '%s' AND table_schema = '%s' AND table_name = '%s'",
-					tn.CatalogName, tn.SchemaName, tn.ObjectName)
+				specifier.TableName = tn
 			}
 
 			return parsePrivilegeStr(args[1], pgPrivList{
@@ -1583,25 +1519,19 @@ SELECT description
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred,
-						privilege.SELECT, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.USAGE, withGrantOpt)
 				},
 				"SELECT": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred,
-						privilege.SELECT, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.SELECT, withGrantOpt)
 				},
 				"UPDATE": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred,
-						privilege.UPDATE, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.UPDATE, withGrantOpt)
 				},
 			})
 		},
@@ -1645,16 +1575,14 @@ SELECT description
 			if err != nil {
 				return nil, err
 			}
-			pred := ""
+			var specifier tree.HasPrivilegeSpecifier
 			retNull := false
 			if tn == nil {
 				// Postgres returns NULL if no matching table is found
 				// when given an OID.
 				retNull = true
 			} else {
-				pred = fmt.Sprintf(
-					"table_catalog = '%s' AND table_schema = '%s' AND table_name = '%s'",
-					tn.CatalogName, tn.SchemaName, tn.ObjectName)
+				specifier.TableName = tn
 			}
 
 			return parsePrivilegeStr(args[1], pgPrivList{
@@ -1662,57 +1590,43 @@ SELECT description
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred,
-						privilege.SELECT, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.SELECT, withGrantOpt)
 				},
 				"INSERT": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred,
-						privilege.INSERT, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.INSERT, withGrantOpt)
 				},
 				"UPDATE": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred,
-						privilege.UPDATE, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.UPDATE, withGrantOpt)
 				},
 				"DELETE": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred,
-						privilege.DELETE, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.DELETE, withGrantOpt)
 				},
 				"TRUNCATE": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred,
-						privilege.DELETE, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.DELETE, withGrantOpt)
 				},
 				"REFERENCES": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred,
-						privilege.SELECT, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.SELECT, withGrantOpt)
 				},
 				"TRIGGER": func(withGrantOpt bool) (tree.Datum, error) {
 					if retNull {
 						return tree.DNull, nil
 					}
-					return evalPrivilegeCheck(ctx, "information_schema",
-						"table_privileges", user, pred,
-						privilege.CREATE, withGrantOpt)
+					return evalPrivilegeCheck(ctx, specifier, user, privilege.CREATE, withGrantOpt)
 				},
 			})
 		},
@@ -1956,9 +1870,11 @@ func getCatalogOidForComments(catalogName string) (id int, ok bool) {
 		return catconstants.PgCatalogClassTableID, true
 	case "pg_database":
 		return catconstants.PgCatalogDatabaseTableID, true
+	case "pg_namespace":
+		return catconstants.PgCatalogNamespaceTableID, true
 	default:
 		// We currently only support comments on pg_class objects
-		// (columns, tables) in this context.
+		// (columns, tables) and schemas in this context.
 		// see a different name, matching pg.
 		return 0, false
 	}
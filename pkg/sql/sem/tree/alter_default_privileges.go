@@ -0,0 +1,130 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package tree
+
+import (
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
+	"github.com/cockroachdb/errors"
+)
+
+// AlterDefaultPrivileges represents an ALTER DEFAULT PRIVILEGES statement.
+type AlterDefaultPrivileges struct {
+	// Roles are the roles whose future objects are affected. If empty, the
+	// current user is used.
+	Roles []security.SQLUsername
+	// Schemas restricts the defaults to objects created in the given schemas.
+	// If empty, the defaults apply to objects created in any schema of the
+	// current database.
+	Schemas ObjectNamePrefixList
+
+	// Only one of Grant or Revoke is set, depending on IsGrant.
+	IsGrant bool
+	Grant   AbbreviatedGrant
+	Revoke  AbbreviatedRevoke
+}
+
+// Format implements the NodeFormatter interface.
+func (n *AlterDefaultPrivileges) Format(ctx *FmtCtx) {
+	ctx.WriteString("ALTER DEFAULT PRIVILEGES ")
+	if len(n.Roles) > 0 {
+		ctx.WriteString("FOR ROLE ")
+		for i := range n.Roles {
+			if i > 0 {
+				ctx.WriteString(", ")
+			}
+			ctx.FormatUsername(n.Roles[i])
+		}
+		ctx.WriteString(" ")
+	}
+	if len(n.Schemas) > 0 {
+		ctx.WriteString("IN SCHEMA ")
+		ctx.FormatNode(&n.Schemas)
+		ctx.WriteString(" ")
+	}
+	if n.IsGrant {
+		ctx.FormatNode(&n.Grant)
+	} else {
+		ctx.FormatNode(&n.Revoke)
+	}
+}
+
+// AlterDefaultPrivilegesTargetObject represents the kind of object affected
+// by an ALTER DEFAULT PRIVILEGES statement.
+type AlterDefaultPrivilegesTargetObject uint32
+
+// The values for AlterDefaultPrivilegesTargetObject.
+const (
+	Tables    AlterDefaultPrivilegesTargetObject = 1
+	Sequences AlterDefaultPrivilegesTargetObject = 2
+	Types     AlterDefaultPrivilegesTargetObject = 3
+	Schemas   AlterDefaultPrivilegesTargetObject = 4
+)
+
+// String implements the fmt.Stringer interface.
+func (t AlterDefaultPrivilegesTargetObject) String() string {
+	switch t {
+	case Tables:
+		return "TABLES"
+	case Sequences:
+		return "SEQUENCES"
+	case Types:
+		return "TYPES"
+	case Schemas:
+		return "SCHEMAS"
+	default:
+		panic(errors.AssertionFailedf("unknown AlterDefaultPrivilegesTargetObject value: %d", t))
+	}
+}
+
+// AbbreviatedGrant represents the GRANT clause of an
+// ALTER DEFAULT PRIVILEGES statement.
+type AbbreviatedGrant struct {
+	Privileges      privilege.List
+	Target          AlterDefaultPrivilegesTargetObject
+	Grantees        NameList
+	WithGrantOption bool
+}
+
+// Format implements the NodeFormatter interface.
+func (n *AbbreviatedGrant) Format(ctx *FmtCtx) {
+	ctx.WriteString("GRANT ")
+	n.Privileges.Format(&ctx.Buffer)
+	ctx.WriteString(" ON ")
+	ctx.WriteString(n.Target.String())
+	ctx.WriteString(" TO ")
+	ctx.FormatNode(&n.Grantees)
+	if n.WithGrantOption {
+		ctx.WriteString(" WITH GRANT OPTION")
+	}
+}
+
+// AbbreviatedRevoke represents the REVOKE clause of an
+// ALTER DEFAULT PRIVILEGES statement.
+type AbbreviatedRevoke struct {
+	Privileges     privilege.List
+	Target         AlterDefaultPrivilegesTargetObject
+	Grantees       NameList
+	GrantOptionFor bool
+}
+
+// Format implements the NodeFormatter interface.
+func (n *AbbreviatedRevoke) Format(ctx *FmtCtx) {
+	ctx.WriteString("REVOKE ")
+	if n.GrantOptionFor {
+		ctx.WriteString("GRANT OPTION FOR ")
+	}
+	n.Privileges.Format(&ctx.Buffer)
+	ctx.WriteString(" ON ")
+	ctx.WriteString(n.Target.String())
+	ctx.WriteString(" FROM ")
+	ctx.FormatNode(&n.Grantees)
+}
@@ -51,8 +51,10 @@ type AlterIndexCmd interface {
 }
 
 func (*AlterIndexPartitionBy) alterIndexCmd() {}
+func (*AlterIndexVisible) alterIndexCmd()     {}
 
 var _ AlterIndexCmd = &AlterIndexPartitionBy{}
+var _ AlterIndexCmd = &AlterIndexVisible{}
 
 // AlterIndexPartitionBy represents an ALTER INDEX PARTITION BY
 // command.
@@ -64,3 +66,18 @@ type AlterIndexPartitionBy struct {
 func (node *AlterIndexPartitionBy) Format(ctx *FmtCtx) {
 	ctx.FormatNode(node.PartitionByIndex)
 }
+
+// AlterIndexVisible represents an ALTER INDEX {VISIBLE | NOT VISIBLE}
+// command.
+type AlterIndexVisible struct {
+	NotVisible bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *AlterIndexVisible) Format(ctx *FmtCtx) {
+	if node.NotVisible {
+		ctx.WriteString(" NOT VISIBLE")
+	} else {
+		ctx.WriteString(" VISIBLE")
+	}
+}
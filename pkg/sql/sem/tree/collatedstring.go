@@ -15,6 +15,11 @@ import "golang.org/x/text/collate"
 // DefaultCollationTag is the "default" collation for strings.
 const DefaultCollationTag = "default"
 
+// CollationVersion is the version of the collation tables used to compare
+// collated strings. It is recorded on the columns of collated string types, so
+// that indexes ordered with different tables can be detected.
+const CollationVersion = collate.CLDRVersion
+
 func init() {
 	if collate.CLDRVersion != "23" {
 		panic("This binary was built with an incompatible version of golang.org/x/text. " +
@@ -214,6 +214,7 @@ type CreateIndex struct {
 	StorageParams    StorageParams
 	Predicate        Expr
 	Concurrently     bool
+	NotVisible       bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -278,6 +279,9 @@ func (node *CreateIndex) Format(ctx *FmtCtx) {
 			ctx.FormatNode(node.Predicate)
 		}
 	}
+	if node.NotVisible && !ctx.HasFlags(FmtPGCatalog) {
+		ctx.WriteString(" NOT VISIBLE")
+	}
 }
 
 // CreateTypeVariety represents a particular variety of user defined types.
@@ -372,6 +376,7 @@ func (*IndexTableDef) tableDef()                {}
 func (*FamilyTableDef) tableDef()               {}
 func (*ForeignKeyConstraintTableDef) tableDef() {}
 func (*CheckConstraintTableDef) tableDef()      {}
+func (*ExclusionConstraintTableDef) tableDef()  {}
 func (*LikeTableDef) tableDef()                 {}
 
 // TableDefs represents a list of table definitions.
@@ -436,6 +441,11 @@ type ColumnTableDef struct {
 		Expr     Expr
 		Virtual  bool
 	}
+	GeneratedIdentity struct {
+		IsGeneratedAsIdentity   bool
+		GeneratedAsIdentityType GeneratedIdentityType
+		SeqOptions              SequenceOptions
+	}
 	Family struct {
 		Name        Name
 		Create      bool
@@ -443,6 +453,15 @@ type ColumnTableDef struct {
 	}
 }
 
+// GeneratedIdentityType represents how an identity column was declared.
+type GeneratedIdentityType int
+
+// The values for GeneratedIdentityType.
+const (
+	GeneratedAlways GeneratedIdentityType = iota
+	GeneratedByDefault
+)
+
 // ColumnTableDefCheckExpr represents a check constraint on a column definition
 // within a CREATE TABLE statement.
 type ColumnTableDefCheckExpr struct {
@@ -515,6 +534,10 @@ func NewColumnTableDef(
 				return nil, pgerror.Newf(pgcode.Syntax,
 					"multiple default values specified for column %q", name)
 			}
+			if d.IsGeneratedAsIdentity() {
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"both default and identity specified for column %q", name)
+			}
 			d.DefaultExpr.Expr = t.Expr
 			d.DefaultExpr.ConstraintName = c.Name
 		case HiddenConstraint:
@@ -562,9 +585,29 @@ func NewColumnTableDef(
 			d.References.Actions = t.Actions
 			d.References.Match = t.Match
 		case *ColumnComputedDef:
+			if d.IsGeneratedAsIdentity() {
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"both identity and generation expression specified for column %q", name)
+			}
 			d.Computed.Computed = true
 			d.Computed.Expr = t.Expr
 			d.Computed.Virtual = t.Virtual
+		case *GeneratedAsIdentityConstraint:
+			if d.IsGeneratedAsIdentity() {
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"multiple identity specifications for column %q", name)
+			}
+			if d.HasDefaultExpr() {
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"both default and identity specified for column %q", name)
+			}
+			if d.IsComputed() {
+				return nil, pgerror.Newf(pgcode.Syntax,
+					"both identity and generation expression specified for column %q", name)
+			}
+			d.GeneratedIdentity.IsGeneratedAsIdentity = true
+			d.GeneratedIdentity.GeneratedAsIdentityType = t.GeneratedAsIdentityType
+			d.GeneratedIdentity.SeqOptions = t.SeqOptions
 		case *ColumnFamilyConstraint:
 			if d.HasColumnFamily() {
 				return nil, pgerror.Newf(pgcode.InvalidTableDefinition,
@@ -600,6 +643,11 @@ func (node *ColumnTableDef) IsVirtual() bool {
 	return node.Computed.Virtual
 }
 
+// IsGeneratedAsIdentity returns if the ColumnTableDef is an identity column.
+func (node *ColumnTableDef) IsGeneratedAsIdentity() bool {
+	return node.GeneratedIdentity.IsGeneratedAsIdentity
+}
+
 // HasColumnFamily returns if the ColumnTableDef has a column family.
 func (node *ColumnTableDef) HasColumnFamily() bool {
 	return node.Family.Name != "" || node.Family.Create
@@ -691,6 +739,19 @@ func (node *ColumnTableDef) Format(ctx *FmtCtx) {
 			ctx.WriteString(") STORED")
 		}
 	}
+	if node.IsGeneratedAsIdentity() {
+		switch node.GeneratedIdentity.GeneratedAsIdentityType {
+		case GeneratedAlways:
+			ctx.WriteString(" GENERATED ALWAYS AS IDENTITY")
+		case GeneratedByDefault:
+			ctx.WriteString(" GENERATED BY DEFAULT AS IDENTITY")
+		}
+		if len(node.GeneratedIdentity.SeqOptions) > 0 {
+			ctx.WriteString(" (")
+			ctx.FormatNode(&node.GeneratedIdentity.SeqOptions)
+			ctx.WriteString(" )")
+		}
+	}
 	if node.HasColumnFamily() {
 		if node.Family.Create {
 			ctx.WriteString(" CREATE")
@@ -737,18 +798,19 @@ type ColumnQualification interface {
 	columnQualification()
 }
 
-func (ColumnCollation) columnQualification()             {}
-func (*ColumnDefault) columnQualification()              {}
-func (NotNullConstraint) columnQualification()           {}
-func (NullConstraint) columnQualification()              {}
-func (HiddenConstraint) columnQualification()            {}
-func (PrimaryKeyConstraint) columnQualification()        {}
-func (ShardedPrimaryKeyConstraint) columnQualification() {}
-func (UniqueConstraint) columnQualification()            {}
-func (*ColumnCheckConstraint) columnQualification()      {}
-func (*ColumnComputedDef) columnQualification()          {}
-func (*ColumnFKConstraint) columnQualification()         {}
-func (*ColumnFamilyConstraint) columnQualification()     {}
+func (ColumnCollation) columnQualification()                {}
+func (*ColumnDefault) columnQualification()                 {}
+func (NotNullConstraint) columnQualification()              {}
+func (NullConstraint) columnQualification()                 {}
+func (HiddenConstraint) columnQualification()               {}
+func (PrimaryKeyConstraint) columnQualification()           {}
+func (ShardedPrimaryKeyConstraint) columnQualification()    {}
+func (UniqueConstraint) columnQualification()               {}
+func (*ColumnCheckConstraint) columnQualification()         {}
+func (*ColumnComputedDef) columnQualification()             {}
+func (*ColumnFKConstraint) columnQualification()            {}
+func (*ColumnFamilyConstraint) columnQualification()        {}
+func (*GeneratedAsIdentityConstraint) columnQualification() {}
 
 // ColumnCollation represents a COLLATE clause for a column.
 type ColumnCollation string
@@ -795,6 +857,13 @@ type ColumnFKConstraint struct {
 	Match   CompositeKeyMatchMethod
 }
 
+// GeneratedAsIdentityConstraint represents GENERATED {ALWAYS | BY DEFAULT}
+// AS IDENTITY on a column.
+type GeneratedAsIdentityConstraint struct {
+	GeneratedAsIdentityType GeneratedIdentityType
+	SeqOptions              SequenceOptions
+}
+
 // ColumnComputedDef represents the description of a computed column.
 type ColumnComputedDef struct {
 	Expr    Expr
@@ -820,6 +889,7 @@ type IndexTableDef struct {
 	PartitionByIndex *PartitionByIndex
 	StorageParams    StorageParams
 	Predicate        Expr
+	NotVisible       bool
 }
 
 // Format implements the NodeFormatter interface.
@@ -858,6 +928,9 @@ func (node *IndexTableDef) Format(ctx *FmtCtx) {
 		ctx.WriteString(" WHERE ")
 		ctx.FormatNode(node.Predicate)
 	}
+	if node.NotVisible {
+		ctx.WriteString(" NOT VISIBLE")
+	}
 }
 
 // ConstraintTableDef represents a constraint definition within a CREATE TABLE
@@ -875,6 +948,7 @@ type ConstraintTableDef interface {
 func (*UniqueConstraintTableDef) constraintTableDef()     {}
 func (*ForeignKeyConstraintTableDef) constraintTableDef() {}
 func (*CheckConstraintTableDef) constraintTableDef()      {}
+func (*ExclusionConstraintTableDef) constraintTableDef()  {}
 
 // UniqueConstraintTableDef represents a unique constraint within a CREATE
 // TABLE statement.
@@ -891,6 +965,12 @@ func (node *UniqueConstraintTableDef) SetName(name Name) {
 
 // Format implements the NodeFormatter interface.
 func (node *UniqueConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.NotVisible && !node.PrimaryKey && !node.WithoutIndex {
+		// NOT VISIBLE can only be specified using the UNIQUE INDEX syntax.
+		ctx.WriteString("UNIQUE ")
+		ctx.FormatNode(&node.IndexTableDef)
+		return
+	}
 	if node.Name != "" {
 		ctx.WriteString("CONSTRAINT ")
 		ctx.FormatNode(&node.Name)
@@ -981,7 +1061,7 @@ type CompositeKeyMatchMethod int
 const (
 	MatchSimple CompositeKeyMatchMethod = iota
 	MatchFull
-	MatchPartial // Note: PARTIAL not actually supported at this point.
+	MatchPartial // Note: PARTIAL is only supported for single-column foreign keys.
 )
 
 var compositeKeyMatchMethodName = [...]string{
@@ -996,12 +1076,13 @@ func (c CompositeKeyMatchMethod) String() string {
 
 // ForeignKeyConstraintTableDef represents a FOREIGN KEY constraint in the AST.
 type ForeignKeyConstraintTableDef struct {
-	Name     Name
-	Table    TableName
-	FromCols NameList
-	ToCols   NameList
-	Actions  ReferenceActions
-	Match    CompositeKeyMatchMethod
+	Name          Name
+	Table         TableName
+	FromCols      NameList
+	ToCols        NameList
+	Actions       ReferenceActions
+	Match         CompositeKeyMatchMethod
+	Deferrability ConstraintDeferrability
 }
 
 // Format implements the NodeFormatter interface.
@@ -1029,6 +1110,7 @@ func (node *ForeignKeyConstraintTableDef) Format(ctx *FmtCtx) {
 	}
 
 	ctx.FormatNode(&node.Actions)
+	ctx.FormatNode(&node.Deferrability)
 }
 
 // SetName implements the ConstraintTableDef interface.
@@ -1036,6 +1118,24 @@ func (node *ForeignKeyConstraintTableDef) SetName(name Name) {
 	node.Name = name
 }
 
+// ConstraintDeferrability describes whether the checking of a constraint can
+// be deferred until the end of the transaction, and whether it is deferred by
+// default.
+type ConstraintDeferrability struct {
+	Deferrable        bool
+	InitiallyDeferred bool
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ConstraintDeferrability) Format(ctx *FmtCtx) {
+	if node.Deferrable {
+		ctx.WriteString(" DEFERRABLE")
+	}
+	if node.InitiallyDeferred {
+		ctx.WriteString(" INITIALLY DEFERRED")
+	}
+}
+
 // CheckConstraintTableDef represents a check constraint within a CREATE
 // TABLE statement.
 type CheckConstraintTableDef struct {
@@ -1061,6 +1161,73 @@ func (node *CheckConstraintTableDef) Format(ctx *FmtCtx) {
 	ctx.WriteByte(')')
 }
 
+// ExclusionConstraintTableDef represents an exclusion constraint within a
+// CREATE TABLE statement. Exclusion constraints are parsed so that schemas
+// dumped from Postgres produce a clear error, but they cannot be created yet.
+type ExclusionConstraintTableDef struct {
+	Name Name
+	// Using is the access method of the underlying index, e.g. "gist". It is
+	// empty if no access method was specified.
+	Using     string
+	Elems     ExclusionConstraintElemList
+	Predicate Expr
+}
+
+// SetName implements the ConstraintTableDef interface.
+func (node *ExclusionConstraintTableDef) SetName(name Name) {
+	node.Name = name
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExclusionConstraintTableDef) Format(ctx *FmtCtx) {
+	if node.Name != "" {
+		ctx.WriteString("CONSTRAINT ")
+		ctx.FormatNode(&node.Name)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteString("EXCLUDE ")
+	if node.Using != "" {
+		ctx.WriteString("USING ")
+		ctx.WriteString(node.Using)
+		ctx.WriteByte(' ')
+	}
+	ctx.WriteByte('(')
+	ctx.FormatNode(&node.Elems)
+	ctx.WriteByte(')')
+	if node.Predicate != nil {
+		ctx.WriteString(" WHERE (")
+		ctx.FormatNode(node.Predicate)
+		ctx.WriteByte(')')
+	}
+}
+
+// ExclusionConstraintElem is a single element of an exclusion constraint: an
+// index element and the operator used to compare it against other rows.
+type ExclusionConstraintElem struct {
+	Elem     IndexElem
+	Operator string
+}
+
+// Format implements the NodeFormatter interface.
+func (node *ExclusionConstraintElem) Format(ctx *FmtCtx) {
+	ctx.FormatNode(&node.Elem)
+	ctx.WriteString(" WITH ")
+	ctx.WriteString(node.Operator)
+}
+
+// ExclusionConstraintElemList is a list of ExclusionConstraintElem.
+type ExclusionConstraintElemList []ExclusionConstraintElem
+
+// Format implements the NodeFormatter interface.
+func (l *ExclusionConstraintElemList) Format(ctx *FmtCtx) {
+	for i := range *l {
+		if i > 0 {
+			ctx.WriteString(", ")
+		}
+		ctx.FormatNode(&(*l)[i])
+	}
+}
+
 // FamilyTableDef represents a family definition within a CREATE TABLE
 // statement.
 type FamilyTableDef struct {
@@ -1509,6 +1676,10 @@ func (node *SequenceOptions) Format(ctx *FmtCtx) {
 		option := &(*node)[i]
 		ctx.WriteByte(' ')
 		switch option.Name {
+		case SeqOptAs:
+			ctx.WriteString(option.Name)
+			ctx.WriteByte(' ')
+			ctx.WriteString(option.AsIntegerType.SQLString())
 		case SeqOptCycle, SeqOptNoCycle:
 			ctx.WriteString(option.Name)
 		case SeqOptCache:
@@ -1588,6 +1759,9 @@ type SequenceOption struct {
 	OptionalWord bool
 
 	ColumnItemVal *ColumnItem
+
+	// AsIntegerType is the integer type of the sequence specified with AS.
+	AsIntegerType *types.T
 }
 
 // Names of options on CREATE SEQUENCE.
@@ -1602,9 +1776,6 @@ const (
 	SeqOptMaxValue  = "MAXVALUE"
 	SeqOptStart     = "START"
 	SeqOptVirtual   = "VIRTUAL"
-
-	// Avoid unused warning for constants.
-	_ = SeqOptAs
 )
 
 // LikeTableDef represents a LIKE table declaration on a CREATE TABLE statement.
@@ -1807,6 +1978,39 @@ type CreateView struct {
 	Persistence  Persistence
 	Replace      bool
 	Materialized bool
+	CheckOption  ViewCheckOption
+	// Options are the view options specified in the WITH clause, such as
+	// security_invoker and security_barrier.
+	Options StorageParams
+}
+
+// ViewCheckOption is the check option of a view, which prevents rows that
+// would not be visible through the view from being inserted or updated
+// through it.
+type ViewCheckOption int
+
+const (
+	// ViewCheckOptionNone means that no check option was specified.
+	ViewCheckOptionNone ViewCheckOption = iota
+	// ViewCheckOptionLocal checks the rows against the condition of the view,
+	// and of the underlying views which have a check option themselves.
+	ViewCheckOptionLocal
+	// ViewCheckOptionCascaded checks the rows against the condition of the view
+	// and of all the underlying views.
+	ViewCheckOptionCascaded
+)
+
+// String returns the name of the check option, as reported by
+// information_schema.views.
+func (o ViewCheckOption) String() string {
+	switch o {
+	case ViewCheckOptionLocal:
+		return "LOCAL"
+	case ViewCheckOptionCascaded:
+		return "CASCADED"
+	default:
+		return "NONE"
+	}
 }
 
 // Format implements the NodeFormatter interface.
@@ -1839,8 +2043,20 @@ func (node *CreateView) Format(ctx *FmtCtx) {
 		ctx.WriteByte(')')
 	}
 
+	if node.Options != nil {
+		ctx.WriteString(" WITH (")
+		ctx.FormatNode(&node.Options)
+		ctx.WriteString(")")
+	}
+
 	ctx.WriteString(" AS ")
 	ctx.FormatNode(node.AsSource)
+
+	if node.CheckOption != ViewCheckOptionNone {
+		ctx.WriteString(" WITH ")
+		ctx.WriteString(node.CheckOption.String())
+		ctx.WriteString(" CHECK OPTION")
+	}
 }
 
 // RefreshMaterializedView represents a REFRESH MATERIALIZED VIEW statement.
@@ -33,6 +33,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgnotice"
+	"github.com/cockroachdb/cockroach/pkg/sql/privilege"
 	"github.com/cockroachdb/cockroach/pkg/sql/roleoption"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
@@ -3117,6 +3118,38 @@ type EvalPlanner interface {
 		ctx context.Context,
 		member security.SQLUsername,
 	) (map[security.SQLUsername]bool, error)
+
+	// HasPrivilege returns whether the user holds the privilege on the
+	// specified object, directly or through one of its roles. If withGrantOpt
+	// is set, the privilege must also be grantable. See the comment on the
+	// planner implementation in has_privilege.go.
+	HasPrivilege(
+		ctx context.Context,
+		specifier HasPrivilegeSpecifier,
+		user security.SQLUsername,
+		priv privilege.Kind,
+		withGrantOpt bool,
+	) (bool, error)
+
+	// DeferForeignKeyCheck records that the foreign key constraint with the
+	// given name on the table with the given ID has to be validated before the
+	// current transaction commits. It returns false if the check cannot be
+	// deferred, in which case the violation must be reported right away.
+	DeferForeignKeyCheck(tableID int64, fkName string) bool
+}
+
+// HasPrivilegeSpecifier specifies the object on which a privilege inquiry is
+// made. Exactly one kind of object is specified.
+type HasPrivilegeSpecifier struct {
+	// DatabaseName is set to inquire about a database.
+	DatabaseName *string
+
+	// SchemaDatabaseName and SchemaName are set to inquire about a schema.
+	SchemaDatabaseName *string
+	SchemaName         *string
+
+	// TableName is set to inquire about a table, a view or a sequence.
+	TableName *TableName
 }
 
 // EvalSessionAccessor is a limited interface to access session variables.
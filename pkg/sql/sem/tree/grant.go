@@ -28,9 +28,10 @@ import (
 
 // Grant represents a GRANT statement.
 type Grant struct {
-	Privileges privilege.List
-	Targets    TargetList
-	Grantees   NameList
+	Privileges      privilege.List
+	Targets         TargetList
+	Grantees        NameList
+	WithGrantOption bool
 }
 
 // TargetList represents a list of targets.
@@ -42,6 +43,12 @@ type TargetList struct {
 	Tenant    roachpb.TenantID
 	Types     []*UnresolvedObjectName
 
+	// AllTablesInSchema and AllSequencesInSchema are set for the ALL TABLES
+	// IN SCHEMA and ALL SEQUENCES IN SCHEMA targets respectively, in which
+	// case Schemas lists the schemas containing the targeted objects.
+	AllTablesInSchema    bool
+	AllSequencesInSchema bool
+
 	// ForRoles and Roles are used internally in the parser and not used
 	// in the AST. Therefore they do not participate in pretty-printing,
 	// etc.
@@ -55,7 +62,13 @@ func (tl *TargetList) Format(ctx *FmtCtx) {
 		ctx.WriteString("DATABASE ")
 		ctx.FormatNode(&tl.Databases)
 	} else if tl.Schemas != nil {
-		ctx.WriteString("SCHEMA ")
+		if tl.AllTablesInSchema {
+			ctx.WriteString("ALL TABLES IN SCHEMA ")
+		} else if tl.AllSequencesInSchema {
+			ctx.WriteString("ALL SEQUENCES IN SCHEMA ")
+		} else {
+			ctx.WriteString("SCHEMA ")
+		}
 		ctx.FormatNode(&tl.Schemas)
 	} else if tl.Tenant != (roachpb.TenantID{}) {
 		ctx.WriteString(fmt.Sprintf("TENANT %d", tl.Tenant.ToUint64()))
@@ -81,6 +94,9 @@ func (node *Grant) Format(ctx *FmtCtx) {
 	ctx.FormatNode(&node.Targets)
 	ctx.WriteString(" TO ")
 	ctx.FormatNode(&node.Grantees)
+	if node.WithGrantOption {
+		ctx.WriteString(" WITH GRANT OPTION")
+	}
 }
 
 // GrantRole represents a GRANT <role> statement.
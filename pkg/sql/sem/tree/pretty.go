@@ -1253,8 +1253,9 @@ func (node *CreateTable) doc(p *PrettyCfg) pretty.Doc {
 func (node *CreateView) doc(p *PrettyCfg) pretty.Doc {
 	// Final layout:
 	//
-	// CREATE [TEMP] VIEW name ( ... ) AS
+	// CREATE [TEMP] VIEW name ( ... ) [WITH ( ... )] AS
 	//     SELECT ...
+	// [WITH ... CHECK OPTION]
 	//
 	title := pretty.Keyword("CREATE")
 	if node.Replace {
@@ -1280,10 +1281,23 @@ func (node *CreateView) doc(p *PrettyCfg) pretty.Doc {
 			p.bracket("(", p.Doc(&node.ColumnNames), ")"),
 		)
 	}
-	return p.nestUnder(
+	if node.Options != nil {
+		d = pretty.ConcatSpace(
+			d,
+			p.bracketKeyword("WITH", "(", p.Doc(&node.Options), ")", ""),
+		)
+	}
+	d = p.nestUnder(
 		pretty.ConcatSpace(d, pretty.Keyword("AS")),
 		p.Doc(node.AsSource),
 	)
+	if node.CheckOption != ViewCheckOptionNone {
+		d = pretty.Stack(
+			d,
+			pretty.Keyword("WITH "+node.CheckOption.String()+" CHECK OPTION"),
+		)
+	}
+	return d
 }
 
 func (node *TableDefs) doc(p *PrettyCfg) pretty.Doc {
@@ -1632,6 +1646,9 @@ func (node *CreateIndex) doc(p *PrettyCfg) pretty.Doc {
 	if node.Predicate != nil {
 		clauses = append(clauses, p.nestUnder(pretty.Keyword("WHERE"), p.Doc(node.Predicate)))
 	}
+	if node.NotVisible {
+		clauses = append(clauses, pretty.Keyword("NOT VISIBLE"))
+	}
 	return p.nestUnder(
 		pretty.Fold(pretty.ConcatSpace, title...),
 		pretty.Group(pretty.Stack(clauses...)))
@@ -1704,6 +1721,9 @@ func (node *IndexTableDef) doc(p *PrettyCfg) pretty.Doc {
 	if node.Predicate != nil {
 		clauses = append(clauses, p.nestUnder(pretty.Keyword("WHERE"), p.Doc(node.Predicate)))
 	}
+	if node.NotVisible {
+		clauses = append(clauses, pretty.Keyword("NOT VISIBLE"))
+	}
 
 	if len(clauses) == 0 {
 		return title
@@ -1728,6 +1748,10 @@ func (node *UniqueConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    [PARTITION BY ...]
 	//    [WHERE ...]
 	//
+	if node.NotVisible && !node.PrimaryKey && !node.WithoutIndex {
+		// NOT VISIBLE can only be specified using the UNIQUE INDEX syntax.
+		return pretty.ConcatSpace(pretty.Keyword("UNIQUE"), p.Doc(&node.IndexTableDef))
+	}
 	clauses := make([]pretty.Doc, 0, 5)
 	var title pretty.Doc
 	if node.PrimaryKey {
@@ -1775,6 +1799,7 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl (...)
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE [INITIALLY DEFERRED]]
 	//
 	// or (no constraint name):
 	//
@@ -1782,8 +1807,9 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 	//    REFERENCES tbl [(...)]
 	//    [MATCH ...]
 	//    [ACTIONS ...]
+	//    [DEFERRABLE [INITIALLY DEFERRED]]
 	//
-	clauses := make([]pretty.Doc, 0, 4)
+	clauses := make([]pretty.Doc, 0, 5)
 	title := pretty.ConcatSpace(
 		pretty.Keyword("FOREIGN KEY"),
 		p.bracket("(", p.Doc(&node.FromCols), ")"))
@@ -1808,6 +1834,14 @@ func (node *ForeignKeyConstraintTableDef) doc(p *PrettyCfg) pretty.Doc {
 		clauses = append(clauses, actions)
 	}
 
+	if node.Deferrability.Deferrable {
+		deferrable := pretty.Keyword("DEFERRABLE")
+		if node.Deferrability.InitiallyDeferred {
+			deferrable = pretty.ConcatSpace(deferrable, pretty.Keyword("INITIALLY DEFERRED"))
+		}
+		clauses = append(clauses, deferrable)
+	}
+
 	return p.nestUnder(title, pretty.Group(pretty.Stack(clauses...)))
 }
 
@@ -1832,6 +1866,7 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 	//   [AS ( ... ) STORED]
 	//   [[CREATE [IF NOT EXISTS]] FAMILY [name]]
 	//   [[CONSTRAINT name] DEFAULT expr]
+	//   [GENERATED {ALWAYS|BY DEFAULT} AS IDENTITY [( ... )]]
 	//   [[CONSTRAINT name] {NULL|NOT NULL}]
 	//   [[CONSTRAINT name] {PRIMARY KEY|UNIQUE [WITHOUT INDEX]}]
 	//   [[CONSTRAINT name] CHECK ...]
@@ -1889,6 +1924,21 @@ func (node *ColumnTableDef) docRow(p *PrettyCfg) pretty.TableRow {
 			pretty.ConcatSpace(pretty.Keyword("DEFAULT"), p.Doc(node.DefaultExpr.Expr))))
 	}
 
+	// GENERATED ... AS IDENTITY constraint.
+	if node.IsGeneratedAsIdentity() {
+		var d pretty.Doc
+		switch node.GeneratedIdentity.GeneratedAsIdentityType {
+		case GeneratedAlways:
+			d = pretty.Keyword("GENERATED ALWAYS AS IDENTITY")
+		case GeneratedByDefault:
+			d = pretty.Keyword("GENERATED BY DEFAULT AS IDENTITY")
+		}
+		if len(node.GeneratedIdentity.SeqOptions) > 0 {
+			d = pretty.ConcatSpace(d, p.bracket("(", p.Doc(&node.GeneratedIdentity.SeqOptions), ")"))
+		}
+		clauses = append(clauses, d)
+	}
+
 	// [NOT] VISIBLE constraint.
 	if node.Hidden {
 		hiddenConstraint := pretty.Keyword("NOT VISIBLE")
@@ -24,14 +24,18 @@ import "github.com/cockroachdb/cockroach/pkg/sql/privilege"
 // Revoke represents a REVOKE statement.
 // PrivilegeList and TargetList are defined in grant.go
 type Revoke struct {
-	Privileges privilege.List
-	Targets    TargetList
-	Grantees   NameList
+	Privileges     privilege.List
+	Targets        TargetList
+	Grantees       NameList
+	GrantOptionFor bool
 }
 
 // Format implements the NodeFormatter interface.
 func (node *Revoke) Format(ctx *FmtCtx) {
 	ctx.WriteString("REVOKE ")
+	if node.GrantOptionFor {
+		ctx.WriteString("GRANT OPTION FOR ")
+	}
 	// NB: we cannot use FormatNode() here because node.Privileges is
 	// not an AST node. This is OK, because a privilege list cannot
 	// contain sensitive information.
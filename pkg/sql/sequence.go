@@ -32,7 +32,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/builtins"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
-	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
+	"github.com/cockroachdb/cockroach/pkg/sql/types"
 	"github.com/cockroachdb/cockroach/pkg/util/log"
 	"github.com/cockroachdb/cockroach/pkg/util/sequence"
 	"github.com/cockroachdb/errors"
@@ -49,31 +49,46 @@ func (p *planner) GetSerialSequenceNameFromColumn(
 	}
 	for _, col := range tableDesc.PublicColumns() {
 		if col.ColName() == columnName {
-			// Seems like we have no way of detecting whether this was done using "SERIAL".
-			// Guess by assuming it is SERIAL it it uses only one sequence.
-			// NOTE: This could be alleviated by going through the process of saving SERIAL
-			//       into the descriptor for the column, but has flow on effects for
-			//       which have not been thought about (e.g. implication for backup and restore,
-			//       as well as backward compatibility) so we're using this heuristic for now.
-			// TODO(#52487): fix this up.
-			if col.NumUsesSequences() == 1 {
-				seq, err := p.Descriptors().GetImmutableTableByID(
-					ctx,
-					p.txn,
-					col.GetUsesSequenceID(0),
-					tree.ObjectLookupFlagsWithRequiredTableKind(tree.ResolveRequireSequenceDesc),
-				)
-				if err != nil {
-					return nil, err
-				}
-				return p.getQualifiedTableName(ctx, seq)
+			seqID, _, ok := serialSequenceID(col)
+			if !ok {
+				return nil, nil
 			}
-			return nil, nil
+			seq, err := p.Descriptors().GetImmutableTableByID(
+				ctx,
+				p.txn,
+				seqID,
+				tree.ObjectLookupFlagsWithRequiredTableKind(tree.ResolveRequireSequenceDesc),
+			)
+			if err != nil {
+				return nil, err
+			}
+			return p.getQualifiedTableName(ctx, seq)
 		}
 	}
 	return nil, colinfo.NewUndefinedColumnError(string(columnName))
 }
 
+// serialSequenceID returns the ID of the sequence backing the given column, as
+// reported by pg_get_serial_sequence and crdb_internal.serial_sequences. As in
+// Postgres, this is the sequence owned by the column, if any; owned is then
+// true. ok is false if the column is not backed by a sequence.
+func serialSequenceID(col catalog.Column) (id descpb.ID, owned bool, ok bool) {
+	if col.NumOwnsSequences() == 1 {
+		return col.GetOwnsSequenceID(0), true, true
+	}
+	// Seems like we have no way of detecting whether this was done using "SERIAL".
+	// Guess by assuming it is SERIAL it it uses only one sequence.
+	// NOTE: This could be alleviated by going through the process of saving SERIAL
+	//       into the descriptor for the column, but has flow on effects for
+	//       which have not been thought about (e.g. implication for backup and restore,
+	//       as well as backward compatibility) so we're using this heuristic for now.
+	// TODO(#52487): fix this up.
+	if col.NumUsesSequences() == 1 {
+		return col.GetUsesSequenceID(0), false, true
+	}
+	return 0, false, false
+}
+
 // IncrementSequence implements the tree.SequenceOperators interface.
 func (p *planner) IncrementSequence(ctx context.Context, seqName *tree.TableName) (int64, error) {
 	if p.EvalContext().TxnReadOnly {
@@ -113,8 +128,11 @@ func (p *planner) IncrementSequenceByID(ctx context.Context, seqID int64) (int64
 func incrementSequenceHelper(
 	ctx context.Context, p *planner, descriptor catalog.TableDescriptor,
 ) (int64, error) {
+	// As in postgres, either USAGE or UPDATE allow incrementing the sequence.
 	if err := p.CheckPrivilege(ctx, descriptor, privilege.UPDATE); err != nil {
-		return 0, err
+		if p.CheckPrivilege(ctx, descriptor, privilege.USAGE) != nil {
+			return 0, err
+		}
 	}
 
 	seqOpts := descriptor.GetSequenceOpts()
@@ -187,15 +205,33 @@ func (p *planner) incrementSequenceUsingCache(
 		return endValue - seqOpts.Increment*(cacheSize-1), seqOpts.Increment, cacheSize, nil
 	}
 
+	// Once a cycling sequence runs out of values, it wraps around instead of
+	// returning an error.
+	fetchNextValuesWithCycle := func() (currentValue, incrementAmount, sizeOfCache int64, err error) {
+		for {
+			currentValue, incrementAmount, sizeOfCache, err = fetchNextValues()
+			if !seqOpts.Cycle || pgerror.GetPGCode(err) != pgcode.SequenceGeneratorLimitExceeded {
+				return currentValue, incrementAmount, sizeOfCache, err
+			}
+			restartValue, restarted, err := restartCyclingSequence(
+				ctx, p.txn.DB(), p.ExecCfg().Codec.SequenceKey(uint32(descriptor.GetID())),
+				seqOpts, seqOpts.Increment*cacheSize,
+			)
+			if err != nil || restarted {
+				return restartValue, seqOpts.Increment, 1, err
+			}
+		}
+	}
+
 	var val int64
 	var err error
 	if cacheSize == 1 {
-		val, _, _, err = fetchNextValues()
+		val, _, _, err = fetchNextValuesWithCycle()
 		if err != nil {
 			return 0, err
 		}
 	} else {
-		val, err = p.GetOrInitSequenceCache().NextValue(uint32(descriptor.GetID()), uint32(descriptor.GetVersion()), fetchNextValues)
+		val, err = p.GetOrInitSequenceCache().NextValue(uint32(descriptor.GetID()), uint32(descriptor.GetVersion()), fetchNextValuesWithCycle)
 		if err != nil {
 			return 0, err
 		}
@@ -203,6 +239,41 @@ func (p *planner) incrementSequenceUsingCache(
 	return val, nil
 }
 
+// restartCyclingSequence wraps a cycling sequence which ran out of values
+// around to its minimum value (or maximum value, for descending sequences),
+// which is returned to the caller. If a concurrent caller has already
+// restarted the sequence, restarted is false and the sequence should be
+// incremented again. step is the amount by which the caller tried to
+// increment the sequence.
+func restartCyclingSequence(
+	ctx context.Context,
+	db *kv.DB,
+	seqValueKey roachpb.Key,
+	seqOpts *descpb.TableDescriptor_SequenceOpts,
+	step int64,
+) (restartValue int64, restarted bool, err error) {
+	restartValue = seqOpts.MinValue
+	if seqOpts.Increment < 0 {
+		restartValue = seqOpts.MaxValue
+	}
+	err = db.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+		restarted = false
+		res, err := txn.Get(ctx, seqValueKey)
+		if err != nil {
+			return err
+		}
+		// If the sequence can be incremented by step again, it has already been
+		// restarted.
+		if val := res.ValueInt(); (step > 0 && val <= seqOpts.MaxValue-step) ||
+			(step < 0 && val >= seqOpts.MinValue-step) {
+			return nil
+		}
+		restarted = true
+		return txn.Put(ctx, seqValueKey, restartValue)
+	})
+	return restartValue, restarted, err
+}
+
 func boundsExceededError(descriptor catalog.TableDescriptor) error {
 	seqOpts := descriptor.GetSequenceOpts()
 	isAscending := seqOpts.Increment > 0
@@ -384,6 +455,18 @@ func readOnlyError(s string) error {
 		"cannot execute %s in a read-only transaction", s)
 }
 
+// checkSequenceOptionSupported returns an error if the given sequence option,
+// which older nodes cannot interpret, is used before the given cluster
+// version is finalized. params is nil when the options are not set by a
+// statement, in which case nothing is checked.
+func checkSequenceOptionSupported(params *runParams, key clusterversion.Key, option string) error {
+	if params == nil || params.p.ExecCfg().Settings.Version.IsActive(params.ctx, key) {
+		return nil
+	}
+	return pgerror.Newf(pgcode.FeatureNotSupported,
+		"version %v must be finalized to use the %s option of sequences", key, option)
+}
+
 // assignSequenceOptions moves options from the AST node to the sequence options descriptor,
 // starting with defaults and overriding them with user-provided options.
 func assignSequenceOptions(
@@ -395,10 +478,18 @@ func assignSequenceOptions(
 	sequenceParentID descpb.ID,
 ) error {
 	// All other defaults are dependent on the value of increment,
-	// i.e. whether the sequence is ascending or descending.
+	// i.e. whether the sequence is ascending or descending, and on the
+	// integer type of the sequence.
+	prevLowerBound, prevUpperBound := sequenceIntegerBounds(opts.IntegerType())
 	for _, option := range optsNode {
-		if option.Name == tree.SeqOptIncrement {
+		switch option.Name {
+		case tree.SeqOptIncrement:
 			opts.Increment = *option.IntVal
+		case tree.SeqOptAs:
+			if err := checkSequenceOptionSupported(params, clusterversion.SequenceIntegerTypes, option.Name); err != nil {
+				return err
+			}
+			opts.AsIntegerType = option.AsIntegerType.SQLString()
 		}
 	}
 	if opts.Increment == 0 {
@@ -406,20 +497,31 @@ func assignSequenceOptions(
 			pgcode.InvalidParameterValue, "INCREMENT must not be zero")
 	}
 	isAscending := opts.Increment > 0
+	integerType := opts.IntegerType()
+	lowerBound, upperBound := sequenceIntegerBounds(integerType)
 
 	// Set increment-dependent defaults.
 	if setDefaults {
 		if isAscending {
 			opts.MinValue = 1
-			opts.MaxValue = math.MaxInt64
+			opts.MaxValue = upperBound
 			opts.Start = opts.MinValue
 		} else {
-			opts.MinValue = math.MinInt64
+			opts.MinValue = lowerBound
 			opts.MaxValue = -1
 			opts.Start = opts.MaxValue
 		}
 		// No Caching
 		opts.CacheSize = 1
+	} else {
+		// Like Postgres, bounds that were defaulted from the previous integer
+		// type follow a change of type.
+		if opts.MinValue == prevLowerBound {
+			opts.MinValue = lowerBound
+		}
+		if opts.MaxValue == prevUpperBound {
+			opts.MaxValue = upperBound
+		}
 	}
 
 	// Fill in all other options.
@@ -434,22 +536,23 @@ func assignSequenceOptions(
 
 		switch option.Name {
 		case tree.SeqOptCycle:
-			return unimplemented.NewWithIssue(20961,
-				"CYCLE option is not supported")
+			if err := checkSequenceOptionSupported(params, clusterversion.SequenceCycle, option.Name); err != nil {
+				return err
+			}
+			opts.Cycle = true
 		case tree.SeqOptNoCycle:
-			// Do nothing; this is the default.
+			opts.Cycle = false
 		case tree.SeqOptCache:
 			v := *option.IntVal
-			switch {
-			case v < 1:
+			if v < 1 {
 				return pgerror.Newf(pgcode.InvalidParameterValue,
 					"CACHE (%d) must be greater than zero", v)
-			case v == 1:
-				// Do nothing; this is the default.
-			case v > 1:
-				opts.CacheSize = *option.IntVal
 			}
-		case tree.SeqOptIncrement:
+			// Setting the value explicitly, rather than only when it is larger
+			// than the default, allows ALTER SEQUENCE ... CACHE 1 to turn
+			// caching back off.
+			opts.CacheSize = v
+		case tree.SeqOptIncrement, tree.SeqOptAs:
 			// Do nothing; this has already been set.
 		case tree.SeqOptMinValue:
 			// A value of nil represents the user explicitly saying `NO MINVALUE`.
@@ -518,6 +621,19 @@ func assignSequenceOptions(
 		}
 	}
 
+	if opts.MinValue < lowerBound || opts.MinValue > upperBound {
+		return pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"MINVALUE (%d) is out of range for sequence data type %s",
+			opts.MinValue, integerType.SQLStandardName())
+	}
+	if opts.MaxValue < lowerBound || opts.MaxValue > upperBound {
+		return pgerror.Newf(
+			pgcode.InvalidParameterValue,
+			"MAXVALUE (%d) is out of range for sequence data type %s",
+			opts.MaxValue, integerType.SQLStandardName())
+	}
+
 	if opts.Start > opts.MaxValue {
 		return pgerror.Newf(
 			pgcode.InvalidParameterValue,
@@ -532,6 +648,19 @@ func assignSequenceOptions(
 	return nil
 }
 
+// sequenceIntegerBounds returns the smallest and largest values that can be
+// stored in a sequence of the given integer type.
+func sequenceIntegerBounds(typ *types.T) (lower int64, upper int64) {
+	switch typ.Width() {
+	case 16:
+		return math.MinInt16, math.MaxInt16
+	case 32:
+		return math.MinInt32, math.MaxInt32
+	default:
+		return math.MinInt64, math.MaxInt64
+	}
+}
+
 func removeSequenceOwnerIfExists(
 	ctx context.Context, p *planner, sequenceID descpb.ID, opts *descpb.TableDescriptor_SequenceOpts,
 ) error {
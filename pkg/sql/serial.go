@@ -14,6 +14,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cockroachdb/cockroach/pkg/clusterversion"
 	"github.com/cockroachdb/cockroach/pkg/server/telemetry"
 	"github.com/cockroachdb/cockroach/pkg/settings"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -66,6 +67,10 @@ func (p *planner) processSerialInColumnDef(
 	tree.SequenceOptions,
 	error,
 ) {
+	if d.IsGeneratedAsIdentity() {
+		return p.processIdentityInColumnDef(ctx, d, tableName)
+	}
+
 	if !d.IsSerial {
 		// Column is not SERIAL: nothing to do.
 		return d, nil, nil, nil, nil
@@ -122,6 +127,100 @@ func (p *planner) processSerialInColumnDef(
 
 	log.VEventf(ctx, 2, "creating sequence for new column %q of %q", d, tableName)
 
+	dbDesc, seqName, err := p.makeColumnSequenceName(ctx, d, tableName)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	defaultExpr := makeNextvalExpr(seqName)
+
+	seqType := ""
+	seqOpts := realSequenceOpts
+	if serialNormalizationMode == sessiondata.SerialUsesVirtualSequences {
+		seqType = "virtual "
+		seqOpts = virtualSequenceOpts
+	} else if serialNormalizationMode == sessiondata.SerialUsesCachedSQLSequences {
+		seqType = "cached "
+
+		value := cachedSequencesCacheSizeSetting.Get(&p.ExecCfg().Settings.SV)
+		seqOpts = tree.SequenceOptions{
+			tree.SequenceOption{Name: tree.SeqOptCache, IntVal: &value},
+		}
+	}
+	log.VEventf(ctx, 2, "new column %q of %q will have %s sequence name %q and default %q",
+		d, tableName, seqType, seqName, defaultExpr)
+
+	newSpec.DefaultExpr.Expr = defaultExpr
+
+	return &newSpec, dbDesc, seqName, seqOpts, nil
+}
+
+// processIdentityInColumnDef is the counterpart of processSerialInColumnDef
+// for identity columns. Regardless of the session's serial normalization mode,
+// an identity column is backed by a new SQL sequence, created with the
+// sequence options given in the column definition.
+func (p *planner) processIdentityInColumnDef(
+	ctx context.Context, d *tree.ColumnTableDef, tableName *tree.TableName,
+) (
+	*tree.ColumnTableDef,
+	catalog.DatabaseDescriptor,
+	*tree.TableName,
+	tree.SequenceOptions,
+	error,
+) {
+	if !p.ExecCfg().Settings.Version.IsActive(ctx, clusterversion.IdentityColumns) {
+		return nil, nil, nil, nil, pgerror.Newf(pgcode.FeatureNotSupported,
+			"version %v must be finalized to use identity columns",
+			clusterversion.IdentityColumns)
+	}
+	if d.IsSerial {
+		return nil, nil, nil, nil, pgerror.Newf(pgcode.Syntax,
+			"both default and identity specified for column %q of table %q",
+			tree.ErrString(&d.Name), tree.ErrString(tableName))
+	}
+	if d.Nullable.Nullability == tree.Null {
+		return nil, nil, nil, nil, pgerror.Newf(pgcode.Syntax,
+			"conflicting NULL/NOT NULL declarations for column %q of table %q",
+			tree.ErrString(&d.Name), tree.ErrString(tableName))
+	}
+	defType, err := tree.ResolveType(ctx, d.Type, p.semaCtx.GetTypeResolver())
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	if defType.Family() != types.IntFamily {
+		return nil, nil, nil, nil, pgerror.Newf(pgcode.InvalidParameterValue,
+			"identity column type must be smallint, integer, or bigint")
+	}
+	for _, opt := range d.GeneratedIdentity.SeqOptions {
+		switch opt.Name {
+		case tree.SeqOptOwnedBy, tree.SeqOptVirtual:
+			return nil, nil, nil, nil, pgerror.Newf(pgcode.Syntax,
+				"%s is not allowed in the sequence options of identity column %q",
+				opt.Name, tree.ErrString(&d.Name))
+		}
+	}
+
+	log.VEventf(ctx, 2, "creating sequence for new identity column %q of %q", d, tableName)
+
+	dbDesc, seqName, err := p.makeColumnSequenceName(ctx, d, tableName)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	newSpec := *d
+	// Identity columns are implicitly NOT NULL, like in PostgreSQL.
+	newSpec.Nullable.Nullability = tree.NotNull
+	newSpec.DefaultExpr.Expr = makeNextvalExpr(seqName)
+
+	return &newSpec, dbDesc, seqName, d.GeneratedIdentity.SeqOptions, nil
+}
+
+// makeColumnSequenceName generates the name of the sequence to create for a
+// new SERIAL or identity column. It returns the name along with the
+// DatabaseDescriptor of the parent database where the sequence should be
+// created.
+func (p *planner) makeColumnSequenceName(
+	ctx context.Context, d *tree.ColumnTableDef, tableName *tree.TableName,
+) (catalog.DatabaseDescriptor, *tree.TableName, error) {
 	// We want a sequence; for this we need to generate a new sequence name.
 	// The constraint on the name is that an object of this name must not exist already.
 	seqName := tree.NewUnqualifiedTableName(
@@ -136,7 +235,7 @@ func (p *planner) processSerialInColumnDef(
 	un := seqName.ToUnresolvedObjectName()
 	dbDesc, _, prefix, err := p.ResolveTargetObject(ctx, un)
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, err
 	}
 	seqName.ObjectNamePrefix = prefix
 
@@ -148,37 +247,22 @@ func (p *planner) processSerialInColumnDef(
 		}
 		res, err := p.ResolveUncachedTableDescriptor(ctx, seqName, false /*required*/, tree.ResolveAnyTableKind)
 		if err != nil {
-			return nil, nil, nil, nil, err
+			return nil, nil, err
 		}
 		if res == nil {
 			break
 		}
 	}
+	return dbDesc, seqName, nil
+}
 
-	defaultExpr := &tree.FuncExpr{
+// makeNextvalExpr returns the DEFAULT expression of a column populated from
+// the given sequence.
+func makeNextvalExpr(seqName *tree.TableName) tree.Expr {
+	return &tree.FuncExpr{
 		Func:  tree.WrapFunction("nextval"),
 		Exprs: tree.Exprs{tree.NewStrVal(seqName.String())},
 	}
-
-	seqType := ""
-	seqOpts := realSequenceOpts
-	if serialNormalizationMode == sessiondata.SerialUsesVirtualSequences {
-		seqType = "virtual "
-		seqOpts = virtualSequenceOpts
-	} else if serialNormalizationMode == sessiondata.SerialUsesCachedSQLSequences {
-		seqType = "cached "
-
-		value := cachedSequencesCacheSizeSetting.Get(&p.ExecCfg().Settings.SV)
-		seqOpts = tree.SequenceOptions{
-			tree.SequenceOption{Name: tree.SeqOptCache, IntVal: &value},
-		}
-	}
-	log.VEventf(ctx, 2, "new column %q of %q will have %s sequence name %q and default %q",
-		d, tableName, seqType, seqName, defaultExpr)
-
-	newSpec.DefaultExpr.Expr = defaultExpr
-
-	return &newSpec, dbDesc, seqName, seqOpts, nil
 }
 
 // SimplifySerialInColumnDefWithRowID analyzes a column definition and
@@ -251,6 +251,41 @@ type LocalOnlySessionData struct {
 	// tables that are not yet implemented.
 	StubCatalogTablesEnabled bool
 
+	// ExcludeHiddenColumnsFromInformationSchema hides the hidden columns of
+	// tables from information_schema.columns.
+	ExcludeHiddenColumnsFromInformationSchema bool
+
+	// IncludeNonPublicTablesInInformationSchema lists the tables which are
+	// being added, are offline or are dropped in information_schema.tables.
+	IncludeNonPublicTablesInInformationSchema bool
+
+	// ExcludeNotNullConstraintsFromInformationSchema hides the synthetic
+	// CHECK constraints of NOT NULL columns from
+	// information_schema.check_constraints and table_constraints.
+	ExcludeNotNullConstraintsFromInformationSchema bool
+
+	// ExcludeOtherTempSchemasFromInformationSchema hides the temporary
+	// schemas of other sessions from information_schema.schemata.
+	ExcludeOtherTempSchemasFromInformationSchema bool
+
+	// ExcludeSystemTablesFromInformationSchema hides the virtual tables and
+	// the tables of the system database from information_schema.tables.
+	ExcludeSystemTablesFromInformationSchema bool
+
+	// MaterializedViewsAsTablesInInformationSchema reports materialized
+	// views as base tables in information_schema.tables, and omits them from
+	// information_schema.views.
+	MaterializedViewsAsTablesInInformationSchema bool
+
+	// MySQLCompat makes the information_schema tables follow the MySQL
+	// conventions where they differ from the Postgres ones.
+	MySQLCompat bool
+
+	// CustomOptions stores the values of the custom options, i.e. the
+	// namespaced session variables such as my_app.flag, which were defined in
+	// the session.
+	CustomOptions map[string]string
+
 	///////////////////////////////////////////////////////////////////////////
 	// WARNING: consider whether a session parameter you're adding needs to  //
 	// be propagated to the remote nodes. If so, that parameter should live  //
@@ -81,7 +81,7 @@ func (p *planner) SetVar(ctx context.Context, n *tree.SetVar) (planNode, error)
 		}
 	}
 
-	if v.Set == nil && v.RuntimeSet == nil {
+	if v.readOnly() {
 		return nil, newCannotChangeParameterError(name)
 	}
 
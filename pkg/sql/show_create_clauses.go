@@ -104,7 +104,13 @@ func ShowCreateView(
 		name := col.GetName()
 		f.FormatNameP(&name)
 	}
-	f.WriteString(") AS ")
+	f.WriteString(")")
+	if opts := viewOptionsFromDesc(desc); len(opts) > 0 {
+		f.WriteString(" WITH (")
+		f.WriteString(strings.Join(opts, ", "))
+		f.WriteString(")")
+	}
+	f.WriteString(" AS ")
 
 	// Convert sequences referenced by ID in the view back to their names.
 	decodedViewQuery, err := formatViewQueryForDisplay(ctx, semaCtx, desc)
@@ -116,6 +122,11 @@ func ShowCreateView(
 	} else {
 		f.WriteString(decodedViewQuery)
 	}
+	if checkOption := viewCheckOptionFromDesc(desc.GetViewCheckOption()); checkOption != tree.ViewCheckOptionNone {
+		f.WriteString(" WITH ")
+		f.WriteString(checkOption.String())
+		f.WriteString(" CHECK OPTION")
+	}
 	return f.CloseAndGetString(), nil
 }
 
@@ -261,6 +272,12 @@ func showForeignKeyConstraint(
 		buf.WriteString(" ON UPDATE ")
 		buf.WriteString(fk.OnUpdate.String())
 	}
+	if fk.Deferrable {
+		buf.WriteString(" DEFERRABLE")
+	}
+	if fk.InitiallyDeferred {
+		buf.WriteString(" INITIALLY DEFERRED")
+	}
 	if fk.Validity != descpb.ConstraintValidity_Validated {
 		buf.WriteString(" NOT VALID")
 	}
@@ -280,6 +297,9 @@ func ShowCreateSequence(
 	f.WriteString("SEQUENCE ")
 	f.FormatNode(tn)
 	opts := desc.GetSequenceOpts()
+	if opts.AsIntegerType != "" {
+		f.Printf(" AS %s", opts.AsIntegerType)
+	}
 	f.Printf(" MINVALUE %d", opts.MinValue)
 	f.Printf(" MAXVALUE %d", opts.MaxValue)
 	f.Printf(" INCREMENT %d", opts.Increment)
@@ -290,6 +310,9 @@ func ShowCreateSequence(
 	if opts.CacheSize > 1 {
 		f.Printf(" CACHE %d", opts.CacheSize)
 	}
+	if opts.Cycle {
+		f.Printf(" CYCLE")
+	}
 	return f.CloseAndGetString(), nil
 }
 
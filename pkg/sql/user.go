@@ -94,24 +94,8 @@ func GetUserHashedPassword(
 func retrieveUserAndPassword(
 	ctx context.Context, ie *InternalExecutor, isRoot bool, normalizedUsername security.SQLUsername,
 ) (exists bool, canLogin bool, hashedPassword []byte, validUntil *tree.DTimestamp, err error) {
-	// We may be operating with a timeout.
-	timeout := userLoginTimeout.Get(&ie.s.cfg.Settings.SV)
-	// We don't like long timeouts for root.
-	// (4.5 seconds to not exceed the default 5s timeout configured in many clients.)
-	const maxRootTimeout = 4*time.Second + 500*time.Millisecond
-	if isRoot && (timeout == 0 || timeout > maxRootTimeout) {
-		timeout = maxRootTimeout
-	}
-
-	runFn := func(fn func(ctx context.Context) error) error { return fn(ctx) }
-	if timeout != 0 {
-		runFn = func(fn func(ctx context.Context) error) error {
-			return contextutil.RunWithTimeout(ctx, "get-user-timeout", timeout, fn)
-		}
-	}
-
 	// Perform the lookup with a timeout.
-	err = runFn(func(ctx context.Context) (retErr error) {
+	err = runWithUserLoginTimeout(ctx, ie, isRoot, func(ctx context.Context) (retErr error) {
 		// Use fully qualified table name to avoid looking up "".system.users.
 		const getHashedPassword = `SELECT "hashedPassword" FROM system.public.users ` +
 			`WHERE username=$1`
@@ -189,6 +173,25 @@ func retrieveUserAndPassword(
 	return exists, canLogin, hashedPassword, validUntil, err
 }
 
+// runWithUserLoginTimeout runs a lookup performed during the authentication
+// of a user, with the timeout configured via the cluster setting.
+func runWithUserLoginTimeout(
+	ctx context.Context, ie *InternalExecutor, isRoot bool, fn func(ctx context.Context) error,
+) error {
+	// We may be operating with a timeout.
+	timeout := userLoginTimeout.Get(&ie.s.cfg.Settings.SV)
+	// We don't like long timeouts for root.
+	// (4.5 seconds to not exceed the default 5s timeout configured in many clients.)
+	const maxRootTimeout = 4*time.Second + 500*time.Millisecond
+	if isRoot && (timeout == 0 || timeout > maxRootTimeout) {
+		timeout = maxRootTimeout
+	}
+	if timeout == 0 {
+		return fn(ctx)
+	}
+	return contextutil.RunWithTimeout(ctx, "get-user-timeout", timeout, fn)
+}
+
 var userLoginTimeout = settings.RegisterDurationSetting(
 	"server.user_login.timeout",
 	"timeout after which client authentication times out if some system range is unavailable (0 = no timeout)",
@@ -251,3 +254,17 @@ func (p *planner) BumpRoleMembershipTableVersion(ctx context.Context) error {
 		ctx, tableDesc, descpb.InvalidMutationID, "updating version for role membership table",
 	)
 }
+
+// bumpUsersTableVersion increases the table version for the users table,
+// which invalidates the RoleCache. It must be called after modifying
+// system.users or system.role_options.
+func (p *planner) bumpUsersTableVersion(ctx context.Context) error {
+	tableDesc, err := p.ResolveMutableTableDescriptor(ctx, userTableName, true, tree.ResolveAnyTableKind)
+	if err != nil {
+		return err
+	}
+
+	return p.writeSchemaChange(
+		ctx, tableDesc, descpb.InvalidMutationID, "updating version for users table",
+	)
+}
@@ -54,6 +54,15 @@ type sessionVar struct {
 	// Hidden indicates that the variable should not show up in the output of SHOW ALL.
 	Hidden bool
 
+	// ClusterScoped indicates that the variable reports a property of the
+	// cluster, such as the server version, rather than of the session. Such
+	// variables cannot be changed.
+	ClusterScoped bool
+
+	// Description is a human-readable description of the variable, shown in
+	// the output of SHOW ALL.
+	Description string
+
 	// Get returns a string representation of a given variable to be used
 	// either by SHOW or in the pg_catalog table.
 	Get func(evalCtx *extendedEvalContext) string
@@ -91,6 +100,21 @@ type sessionVar struct {
 	GlobalDefault func(sv *settings.Values) string
 }
 
+// readOnly returns whether the variable cannot be changed by SET or
+// set_config.
+func (v *sessionVar) readOnly() bool {
+	return v.Set == nil && v.RuntimeSet == nil && v.SetWithPlanner == nil
+}
+
+// scope returns the scope of the variable, as reported in
+// crdb_internal.session_variables.
+func (v *sessionVar) scope() string {
+	if v.ClusterScoped {
+		return "cluster"
+	}
+	return "session"
+}
+
 func formatBoolAsPostgresSetting(b bool) string {
 	if b {
 		return "on"
@@ -102,12 +126,13 @@ func formatBoolAsPostgresSetting(b bool) string {
 // These functions allow the setting to be changed, but whose values are not used.
 // They are logged to telemetry and output a notice that these are unused.
 func makeDummyBooleanSessionVar(
-	name string,
+	name, description string,
 	getFunc func(*extendedEvalContext) string,
 	setFunc func(*sessionDataMutator, bool),
 	sv func(_ *settings.Values) string,
 ) sessionVar {
 	return sessionVar{
+		Description:  description + compatAnyValueDescription,
 		GetStringVal: makePostgresBoolGetStringValFn(name),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar(name, s)
@@ -128,6 +153,7 @@ var varGen = map[string]sessionVar{
 	// Set by clients to improve query logging.
 	// See https://www.postgresql.org/docs/10/static/runtime-config-logging.html#GUC-APPLICATION-NAME
 	`application_name`: {
+		Description: "Name of the client application, reported in logs and statement statistics.",
 		Set: func(
 			_ context.Context, m *sessionDataMutator, s string,
 		) error {
@@ -143,6 +169,7 @@ var varGen = map[string]sessionVar{
 	// See https://www.postgresql.org/docs/10/static/runtime-config-client.html
 	// and https://www.postgresql.org/docs/10/static/datatype-binary.html
 	`bytea_output`: {
+		Description: "Output format of values of type BYTES.",
 		Set: func(
 			_ context.Context, m *sessionDataMutator, s string,
 		) error {
@@ -160,6 +187,7 @@ var varGen = map[string]sessionVar{
 	},
 
 	`client_min_messages`: {
+		Description: "Minimum severity of the notices sent to the client.",
 		Set: func(
 			_ context.Context, m *sessionDataMutator, s string,
 		) error {
@@ -187,6 +215,7 @@ var varGen = map[string]sessionVar{
 	// See https://www.postgresql.org/docs/9.6/static/multibyte.html
 	// Also aliased to SET NAMES.
 	`client_encoding`: {
+		Description: "Character encoding of the client. Only UTF8 is supported.",
 		Set: func(
 			_ context.Context, m *sessionDataMutator, s string,
 		) error {
@@ -206,10 +235,11 @@ var varGen = map[string]sessionVar{
 
 	// Supported for PG compatibility only.
 	// See https://www.postgresql.org/docs/9.6/static/multibyte.html
-	`server_encoding`: makeReadOnlyVar("UTF8"),
+	`server_encoding`: makeReadOnlyVar("UTF8", "Character encoding of the server."),
 
 	// CockroachDB extension.
 	`database`: {
+		Description: "Current database, used to resolve names which do not specify one.",
 		GetStringVal: func(
 			ctx context.Context, evalCtx *extendedEvalContext, values []tree.TypedExpr,
 		) (string, error) {
@@ -253,6 +283,7 @@ var varGen = map[string]sessionVar{
 	// Supported for PG compatibility only.
 	// See https://www.postgresql.org/docs/10/static/runtime-config-client.html#GUC-DATESTYLE
 	`datestyle`: {
+		Description: "Display format of date and time values.",
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			s = strings.ToLower(s)
 			parts := strings.Split(s, ",")
@@ -273,6 +304,7 @@ var varGen = map[string]sessionVar{
 	// Controls the subsequent parsing of a "naked" INT type.
 	// TODO(bob): Remove or no-op this in v2.4: https://github.com/cockroachdb/cockroach/issues/32844
 	`default_int_size`: {
+		Description: "Size in bytes of the INT type: 4 or 8.",
 		Get: func(evalCtx *extendedEvalContext) string {
 			return strconv.FormatInt(int64(evalCtx.SessionData.DefaultIntSize), 10)
 		},
@@ -308,6 +340,7 @@ var varGen = map[string]sessionVar{
 	// Supported only for pg compatibility - CockroachDB has no notion of
 	// tablespaces.
 	`default_tablespace`: {
+		Description: "Default tablespace of new objects. CockroachDB has no tablespaces, so only the empty string is supported.",
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			if s != "" {
 				return newVarValueError(`default_tablespace`, s, "")
@@ -322,6 +355,7 @@ var varGen = map[string]sessionVar{
 
 	// See https://www.postgresql.org/docs/10/static/runtime-config-client.html#GUC-DEFAULT-TRANSACTION-ISOLATION
 	`default_transaction_isolation`: {
+		Description: "Default isolation level of new transactions.",
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			switch strings.ToUpper(s) {
 			case `READ UNCOMMITTED`, `READ COMMITTED`, `SNAPSHOT`, `REPEATABLE READ`, `SERIALIZABLE`, `DEFAULT`:
@@ -340,6 +374,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`default_transaction_priority`: {
+		Description: "Default priority of new transactions.",
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			pri, ok := tree.UserPriorityFromString(s)
 			if !ok {
@@ -362,6 +397,7 @@ var varGen = map[string]sessionVar{
 
 	// See https://www.postgresql.org/docs/9.3/static/runtime-config-client.html#GUC-DEFAULT-TRANSACTION-READ-ONLY
 	`default_transaction_read_only`: {
+		Description:  "Whether new transactions are read-only by default.",
 		GetStringVal: makePostgresBoolGetStringValFn("default_transaction_read_only"),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("default_transaction_read_only", s)
@@ -379,6 +415,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`default_transaction_use_follower_reads`: {
+		Description:  "Whether read-only transactions read from the closest replica by default, at a slightly stale timestamp.",
 		GetStringVal: makePostgresBoolGetStringValFn("default_transaction_use_follower_reads"),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("default_transaction_use_follower_reads", s)
@@ -396,6 +433,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`distsql`: {
+		Description: "When queries are executed using distributed SQL.",
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			mode, ok := sessiondata.DistSQLExecModeFromString(s)
 			if !ok {
@@ -414,6 +452,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`experimental_distsql_planning`: {
+		Description:  "Whether queries are planned by the experimental DistSQL planner.",
 		GetStringVal: makePostgresBoolGetStringValFn(`experimental_distsql_planning`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			mode, ok := sessiondata.ExperimentalDistSQLPlanningModeFromString(s)
@@ -434,6 +473,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`disable_partially_distributed_plans`: {
+		Description:  "Whether query plans which are only partially distributed are disallowed.",
 		GetStringVal: makePostgresBoolGetStringValFn(`disable_partially_distributed_plans`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("disable_partially_distributed_plans", s)
@@ -453,6 +493,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`enable_zigzag_join`: {
+		Description:  "Whether the optimizer may plan zigzag joins.",
 		GetStringVal: makePostgresBoolGetStringValFn(`enable_zigzag_join`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("enable_zigzag_join", s)
@@ -472,6 +513,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`reorder_joins_limit`: {
+		Description:  "Maximum number of joins in a query which the optimizer reorders.",
 		GetStringVal: makeIntGetStringValFn(`reorder_joins_limit`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := strconv.ParseInt(s, 10, 64)
@@ -495,6 +537,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`require_explicit_primary_keys`: {
+		Description:  "Whether CREATE TABLE fails for tables without an explicit primary key.",
 		GetStringVal: makePostgresBoolGetStringValFn(`require_explicit_primary_keys`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("require_explicit_primary_key", s)
@@ -514,6 +557,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`vectorize`: {
+		Description: "When queries are executed by the vectorized engine.",
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			mode, ok := sessiondatapb.VectorizeExecModeFromString(s)
 			if !ok {
@@ -534,6 +578,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`testing_vectorize_inject_panics`: {
+		Description:  "Whether panics are injected into the vectorized engine, for testing.",
 		GetStringVal: makePostgresBoolGetStringValFn(`testing_vectorize_inject_panics`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("testing_vectorize_inject_panics", s)
@@ -554,6 +599,7 @@ var varGen = map[string]sessionVar{
 	// CockroachDB extension.
 	// This is deprecated; the only allowable setting is "on".
 	`optimizer`: {
+		Description: "Whether the cost-based optimizer is used. Only on is supported.",
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			if strings.ToUpper(s) != "ON" {
 				return newVarValueError(`optimizer`, s, "on")
@@ -570,6 +616,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`foreign_key_cascades_limit`: {
+		Description:  "Maximum number of cascading foreign key operations a statement may perform.",
 		GetStringVal: makeIntGetStringValFn(`foreign_key_cascades_limit`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := strconv.ParseInt(s, 10, 64)
@@ -593,6 +640,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`optimizer_use_histograms`: {
+		Description:  "Whether the optimizer uses histograms to estimate the selectivity of filters.",
 		GetStringVal: makePostgresBoolGetStringValFn(`optimizer_use_histograms`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("optimizer_use_histograms", s)
@@ -612,6 +660,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`optimizer_use_multicol_stats`: {
+		Description:  "Whether the optimizer uses multi-column statistics.",
 		GetStringVal: makePostgresBoolGetStringValFn(`optimizer_use_multicol_stats`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("optimizer_use_multicol_stats", s)
@@ -631,6 +680,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`locality_optimized_partitioned_index_scan`: {
+		Description:  "Whether the optimizer plans scans of partitioned indexes which search the local partitions first.",
 		GetStringVal: makePostgresBoolGetStringValFn(`locality_optimized_partitioned_index_scan`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar(`locality_optimized_partitioned_index_scan`, s)
@@ -650,6 +700,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`enable_implicit_select_for_update`: {
+		Description:  "Whether UPDATE and UPSERT statements lock the rows they read.",
 		GetStringVal: makePostgresBoolGetStringValFn(`enable_implicit_select_for_update`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("enabled_implicit_select_for_update", s)
@@ -669,6 +720,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`enable_insert_fast_path`: {
+		Description:  "Whether the optimizer may plan INSERT statements which check foreign keys without a separate query.",
 		GetStringVal: makePostgresBoolGetStringValFn(`enable_insert_fast_path`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("enable_insert_fast_path", s)
@@ -688,6 +740,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`serial_normalization`: {
+		Description: "How columns of type SERIAL are implemented.",
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			mode, ok := sessiondata.SerialNormalizationModeFromString(s)
 			if !ok {
@@ -708,6 +761,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`stub_catalog_tables`: {
+		Description:  "Whether the unimplemented tables of pg_catalog can be queried, and return no rows.",
 		GetStringVal: makePostgresBoolGetStringValFn(`stub_catalog_tables`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("stub_catalog_tables", s)
@@ -727,6 +781,7 @@ var varGen = map[string]sessionVar{
 
 	// See https://www.postgresql.org/docs/10/static/runtime-config-client.html
 	`extra_float_digits`: {
+		Description:  "Number of digits shown for floating point values, relative to the default.",
 		GetStringVal: makeIntGetStringValFn(`extra_float_digits`),
 		Set: func(
 			_ context.Context, m *sessionDataMutator, s string,
@@ -754,6 +809,7 @@ var varGen = map[string]sessionVar{
 	// CockroachDB extension. See docs on SessionData.ForceSavepointRestart.
 	// https://github.com/cockroachdb/cockroach/issues/30588
 	`force_savepoint_restart`: {
+		Description: "Whether every SAVEPOINT statement is treated as SAVEPOINT cockroach_restart.",
 		Get: func(evalCtx *extendedEvalContext) string {
 			return formatBoolAsPostgresSetting(evalCtx.SessionData.ForceSavepointRestart)
 		},
@@ -772,33 +828,189 @@ var varGen = map[string]sessionVar{
 		GlobalDefault: globalFalse,
 	},
 
+	// CockroachDB extension. Hidden columns, such as rowid or the shard columns
+	// of hash-sharded indexes, are listed with is_hidden set when enabled.
+	`include_hidden_columns_in_information_schema`: {
+		Description:  "Whether information_schema lists hidden columns, such as rowid.",
+		GetStringVal: makePostgresBoolGetStringValFn(`include_hidden_columns_in_information_schema`),
+		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar("include_hidden_columns_in_information_schema", s)
+			if err != nil {
+				return err
+			}
+			m.SetIncludeHiddenColumnsInInformationSchema(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) string {
+			return formatBoolAsPostgresSetting(!evalCtx.SessionData.ExcludeHiddenColumnsFromInformationSchema)
+		},
+		GlobalDefault: globalTrue,
+	},
+
+	// CockroachDB extension. Tables which are not public, e.g. because they are
+	// being imported or restored, are listed in information_schema.tables along
+	// with their state when enabled.
+	`include_non_public_tables_in_information_schema`: {
+		Description:  "Whether information_schema.tables lists tables which are being imported, restored or dropped.",
+		GetStringVal: makePostgresBoolGetStringValFn(`include_non_public_tables_in_information_schema`),
+		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar("include_non_public_tables_in_information_schema", s)
+			if err != nil {
+				return err
+			}
+			m.SetIncludeNonPublicTablesInInformationSchema(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) string {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData.IncludeNonPublicTablesInInformationSchema)
+		},
+		GlobalDefault: globalFalse,
+	},
+
+	// CockroachDB extension. Like Postgres, information_schema.check_constraints
+	// and table_constraints list a synthetic CHECK constraint for each NOT NULL
+	// column when enabled. Tools which only expect declared constraints can
+	// disable it.
+	`include_not_null_constraints_in_information_schema`: {
+		Description:  "Whether information_schema lists a CHECK constraint for each NOT NULL column.",
+		GetStringVal: makePostgresBoolGetStringValFn(`include_not_null_constraints_in_information_schema`),
+		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar("include_not_null_constraints_in_information_schema", s)
+			if err != nil {
+				return err
+			}
+			m.SetIncludeNotNullConstraintsInInformationSchema(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) string {
+			return formatBoolAsPostgresSetting(!evalCtx.SessionData.ExcludeNotNullConstraintsFromInformationSchema)
+		},
+		GlobalDefault: globalTrue,
+	},
+
+	// CockroachDB extension. Like for superusers in Postgres, the temporary
+	// schemas of every session are listed in information_schema.schemata when
+	// enabled. Tools which only care about the schemas they can use can disable
+	// it to only list the temporary schema of the current session.
+	`include_other_temp_schemas_in_information_schema`: {
+		Description:  "Whether information_schema.schemata lists the temporary schemas of other sessions.",
+		GetStringVal: makePostgresBoolGetStringValFn(`include_other_temp_schemas_in_information_schema`),
+		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar("include_other_temp_schemas_in_information_schema", s)
+			if err != nil {
+				return err
+			}
+			m.SetIncludeOtherTempSchemasInInformationSchema(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) string {
+			return formatBoolAsPostgresSetting(!evalCtx.SessionData.ExcludeOtherTempSchemasFromInformationSchema)
+		},
+		GlobalDefault: globalTrue,
+	},
+
+	// CockroachDB extension. The virtual tables and the tables of the system
+	// database are listed in information_schema.tables when enabled. Tools
+	// which treat every listed table as user data can disable it.
+	`include_system_tables_in_information_schema`: {
+		Description:  "Whether information_schema.tables lists virtual tables and the tables of the system database.",
+		GetStringVal: makePostgresBoolGetStringValFn(`include_system_tables_in_information_schema`),
+		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar("include_system_tables_in_information_schema", s)
+			if err != nil {
+				return err
+			}
+			m.SetIncludeSystemTablesInInformationSchema(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) string {
+			return formatBoolAsPostgresSetting(!evalCtx.SessionData.ExcludeSystemTablesFromInformationSchema)
+		},
+		GlobalDefault: globalTrue,
+	},
+
+	// CockroachDB extension. Materialized views are reported with the
+	// MATERIALIZED VIEW table type in information_schema.tables unless enabled,
+	// for compatibility with tools which expect them to be base tables.
+	`materialized_views_as_tables_in_information_schema`: {
+		Description:  "Whether information_schema.tables reports materialized views as base tables.",
+		GetStringVal: makePostgresBoolGetStringValFn(`materialized_views_as_tables_in_information_schema`),
+		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar("materialized_views_as_tables_in_information_schema", s)
+			if err != nil {
+				return err
+			}
+			m.SetMaterializedViewsAsTablesInInformationSchema(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) string {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData.MaterializedViewsAsTablesInInformationSchema)
+		},
+		GlobalDefault: globalFalse,
+	},
+
 	// See https://www.postgresql.org/docs/10/static/runtime-config-preset.html
-	`integer_datetimes`: makeReadOnlyVar("on"),
+	`integer_datetimes`: makeReadOnlyVar(
+		"on",
+		"Whether date and time values are stored as integers.",
+	),
+
+	// CockroachDB extension. The information_schema tables follow the MySQL
+	// conventions where they differ from the Postgres ones when enabled, for
+	// tools which introspect both dialects.
+	`mysql_compat`: {
+		Description:  "Whether information_schema tables follow the MySQL conventions where they differ from the Postgres ones.",
+		GetStringVal: makePostgresBoolGetStringValFn(`mysql_compat`),
+		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
+			b, err := paramparse.ParseBoolVar("mysql_compat", s)
+			if err != nil {
+				return err
+			}
+			m.SetMySQLCompat(b)
+			return nil
+		},
+		Get: func(evalCtx *extendedEvalContext) string {
+			return formatBoolAsPostgresSetting(evalCtx.SessionData.MySQLCompat)
+		},
+		GlobalDefault: globalFalse,
+	},
 
 	// See https://www.postgresql.org/docs/10/static/runtime-config-client.html#GUC-INTERVALSTYLE
-	`intervalstyle`: makeCompatStringVar(`IntervalStyle`, "postgres"),
+	`intervalstyle`: makeCompatStringVar(
+		`IntervalStyle`,
+		"Display format of interval values.",
+		"postgres",
+	),
 
 	// CockroachDB extension.
 	`locality`: {
+		Description: "Locality of the node the session is connected to.",
 		Get: func(evalCtx *extendedEvalContext) string {
 			return evalCtx.Locality.String()
 		},
 	},
 
 	// See https://www.postgresql.org/docs/10/static/runtime-config-client.html#GUC-LOC-TIMEOUT
-	`lock_timeout`: makeCompatIntVar(`lock_timeout`, 0),
+	`lock_timeout`: makeCompatIntVar(
+		`lock_timeout`,
+		"Maximum duration a statement waits to acquire a lock.",
+		0,
+	),
 
 	// Supported for PG compatibility only.
 	// See https://www.postgresql.org/docs/10/static/sql-syntax-lexical.html#SQL-SYNTAX-IDENTIFIERS
 	`max_identifier_length`: {
-		Get: func(evalCtx *extendedEvalContext) string { return "128" },
+		Description:   "Maximum length of identifiers.",
+		ClusterScoped: true,
+		Get:           func(evalCtx *extendedEvalContext) string { return "128" },
 	},
 
 	// See https://www.postgresql.org/docs/10/static/runtime-config-preset.html#GUC-MAX-INDEX-KEYS
-	`max_index_keys`: makeReadOnlyVar("32"),
+	`max_index_keys`: makeReadOnlyVar("32", "Maximum number of columns in an index."),
 
 	// CockroachDB extension.
 	`node_id`: {
+		Description: "ID of the node the session is connected to.",
 		Get: func(evalCtx *extendedEvalContext) string {
 			nodeID, _ := evalCtx.NodeID.OptionalNodeID() // zero if unavailable
 			return fmt.Sprintf("%d", nodeID)
@@ -808,6 +1020,7 @@ var varGen = map[string]sessionVar{
 	// CockroachDB extension.
 	// TODO(dan): This should also work with SET.
 	`results_buffer_size`: {
+		Description: "Size in bytes of the buffer which accumulates results before they are sent to the client.",
 		Get: func(evalCtx *extendedEvalContext) string {
 			return strconv.FormatInt(evalCtx.SessionData.ResultsBufferSize, 10)
 		},
@@ -816,6 +1029,7 @@ var varGen = map[string]sessionVar{
 	// CockroachDB extension (inspired by MySQL).
 	// See https://dev.mysql.com/doc/refman/5.7/en/server-system-variables.html#sysvar_sql_safe_updates
 	`sql_safe_updates`: {
+		Description: "Whether potentially unsafe statements, such as DELETE without a WHERE clause, are disallowed.",
 		Get: func(evalCtx *extendedEvalContext) string {
 			return formatBoolAsPostgresSetting(evalCtx.SessionData.SafeUpdates)
 		},
@@ -833,6 +1047,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`prefer_lookup_joins_for_fks`: {
+		Description: "Whether the optimizer prefers lookup joins to check foreign keys.",
 		Get: func(evalCtx *extendedEvalContext) string {
 			return formatBoolAsPostgresSetting(evalCtx.SessionData.PreferLookupJoinsForFKs)
 		},
@@ -853,6 +1068,7 @@ var varGen = map[string]sessionVar{
 	// See https://www.postgresql.org/docs/10/static/ddl-schemas.html#DDL-SCHEMAS-PATH
 	// https://www.postgresql.org/docs/9.6/static/runtime-config-client.html
 	`search_path`: {
+		Description: "Schemas searched to resolve names which do not specify one.",
 		GetStringVal: func(
 			_ context.Context, evalCtx *extendedEvalContext, values []tree.TypedExpr,
 		) (string, error) {
@@ -892,13 +1108,20 @@ var varGen = map[string]sessionVar{
 	},
 
 	// See https://www.postgresql.org/docs/10/static/runtime-config-preset.html#GUC-SERVER-VERSION
-	`server_version`: makeReadOnlyVar(PgServerVersion),
+	`server_version`: makeReadOnlyVar(
+		PgServerVersion,
+		"Version of PostgreSQL the server is compatible with.",
+	),
 
 	// See https://www.postgresql.org/docs/10/static/runtime-config-preset.html#GUC-SERVER-VERSION-NUM
-	`server_version_num`: makeReadOnlyVar(PgServerVersionNum),
+	`server_version_num`: makeReadOnlyVar(
+		PgServerVersionNum,
+		"Version of PostgreSQL the server is compatible with, as a number.",
+	),
 
 	// See https://www.postgresql.org/docs/9.4/runtime-config-connection.html
 	`ssl_renegotiation_limit`: {
+		Description:   "Amount of traffic after which SSL sessions are renegotiated. Only 0 is supported.",
 		Hidden:        true,
 		GetStringVal:  makeIntGetStringValFn(`ssl_renegotiation_limit`),
 		Get:           func(_ *extendedEvalContext) string { return "0" },
@@ -917,43 +1140,61 @@ var varGen = map[string]sessionVar{
 	},
 
 	// CockroachDB extension.
-	`crdb_version`: makeReadOnlyVarWithFn(func() string {
-		return build.GetInfo().Short()
-	}),
+	`crdb_version`: makeReadOnlyVarWithFn(
+		"Version of CockroachDB running on the node the session is connected to.",
+		func() string {
+			return build.GetInfo().Short()
+		},
+	),
 
 	// CockroachDB extension
 	`session_id`: {
-		Get: func(evalCtx *extendedEvalContext) string { return evalCtx.SessionID.String() },
+		Description: "ID of the session.",
+		Get:         func(evalCtx *extendedEvalContext) string { return evalCtx.SessionID.String() },
 	},
 
 	// CockroachDB extension.
 	// In PG this is a pseudo-function used with SELECT, not SHOW.
 	// See https://www.postgresql.org/docs/10/static/functions-info.html
 	`session_user`: {
-		Get: func(evalCtx *extendedEvalContext) string { return evalCtx.SessionData.User().Normalized() },
+		Description: "User which opened the session.",
+		Get:         func(evalCtx *extendedEvalContext) string { return evalCtx.SessionData.User().Normalized() },
 	},
 
 	// See pg sources src/backend/utils/misc/guc.c. The variable is defined
 	// but is hidden from SHOW ALL.
 	`session_authorization`: {
-		Hidden: true,
-		Get:    func(evalCtx *extendedEvalContext) string { return evalCtx.SessionData.User().Normalized() },
+		Description: "User which opened the session.",
+		Hidden:      true,
+		Get:         func(evalCtx *extendedEvalContext) string { return evalCtx.SessionData.User().Normalized() },
 	},
 
 	// Supported for PG compatibility only.
 	// See https://www.postgresql.org/docs/10/static/runtime-config-compatible.html#GUC-STANDARD-CONFORMING-STRINGS
 	// If this gets properly implemented, we will need to re-evaluate how escape_string_warning is implemented
-	`standard_conforming_strings`: makeCompatBoolVar(`standard_conforming_strings`, true, false /* anyAllowed */),
+	`standard_conforming_strings`: makeCompatBoolVar(
+		`standard_conforming_strings`,
+		"Whether backslashes are treated literally in ordinary string literals.",
+		true, false, /* anyAllowed */
+	),
 
 	// See https://www.postgresql.org/docs/10/runtime-config-compatible.html#GUC-ESCAPE-STRING-WARNING
 	// Supported for PG compatibility only.
 	// If this gets properly implemented, we will need to re-evaluate how standard_conforming_strings is implemented
-	`escape_string_warning`: makeCompatBoolVar(`escape_string_warning`, true, true /* anyAllowed */),
+	`escape_string_warning`: makeCompatBoolVar(
+		`escape_string_warning`,
+		"Whether a warning is issued for backslashes in ordinary string literals.",
+		true, true, /* anyAllowed */
+	),
 
 	// See https://www.postgresql.org/docs/10/static/runtime-config-compatible.html#GUC-SYNCHRONIZE-SEQSCANS
 	// The default in pg is "on" but the behavior in CockroachDB is "off". As this does not affect
 	// results received by clients, we accept both values.
-	`synchronize_seqscans`: makeCompatBoolVar(`synchronize_seqscans`, true, true /* anyAllowed */),
+	`synchronize_seqscans`: makeCompatBoolVar(
+		`synchronize_seqscans`,
+		"Whether concurrent sequential scans of a table are synchronized.",
+		true, true, /* anyAllowed */
+	),
 
 	// See https://www.postgresql.org/docs/10/static/runtime-config-client.html#GUC-ROW-SECURITY
 	// The default in pg is "on" but row security is not supported in CockroachDB.
@@ -963,9 +1204,14 @@ var varGen = map[string]sessionVar{
 	// is postgres-compatible.
 	// If/when CockroachDB is extended to support row security, the default and allowed values
 	// should be modified accordingly.
-	`row_security`: makeCompatBoolVar(`row_security`, false, true /* anyAllowed */),
+	`row_security`: makeCompatBoolVar(
+		`row_security`,
+		"Whether row security policies are applied.",
+		false, true, /* anyAllowed */
+	),
 
 	`statement_timeout`: {
+		Description:  "Maximum duration of a statement.",
 		GetStringVal: makeTimeoutVarGetter(`statement_timeout`),
 		Set:          stmtTimeoutVarSet,
 		Get: func(evalCtx *extendedEvalContext) string {
@@ -978,6 +1224,7 @@ var varGen = map[string]sessionVar{
 	},
 
 	`idle_in_session_timeout`: {
+		Description:  "Maximum duration a session may be idle before it is closed.",
 		GetStringVal: makeTimeoutVarGetter(`idle_in_session_timeout`),
 		Set:          idleInSessionTimeoutVarSet,
 		Get: func(evalCtx *extendedEvalContext) string {
@@ -990,6 +1237,7 @@ var varGen = map[string]sessionVar{
 	},
 
 	`idle_in_transaction_session_timeout`: {
+		Description:  "Maximum duration a session may be idle in an open transaction before it is closed.",
 		GetStringVal: makeTimeoutVarGetter(`idle_in_transaction_session_timeout`),
 		Set:          idleInTransactionSessionTimeoutVarSet,
 		Get: func(evalCtx *extendedEvalContext) string {
@@ -1003,6 +1251,7 @@ var varGen = map[string]sessionVar{
 
 	// See https://www.postgresql.org/docs/10/static/runtime-config-client.html#GUC-TIMEZONE
 	`timezone`: {
+		Description: "Time zone of date and time values.",
 		Get: func(evalCtx *extendedEvalContext) string {
 			return sessionDataTimeZoneFormat(evalCtx.SessionData.GetLocation())
 		},
@@ -1014,6 +1263,7 @@ var varGen = map[string]sessionVar{
 	// This is not directly documented in PG's docs but does indeed behave this way.
 	// See https://github.com/postgres/postgres/blob/REL_10_STABLE/src/backend/utils/misc/guc.c#L3401-L3409
 	`transaction_isolation`: {
+		Description: "Isolation level of the current transaction.",
 		Get: func(evalCtx *extendedEvalContext) string {
 			return "serializable"
 		},
@@ -1029,6 +1279,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`transaction_priority`: {
+		Description: "Priority of the current transaction.",
 		Get: func(evalCtx *extendedEvalContext) string {
 			return evalCtx.Txn.UserPriority().String()
 		},
@@ -1036,6 +1287,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`transaction_status`: {
+		Description: "State of the current transaction.",
 		Get: func(evalCtx *extendedEvalContext) string {
 			return evalCtx.TxnState
 		},
@@ -1043,6 +1295,7 @@ var varGen = map[string]sessionVar{
 
 	// See https://www.postgresql.org/docs/10/static/hot-standby.html#HOT-STANDBY-USERS
 	`transaction_read_only`: {
+		Description:  "Whether the current transaction is read-only.",
 		GetStringVal: makePostgresBoolGetStringValFn("transaction_read_only"),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("transaction_read_only", s)
@@ -1060,6 +1313,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`tracing`: {
+		Description: "Whether the session is traced, as set by SET TRACING.",
 		Get: func(evalCtx *extendedEvalContext) string {
 			sessTracing := evalCtx.Tracing
 			if sessTracing.Enabled() {
@@ -1076,7 +1330,8 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`allow_prepare_as_opt_plan`: {
-		Hidden: true,
+		Description: "Whether PREPARE ... AS OPT PLAN is allowed, for testing.",
+		Hidden:      true,
 		Get: func(evalCtx *extendedEvalContext) string {
 			return formatBoolAsPostgresSetting(evalCtx.SessionData.AllowPrepareAsOptPlan)
 		},
@@ -1093,7 +1348,8 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`save_tables_prefix`: {
-		Hidden: true,
+		Description: "Prefix of the tables which save the intermediate results of queries, for testing.",
+		Hidden:      true,
 		Get: func(evalCtx *extendedEvalContext) string {
 			return evalCtx.SessionData.SaveTablesPrefix
 		},
@@ -1106,6 +1362,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`experimental_enable_temp_tables`: {
+		Description:  "Whether temporary tables, views and sequences can be created.",
 		GetStringVal: makePostgresBoolGetStringValFn(`experimental_enable_temp_tables`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("experimental_enable_temp_tables", s)
@@ -1125,6 +1382,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`experimental_enable_implicit_column_partitioning`: {
+		Description:  "Whether tables can be partitioned by columns which are not part of their primary key.",
 		GetStringVal: makePostgresBoolGetStringValFn(`experimental_enable_implicit_column_partitioning`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("experimental_enable_implicit_column_partitioning", s)
@@ -1144,6 +1402,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`enable_drop_enum_value`: {
+		Description:  "Whether values can be dropped from enums.",
 		GetStringVal: makePostgresBoolGetStringValFn(`enable_drop_enum_value`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("enable_drop_enum_value", s)
@@ -1163,6 +1422,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`override_multi_region_zone_config`: {
+		Description:  "Whether zone configurations managed by multi-region abstractions can be overridden.",
 		GetStringVal: makePostgresBoolGetStringValFn(`override_multi_region_zone_config`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("override_multi_region_zone_config", s)
@@ -1182,6 +1442,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`experimental_enable_hash_sharded_indexes`: {
+		Description:  "Whether hash-sharded indexes can be created.",
 		GetStringVal: makePostgresBoolGetStringValFn(`experimental_enable_hash_sharded_indexes`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("experimental_enable_hash_sharded_indexes", s)
@@ -1200,6 +1461,7 @@ var varGen = map[string]sessionVar{
 	},
 
 	`disallow_full_table_scans`: {
+		Description:  "Whether queries which scan a whole table or index are disallowed.",
 		GetStringVal: makePostgresBoolGetStringValFn(`disallow_full_table_scan`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar(`disallow_full_table_scans`, s)
@@ -1219,6 +1481,7 @@ var varGen = map[string]sessionVar{
 
 	// CockroachDB extension.
 	`enable_experimental_alter_column_type_general`: {
+		Description:  "Whether ALTER COLUMN TYPE may perform conversions which rewrite the column.",
 		GetStringVal: makePostgresBoolGetStringValFn(`enable_experimental_alter_column_type_general`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar("enable_experimental_alter_column_type_general", s)
@@ -1239,6 +1502,7 @@ var varGen = map[string]sessionVar{
 	// TODO(rytaft): remove this once unique without index constraints are fully
 	// supported.
 	`experimental_enable_unique_without_index_constraints`: {
+		Description:  "Whether UNIQUE WITHOUT INDEX constraints can be created.",
 		GetStringVal: makePostgresBoolGetStringValFn(`experimental_enable_unique_without_index_constraints`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar(`experimental_enable_unique_without_index_constraints`, s)
@@ -1257,6 +1521,7 @@ var varGen = map[string]sessionVar{
 	},
 
 	`experimental_use_new_schema_changer`: {
+		Description:  "When the declarative schema changer is used.",
 		GetStringVal: makePostgresBoolGetStringValFn(`experimental_use_new_schema_changer`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			mode, ok := sessiondata.NewSchemaChangerModeFromString(s)
@@ -1276,6 +1541,7 @@ var varGen = map[string]sessionVar{
 	},
 
 	`enable_experimental_stream_replication`: {
+		Description:  "Whether stream replication statements can be used.",
 		GetStringVal: makePostgresBoolGetStringValFn(`enable_experimental_stream_replication`),
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar(`enable_experimental_stream_replication`, s)
@@ -1339,15 +1605,19 @@ func makePostgresBoolGetStringValFn(varName string) getStringValFn {
 	}
 }
 
-func makeReadOnlyVar(value string) sessionVar {
+func makeReadOnlyVar(value, description string) sessionVar {
 	return sessionVar{
+		ClusterScoped: true,
+		Description:   description,
 		Get:           func(_ *extendedEvalContext) string { return value },
 		GlobalDefault: func(_ *settings.Values) string { return value },
 	}
 }
 
-func makeReadOnlyVarWithFn(fn func() string) sessionVar {
+func makeReadOnlyVarWithFn(description string, fn func() string) sessionVar {
 	return sessionVar{
+		ClusterScoped: true,
+		Description:   description,
 		Get:           func(_ *extendedEvalContext) string { return fn() },
 		GlobalDefault: func(_ *settings.Values) string { return fn() },
 	}
@@ -1359,6 +1629,7 @@ func displayPgBool(val bool) func(_ *settings.Values) string {
 }
 
 var globalFalse = displayPgBool(false)
+var globalTrue = displayPgBool(true)
 
 // sessionDataTimeZoneFormat returns the appropriate timezone format
 // to output when the `timezone` is required output.
@@ -1375,10 +1646,28 @@ func sessionDataTimeZoneFormat(loc *time.Location) string {
 	return locStr
 }
 
-func makeCompatBoolVar(varName string, displayValue, anyValAllowed bool) sessionVar {
+// compatAnyValueDescription is appended to the description of variables which
+// are supported for compatibility only, and accept any value.
+const compatAnyValueDescription = " Any value is accepted, but has no effect."
+
+// makeCompatDescription appends the values supported by a variable which is
+// supported for compatibility only to its description.
+func makeCompatDescription(description string, allowedVals ...string) string {
+	return fmt.Sprintf("%s Supported values: %s.", description, strings.Join(allowedVals, ", "))
+}
+
+func makeCompatBoolVar(
+	varName, description string, displayValue, anyValAllowed bool,
+) sessionVar {
 	displayValStr := formatBoolAsPostgresSetting(displayValue)
+	if anyValAllowed {
+		description += compatAnyValueDescription
+	} else {
+		description = makeCompatDescription(description, displayValStr)
+	}
 	return sessionVar{
-		Get: func(_ *extendedEvalContext) string { return displayValStr },
+		Description: description,
+		Get:         func(_ *extendedEvalContext) string { return displayValStr },
 		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
 			b, err := paramparse.ParseBoolVar(varName, s)
 			if err != nil {
@@ -1401,20 +1690,25 @@ func makeCompatBoolVar(varName string, displayValue, anyValAllowed bool) session
 	}
 }
 
-func makeCompatIntVar(varName string, displayValue int, extraAllowed ...int) sessionVar {
+func makeCompatIntVar(
+	varName, description string, displayValue int, extraAllowed ...int,
+) sessionVar {
 	displayValueStr := strconv.Itoa(displayValue)
 	extraAllowedStr := make([]string, len(extraAllowed))
 	for i, v := range extraAllowed {
 		extraAllowedStr[i] = strconv.Itoa(v)
 	}
-	varObj := makeCompatStringVar(varName, displayValueStr, extraAllowedStr...)
+	varObj := makeCompatStringVar(varName, description, displayValueStr, extraAllowedStr...)
 	varObj.GetStringVal = makeIntGetStringValFn(varName)
 	return varObj
 }
 
-func makeCompatStringVar(varName, displayValue string, extraAllowed ...string) sessionVar {
+func makeCompatStringVar(
+	varName, description, displayValue string, extraAllowed ...string,
+) sessionVar {
 	allowedVals := append(extraAllowed, strings.ToLower(displayValue))
 	return sessionVar{
+		Description: makeCompatDescription(description, allowedVals...),
 		Get: func(_ *extendedEvalContext) string {
 			return displayValue
 		},
@@ -1464,6 +1758,9 @@ func getSessionVar(name string, missingOk bool) (bool, sessionVar, error) {
 
 	v, ok := varGen[name]
 	if !ok {
+		if isCustomOptionName(name) {
+			return true, makeCustomOptionVar(name), nil
+		}
 		if missingOk {
 			return false, sessionVar{}, nil
 		}
@@ -1473,6 +1770,53 @@ func getSessionVar(name string, missingOk bool) (bool, sessionVar, error) {
 	return true, v, nil
 }
 
+// isCustomOptionName returns whether the given name, which is not that of a
+// built-in session variable, is that of a custom option. As in Postgres, the
+// name of a custom option is namespaced with a prefix separated by a dot,
+// e.g. my_app.flag, and any such option can be set by the client.
+func isCustomOptionName(name string) bool {
+	return strings.Contains(name, ".")
+}
+
+// makeCustomOptionVar returns the session variable for the custom option with
+// the given name. Custom options are plain strings which are stored in the
+// session data; RESET sets them to the empty string.
+func makeCustomOptionVar(name string) sessionVar {
+	return sessionVar{
+		Description: "Custom option defined by the client.",
+		Get: func(evalCtx *extendedEvalContext) string {
+			return evalCtx.SessionData.CustomOptions[name]
+		},
+		Set: func(_ context.Context, m *sessionDataMutator, s string) error {
+			m.SetCustomOption(name, s)
+			return nil
+		},
+		GlobalDefault: func(sv *settings.Values) string { return "" },
+	}
+}
+
+// forEachSessionVar calls fn for each built-in session variable, in
+// alphabetical order, followed by each custom option defined in the session,
+// also in alphabetical order.
+func (p *planner) forEachSessionVar(fn func(vName string, v sessionVar) error) error {
+	for _, vName := range varNames {
+		if err := fn(vName, varGen[vName]); err != nil {
+			return err
+		}
+	}
+	customNames := make([]string, 0, len(p.SessionData().CustomOptions))
+	for vName := range p.SessionData().CustomOptions {
+		customNames = append(customNames, vName)
+	}
+	sort.Strings(customNames)
+	for _, vName := range customNames {
+		if err := fn(vName, makeCustomOptionVar(vName)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetSessionVar implements the EvalSessionAccessor interface.
 func (p *planner) GetSessionVar(
 	_ context.Context, varName string, missingOk bool,
@@ -1482,6 +1826,17 @@ func (p *planner) GetSessionVar(
 	if err != nil || !ok {
 		return ok, "", err
 	}
+	if _, builtin := varGen[name]; !builtin {
+		// As in Postgres, custom options which were never set in the session
+		// are unknown.
+		if _, defined := p.SessionData().CustomOptions[name]; !defined {
+			if missingOk {
+				return false, "", nil
+			}
+			return false, "", pgerror.Newf(pgcode.UndefinedObject,
+				"unrecognized configuration parameter %q", name)
+		}
+	}
 	return true, v.Get(&p.extendedEvalCtx), nil
 }
 
@@ -1493,7 +1848,7 @@ func (p *planner) SetSessionVar(ctx context.Context, varName, newVal string) err
 		return err
 	}
 
-	if v.Set == nil && v.RuntimeSet == nil && v.SetWithPlanner == nil {
+	if v.readOnly() {
 		return newCannotChangeParameterError(name)
 	}
 	if v.RuntimeSet != nil {
@@ -12,10 +12,13 @@ package sql
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/schemaexpr"
+	"github.com/cockroachdb/cockroach/pkg/sql/parser"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgcode"
 	"github.com/cockroachdb/cockroach/pkg/sql/pgwire/pgerror"
 	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
@@ -87,3 +90,152 @@ func checkViewMatchesMaterialized(
 	}
 	return nil
 }
+
+// viewUpdatableColumns returns whether each of the columns of the view is
+// updatable through the view, following the rules for automatically updatable
+// views of PostgreSQL (see schemaexpr.UpdatableView). The columns which are
+// plain references to updatable columns of the table or view the view selects
+// from are updatable. It returns nil if the view is not updatable.
+//
+// The view is deemed updatable under the same conditions as those under which
+// the optimizer accepts a mutation statement targeting it: its query must be
+// accepted by schemaexpr.ParseUpdatableView, and it must select from a table
+// or from a view which is itself updatable.
+func viewUpdatableColumns(
+	ctx context.Context, p *planner, view catalog.TableDescriptor,
+) ([]bool, error) {
+	if !view.IsView() || view.MaterializedView() {
+		return nil, nil
+	}
+	uv, err := schemaexpr.ParseUpdatableView(view.GetViewQuery(), p.CurrentSearchPath())
+	if err != nil || uv == nil {
+		return nil, err
+	}
+	table, err := viewSource(ctx, p, view, uv.Source)
+	if err != nil || table == nil {
+		return nil, err
+	}
+	var baseUpdatable []bool
+	switch {
+	case table.IsView():
+		// A view over an updatable view is updatable too.
+		if baseUpdatable, err = viewUpdatableColumns(ctx, p, table); err != nil || baseUpdatable == nil {
+			return nil, err
+		}
+	case !table.IsTable() || table.IsVirtualTable():
+		return nil, nil
+	}
+	baseColumns := table.PublicColumns()
+
+	updatable := make([]bool, len(uv.Targets))
+	for i := range uv.Targets {
+		name, ok := uv.SourceColumn(i)
+		if !ok {
+			continue
+		}
+		for j, col := range baseColumns {
+			if col.ColName() != name {
+				continue
+			}
+			updatable[i] = !col.IsComputed() && (baseUpdatable == nil || baseUpdatable[j])
+			break
+		}
+	}
+	return updatable, nil
+}
+
+// viewSource returns the table or view which the given view selects from,
+// among the relations the view depends on, or nil if it is not one of them,
+// e.g. if it is a virtual table.
+func viewSource(
+	ctx context.Context, p *planner, view catalog.TableDescriptor, source *tree.UnresolvedObjectName,
+) (catalog.TableDescriptor, error) {
+	for _, id := range view.GetDependsOn() {
+		table, err := p.LookupTableByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if table.GetName() != source.Object() {
+			continue
+		}
+		// The names in view queries are fully qualified, but the dependencies of
+		// the view may include relations with the same name in other schemas.
+		if source.HasExplicitSchema() {
+			sc, err := p.Descriptors().GetImmutableSchemaByID(
+				ctx, p.txn, table.GetParentSchemaID(), tree.SchemaLookupFlags{Required: true},
+			)
+			if err != nil {
+				return nil, err
+			}
+			if sc.Name != source.Schema() {
+				continue
+			}
+		}
+		return table, nil
+	}
+	return nil, nil
+}
+
+// viewIsUpdatable returns whether rows can be inserted, updated and deleted
+// through the view. Unlike Postgres, which reports a view none of whose
+// columns is updatable as neither updatable nor insertable into, such a view
+// is reported updatable here, since rows can be deleted through it.
+func viewIsUpdatable(ctx context.Context, p *planner, view catalog.TableDescriptor) (bool, error) {
+	updatable, err := viewUpdatableColumns(ctx, p, view)
+	if err != nil {
+		return false, err
+	}
+	return updatable != nil, nil
+}
+
+// viewDefinition returns the query of the given view as reported by the
+// catalogs. Column aliases given outside of the view query are inserted into
+// it, like Postgres does: for the view created via
+//  `CREATE VIEW v (a) AS SELECT b FROM foo`
+// the definition is `SELECT b AS a FROM foo`. The stored view query is
+// returned as is if it cannot be parsed or its targets are not known, e.g. if
+// it is a VALUES clause.
+func viewDefinition(p *planner, view catalog.TableDescriptor) string {
+	query := view.GetViewQuery()
+	stmt, err := parser.ParseOne(query)
+	if err != nil {
+		return query
+	}
+	sel, ok := stmt.AST.(*tree.Select)
+	if !ok {
+		return query
+	}
+	// The column names of a set operation are those of its leftmost operand.
+	var clause *tree.SelectClause
+	for clause == nil {
+		switch s := sel.Select.(type) {
+		case *tree.SelectClause:
+			clause = s
+		case *tree.ParenSelect:
+			sel = s.Select
+		case *tree.UnionClause:
+			sel = s.Left
+		default:
+			return query
+		}
+	}
+	columns := view.VisibleColumns()
+	if len(clause.Exprs) != len(columns) {
+		return query
+	}
+	aliased := false
+	for i := range clause.Exprs {
+		name, err := tree.GetRenderColName(p.SessionData().SearchPath, clause.Exprs[i])
+		if err != nil {
+			return query
+		}
+		if name != columns[i].GetName() {
+			clause.Exprs[i].As = tree.UnrestrictedName(columns[i].GetName())
+			aliased = true
+		}
+	}
+	if !aliased {
+		return query
+	}
+	return tree.AsStringWithFlags(stmt.AST, tree.FmtParsable)
+}
@@ -17,6 +17,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/cockroachdb/cockroach/pkg/keys"
 	"github.com/cockroachdb/cockroach/pkg/security"
 	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
 	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
@@ -35,6 +36,7 @@ import (
 	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqlerrors"
 	"github.com/cockroachdb/cockroach/pkg/sql/sqltelemetry"
+	"github.com/cockroachdb/cockroach/pkg/util"
 	"github.com/cockroachdb/cockroach/pkg/util/errorutil/unimplemented"
 	"github.com/cockroachdb/cockroach/pkg/util/hlc"
 	"github.com/cockroachdb/cockroach/pkg/util/stop"
@@ -73,6 +75,7 @@ type virtualSchemaDef interface {
 	) (descpb.TableDescriptor, error)
 	getComment() string
 	isUnimplemented() bool
+	isSystemTenantOnly() bool
 }
 
 type virtualIndex struct {
@@ -121,12 +124,73 @@ type virtualSchemaTable struct {
 	// will be queryable but return no rows. Otherwise querying the table will
 	// return an unimplemented error.
 	unimplemented bool
+
+	// systemTenantOnly indicates that the table exposes host cluster state
+	// which secondary tenants cannot access. Such tables are omitted from the
+	// catalog listings of secondary tenants (see forEachTableDesc), although
+	// they can still be resolved by name.
+	systemTenantOnly bool
+
+	// materializable indicates that the table is expensive to populate, and
+	// that unconstrained scans of it may be served from an in-memory snapshot
+	// when the sql.catalog.virtual_table_snapshots.enabled cluster setting is
+	// set. See virtualTableSnapshots.
+	materializable bool
+
+	// tableRows, if non-nil, computes the rows of the table which derive from
+	// each table descriptor, independently of the other descriptors. It allows
+	// the snapshots of materializable tables to be refreshed incrementally
+	// after schema changes. A table defining tableRows must define populate
+	// with makePopulateFromTableRows.
+	tableRows virtualTableRowsFunc
+
+	// rowFilter, if non-nil, hides the rows of the table for which it returns
+	// false. Unlike populate, it also applies to the rows served from
+	// snapshots, which are shared across sessions, so rows which depend on
+	// session settings must be filtered out with it.
+	rowFilter func(ctx context.Context, p *planner, row tree.Datums) (bool, error)
+}
+
+// addTableRowsFunc adds the rows derived from a single table descriptor to a
+// virtual table.
+type addTableRowsFunc func(
+	db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor,
+	addRow func(...tree.Datum) error,
+) error
+
+// virtualTableRowsFunc prepares the computation of the rows of a virtual
+// table which derive from each table descriptor.
+type virtualTableRowsFunc func(
+	ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor,
+) (addTableRowsFunc, error)
+
+// makePopulateFromTableRows returns a populate function which adds the rows
+// derived from each of the table descriptors visible in dbContext.
+func makePopulateFromTableRows(
+	tableRows virtualTableRowsFunc,
+) func(ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+	return func(
+		ctx context.Context, p *planner, dbContext catalog.DatabaseDescriptor, addRow func(...tree.Datum) error,
+	) error {
+		addTableRows, err := tableRows(ctx, p, dbContext)
+		if err != nil {
+			return err
+		}
+		return forEachTableDesc(ctx, p, dbContext, virtualMany, func(
+			db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor,
+		) error {
+			return addTableRows(db, scName, table, addRow)
+		})
+	}
 }
 
 // virtualSchemaView represents a view within a virtualSchema
 type virtualSchemaView struct {
 	schema        string
 	resultColumns colinfo.ResultColumns
+
+	// systemTenantOnly is the same as virtualSchemaTable.systemTenantOnly.
+	systemTenantOnly bool
 }
 
 // getSchema is part of the virtualSchemaDef interface.
@@ -235,6 +299,11 @@ func (t virtualSchemaTable) isUnimplemented() bool {
 	return t.unimplemented
 }
 
+// isSystemTenantOnly is part of the virtualSchemaDef interface.
+func (t virtualSchemaTable) isSystemTenantOnly() bool {
+	return t.systemTenantOnly
+}
+
 // getSchema is part of the virtualSchemaDef interface.
 func (v virtualSchemaView) getSchema() string {
 	return v.schema
@@ -285,6 +354,11 @@ func (v virtualSchemaView) isUnimplemented() bool {
 	return false
 }
 
+// isSystemTenantOnly is part of the virtualSchemaDef interface.
+func (v virtualSchemaView) isSystemTenantOnly() bool {
+	return v.systemTenantOnly
+}
+
 // virtualSchemas holds a slice of statically registered virtualSchema objects.
 //
 // When adding a new virtualSchema, define a virtualSchema in a separate file, and
@@ -314,6 +388,11 @@ type VirtualSchemaHolder struct {
 	entries      map[string]*virtualSchemaEntry
 	defsByID     map[descpb.ID]*virtualDefEntry
 	orderedNames []string
+	snapshots    virtualTableSnapshots
+	// introspectionQueries caches the results of the introspection queries.
+	introspectionQueries introspectionQueryCache
+	// metrics records the cost of the populations of the virtual tables.
+	metrics virtualTableMetrics
 }
 
 var _ VirtualTabler = (*VirtualSchemaHolder)(nil)
@@ -405,12 +484,19 @@ type virtualDefEntry struct {
 	comment                    string
 	validWithNoDatabaseContext bool
 	unimplemented              bool
+	systemTenantOnly           bool
 }
 
 func (e *virtualDefEntry) Desc() catalog.Descriptor {
 	return e.desc
 }
 
+// visibleToTenant returns whether the virtual table should be listed in the
+// catalogs of the tenant using the given codec.
+func (e *virtualDefEntry) visibleToTenant(codec keys.SQLCodec) bool {
+	return !e.systemTenantOnly || codec.ForSystemTenant()
+}
+
 func canQueryVirtualTable(evalCtx *tree.EvalContext, e *virtualDefEntry) bool {
 	return !e.unimplemented ||
 		evalCtx == nil ||
@@ -476,10 +562,13 @@ func (e *virtualDefEntry) validateRow(datums tree.Datums, columns colinfo.Result
 // valuesNode for the virtual table. We use deferred construction here
 // so as to avoid populating a RowContainer during query preparation,
 // where we can't guarantee it will be Close()d in case of error.
+// neededColumns contains the ordinals of the columns which the scan needs; see
+// isVirtualColumnNeeded.
 func (e *virtualDefEntry) getPlanInfo(
 	table catalog.TableDescriptor,
 	index *descpb.IndexDescriptor,
 	idxConstraint *constraint.Constraint,
+	neededColumns util.FastIntSet,
 	stopper *stop.Stopper,
 ) (colinfo.ResultColumns, virtualTableConstructor) {
 	var columns colinfo.ResultColumns
@@ -515,6 +604,12 @@ func (e *virtualDefEntry) getPlanInfo(
 				return nil, newInvalidVirtualSchemaError()
 			}
 
+			// The snapshots of materializable tables are shared by all the
+			// scans, so they are always populated with every column.
+			if !def.materializable {
+				ctx = withNeededVirtualColumns(ctx, neededColumns)
+			}
+
 			if def.generator != nil {
 				next, cleanup, err := def.generator(ctx, p, dbDesc, stopper)
 				if err != nil {
@@ -525,14 +620,25 @@ func (e *virtualDefEntry) getPlanInfo(
 
 			constrainedScan := idxConstraint != nil && !idxConstraint.IsUnconstrained()
 			if !constrainedScan {
-				generator, cleanup, setupError := setupGenerator(ctx, func(pusher rowPusher) error {
-					return def.populate(ctx, p, dbDesc, func(row ...tree.Datum) error {
+				populate := def.populate
+				if def.materializable {
+					populate = p.ExecCfg().VirtualSchemas.snapshots.populateFunc(e.desc.GetID(), def, stopper)
+				}
+				generator, cleanup, setupError := setupGenerator(ctx, bufferRows(len(columns), func(pusher rowPusher) error {
+					population := p.ExecCfg().VirtualSchemas.metrics.startPopulation(e)
+					defer population.finish()
+					return populate(ctx, p, dbDesc, func(row ...tree.Datum) error {
+						if def.rowFilter != nil {
+							if ok, err := def.rowFilter(ctx, p, row); err != nil || !ok {
+								return err
+							}
+						}
 						if err := e.validateRow(row, columns); err != nil {
 							return err
 						}
-						return pusher.pushRow(row...)
+						return population.pushRow(pusher, row)
 					})
-				}, stopper)
+				}), stopper)
 				if setupError != nil {
 					return nil, setupError
 				}
@@ -550,8 +656,8 @@ func (e *virtualDefEntry) getPlanInfo(
 			columnIdxMap := catalog.ColumnIDToOrdinalMap(table.PublicColumns())
 			indexKeyDatums := make([]tree.Datum, len(index.ColumnIDs))
 
-			generator, cleanup, setupError := setupGenerator(ctx, e.makeConstrainedRowsGenerator(
-				ctx, p, dbDesc, index, indexKeyDatums, columnIdxMap, idxConstraint, columns), stopper)
+			generator, cleanup, setupError := setupGenerator(ctx, bufferRows(len(columns), e.makeConstrainedRowsGenerator(
+				ctx, p, dbDesc, index, indexKeyDatums, columnIdxMap, idxConstraint, columns)), stopper)
 			if setupError != nil {
 				return nil, setupError
 			}
@@ -565,6 +671,25 @@ func (e *virtualDefEntry) getPlanInfo(
 	return columns, constructor
 }
 
+// neededVirtualColumnsKey is the context key under which the ordinals of the
+// columns needed by the scan of a virtual table are recorded.
+type neededVirtualColumnsKey struct{}
+
+// withNeededVirtualColumns returns a context recording that only the columns
+// with the given ordinals are needed from the virtual table being populated.
+func withNeededVirtualColumns(ctx context.Context, ordinals util.FastIntSet) context.Context {
+	return context.WithValue(ctx, neededVirtualColumnsKey{}, ordinals)
+}
+
+// isVirtualColumnNeeded returns whether the column with the given ordinal is
+// needed from the virtual table being populated with the given context.
+// Columns which are expensive to compute can be left NULL when they are not
+// needed. All the columns are needed unless withNeededVirtualColumns was used.
+func isVirtualColumnNeeded(ctx context.Context, ordinal int) bool {
+	ordinals, ok := ctx.Value(neededVirtualColumnsKey{}).(util.FastIntSet)
+	return !ok || ordinals.Contains(ordinal)
+}
+
 // makeConstrainedRowsGenerator returns a generator function that can be invoked
 // to push all rows from this virtual table that satisfy the input index
 // constraint to a row pusher that's supplied to the generator function.
@@ -580,9 +705,16 @@ func (e *virtualDefEntry) makeConstrainedRowsGenerator(
 ) func(pusher rowPusher) error {
 	def := e.virtualDef.(virtualSchemaTable)
 	return func(pusher rowPusher) error {
+		population := p.ExecCfg().VirtualSchemas.metrics.startPopulation(e)
+		defer population.finish()
 		var span constraint.Span
 		addRowIfPassesFilter := func(idxConstraint *constraint.Constraint) func(datums ...tree.Datum) error {
 			return func(datums ...tree.Datum) error {
+				if def.rowFilter != nil {
+					if ok, err := def.rowFilter(ctx, p, datums); err != nil || !ok {
+						return err
+					}
+				}
 				for i, id := range index.ColumnIDs {
 					indexKeyDatums[i] = datums[columnIdxMap.GetDefault(id)]
 				}
@@ -597,7 +729,7 @@ func (e *virtualDefEntry) makeConstrainedRowsGenerator(
 					if err := e.validateRow(datums, columns); err != nil {
 						return err
 					}
-					return pusher.pushRow(datums...)
+					return population.pushRow(pusher, datums)
 				}
 				return err
 			}
@@ -662,6 +794,9 @@ func NewVirtualSchemaHolder(
 		orderedNames: make([]string, len(virtualSchemas)),
 		defsByID:     make(map[descpb.ID]*virtualDefEntry, math.MaxUint32-catconstants.MinVirtualID),
 	}
+	vs.snapshots.init()
+	vs.introspectionQueries.init()
+	vs.snapshots.onChange = vs.introspectionQueries.invalidate
 
 	order := 0
 	for schemaID, schema := range virtualSchemas {
@@ -695,6 +830,7 @@ func NewVirtualSchemaHolder(
 				validWithNoDatabaseContext: schema.validWithNoDatabaseContext,
 				comment:                    def.getComment(),
 				unimplemented:              def.isUnimplemented(),
+				systemTenantOnly:           def.isSystemTenantOnly(),
 			}
 			defs[tableDesc.Name] = entry
 			vs.defsByID[tableDesc.ID] = entry
@@ -46,18 +46,110 @@ type rowPusher interface {
 	// operations on a transaction while blocked on a call to pushRow.
 	// If pushRow returns an error, the caller must immediately return the error.
 	pushRow(...tree.Datum) error
+	// pushRows is like pushRow, but it pushes a batch of rows at once. The
+	// receiver consumes the rows one at a time without resuming the caller in
+	// between, which saves a round trip per row. It will block until all the
+	// rows have been received and more data has been requested. Once pushRows
+	// returns, the caller is free to mutate the rows.
+	pushRows([]tree.Datums) error
 }
 
-// funcRowPusher implements rowPusher on functions.
-type funcRowPusher func(...tree.Datum) error
+// generatorRowPusher implements rowPusher on the function which sends a
+// response to the receiver of a generator.
+type generatorRowPusher func(virtualTableGeneratorResponse) error
 
-func (f funcRowPusher) pushRow(datums ...tree.Datum) error {
-	return f(datums...)
+func (f generatorRowPusher) pushRow(datums ...tree.Datum) error {
+	return f(virtualTableGeneratorResponse{datums: datums})
+}
+
+func (f generatorRowPusher) pushRows(rows []tree.Datums) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	return f(virtualTableGeneratorResponse{rows: rows})
 }
 
 type virtualTableGeneratorResponse struct {
 	datums tree.Datums
-	err    error
+	// rows, if set, is a batch of rows pushed at once, in which case datums is
+	// unset.
+	rows []tree.Datums
+	err  error
+}
+
+// virtualTableRowBatchSize is the number of rows which a rowBuffer
+// accumulates before pushing them.
+const virtualTableRowBatchSize = 64
+
+// rowBuffer is a rowPusher which accumulates the rows pushed into it and
+// pushes them in batches to the underlying rowPusher. The rows are copied into
+// memory which is reused across the batches, so the caller is free to mutate
+// the rows it pushes. flush must be called once the caller is done pushing
+// rows.
+type rowBuffer struct {
+	pusher  rowPusher
+	numCols int
+	// datums is the memory backing rows, allocated on the first push.
+	datums tree.Datums
+	rows   []tree.Datums
+}
+
+var _ rowPusher = &rowBuffer{}
+
+func newRowBuffer(pusher rowPusher, numCols int) *rowBuffer {
+	return &rowBuffer{pusher: pusher, numCols: numCols}
+}
+
+// pushRow implements the rowPusher interface. It only blocks when the batch
+// is full.
+func (b *rowBuffer) pushRow(datums ...tree.Datum) error {
+	if len(datums) != b.numCols {
+		return errors.AssertionFailedf("expected %d datums, got %d", b.numCols, len(datums))
+	}
+	if b.datums == nil {
+		b.datums = make(tree.Datums, virtualTableRowBatchSize*b.numCols)
+		b.rows = make([]tree.Datums, 0, virtualTableRowBatchSize)
+	}
+	start := len(b.rows) * b.numCols
+	row := b.datums[start : start+b.numCols : start+b.numCols]
+	copy(row, datums)
+	b.rows = append(b.rows, row)
+	if len(b.rows) == virtualTableRowBatchSize {
+		return b.flush()
+	}
+	return nil
+}
+
+// pushRows implements the rowPusher interface.
+func (b *rowBuffer) pushRows(rows []tree.Datums) error {
+	for _, row := range rows {
+		if err := b.pushRow(row...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// flush pushes the buffered rows, if any.
+func (b *rowBuffer) flush() error {
+	if len(b.rows) == 0 {
+		return nil
+	}
+	err := b.pusher.pushRows(b.rows)
+	b.rows = b.rows[:0]
+	return err
+}
+
+// bufferRows wraps a worker so that the rows it pushes are pushed in batches
+// of virtualTableRowBatchSize rows.
+func bufferRows(numCols int, worker func(pusher rowPusher) error) func(pusher rowPusher) error {
+	return func(pusher rowPusher) error {
+		b := newRowBuffer(pusher, numCols)
+		if err := worker(b); err != nil {
+			return err
+		}
+		return b.flush()
+	}
 }
 
 // setupGenerator takes in a worker that generates rows eagerly and transforms
@@ -82,11 +174,11 @@ func setupGenerator(
 	// computation through comm, and the generator places rows to consume
 	// back into comm.
 	comm := make(chan virtualTableGeneratorResponse)
-	addRow := func(datums ...tree.Datum) error {
+	send := func(resp virtualTableGeneratorResponse) error {
 		select {
 		case <-ctx.Done():
 			return cancelchecker.QueryCanceledError
-		case comm <- virtualTableGeneratorResponse{datums: datums}:
+		case comm <- resp:
 		}
 		// Block until the next call to cleanup() or next(). This allows us to
 		// avoid issues with concurrent transaction usage if the worker is using
@@ -117,7 +209,7 @@ func setupGenerator(
 			return
 		case <-comm:
 		}
-		err := worker(funcRowPusher(addRow))
+		err := worker(generatorRowPusher(send))
 		// If the query was canceled, next() will already return a
 		// QueryCanceledError, so just exit here.
 		if errors.Is(err, cancelchecker.QueryCanceledError) {
@@ -137,7 +229,15 @@ func setupGenerator(
 		wg.Done()
 	}
 
+	// pending holds the rows of the last batch pushed by the worker which
+	// haven't been returned yet. The worker stays blocked until they are.
+	var pending []tree.Datums
 	next = func() (tree.Datums, error) {
+		if len(pending) > 0 {
+			row := pending[0]
+			pending = pending[1:]
+			return row, nil
+		}
 		// Notify the worker to begin computing a row.
 		select {
 		case comm <- virtualTableGeneratorResponse{}:
@@ -149,6 +249,10 @@ func setupGenerator(
 		case <-ctx.Done():
 			return nil, cancelchecker.QueryCanceledError
 		case resp := <-comm:
+			if len(resp.rows) > 0 {
+				pending = resp.rows[1:]
+				return resp.rows[0], nil
+			}
 			return resp.datums, resp.err
 		}
 	}
@@ -324,6 +428,16 @@ func (v *vTableLookupJoinNode) Next(params runParams) (bool, error) {
 	}
 }
 
+// pushRows implements the rowPusher interface.
+func (v *vTableLookupJoinNode) pushRows(lookedUpRows []tree.Datums) error {
+	for _, lookedUpRow := range lookedUpRows {
+		if err := v.pushRow(lookedUpRow...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // pushRow implements the rowPusher interface.
 func (v *vTableLookupJoinNode) pushRow(lookedUpRow ...tree.Datum) error {
 	// Reset our output row to just the contents of the input row.
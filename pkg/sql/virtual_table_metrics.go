@@ -0,0 +1,148 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/cockroach/pkg/util/timeutil"
+)
+
+// virtualTableMetrics records, since the node started, the cost of the
+// populations of the information_schema and pg_catalog tables. It backs
+// crdb_internal.node_virtual_table_populations, which lets operators quantify
+// the overhead of the introspection queries (e.g. of ORMs).
+type virtualTableMetrics struct {
+	mu struct {
+		syncutil.Mutex
+		byID map[descpb.ID]*virtualTablePopulationStats
+	}
+}
+
+// virtualTablePopulationStats are the cumulative statistics of the
+// populations of a virtual table.
+type virtualTablePopulationStats struct {
+	id         descpb.ID
+	schemaName string
+	tableName  string
+	// populations counts the full and the constrained populations of the
+	// table.
+	populations int64
+	rows        int64
+	// totalLatency and maxLatency only account for the time spent in the
+	// populate functions, not for the time the rows wait to be consumed.
+	totalLatency time.Duration
+	maxLatency   time.Duration
+}
+
+// startPopulation returns the recorder of a population of the given virtual
+// table, or nil if the table isn't tracked.
+func (m *virtualTableMetrics) startPopulation(e *virtualDefEntry) *virtualTablePopulation {
+	var schemaName string
+	switch e.desc.GetParentSchemaID() {
+	case catconstants.PgCatalogID:
+		schemaName = sessiondata.PgCatalogName
+	case catconstants.InformationSchemaID:
+		schemaName = sessiondata.InformationSchemaName
+	default:
+		return nil
+	}
+	return &virtualTablePopulation{
+		metrics:    m,
+		id:         e.desc.GetID(),
+		schemaName: schemaName,
+		tableName:  e.desc.GetName(),
+		resumed:    timeutil.Now(),
+	}
+}
+
+// record adds a population to the statistics of its table.
+func (m *virtualTableMetrics) record(vp *virtualTablePopulation) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.mu.byID == nil {
+		m.mu.byID = make(map[descpb.ID]*virtualTablePopulationStats)
+	}
+	s, ok := m.mu.byID[vp.id]
+	if !ok {
+		s = &virtualTablePopulationStats{
+			id: vp.id, schemaName: vp.schemaName, tableName: vp.tableName,
+		}
+		m.mu.byID[vp.id] = s
+	}
+	s.populations++
+	s.rows += vp.rows
+	s.totalLatency += vp.latency
+	if vp.latency > s.maxLatency {
+		s.maxLatency = vp.latency
+	}
+}
+
+// populationStats returns a copy of the statistics of every table populated
+// since the node started, sorted by schema and table name.
+func (m *virtualTableMetrics) populationStats() []virtualTablePopulationStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	res := make([]virtualTablePopulationStats, 0, len(m.mu.byID))
+	for _, s := range m.mu.byID {
+		res = append(res, *s)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].schemaName != res[j].schemaName {
+			return res[i].schemaName < res[j].schemaName
+		}
+		return res[i].tableName < res[j].tableName
+	})
+	return res
+}
+
+// virtualTablePopulation records a single population of a virtual table. The
+// time spent pushing the rows, during which the populate function is blocked
+// until the rows are consumed, is excluded from its latency.
+type virtualTablePopulation struct {
+	metrics    *virtualTableMetrics
+	id         descpb.ID
+	schemaName string
+	tableName  string
+	rows       int64
+	latency    time.Duration
+	// resumed is the time at which the populate function last resumed.
+	resumed time.Time
+}
+
+// pushRow pushes a row produced by the populate function. The recorder may be
+// nil, in which case the row is merely pushed.
+func (vp *virtualTablePopulation) pushRow(pusher rowPusher, row tree.Datums) error {
+	if vp == nil {
+		return pusher.pushRow(row...)
+	}
+	vp.rows++
+	vp.latency += timeutil.Since(vp.resumed)
+	err := pusher.pushRow(row...)
+	vp.resumed = timeutil.Now()
+	return err
+}
+
+// finish records the population once the populate function returned. The
+// recorder may be nil.
+func (vp *virtualTablePopulation) finish() {
+	if vp == nil {
+		return
+	}
+	vp.latency += timeutil.Since(vp.resumed)
+	vp.metrics.record(vp)
+}
@@ -0,0 +1,587 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/keys"
+	"github.com/cockroachdb/cockroach/pkg/kv"
+	"github.com/cockroachdb/cockroach/pkg/roachpb"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondata"
+	"github.com/cockroachdb/cockroach/pkg/sql/sessiondatapb"
+	"github.com/cockroachdb/cockroach/pkg/util/hlc"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/cockroach/pkg/util/stop"
+	"github.com/cockroachdb/cockroach/pkg/util/syncutil"
+	"github.com/cockroachdb/logtags"
+)
+
+// virtualTableSnapshotsEnabled controls whether the virtual tables marked as
+// materializable are served from in-memory snapshots.
+var virtualTableSnapshotsEnabled = settings.RegisterBoolSetting(
+	"sql.catalog.virtual_table_snapshots.enabled",
+	"if enabled, expensive information_schema tables are served from in-memory "+
+		"snapshots which are refreshed in the background and may be stale by up to "+
+		"sql.catalog.virtual_table_snapshots.max_staleness",
+	false,
+)
+
+// virtualTableSnapshotsMaxStaleness bounds the age of the snapshots served to
+// queries.
+var virtualTableSnapshotsMaxStaleness = settings.RegisterDurationSetting(
+	"sql.catalog.virtual_table_snapshots.max_staleness",
+	"the maximum staleness of a virtual table snapshot served to queries",
+	10*time.Second,
+	settings.NonNegativeDuration,
+)
+
+// virtualTableSnapshotEvictionMultiple is the multiple of the maximum
+// staleness after which unused snapshots are discarded.
+const virtualTableSnapshotEvictionMultiple = 10
+
+// catalogSessionSettings holds the session settings which affect the contents
+// of the virtual tables.
+type catalogSessionSettings struct {
+	mysqlCompat               bool
+	excludeHiddenColumns      bool
+	materializedViewsAsTables bool
+	includeNonPublicTables    bool
+	excludeSystemTables       bool
+	excludeNotNullConstraints bool
+	stubCatalogTables         bool
+}
+
+func makeCatalogSessionSettings(sd *sessiondata.SessionData) catalogSessionSettings {
+	return catalogSessionSettings{
+		mysqlCompat:               sd.MySQLCompat,
+		excludeHiddenColumns:      sd.ExcludeHiddenColumnsFromInformationSchema,
+		materializedViewsAsTables: sd.MaterializedViewsAsTablesInInformationSchema,
+		includeNonPublicTables:    sd.IncludeNonPublicTablesInInformationSchema,
+		excludeSystemTables:       sd.ExcludeSystemTablesFromInformationSchema,
+		excludeNotNullConstraints: sd.ExcludeNotNullConstraintsFromInformationSchema,
+		stubCatalogTables:         sd.StubCatalogTablesEnabled,
+	}
+}
+
+// apply sets the settings in the given session data.
+func (s catalogSessionSettings) apply(sd *sessiondata.SessionData) {
+	sd.MySQLCompat = s.mysqlCompat
+	sd.ExcludeHiddenColumnsFromInformationSchema = s.excludeHiddenColumns
+	sd.MaterializedViewsAsTablesInInformationSchema = s.materializedViewsAsTables
+	sd.IncludeNonPublicTablesInInformationSchema = s.includeNonPublicTables
+	sd.ExcludeSystemTablesFromInformationSchema = s.excludeSystemTables
+	sd.ExcludeNotNullConstraintsFromInformationSchema = s.excludeNotNullConstraints
+	sd.StubCatalogTablesEnabled = s.stubCatalogTables
+}
+
+// virtualTableSnapshotKey identifies a snapshot. The contents of the virtual
+// tables depend on the database they are queried in, on the privileges of the
+// user querying them and on the catalog settings of the session, so snapshots
+// are never shared across any of these.
+type virtualTableSnapshotKey struct {
+	tableID  descpb.ID
+	dbName   string
+	user     security.SQLUsername
+	settings catalogSessionSettings
+}
+
+// virtualTableRowGroup holds the rows of a snapshot which derive from a
+// single table descriptor. The snapshots of virtual tables which don't define
+// tableRows consist of a single group.
+type virtualTableRowGroup struct {
+	dbID, tableID descpb.ID
+	rows          []tree.Datums
+}
+
+// virtualTableSnapshot is the materialized contents of a virtual table.
+type virtualTableSnapshot struct {
+	groups []virtualTableRowGroup
+	// asOf is the read timestamp of the transaction which populated the
+	// snapshot from scratch. It bounds the staleness of the snapshot.
+	asOf hlc.Timestamp
+	// descsAsOf is the read timestamp of the transaction which last refreshed
+	// the rows of the changed table descriptors. It is never lower than asOf.
+	descsAsOf hlc.Timestamp
+	// lastUsed is the read timestamp of the last query served by the snapshot.
+	lastUsed hlc.Timestamp
+	// refreshing is set while a background refresh of the snapshot is in
+	// flight.
+	refreshing bool
+	// dirty maps the IDs of the table descriptors which changed after asOf,
+	// and whose rows have not been refreshed since, to the sequence number of
+	// their last change.
+	dirty map[descpb.ID]int64
+	// invalid is set when a descriptor other than a table descriptor changes
+	// after asOf. Such a change may affect the rows of any table, so the
+	// snapshot must be populated from scratch.
+	invalid bool
+}
+
+// descriptorChange is a change to a descriptor observed on the rangefeed on
+// system.descriptor.
+type descriptorChange struct {
+	id      descpb.ID
+	ts      hlc.Timestamp
+	isTable bool
+	seq     int64
+}
+
+func (snap *virtualTableSnapshot) apply(c descriptorChange) {
+	if c.ts.LessEq(snap.asOf) {
+		// The change is reflected in the snapshot already.
+		return
+	}
+	if c.isTable {
+		snap.dirty[c.id] = c.seq
+	} else {
+		snap.invalid = true
+	}
+}
+
+// virtualTableSnapshots is the node-wide store of virtual table snapshots.
+//
+// A snapshot is populated by the first query which finds no usable snapshot.
+// Queries then keep being served from it as long as it is fresher than
+// sql.catalog.virtual_table_snapshots.max_staleness. Once a snapshot reaches
+// half of that age, the next query served from it triggers a refresh in the
+// background so that tables queried periodically (e.g. by dashboards) rarely
+// need to be populated in the foreground.
+//
+// In between, the snapshots follow schema changes through a rangefeed on
+// system.descriptor: after a table descriptor changes, the next query served
+// from a snapshot only recomputes the rows derived from that descriptor (see
+// virtualSchemaTable.tableRows). Changes to the other kinds of descriptors,
+// which may affect the rows of any table, cause the snapshot to be populated
+// from scratch.
+type virtualTableSnapshots struct {
+	mu struct {
+		syncutil.Mutex
+		snapshots map[virtualTableSnapshotKey]*virtualTableSnapshot
+		// watching is set once the rangefeed on system.descriptor has been
+		// started.
+		watching bool
+		// seq numbers the descriptor changes.
+		seq int64
+		// recentChanges holds the descriptor changes observed over the last
+		// max_staleness, so that they can be applied to the snapshots which
+		// were being populated while they were observed.
+		recentChanges []descriptorChange
+	}
+	// onChange, if set, is called for every descriptor change observed by the
+	// rangefeed.
+	onChange func()
+}
+
+func (s *virtualTableSnapshots) init() {
+	s.mu.snapshots = make(map[virtualTableSnapshotKey]*virtualTableSnapshot)
+}
+
+// canUseSnapshots returns whether the virtual tables queried by the planner's
+// transaction may be served from snapshots.
+func canUseSnapshots(p *planner) bool {
+	if !virtualTableSnapshotsEnabled.Get(&p.ExecCfg().Settings.SV) {
+		return false
+	}
+	// A transaction must always observe its own schema changes.
+	return !p.Descriptors().HasUncommittedTables() && !p.Descriptors().HasUncommittedTypes()
+}
+
+// populateFunc returns a populate function for the given virtual table which
+// serves its rows from a snapshot when possible.
+func (s *virtualTableSnapshots) populateFunc(
+	id descpb.ID, def virtualSchemaTable, stopper *stop.Stopper,
+) func(ctx context.Context, p *planner, db catalog.DatabaseDescriptor, addRow func(...tree.Datum) error) error {
+	return func(
+		ctx context.Context, p *planner, db catalog.DatabaseDescriptor, addRow func(...tree.Datum) error,
+	) error {
+		if !canUseSnapshots(p) {
+			return def.populate(ctx, p, db, addRow)
+		}
+		key := virtualTableSnapshotKey{
+			tableID: id, user: p.User(), settings: makeCatalogSessionSettings(p.SessionData()),
+		}
+		if db != nil {
+			key.dbName = db.GetName()
+		}
+		readTS := p.txn.ReadTimestamp()
+		maxStaleness := virtualTableSnapshotsMaxStaleness.Get(&p.ExecCfg().Settings.SV)
+
+		snap, groups, dirty, refresh := s.get(key, readTS, maxStaleness)
+		var err error
+		switch {
+		case snap == nil:
+			if groups, err = materializeVirtualTable(ctx, p, def, db, nil, nil); err != nil {
+				return err
+			}
+			s.put(key, groups, readTS, maxStaleness)
+			// Start watching from far enough in the past to observe the changes
+			// which any snapshot that can still be served may be missing.
+			s.maybeWatchDescriptors(ctx, p.ExecCfg(), readTS.Add(-maxStaleness.Nanoseconds(), 0))
+		case len(dirty) > 0:
+			if groups, err = materializeVirtualTable(ctx, p, def, db, groups, dirty); err != nil {
+				return err
+			}
+			s.putRefreshed(key, snap, groups, dirty, readTS)
+		}
+		if refresh {
+			s.refreshAsync(ctx, p.ExecCfg(), stopper, key, def)
+		}
+		for _, g := range groups {
+			for _, row := range g.rows {
+				if err := addRow(row...); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// get returns the snapshot for the given key if it can serve a query reading
+// at readTS, along with its rows, a copy of its dirty table descriptors and
+// whether a background refresh of the snapshot should be started.
+func (s *virtualTableSnapshots) get(
+	key virtualTableSnapshotKey, readTS hlc.Timestamp, maxStaleness time.Duration,
+) (
+	snap *virtualTableSnapshot,
+	groups []virtualTableRowGroup,
+	dirty map[descpb.ID]int64,
+	refresh bool,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snap, ok := s.mu.snapshots[key]
+	if !ok || snap.invalid || readTS.Less(snap.descsAsOf) {
+		return nil, nil, nil, false
+	}
+	age := readTS.GoTime().Sub(snap.asOf.GoTime())
+	if age > maxStaleness {
+		return nil, nil, nil, false
+	}
+	if snap.lastUsed.Less(readTS) {
+		snap.lastUsed = readTS
+	}
+	if len(snap.dirty) > 0 {
+		dirty = make(map[descpb.ID]int64, len(snap.dirty))
+		for id, seq := range snap.dirty {
+			dirty[id] = seq
+		}
+	}
+	refresh = !snap.refreshing && age > maxStaleness/2
+	if refresh {
+		snap.refreshing = true
+	}
+	return snap, snap.groups, dirty, refresh
+}
+
+// put stores a snapshot populated from scratch at asOf for the given key,
+// unless a fresher one has been stored concurrently. It also discards the
+// snapshots that have not been used for a while.
+func (s *virtualTableSnapshots) put(
+	key virtualTableSnapshotKey,
+	groups []virtualTableRowGroup,
+	asOf hlc.Timestamp,
+	maxStaleness time.Duration,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	evictBefore := asOf.Add(-(virtualTableSnapshotEvictionMultiple * maxStaleness).Nanoseconds(), 0)
+	for k, snap := range s.mu.snapshots {
+		if k != key && snap.lastUsed.Less(evictBefore) {
+			delete(s.mu.snapshots, k)
+		}
+	}
+	if prev, ok := s.mu.snapshots[key]; ok && !prev.invalid && asOf.Less(prev.descsAsOf) {
+		prev.refreshing = false
+		return
+	}
+	snap := &virtualTableSnapshot{
+		groups:    groups,
+		asOf:      asOf,
+		descsAsOf: asOf,
+		lastUsed:  asOf,
+		dirty:     make(map[descpb.ID]int64),
+	}
+	for _, c := range s.mu.recentChanges {
+		snap.apply(c)
+	}
+	s.mu.snapshots[key] = snap
+}
+
+// putRefreshed replaces the rows of the given snapshot with rows in which the
+// rows of the given dirty table descriptors were refreshed at readTS, unless
+// the snapshot was replaced or refreshed at a later timestamp concurrently.
+func (s *virtualTableSnapshots) putRefreshed(
+	key virtualTableSnapshotKey,
+	snap *virtualTableSnapshot,
+	groups []virtualTableRowGroup,
+	dirty map[descpb.ID]int64,
+	readTS hlc.Timestamp,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.mu.snapshots[key] != snap || readTS.Less(snap.descsAsOf) {
+		return
+	}
+	snap.groups = groups
+	snap.descsAsOf = readTS
+	for id, seq := range dirty {
+		// Descriptors which changed again while their rows were being
+		// refreshed remain dirty.
+		if snap.dirty[id] == seq {
+			delete(snap.dirty, id)
+		}
+	}
+}
+
+// maybeWatchDescriptors starts the rangefeed on system.descriptor which keeps
+// the snapshots up to date with schema changes, unless it was started
+// already.
+func (s *virtualTableSnapshots) maybeWatchDescriptors(
+	ctx context.Context, execCfg *ExecutorConfig, startTS hlc.Timestamp,
+) {
+	if execCfg.RangeFeedFactory == nil {
+		return
+	}
+	s.mu.Lock()
+	watching := s.mu.watching
+	s.mu.watching = true
+	s.mu.Unlock()
+	if watching {
+		return
+	}
+
+	descriptorTableStart := execCfg.Codec.TablePrefix(keys.DescriptorTableID)
+	descriptorTableSpan := roachpb.Span{
+		Key:    descriptorTableStart,
+		EndKey: descriptorTableStart.PrefixEnd(),
+	}
+	handleEvent := func(ctx context.Context, ev *roachpb.RangeFeedValue) {
+		id, err := execCfg.Codec.DecodeDescMetadataID(ev.Key)
+		if err != nil {
+			log.Warningf(ctx, "unable to decode descriptor key %s: %v", ev.Key, err)
+			return
+		}
+		// Deleted descriptors are treated as table descriptors: by the time a
+		// schema or database descriptor is deleted, the tables it contained
+		// have been dropped.
+		isTable := true
+		if len(ev.Value.RawBytes) != 0 {
+			var descriptor descpb.Descriptor
+			if err := ev.Value.GetProto(&descriptor); err != nil {
+				log.Warningf(ctx, "unable to unmarshal descriptor %d: %v", id, err)
+				isTable = false
+			} else {
+				isTable = descriptor.GetTable() != nil
+			}
+		}
+		s.onDescriptorChange(descriptorChange{
+			id:      descpb.ID(id),
+			ts:      ev.Value.Timestamp,
+			isTable: isTable,
+		}, virtualTableSnapshotsMaxStaleness.Get(&execCfg.Settings.SV))
+		if s.onChange != nil {
+			s.onChange()
+		}
+	}
+	// The rangefeed stops when the server shuts down.
+	ctx = logtags.WithTags(context.Background(), logtags.FromContext(ctx))
+	if _, err := execCfg.RangeFeedFactory.RangeFeed(
+		ctx, "virtual-table-snapshots", descriptorTableSpan, startTS, handleEvent,
+	); err != nil {
+		log.Warningf(ctx, "failed to watch descriptors for virtual table snapshots: %v", err)
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.mu.watching = false
+	}
+}
+
+// onDescriptorChange records a descriptor change and applies it to the
+// snapshots.
+func (s *virtualTableSnapshots) onDescriptorChange(
+	c descriptorChange, maxStaleness time.Duration,
+) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.seq++
+	c.seq = s.mu.seq
+
+	// Snapshots populated before changes older than max_staleness can't be
+	// served anymore, so those changes don't need to be retained.
+	horizon := c.ts.Add(-maxStaleness.Nanoseconds(), 0)
+	recent := s.mu.recentChanges[:0]
+	for _, prev := range s.mu.recentChanges {
+		if horizon.LessEq(prev.ts) {
+			recent = append(recent, prev)
+		}
+	}
+	s.mu.recentChanges = append(recent, c)
+
+	for _, snap := range s.mu.snapshots {
+		snap.apply(c)
+	}
+}
+
+// refreshAsync repopulates the snapshot for the given key from scratch in the
+// background, on behalf of the user the snapshot belongs to and with the
+// catalog settings of the sessions it serves.
+func (s *virtualTableSnapshots) refreshAsync(
+	ctx context.Context,
+	execCfg *ExecutorConfig,
+	stopper *stop.Stopper,
+	key virtualTableSnapshotKey,
+	def virtualSchemaTable,
+) {
+	ctx = logtags.WithTags(context.Background(), logtags.FromContext(ctx))
+	if err := stopper.RunAsyncTask(ctx, "refresh-virtual-table-snapshot", func(ctx context.Context) {
+		var groups []virtualTableRowGroup
+		var asOf hlc.Timestamp
+		err := execCfg.DB.Txn(ctx, func(ctx context.Context, txn *kv.Txn) error {
+			pi, cleanup := NewInternalPlanner(
+				"refresh-virtual-table-snapshot",
+				txn,
+				key.user,
+				&MemoryMetrics{},
+				execCfg,
+				sessiondatapb.SessionData{},
+			)
+			defer cleanup()
+			p := pi.(*planner)
+			key.settings.apply(p.SessionData())
+			var db catalog.DatabaseDescriptor
+			if key.dbName != "" {
+				var err error
+				_, db, err = p.Descriptors().GetImmutableDatabaseByName(ctx, txn, key.dbName,
+					tree.DatabaseLookupFlags{Required: true})
+				if err != nil {
+					return err
+				}
+			}
+			var err error
+			groups, err = materializeVirtualTable(ctx, p, def, db, nil, nil)
+			asOf = txn.ReadTimestamp()
+			return err
+		})
+		if err != nil {
+			log.Warningf(ctx, "failed to refresh virtual table snapshot: %v", err)
+			s.abortRefresh(key)
+			return
+		}
+		s.put(key, groups, asOf, virtualTableSnapshotsMaxStaleness.Get(&execCfg.Settings.SV))
+	}); err != nil {
+		s.abortRefresh(key)
+	}
+}
+
+// abortRefresh allows a new refresh of the snapshot for the given key to be
+// started after a failed one.
+func (s *virtualTableSnapshots) abortRefresh(key virtualTableSnapshotKey) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if snap, ok := s.mu.snapshots[key]; ok {
+		snap.refreshing = false
+	}
+}
+
+// materializeVirtualTable populates the given virtual table into memory. If
+// the virtual table defines tableRows, the rows derived from the table
+// descriptors in prev which are not dirty are reused as is.
+func materializeVirtualTable(
+	ctx context.Context,
+	p *planner,
+	def virtualSchemaTable,
+	db catalog.DatabaseDescriptor,
+	prev []virtualTableRowGroup,
+	dirty map[descpb.ID]int64,
+) ([]virtualTableRowGroup, error) {
+	collect := func(rows *[]tree.Datums) func(...tree.Datum) error {
+		return func(row ...tree.Datum) error {
+			// The populate function is allowed to reuse the row once addRow
+			// returns.
+			*rows = append(*rows, append(tree.Datums(nil), row...))
+			return nil
+		}
+	}
+	if def.tableRows == nil {
+		var g virtualTableRowGroup
+		if err := def.populate(ctx, p, db, collect(&g.rows)); err != nil {
+			return nil, err
+		}
+		return []virtualTableRowGroup{g}, nil
+	}
+
+	type groupKey struct {
+		dbID, tableID descpb.ID
+	}
+	prevRows := make(map[groupKey][]tree.Datums, len(prev))
+	for _, g := range prev {
+		prevRows[groupKey{dbID: g.dbID, tableID: g.tableID}] = g.rows
+	}
+	addTableRows, err := def.tableRows(ctx, p, db)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]virtualTableRowGroup, 0, len(prev))
+	if err := forEachTableDesc(ctx, p, db, virtualMany, func(
+		db catalog.DatabaseDescriptor, scName string, table catalog.TableDescriptor,
+	) error {
+		g := virtualTableRowGroup{dbID: db.GetID(), tableID: table.GetID()}
+		if _, changed := dirty[g.tableID]; !changed && !dependsOnDirtyDescriptor(table, dirty) {
+			if rows, ok := prevRows[groupKey{dbID: g.dbID, tableID: g.tableID}]; ok {
+				g.rows = rows
+				groups = append(groups, g)
+				return nil
+			}
+		}
+		if err := addTableRows(db, scName, table, collect(&g.rows)); err != nil {
+			return err
+		}
+		groups = append(groups, g)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// dependsOnDirtyDescriptor returns whether the table is a view depending on
+// one of the dirty descriptors, or whether one of its columns uses one of the
+// dirty sequences. The rows derived from such a table describe these
+// descriptors too (e.g. the is_updatable column of the views or the identity_*
+// and crdb_default_sequences columns of information_schema.columns), so they
+// must be refreshed along with them.
+func dependsOnDirtyDescriptor(table catalog.TableDescriptor, dirty map[descpb.ID]int64) bool {
+	if len(dirty) == 0 {
+		return false
+	}
+	if table.IsView() {
+		for _, id := range table.GetDependsOn() {
+			if _, ok := dirty[id]; ok {
+				return true
+			}
+		}
+	}
+	for _, col := range table.PublicColumns() {
+		for i := 0; i < col.NumUsesSequences(); i++ {
+			if _, ok := dirty[col.GetUsesSequenceID(i)]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/base"
+	"github.com/cockroachdb/cockroach/pkg/security"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/testutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/serverutils"
+	"github.com/cockroachdb/cockroach/pkg/testutils/sqlutils"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/cockroachdb/errors"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVirtualTableSnapshotRefreshSessionSettings checks that a snapshot
+// refreshed in the background is populated with the catalog settings of the
+// sessions it serves.
+func TestVirtualTableSnapshotRefreshSessionSettings(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	ctx := context.Background()
+	s, sqlDB, _ := serverutils.StartServer(t, base.TestServerArgs{})
+	defer s.Stopper().Stop(ctx)
+	execCfg := s.ExecutorConfig().(ExecutorConfig)
+
+	sqlutils.MakeSQLRunner(sqlDB).Exec(t, `CREATE DATABASE d; CREATE TABLE d.t (a INT PRIMARY KEY)`)
+
+	e, ok := execCfg.VirtualSchemas.defsByID[catconstants.InformationSchemaColumnsTableID]
+	require.True(t, ok)
+	ordinal := func(name string) int {
+		col, err := e.desc.FindColumnWithName(tree.Name(name))
+		require.NoError(t, err)
+		return col.Ordinal()
+	}
+	tableName, columnName, columnType := ordinal("table_name"), ordinal("column_name"), ordinal("column_type")
+
+	var snapshots virtualTableSnapshots
+	snapshots.init()
+	key := virtualTableSnapshotKey{
+		tableID:  catconstants.InformationSchemaColumnsTableID,
+		dbName:   "d",
+		user:     security.RootUserName(),
+		settings: catalogSessionSettings{mysqlCompat: true},
+	}
+	snapshots.refreshAsync(ctx, &execCfg, s.Stopper(), key, informationSchemaColumnsTable)
+
+	testutils.SucceedsSoon(t, func() error {
+		snapshots.mu.Lock()
+		defer snapshots.mu.Unlock()
+		snap, ok := snapshots.mu.snapshots[key]
+		if !ok {
+			return errors.New("snapshot not refreshed yet")
+		}
+		for _, g := range snap.groups {
+			for _, row := range g.rows {
+				if tree.MustBeDString(row[tableName]) != "t" || tree.MustBeDString(row[columnName]) != "a" {
+					continue
+				}
+				// column_type is only reported in MySQL compatibility mode.
+				if typ, ok := row[columnType].(*tree.DString); !ok || *typ != "int8" {
+					return errors.Errorf("expected column_type int8, found %s", row[columnType])
+				}
+				return nil
+			}
+		}
+		return errors.New("no row for column a of table t")
+	})
+}
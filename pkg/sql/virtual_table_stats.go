@@ -0,0 +1,345 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/cockroach/pkg/settings"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/catconstants"
+	"github.com/cockroachdb/cockroach/pkg/sql/catalog/descpb"
+	"github.com/cockroachdb/cockroach/pkg/sql/opt/cat"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+)
+
+// virtualTableStatisticsEnabled controls whether the optimizer is given
+// statistics for the virtual tables.
+var virtualTableStatisticsEnabled = settings.RegisterBoolSetting(
+	"sql.catalog.virtual_table_statistics.enabled",
+	"if enabled, the optimizer estimates the row counts and distinct counts of the "+
+		"pg_catalog and information_schema tables from the number of objects in the catalog",
+	true,
+)
+
+// catalogObjectKind is a kind of catalog object which virtual tables have one
+// row per.
+type catalogObjectKind int
+
+const (
+	catalogDatabases catalogObjectKind = iota
+	catalogSchemas
+	catalogTables
+	catalogColumns
+	catalogIndexes
+	catalogConstraints
+	// catalogRelations counts the tables and the indexes, which are both
+	// relations in pg_catalog.
+	catalogRelations
+	numCatalogObjectKinds
+)
+
+// catalogCounts holds the number of objects of each kind in the catalog, or
+// in a database.
+type catalogCounts [numCatalogObjectKinds]uint64
+
+// virtualColumnsStat describes a set of columns of a virtual table, whose
+// values identify an object of the given kind.
+type virtualColumnsStat struct {
+	columns []string
+	kind    catalogObjectKind
+}
+
+// virtualTableStatsSpec describes how to estimate the statistics of a virtual
+// table from the catalog counts.
+type virtualTableStatsSpec struct {
+	// rows is the kind of objects the table has one row per.
+	rows catalogObjectKind
+	// distinct lists the sets of columns whose distinct counts are estimated.
+	distinct []virtualColumnsStat
+}
+
+// virtualTableStatsSpecs holds the statistics specs of the catalog tables
+// which are commonly joined with each other by the introspection queries of
+// drivers and ORMs. Without statistics, the optimizer assumes that every
+// virtual table has 1000 rows and may pick nested loop joins, with the wrong
+// build side, between tables such as information_schema.columns and
+// information_schema.key_column_usage.
+var virtualTableStatsSpecs = map[descpb.ID]virtualTableStatsSpec{
+	catconstants.InformationSchemaColumnsTableID: {
+		rows: catalogColumns,
+		distinct: []virtualColumnsStat{
+			{[]string{"table_schema"}, catalogSchemas},
+			{[]string{"table_name"}, catalogTables},
+			{[]string{"table_schema", "table_name"}, catalogTables},
+			{[]string{"table_schema", "table_name", "column_name"}, catalogColumns},
+		},
+	},
+	catconstants.InformationSchemaConstraintColumnUsageTableID: {
+		rows: catalogConstraints,
+		distinct: []virtualColumnsStat{
+			{[]string{"constraint_schema"}, catalogSchemas},
+			{[]string{"constraint_name"}, catalogConstraints},
+			{[]string{"constraint_schema", "constraint_name"}, catalogConstraints},
+			{[]string{"table_name"}, catalogTables},
+		},
+	},
+	catconstants.InformationSchemaKeyColumnUsageTableID: {
+		rows: catalogConstraints,
+		distinct: []virtualColumnsStat{
+			{[]string{"constraint_schema"}, catalogSchemas},
+			{[]string{"constraint_name"}, catalogConstraints},
+			{[]string{"constraint_schema", "constraint_name"}, catalogConstraints},
+			{[]string{"table_schema"}, catalogSchemas},
+			{[]string{"table_name"}, catalogTables},
+			{[]string{"table_schema", "table_name"}, catalogTables},
+		},
+	},
+	catconstants.InformationSchemaReferentialConstraintsTableID: {
+		rows: catalogConstraints,
+		distinct: []virtualColumnsStat{
+			{[]string{"constraint_schema"}, catalogSchemas},
+			{[]string{"constraint_name"}, catalogConstraints},
+			{[]string{"constraint_schema", "constraint_name"}, catalogConstraints},
+			{[]string{"table_name"}, catalogTables},
+		},
+	},
+	catconstants.InformationSchemaSchemataTableID: {
+		rows: catalogSchemas,
+		distinct: []virtualColumnsStat{
+			{[]string{"schema_name"}, catalogSchemas},
+		},
+	},
+	catconstants.InformationSchemaStatisticsTableID: {
+		rows: catalogIndexes,
+		distinct: []virtualColumnsStat{
+			{[]string{"table_schema"}, catalogSchemas},
+			{[]string{"table_name"}, catalogTables},
+			{[]string{"index_name"}, catalogIndexes},
+		},
+	},
+	catconstants.InformationSchemaTableConstraintTableID: {
+		rows: catalogConstraints,
+		distinct: []virtualColumnsStat{
+			{[]string{"constraint_schema"}, catalogSchemas},
+			{[]string{"constraint_name"}, catalogConstraints},
+			{[]string{"constraint_schema", "constraint_name"}, catalogConstraints},
+			{[]string{"table_schema"}, catalogSchemas},
+			{[]string{"table_name"}, catalogTables},
+			{[]string{"table_schema", "table_name"}, catalogTables},
+		},
+	},
+	catconstants.InformationSchemaTablesTableID: {
+		rows: catalogTables,
+		distinct: []virtualColumnsStat{
+			{[]string{"table_schema"}, catalogSchemas},
+			{[]string{"table_name"}, catalogTables},
+			{[]string{"table_schema", "table_name"}, catalogTables},
+		},
+	},
+	catconstants.PgCatalogAttributeTableID: {
+		rows: catalogColumns,
+		distinct: []virtualColumnsStat{
+			{[]string{"attrelid"}, catalogTables},
+			{[]string{"attrelid", "attnum"}, catalogColumns},
+		},
+	},
+	catconstants.PgCatalogClassTableID: {
+		rows: catalogRelations,
+		distinct: []virtualColumnsStat{
+			{[]string{"oid"}, catalogRelations},
+			{[]string{"relname"}, catalogRelations},
+			{[]string{"relnamespace"}, catalogSchemas},
+		},
+	},
+	catconstants.PgCatalogConstraintTableID: {
+		rows: catalogConstraints,
+		distinct: []virtualColumnsStat{
+			{[]string{"oid"}, catalogConstraints},
+			{[]string{"conrelid"}, catalogTables},
+			{[]string{"connamespace"}, catalogSchemas},
+		},
+	},
+	catconstants.PgCatalogIndexTableID: {
+		rows: catalogIndexes,
+		distinct: []virtualColumnsStat{
+			{[]string{"indexrelid"}, catalogIndexes},
+			{[]string{"indrelid"}, catalogTables},
+		},
+	},
+	catconstants.PgCatalogNamespaceTableID: {
+		rows: catalogSchemas,
+		distinct: []virtualColumnsStat{
+			{[]string{"oid"}, catalogSchemas},
+			{[]string{"nspname"}, catalogSchemas},
+		},
+	},
+}
+
+// virtualTableStat is a statistic synthesized for a virtual table. It
+// implements the cat.TableStatistic interface.
+type virtualTableStat struct {
+	createdAt      time.Time
+	columnOrdinals []int
+	rowCount       uint64
+	distinctCount  uint64
+}
+
+var _ cat.TableStatistic = &virtualTableStat{}
+
+// CreatedAt is part of the cat.TableStatistic interface.
+func (s *virtualTableStat) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// ColumnCount is part of the cat.TableStatistic interface.
+func (s *virtualTableStat) ColumnCount() int {
+	return len(s.columnOrdinals)
+}
+
+// ColumnOrdinal is part of the cat.TableStatistic interface.
+func (s *virtualTableStat) ColumnOrdinal(i int) int {
+	return s.columnOrdinals[i]
+}
+
+// RowCount is part of the cat.TableStatistic interface.
+func (s *virtualTableStat) RowCount() uint64 {
+	return s.rowCount
+}
+
+// DistinctCount is part of the cat.TableStatistic interface.
+func (s *virtualTableStat) DistinctCount() uint64 {
+	return s.distinctCount
+}
+
+// NullCount is part of the cat.TableStatistic interface.
+func (s *virtualTableStat) NullCount() uint64 {
+	return 0
+}
+
+// Histogram is part of the cat.TableStatistic interface.
+func (s *virtualTableStat) Histogram() []cat.HistogramBucket {
+	return nil
+}
+
+// makeVirtualTableStats synthesizes the statistics of a virtual table. dbID
+// is the database whose catalog the table describes, or zero if the table
+// describes all the databases. No statistics are returned for the virtual
+// tables without a spec, or if the catalog counts can't be read.
+func (oc *optCatalog) makeVirtualTableStats(
+	ctx context.Context, ot *optVirtualTable, dbID descpb.ID,
+) []virtualTableStat {
+	spec, ok := virtualTableStatsSpecs[ot.desc.GetID()]
+	if !ok || !virtualTableStatisticsEnabled.Get(&oc.planner.ExecCfg().Settings.SV) {
+		return nil
+	}
+	counts, err := oc.catalogCounts(ctx, dbID)
+	if err != nil {
+		// Ignore the error; the table is planned without statistics.
+		return nil
+	}
+	rowCount := counts[spec.rows]
+	createdAt := oc.planner.ExecCfg().Clock.PhysicalTime()
+	// The first statistic determines the row count of the table. It is on the
+	// dummy PK column, whose values are unique.
+	res := make([]virtualTableStat, 1, len(spec.distinct)+1)
+	res[0] = virtualTableStat{
+		createdAt:      createdAt,
+		columnOrdinals: []int{0},
+		rowCount:       rowCount,
+		distinctCount:  rowCount,
+	}
+	for _, d := range spec.distinct {
+		ords := make([]int, len(d.columns))
+		for i, name := range d.columns {
+			col, err := ot.desc.FindColumnWithName(tree.Name(name))
+			if err != nil {
+				return nil
+			}
+			ords[i] = col.Ordinal() + 1
+		}
+		distinct := counts[d.kind]
+		if distinct > rowCount {
+			distinct = rowCount
+		}
+		res = append(res, virtualTableStat{
+			createdAt:      createdAt,
+			columnOrdinals: ords,
+			rowCount:       rowCount,
+			distinctCount:  distinct,
+		})
+	}
+	return res
+}
+
+// catalogCounts returns the number of objects of each kind in the given
+// database, or in all the databases if dbID is zero. The virtual schemas and
+// tables are included, as they are listed by the virtual tables. The counts
+// are computed once per statement.
+func (oc *optCatalog) catalogCounts(ctx context.Context, dbID descpb.ID) (*catalogCounts, error) {
+	if c, ok := oc.catalogCountsCache[dbID]; ok {
+		return c, nil
+	}
+	all, err := oc.planner.getCatalogDescriptors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var c catalogCounts
+	var numDatabases uint64
+	for _, desc := range all {
+		if desc.Dropped() {
+			continue
+		}
+		switch d := desc.(type) {
+		case catalog.DatabaseDescriptor:
+			numDatabases++
+			if dbID == 0 || d.GetID() == dbID {
+				c[catalogDatabases]++
+				// The public schema of the database.
+				c[catalogSchemas]++
+			}
+		case catalog.SchemaDescriptor:
+			if dbID == 0 || d.GetParentID() == dbID {
+				c[catalogSchemas]++
+			}
+		case catalog.TableDescriptor:
+			if dbID != 0 && d.GetParentID() != dbID {
+				continue
+			}
+			c[catalogTables]++
+			c[catalogColumns] += uint64(len(d.PublicColumns()))
+			c[catalogIndexes] += uint64(len(d.ActiveIndexes()))
+			c[catalogConstraints] += uint64(len(d.ActiveIndexes()) + len(d.GetChecks()) +
+				len(d.GetOutboundFKs()) + len(d.GetUniqueWithoutIndexConstraints()))
+		}
+	}
+	// Every database contains the virtual schemas.
+	vs := oc.planner.ExecCfg().VirtualSchemas
+	var virtualTables, virtualColumns uint64
+	for _, e := range vs.defsByID {
+		virtualTables++
+		virtualColumns += uint64(len(e.desc.PublicColumns()))
+	}
+	if dbID != 0 {
+		numDatabases = 1
+	}
+	c[catalogSchemas] += numDatabases * uint64(len(vs.entries))
+	c[catalogTables] += numDatabases * virtualTables
+	c[catalogColumns] += numDatabases * virtualColumns
+	c[catalogRelations] = c[catalogTables] + c[catalogIndexes]
+
+	if oc.catalogCountsCache == nil {
+		oc.catalogCountsCache = make(map[descpb.ID]*catalogCounts)
+	}
+	oc.catalogCountsCache[dbID] = &c
+	return &c, nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2021 The Cockroach Authors.
+//
+// Use of this software is governed by the Business Source License
+// included in the file licenses/BSL.txt.
+//
+// As of the Change Date specified in that file, in accordance with
+// the Business Source License, use of this software will be governed
+// by the Apache License, Version 2.0, included in the file
+// licenses/APL.txt.
+
+package sql
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/cockroach/pkg/settings/cluster"
+	"github.com/cockroachdb/cockroach/pkg/sql/sem/tree"
+	"github.com/cockroachdb/cockroach/pkg/util/leaktest"
+	"github.com/cockroachdb/cockroach/pkg/util/log"
+	"github.com/stretchr/testify/require"
+)
+
+// TestVirtualTableStatsSpecs checks that the statistics specs refer to
+// existing virtual tables and columns.
+func TestVirtualTableStatsSpecs(t *testing.T) {
+	defer leaktest.AfterTest(t)()
+	defer log.Scope(t).Close(t)
+
+	vs, err := NewVirtualSchemaHolder(context.Background(), cluster.MakeTestingClusterSettings())
+	require.NoError(t, err)
+	for id, spec := range virtualTableStatsSpecs {
+		e, ok := vs.defsByID[id]
+		require.True(t, ok, "no virtual table with ID %d", id)
+		require.Less(t, int(spec.rows), int(numCatalogObjectKinds))
+		for _, d := range spec.distinct {
+			require.NotEmpty(t, d.columns)
+			for _, name := range d.columns {
+				_, err := e.desc.FindColumnWithName(tree.Name(name))
+				require.NoError(t, err, "table %s", e.desc.GetName())
+			}
+		}
+	}
+}
@@ -130,6 +130,49 @@ func TestVirtualTableGenerators(t *testing.T) {
 		cancel()
 		cleanup()
 	})
+
+	t.Run("test buffered rows", func(t *testing.T) {
+		// Test that the rows pushed through a rowBuffer are returned in order,
+		// across several batches, even though the worker reuses its row.
+		const numRows = 2*virtualTableRowBatchSize + 1
+		worker := bufferRows(2, func(pusher rowPusher) error {
+			row := make(tree.Datums, 2)
+			for i := 0; i < numRows; i++ {
+				row[0], row[1] = tree.NewDInt(tree.DInt(i)), tree.NewDString("foo")
+				if err := pusher.pushRow(row...); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		next, cleanup, setupError := setupGenerator(ctx, worker, stopper)
+		require.NoError(t, setupError)
+		defer cleanup()
+		for i := 0; i < numRows; i++ {
+			row, err := next()
+			require.NoError(t, err)
+			require.Equal(t, tree.Datums{tree.NewDInt(tree.DInt(i)), tree.NewDString("foo")}, row)
+		}
+		row, err := next()
+		require.NoError(t, err)
+		require.Nil(t, row)
+	})
+
+	t.Run("test buffered rows error", func(t *testing.T) {
+		// Test that the rows buffered before the worker returns an error aren't
+		// returned.
+		worker := bufferRows(1, func(pusher rowPusher) error {
+			if err := pusher.pushRow(tree.NewDInt(1)); err != nil {
+				return err
+			}
+			return errors.New("dummy error")
+		})
+		next, cleanup, setupError := setupGenerator(ctx, worker, stopper)
+		require.NoError(t, setupError)
+		defer cleanup()
+		_, err := next()
+		require.Error(t, err)
+	})
 }
 
 func BenchmarkVirtualTableGenerators(b *testing.B) {
@@ -155,4 +198,14 @@ func BenchmarkVirtualTableGenerators(b *testing.B) {
 		}
 		cleanup()
 	})
+	b.Run("bench buffered read", func(b *testing.B) {
+		next, cleanup, setupError := setupGenerator(ctx, bufferRows(1, worker), stopper)
+		require.NoError(b, setupError)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_, err := next()
+			require.NoError(b, err)
+		}
+		cleanup()
+	})
 }
@@ -77,7 +77,15 @@ CREATE TABLE information_schema.columns (
 	GENERATION_EXPRESSION    STRING,          -- MySQL/CockroachDB extension.
 	IS_UPDATABLE             STRING,
 	IS_HIDDEN                STRING NOT NULL, -- CockroachDB extension for SHOW COLUMNS / dump.
-	CRDB_SQL_TYPE            STRING NOT NULL  -- CockroachDB extension for SHOW COLUMNS / dump.
+	CRDB_SQL_TYPE            STRING NOT NULL, -- CockroachDB extension for SHOW COLUMNS / dump.
+	CRDB_IS_STORED           STRING,          -- CockroachDB extension: whether a computed column is STORED or VIRTUAL.
+	CRDB_COLUMN_FAMILY       STRING,          -- CockroachDB extension: the column family storing the column.
+	CRDB_COLLATION_VERSION   STRING,          -- CockroachDB extension: the collation version recorded for the column.
+	CRDB_DEFAULT_SEQUENCES   STRING[],        -- CockroachDB extension: the sequences used by the DEFAULT expression.
+	COLUMN_TYPE              STRING,          -- MySQL extension, only populated when mysql_compat is set: the full type of the column.
+	COLUMN_KEY               STRING,          -- MySQL extension, only populated when mysql_compat is set: PRI, UNI or MUL.
+	EXTRA                    STRING,          -- MySQL extension, only populated when mysql_compat is set: e.g. auto_increment.
+	PRIVILEGES               STRING           -- MySQL extension, only populated when mysql_compat is set: the privileges of the current user.
 )`
 
 // InformationSchemaAdministrableRoleAuthorizations describes the schema of the
@@ -102,6 +110,39 @@ CREATE TABLE information_schema.applicable_roles (
 	IS_GRANTABLE STRING NOT NULL
 )`
 
+// InformationSchemaEngines describes the schema of the
+// information_schema.engines table.
+// MySQL: https://dev.mysql.com/doc/refman/8.0/en/information-schema-engines-table.html
+const InformationSchemaEngines = `
+CREATE TABLE information_schema.engines (
+	ENGINE       STRING NOT NULL,
+	SUPPORT      STRING NOT NULL,
+	COMMENT      STRING NOT NULL,
+	TRANSACTIONS STRING NOT NULL,
+	XA           STRING NOT NULL,
+	SAVEPOINTS   STRING NOT NULL
+)`
+
+// InformationSchemaEvents describes the schema of the
+// information_schema.events table.
+// MySQL: https://dev.mysql.com/doc/refman/8.0/en/information-schema-events-table.html
+const InformationSchemaEvents = `
+CREATE TABLE information_schema.events (
+	EVENT_CATALOG      STRING,               -- schedules do not belong to a database.
+	EVENT_SCHEMA       STRING,
+	EVENT_NAME         STRING NOT NULL,
+	DEFINER            STRING NOT NULL,
+	EVENT_TYPE         STRING NOT NULL,
+	EXECUTE_AT         TIMESTAMPTZ,
+	INTERVAL_VALUE     STRING,               -- the recurrence of the schedule, as a cron expression.
+	INTERVAL_FIELD     STRING,
+	STATUS             STRING NOT NULL,
+	CREATED            TIMESTAMPTZ NOT NULL,
+	CRDB_SCHEDULE_ID   INT NOT NULL,         -- CockroachDB extension: references a schedule in SHOW SCHEDULES.
+	CRDB_EXECUTOR_TYPE STRING NOT NULL,      -- CockroachDB extension: the kind of schedule, e.g. scheduled-backup-executor.
+	CRDB_NEXT_RUN      TIMESTAMPTZ           -- CockroachDB extension: the next execution, if the schedule is not paused.
+)`
+
 // InformationSchemaCharacterSets describes the schema of the
 // information_schema.character_sets table.
 // Postgres: https://www.postgresql.org/docs/9.5/infoschema-character-sets.html
@@ -127,7 +168,8 @@ CREATE TABLE information_schema.check_constraints (
 	CONSTRAINT_CATALOG STRING NOT NULL,
 	CONSTRAINT_SCHEMA  STRING NOT NULL,
 	CONSTRAINT_NAME    STRING NOT NULL,
-	CHECK_CLAUSE       STRING NOT NULL
+	CHECK_CLAUSE       STRING NOT NULL,
+	CRDB_IS_VALIDATED  STRING NOT NULL -- CockroachDB extension: whether the constraint holds for all existing rows.
 )`
 
 // InformationSchemaColumnPrivileges describes the schema of the
@@ -152,9 +194,11 @@ const InformationSchemaSchemata = `
 CREATE TABLE information_schema.schemata (
 	CATALOG_NAME               STRING NOT NULL,
 	SCHEMA_NAME                STRING NOT NULL,
+	SCHEMA_OWNER               STRING,
 	DEFAULT_CHARACTER_SET_NAME STRING,
 	SQL_PATH                   STRING,
-	CRDB_IS_USER_DEFINED       STRING
+	CRDB_IS_USER_DEFINED       STRING,
+	CRDB_COMMENT               STRING
 )`
 
 // InformationSchemaTables describes the schema of the
@@ -163,12 +207,62 @@ CREATE TABLE information_schema.schemata (
 // MySQL:    https://dev.mysql.com/doc/refman/5.7/en/tables-table.html
 const InformationSchemaTables = `
 CREATE TABLE information_schema.tables (
-	TABLE_CATALOG      STRING NOT NULL,
-	TABLE_SCHEMA       STRING NOT NULL,
-	TABLE_NAME         STRING NOT NULL,
-	TABLE_TYPE         STRING NOT NULL,
-	IS_INSERTABLE_INTO STRING NOT NULL,
-	VERSION            INT
+	TABLE_CATALOG                STRING NOT NULL,
+	TABLE_SCHEMA                 STRING NOT NULL,
+	TABLE_NAME                   STRING NOT NULL,
+	TABLE_TYPE                   STRING NOT NULL,
+	IS_INSERTABLE_INTO           STRING NOT NULL,
+	VERSION                      INT,
+	SELF_REFERENCING_COLUMN_NAME STRING,
+	REFERENCE_GENERATION         STRING,
+	USER_DEFINED_TYPE_CATALOG    STRING,
+	USER_DEFINED_TYPE_SCHEMA     STRING,
+	USER_DEFINED_TYPE_NAME       STRING,
+	IS_TYPED                     STRING NOT NULL,
+	COMMIT_ACTION                STRING,
+	CRDB_IS_PARTITIONED          STRING NOT NULL, -- CockroachDB extension: whether one of the indexes is partitioned.
+	CRDB_ESTIMATED_ROW_COUNT     INT,             -- CockroachDB extension: the row count from the latest table statistics.
+	CRDB_TEMPORARY_SESSION_ID    STRING,          -- CockroachDB extension: the session owning a temporary table.
+	CRDB_CREATE_TIME             TIMESTAMPTZ,     -- CockroachDB extension: the time at which the table was created.
+	CRDB_MODIFICATION_TIME       TIMESTAMPTZ,     -- CockroachDB extension: the time of the latest schema change.
+	CRDB_LOCALITY                STRING,          -- CockroachDB extension: the locality of a table in a multi-region database.
+	CRDB_HOME_REGION             STRING,          -- CockroachDB extension: the region a table is homed in.
+	CRDB_STATE                   STRING NOT NULL, -- CockroachDB extension: the state of the table descriptor, e.g. OFFLINE.
+	CRDB_OFFLINE_REASON          STRING           -- CockroachDB extension: the reason why the table is offline.
+)`
+
+// InformationSchemaPartitions describes the schema of the
+// information_schema.partitions table.
+// MySQL: https://dev.mysql.com/doc/refman/8.0/en/information-schema-partitions-table.html
+const InformationSchemaPartitions = `
+CREATE TABLE information_schema.partitions (
+	TABLE_CATALOG              STRING NOT NULL,
+	TABLE_SCHEMA               STRING NOT NULL,
+	TABLE_NAME                 STRING NOT NULL,
+	INDEX_NAME                 STRING NOT NULL, -- CockroachDB extension: partitions are defined per index.
+	PARTITION_NAME             STRING,
+	PARENT_PARTITION_NAME      STRING,          -- CockroachDB extension: the partition this one subpartitions.
+	PARTITION_ORDINAL_POSITION INT,
+	PARTITION_METHOD           STRING,
+	PARTITION_EXPRESSION       STRING,
+	PARTITION_DESCRIPTION      STRING,
+	TABLE_ROWS                 INT,             -- only known for tables which are not partitioned.
+	CRDB_ZONE_ID               INT,             -- CockroachDB extension: references a zone id in crdb_internal.zones.
+	CRDB_SUBZONE_ID            INT              -- CockroachDB extension: references a subzone id in crdb_internal.zones.
+)`
+
+// InformationSchemaPlugins describes the schema of the
+// information_schema.plugins table.
+// MySQL: https://dev.mysql.com/doc/refman/8.0/en/information-schema-plugins-table.html
+const InformationSchemaPlugins = `
+CREATE TABLE information_schema.plugins (
+	PLUGIN_NAME        STRING NOT NULL,
+	PLUGIN_VERSION     STRING NOT NULL,
+	PLUGIN_STATUS      STRING NOT NULL,
+	PLUGIN_TYPE        STRING NOT NULL,
+	PLUGIN_DESCRIPTION STRING NOT NULL,
+	PLUGIN_LICENSE     STRING,          -- only known for enterprise features.
+	CRDB_SETTING       STRING NOT NULL  -- CockroachDB extension: the cluster setting which enables the feature.
 )`
 
 // InformationSchemaCollationCharacterSetApplicability describes the schema of
@@ -684,6 +684,26 @@ CREATE TABLE pg_catalog.pg_sequence (
 	seqcycle BOOL
 )`
 
+// PGCatalogSequences describes the schema of the pg_catalog.pg_sequences table.
+// https://www.postgresql.org/docs/13/view-pg-sequences.html,
+// Note: crdb_is_called is an extension of the schema that mirrors the
+// is_called column of a sequence.
+const PGCatalogSequences = `
+CREATE TABLE pg_catalog.pg_sequences (
+	schemaname NAME,
+	sequencename NAME,
+	sequenceowner NAME,
+	data_type REGTYPE,
+	start_value INT8,
+	min_value INT8,
+	max_value INT8,
+	increment_by INT8,
+	cycle BOOL,
+	cache_size INT8,
+	last_value INT8,
+	crdb_is_called BOOL
+)`
+
 // PGCatalogSettings describes the schema of the pg_catalog.pg_settings table.
 // https://www.postgresql.org/docs/9.5/catalog-pg-settings.html,
 const PGCatalogSettings = `
@@ -248,6 +248,9 @@ func (v *planVisitor) visitInternal(plan planNode, name string) {
 	case *ordinalityNode:
 		n.source = v.visit(n.source)
 
+	case *introspectionQueryRecorderNode:
+		n.source = v.visit(n.source)
+
 	case *spoolNode:
 		n.source = v.visit(n.source)
 
@@ -338,6 +341,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&alterDatabasePrimaryRegionNode{}): "alter database primary region",
 	reflect.TypeOf(&alterDatabaseSurvivalGoalNode{}):  "alter database survive",
 	reflect.TypeOf(&alterDatabaseDropRegionNode{}):    "alter database drop region",
+	reflect.TypeOf(&alterDefaultPrivilegesNode{}):     "alter default privileges",
 	reflect.TypeOf(&alterIndexNode{}):                 "alter index",
 	reflect.TypeOf(&alterSequenceNode{}):              "alter sequence",
 	reflect.TypeOf(&alterSchemaNode{}):                "alter schema",
@@ -353,8 +357,10 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&cancelSessionsNode{}):             "cancel sessions",
 	reflect.TypeOf(&changePrivilegesNode{}):           "change privileges",
 	reflect.TypeOf(&commentOnColumnNode{}):            "comment on column",
+	reflect.TypeOf(&commentOnConstraintNode{}):        "comment on constraint",
 	reflect.TypeOf(&commentOnDatabaseNode{}):          "comment on database",
 	reflect.TypeOf(&commentOnIndexNode{}):             "comment on index",
+	reflect.TypeOf(&commentOnSchemaNode{}):            "comment on schema",
 	reflect.TypeOf(&commentOnTableNode{}):             "comment on table",
 	reflect.TypeOf(&controlJobsNode{}):                "control jobs",
 	reflect.TypeOf(&controlSchedulesNode{}):           "control schedules",
@@ -392,6 +398,7 @@ var planNodeNames = map[reflect.Type]string{
 	reflect.TypeOf(&indexJoinNode{}):                  "index join",
 	reflect.TypeOf(&insertNode{}):                     "insert",
 	reflect.TypeOf(&insertFastPathNode{}):             "insert fast path",
+	reflect.TypeOf(&introspectionQueryRecorderNode{}): "introspection query recorder",
 	reflect.TypeOf(&invertedFilterNode{}):             "inverted filter",
 	reflect.TypeOf(&invertedJoinNode{}):               "inverted join",
 	reflect.TypeOf(&joinNode{}):                       "join",